@@ -20,6 +20,7 @@ import (
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/handlers"
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/infrastructure"
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/infrastructure/observability"
+	infratopology "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/infrastructure/topology"
 	connectpresentation "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/presentation/connect"
 	grpcpresentation "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/presentation/grpc"
 	grpcservices "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/presentation/grpc/services"
@@ -72,6 +73,10 @@ func main() {
 	// Start heartbeat in background
 	go serviceDiscovery.StartHeartbeat(ctx)
 
+	// Background workers run under a context cancelled on shutdown, before the servers stop
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
 	// Initialize audit service (will use DataAdapter if available)
 	var auditService *services.AuditService
 	if dataAdapter := cfg.GetDataAdapter(); dataAdapter != nil {
@@ -80,8 +85,66 @@ func main() {
 		auditService = services.NewAuditService(logger)
 	}
 
-	grpcServer := grpcpresentation.NewAuditGRPCServer(cfg, auditService, logger)
-	httpServer := setupHTTPServer(cfg, auditService, grpcServer, logger)
+	// Initialize assertion service and preload suites from file (if exists)
+	assertionService := services.NewAssertionService(auditService, logger)
+	if err := assertionService.LoadSuitesFromFile(cfg.AssertionSuitesPath); err != nil {
+		logger.WithError(err).Warn("Failed to load assertion suites, starting with none")
+	}
+
+	// Initialize topology service shared by the gRPC and Connect APIs and by reports
+	topologyService := services.NewTopologyService(logger)
+
+	// Load topology configuration from file (if exists)
+	if err := topologyService.LoadConfigFromFile("/app/config/topology.json"); err != nil {
+		logger.WithError(err).Warn("Failed to load topology config, starting with empty topology")
+	}
+
+	// Node status is the worst of the registry heartbeat and the health probe
+	nodeStatuses := infratopology.NewNodeStatusArbiter()
+
+	// Keep topology nodes in line with the service registry
+	if dataAdapter := cfg.GetDataAdapter(); dataAdapter != nil {
+		registrySync := infratopology.NewRegistrySync(dataAdapter, topologyService.Tracker(), infratopology.RegistrySyncOptions{
+			ServiceNames: cfg.DiscoveryServiceNames,
+			StaleAfter:   cfg.DiscoveryStaleAfter,
+			DeadAfter:    cfg.DiscoveryDeadAfter,
+			Statuses:     nodeStatuses,
+		}, logger)
+		go registrySync.Run(workerCtx, cfg.DiscoveryInterval)
+	} else {
+		logger.Info("No DataAdapter available - topology discovery from the service registry disabled")
+	}
+
+	// Probe node health endpoints and critical edges to keep their status current
+	if cfg.HealthProbeEnabled {
+		healthProber := infratopology.NewHealthProber(topologyService.Tracker(), infratopology.HealthProberOptions{
+			Timeout:       cfg.HealthProbeTimeout,
+			SlowThreshold: cfg.HealthProbeSlowThreshold,
+			DegradedAfter: cfg.HealthProbeDegradedAfter,
+			DeadAfter:     cfg.HealthProbeDeadAfter,
+			RecoverAfter:  cfg.HealthProbeRecoverAfter,
+			Statuses:      nodeStatuses,
+		}, logger)
+		go healthProber.Run(workerCtx, cfg.HealthProbeInterval)
+	}
+
+	reportService := services.NewReportService(auditService, assertionService, topologyService, logger)
+
+	grpcServer := grpcpresentation.NewAuditGRPCServerWithTopology(cfg, auditService, topologyService, logger)
+	svc := &serverServices{audit: auditService, assertions: assertionService, reports: reportService}
+	startWorkers(workerCtx, cfg, svc, grpcServer, logger)
+
+	// Reads of audit data are recorded in an access log kept apart from the event store
+	svc.accessLog = services.NewAccessLog(cfg.AccessLogPath, []byte(cfg.AccessLogKey), logger)
+	if cfg.AccessLogKey == "" {
+		logger.Warn("ACCESS_LOG_HMAC_KEY is not set, the access log chain is unkeyed")
+	}
+	if err := svc.accessLog.Open(); err != nil {
+		logger.WithError(err).Error("Failed to open access log, reads of audit data will be refused")
+	}
+	defer svc.accessLog.Close()
+	grpcServer.SetAccessLog(svc.accessLog)
+	httpServer := setupHTTPServer(cfg, svc, grpcServer, logger)
 
 	go func() {
 		logger.WithField("port", cfg.GRPCPort).Info("Starting gRPC server")
@@ -106,6 +169,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down servers...")
+	stopWorkers()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
@@ -119,7 +183,90 @@ func main() {
 }
 
 
-func setupHTTPServer(cfg *config.Config, auditService *services.AuditService, grpcServer *grpcpresentation.AuditGRPCServer, logger *logrus.Logger) *http.Server {
+// serverServices are the services behind the HTTP API
+type serverServices struct {
+	audit              *services.AuditService
+	assertions         *services.AssertionService
+	reports            *services.ReportService
+	metricsPort        *observability.PrometheusMetricsAdapter
+	coverage           *services.RiskCoverageTracker
+	alertEffectiveness *services.AlertEffectivenessService
+	businessKeys       *services.BusinessKeyService
+	lifecycle          *services.EventLifecycleProcessor
+	retention          *services.RetentionService
+	savedQueries       *services.SavedQueryService
+	rootCause          *services.RootCauseService
+	edgeInference      *services.EdgeInferenceService
+	accessLog          *services.AccessLog
+}
+
+// startWorkers creates the services with background work and starts that work under ctx,
+// so cancelling ctx stops every worker
+func startWorkers(ctx context.Context, cfg *config.Config, svc *serverServices, grpcServer *grpcpresentation.AuditGRPCServer, logger *logrus.Logger) {
+	auditService := svc.audit
+
+	// Initialize observability (Clean Architecture: port + adapter)
+	constantLabels := map[string]string{
+		"service":  cfg.ServiceName,
+		"instance": cfg.ServiceInstanceName,
+		"version":  cfg.ServiceVersion,
+	}
+	svc.metricsPort = observability.NewPrometheusMetricsAdapter(constantLabels)
+
+	svc.coverage = services.NewRiskCoverageTracker(auditService, logger)
+	if err := svc.coverage.LoadCatalogueFromFile(cfg.RiskScenariosPath); err != nil {
+		logger.WithError(err).Warn("Failed to load risk scenario catalogue, starting with empty catalogue")
+	}
+	svc.coverage.SetMetricsPort(svc.metricsPort)
+	svc.alertEffectiveness = services.NewAlertEffectivenessService(svc.coverage, svc.metricsPort, logger)
+	go svc.alertEffectiveness.Run(ctx, 30*time.Second, time.Hour)
+
+	svc.businessKeys = services.NewBusinessKeyService(auditService, logger)
+	if err := svc.businessKeys.LoadExtractorsFromFile(cfg.BusinessKeysPath); err != nil {
+		logger.WithError(err).Warn("Failed to load business key extractors, using defaults")
+	}
+
+	// Lifecycle records are persisted through the data adapter cache when one is connected
+	var lifecycleStore services.LifecycleStore
+	if dataAdapter := cfg.GetDataAdapter(); dataAdapter != nil {
+		lifecycleStore = dataAdapter
+	}
+	svc.lifecycle = services.NewEventLifecycleProcessor(auditService, lifecycleStore, logger)
+	svc.lifecycle.SetBusinessKeyFunc(svc.businessKeys.ExtractKeys)
+	svc.lifecycle.Start(ctx, 4)
+
+	// Hot storage is purged only when the data adapter supports deleting events
+	purger, _ := cfg.GetDataAdapter().(services.EventPurger)
+	svc.retention = services.NewRetentionService(auditService, purger, cfg.ArchivePath, logger)
+	if err := svc.retention.Open(); err != nil {
+		logger.WithError(err).Warn("Failed to open event archive")
+	}
+	if err := svc.retention.LoadPoliciesFromFile(cfg.RetentionPoliciesPath); err != nil {
+		logger.WithError(err).Warn("Failed to load retention policies, nothing will expire")
+	}
+	go svc.retention.Run(ctx, cfg.RetentionSweepInterval)
+
+	var savedQueryStore services.SavedQueryStore
+	if dataAdapter := cfg.GetDataAdapter(); dataAdapter != nil {
+		savedQueryStore = dataAdapter
+	}
+	svc.savedQueries = services.NewSavedQueryService(auditService, savedQueryStore, cfg.NotificationFilesPath, logger)
+	svc.savedQueries.SetAllowedSinkHosts(cfg.NotificationSinkHosts)
+	if err := svc.savedQueries.LoadFromFile(cfg.SavedQueriesPath); err != nil {
+		logger.WithError(err).Warn("Failed to load saved queries")
+	}
+	svc.savedQueries.Start(ctx)
+
+	svc.rootCause = services.NewRootCauseService(auditService, grpcServer.TopologyService(), logger)
+	if err := svc.rootCause.Start(ctx, 30*time.Second); err != nil {
+		logger.WithError(err).Warn("Failed to start root-cause analysis")
+	}
+
+	svc.edgeInference = services.NewEdgeInferenceService(auditService, grpcServer.TopologyService(), cfg.EdgeInferenceWindow, logger)
+	go svc.edgeInference.Run(ctx, cfg.EdgeInferenceInterval)
+}
+
+func setupHTTPServer(cfg *config.Config, svc *serverServices, grpcServer *grpcpresentation.AuditGRPCServer, logger *logrus.Logger) *http.Server {
 	router := gin.New()
 	router.Use(gin.Recovery())
 
@@ -138,24 +285,39 @@ func setupHTTPServer(cfg *config.Config, auditService *services.AuditService, gr
 		c.Next()
 	})
 
-	// Initialize observability (Clean Architecture: port + adapter)
-	constantLabels := map[string]string{
-		"service":  cfg.ServiceName,
-		"instance": cfg.ServiceInstanceName,
-		"version":  cfg.ServiceVersion,
-	}
-	metricsPort := observability.NewPrometheusMetricsAdapter(constantLabels)
-
 	// Add RED metrics middleware (Rate, Errors, Duration)
-	router.Use(observability.REDMetricsMiddleware(metricsPort))
+	router.Use(observability.REDMetricsMiddleware(svc.metricsPort))
 
 	// Initialize handlers
+	auditService := svc.audit
 	healthHandler := handlers.NewHealthHandlerWithConfig(cfg, auditService, logger)
 	auditHandler := handlers.NewAuditHandler(auditService, logger)
-	metricsHandler := handlers.NewMetricsHandler(metricsPort)
+	assertionHandler := handlers.NewAssertionHandler(svc.assertions, logger)
+	reportHandler := handlers.NewReportHandler(svc.reports, logger)
+	orderService := services.NewOrderLifecycleService(auditService, logger)
+	orderHandler := handlers.NewOrderHandler(orderService, logger)
+	reconciliationHandler := handlers.NewReconciliationHandler(services.NewReconciliationService(auditService, logger), logger)
+	riskHandler := handlers.NewRiskHandler(svc.coverage, svc.alertEffectiveness, logger)
+	searchHandler := handlers.NewSearchHandler(services.NewSearchService(auditService, logger), logger)
+	exportHandler := handlers.NewExportHandler(services.NewExportService(auditService, logger), logger)
+	tailHandler := handlers.NewTailHandler(grpcServer.EventTailService(), logger)
+	businessKeyHandler := handlers.NewBusinessKeyHandler(svc.businessKeys, logger)
+	aggregationHandler := handlers.NewAggregationHandler(services.NewAggregationService(auditService, svc.businessKeys, logger), logger)
+	lifecycleHandler := handlers.NewLifecycleHandler(svc.lifecycle, logger)
+	retentionHandler := handlers.NewRetentionHandler(svc.retention, logger)
+	savedQueryHandler := handlers.NewSavedQueryHandler(svc.savedQueries, logger)
+
+	accessLogHandler := handlers.NewAccessLogHandler(svc.accessLog, logger)
+	accessAudit := func(resource string) gin.HandlerFunc {
+		return handlers.AccessAuditMiddleware(svc.accessLog, resource, logger)
+	}
+
+	rootCauseHandler := handlers.NewRootCauseHandler(svc.rootCause, logger)
+	edgeInferenceHandler := handlers.NewEdgeInferenceHandler(svc.edgeInference, logger)
+	metricsHandler := handlers.NewMetricsHandler(svc.metricsPort)
 
 	// Register Connect protocol handlers (for browser gRPC-Web/Connect clients)
-	registerConnectHandlers(router, grpcServer, logger)
+	registerConnectHandlers(router, grpcServer, svc.accessLog, logger)
 
 	// Observability endpoints (separate from business logic)
 	router.GET("/metrics", metricsHandler.Metrics)
@@ -166,15 +328,141 @@ func setupHTTPServer(cfg *config.Config, auditService *services.AuditService, gr
 		v1.GET("/health", healthHandler.Health)
 		v1.GET("/ready", healthHandler.Ready)
 
+		// Admin endpoints; reads of the access log are not themselves audited
+		requireAdmin := handlers.RequireAdminToken(cfg.AdminAuthToken)
+		admin := v1.Group("/admin", requireAdmin)
+		{
+			admin.GET("/access-log", accessLogHandler.QueryAccessLog)
+			admin.GET("/access-log/verify", accessLogHandler.VerifyAccessLog)
+		}
+
 		// Audit endpoints
 		audit := v1.Group("/audit")
 		{
 			audit.POST("/events", auditHandler.LogEvent)
-			audit.GET("/correlations", auditHandler.CorrelateEvents)
-			audit.GET("/events/trace/:trace_id", auditHandler.GetEventsByTraceID)
-			audit.GET("/events/service", auditHandler.GetEventsByServiceType)
+			audit.POST("/events/ingest", auditHandler.IngestEvent)
+			audit.GET("/events/search", accessAudit("search"), searchHandler.SearchEvents)
+			audit.POST("/events/search", accessAudit("search"), searchHandler.SearchEvents)
+			audit.GET("/events/tail", accessAudit("tail"), tailHandler.StreamSSE)
+			audit.GET("/events/tail/ws", accessAudit("tail"), tailHandler.StreamWebSocket)
+			audit.GET("/events/tail/subscriptions", tailHandler.ListSubscriptions)
+			audit.GET("/aggregations", aggregationHandler.Aggregate)
+			audit.POST("/aggregations", aggregationHandler.Aggregate)
+			audit.GET("/export", accessAudit("export"), exportHandler.ExportEvents)
+			audit.POST("/export", accessAudit("export"), exportHandler.ExportEvents)
+
+			// Event status lifecycle
+			lifecycle := audit.Group("/lifecycle")
+			{
+				lifecycle.GET("/events", accessAudit("lifecycle"), lifecycleHandler.ListEvents)
+				lifecycle.GET("/events/:event_id", accessAudit("lifecycle"), lifecycleHandler.GetEvent)
+				lifecycle.POST("/events/:event_id/requeue", lifecycleHandler.RequeueEvent)
+				lifecycle.POST("/requeue", lifecycleHandler.RequeueFailed)
+				lifecycle.GET("/stats", lifecycleHandler.Stats)
+			}
+
+			// Retention, archival and legal holds; changes that let data be purged need the admin token
+			retention := audit.Group("/retention")
+			{
+				retention.GET("/policies", retentionHandler.ListPolicies)
+				retention.PUT("/policies", requireAdmin, retentionHandler.SetPolicies)
+				retention.POST("/sweep", requireAdmin, retentionHandler.Sweep)
+				retention.GET("/segments", retentionHandler.ListSegments)
+				retention.GET("/holds", retentionHandler.ListLegalHolds)
+				retention.POST("/holds", retentionHandler.AddLegalHold)
+				retention.DELETE("/holds/:hold_id", requireAdmin, retentionHandler.ReleaseLegalHold)
+				retention.GET("/events", accessAudit("archive"), retentionHandler.QueryEvents)
+				retention.POST("/events", accessAudit("archive"), retentionHandler.QueryEvents)
+			}
+
+			// Saved queries and notification sinks
+			savedQueries := audit.Group("/saved-queries")
+			{
+				savedQueries.GET("", savedQueryHandler.ListQueries)
+				savedQueries.POST("", savedQueryHandler.CreateQuery)
+				savedQueries.GET("/:query_id", savedQueryHandler.GetQuery)
+				savedQueries.PUT("/:query_id", savedQueryHandler.UpdateQuery)
+				savedQueries.DELETE("/:query_id", savedQueryHandler.DeleteQuery)
+				savedQueries.POST("/:query_id/run", accessAudit("saved_query"), savedQueryHandler.RunQuery)
+				savedQueries.GET("/:query_id/notifications", savedQueryHandler.GetNotifications)
+			}
+			// Sinks choose where the service sends requests and hold their credentials
+			sinks := audit.Group("/notification-sinks", requireAdmin)
+			{
+				sinks.GET("", savedQueryHandler.ListSinks)
+				sinks.POST("", savedQueryHandler.CreateSink)
+				sinks.DELETE("/:sink_id", savedQueryHandler.DeleteSink)
+				sinks.POST("/:sink_id/test", savedQueryHandler.TestSink)
+			}
+
+			audit.GET("/correlations", accessAudit("correlation"), auditHandler.CorrelateEvents)
+			audit.GET("/events/trace/:trace_id", accessAudit("trace"), auditHandler.GetEventsByTraceID)
+			audit.GET("/events/service", accessAudit("search"), auditHandler.GetEventsByServiceType)
+			audit.GET("/timeline/trace/:trace_id", accessAudit("trace"), auditHandler.GetTraceTimeline)
+			audit.GET("/clock-offsets", auditHandler.GetClockOffsets)
 			audit.POST("/correlations", auditHandler.CreateCorrelation)
 			audit.GET("/status", auditHandler.GetAuditStatus)
+
+			// Business-key extraction and pivots
+			keys := audit.Group("/keys")
+			{
+				keys.GET("", businessKeyHandler.ListKeys)
+				keys.POST("/extractors", businessKeyHandler.AddExtractor)
+				keys.POST("/reindex", businessKeyHandler.Reindex)
+				keys.GET("/:key/correlations", accessAudit("business_key"), businessKeyHandler.CorrelateByKey)
+				keys.GET("/:key/values/:value/events", accessAudit("business_key"), businessKeyHandler.GetEventsByKey)
+				keys.GET("/:key/values/:value/timeline", accessAudit("business_key"), businessKeyHandler.GetTimelineByKey)
+			}
+
+			// Validation assertions
+			assertions := audit.Group("/assertions")
+			{
+				assertions.GET("/suites", assertionHandler.ListSuites)
+				assertions.POST("/suites", assertionHandler.RegisterSuite)
+				assertions.GET("/suites/:suite_id", assertionHandler.GetSuite)
+				assertions.POST("/suites/:suite_id/evaluate", assertionHandler.EvaluateSuite)
+				assertions.GET("/suites/:suite_id/results", assertionHandler.GetSuiteResults)
+				assertions.POST("/suites/:suite_id/live", assertionHandler.StartLiveRun)
+				assertions.POST("/evaluate", assertionHandler.EvaluateInline)
+				assertions.GET("/runs/:run_id", assertionHandler.GetLiveRun)
+			}
+
+			// Correlation reports
+			audit.GET("/reports", accessAudit("report"), reportHandler.GenerateReport)
+
+			// Order lifecycle reconstruction
+			audit.GET("/orders/violations", accessAudit("order"), orderHandler.GetOrderViolations)
+			audit.GET("/orders/:order_id/lifecycle", accessAudit("order"), orderHandler.GetOrderLifecycle)
+
+			// Trade reconciliation
+			reconciliation := audit.Group("/reconciliation")
+			{
+				reconciliation.POST("/runs", reconciliationHandler.RunReconciliation)
+				reconciliation.GET("/runs", reconciliationHandler.ListRuns)
+				reconciliation.GET("/breaks", reconciliationHandler.ListBreaks)
+				reconciliation.GET("/breaks/:break_id", reconciliationHandler.GetBreak)
+				reconciliation.POST("/breaks/:break_id/resolve", reconciliationHandler.ResolveBreak)
+			}
+
+			// Risk scenario coverage
+			risk := audit.Group("/risk")
+			{
+				risk.GET("/scenarios", riskHandler.ListScenarios)
+				risk.GET("/scenarios/:scenario_id/runs", riskHandler.GetScenarioRuns)
+				risk.POST("/scenarios/:scenario_id/runs", riskHandler.RecordScenarioRun)
+				risk.GET("/coverage", riskHandler.GetCoverageMatrix)
+				risk.POST("/coverage/scan", riskHandler.ScanCoverage)
+				risk.GET("/effectiveness", riskHandler.GetAlertEffectiveness)
+			}
+
+			// Root-cause ranking for degraded topology nodes
+			audit.GET("/root-cause", rootCauseHandler.ListAnalyses)
+			audit.POST("/root-cause/analyze", rootCauseHandler.AnalyzeNode)
+			audit.GET("/root-cause/:analysis_id", rootCauseHandler.GetAnalysis)
+
+			// Topology edges inferred from cross-service spans
+			audit.GET("/edge-inference", edgeInferenceHandler.GetLatest)
+			audit.POST("/edge-inference/run", edgeInferenceHandler.RunInference)
 		}
 	}
 
@@ -185,17 +473,9 @@ func setupHTTPServer(cfg *config.Config, auditService *services.AuditService, gr
 }
 
 // registerConnectHandlers registers Connect protocol handlers for browser-based gRPC clients
-func registerConnectHandlers(router *gin.Engine, grpcServer *grpcpresentation.AuditGRPCServer, logger *logrus.Logger) {
-	// Create a new TopologyService instance (matches pattern from grpc/server.go)
-	topologyService := services.NewTopologyService(logger)
-
-	// Load topology configuration from file (if exists)
-	configPath := "/app/config/topology.json"
-	if err := topologyService.LoadConfigFromFile(configPath); err != nil {
-		logger.WithError(err).Warn("Failed to load topology config, starting with empty topology")
-	}
-
-	topologyServer := grpcservices.NewTopologyServiceServer(topologyService, logger)
+func registerConnectHandlers(router *gin.Engine, grpcServer *grpcpresentation.AuditGRPCServer, accessLog *services.AccessLog, logger *logrus.Logger) {
+	// Share the gRPC server's TopologyService so both protocols serve the same topology
+	topologyServer := grpcservices.NewTopologyServiceServer(grpcServer.TopologyService(), logger)
 
 	// Create Connect adapter
 	connectAdapter := connectpresentation.NewTopologyConnectAdapter(topologyServer)
@@ -208,5 +488,13 @@ func registerConnectHandlers(router *gin.Engine, grpcServer *grpcpresentation.Au
 	router.Any(path+"*method", gin.WrapH(handler))
 
 	logger.WithField("path", path).Info("Registered Connect protocol handlers for TopologyService")
+
+	// Live tail shares the gRPC server's tail service so subscribers are counted across protocols
+	auditEventServer := grpcservices.NewAuditEventServiceServer(grpcServer.EventTailService(), logger)
+	auditEventServer.SetAccessLog(accessLog)
+	eventsPath, eventsHandler := auditv1connect.NewAuditEventServiceHandler(connectpresentation.NewAuditEventConnectAdapter(auditEventServer))
+	router.Any(eventsPath+"*method", gin.WrapH(eventsHandler))
+
+	logger.WithField("path", eventsPath).Info("Registered Connect protocol handlers for AuditEventService")
 }
 
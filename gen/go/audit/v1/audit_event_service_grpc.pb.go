@@ -0,0 +1,136 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.6
+// source: audit/v1/audit_event_service.proto
+
+package auditv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AuditEventServiceClient is the client API for AuditEventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuditEventServiceClient interface {
+	// TailEvents streams audit events as they are accepted by the correlator.
+	// Events are buffered per subscriber; a slow consumer receives EventsDropped
+	// notifications instead of blocking ingestion.
+	TailEvents(ctx context.Context, in *TailEventsRequest, opts ...grpc.CallOption) (AuditEventService_TailEventsClient, error)
+}
+
+type auditEventServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditEventServiceClient(cc grpc.ClientConnInterface) AuditEventServiceClient {
+	return &auditEventServiceClient{cc}
+}
+
+func (c *auditEventServiceClient) TailEvents(ctx context.Context, in *TailEventsRequest, opts ...grpc.CallOption) (AuditEventService_TailEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AuditEventService_ServiceDesc.Streams[0], "/audit.v1.AuditEventService/TailEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &auditEventServiceTailEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AuditEventService_TailEventsClient interface {
+	Recv() (*TailEventsResponse, error)
+	grpc.ClientStream
+}
+
+type auditEventServiceTailEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *auditEventServiceTailEventsClient) Recv() (*TailEventsResponse, error) {
+	m := new(TailEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AuditEventServiceServer is the server API for AuditEventService service.
+// All implementations should embed UnimplementedAuditEventServiceServer
+// for forward compatibility
+type AuditEventServiceServer interface {
+	// TailEvents streams audit events as they are accepted by the correlator.
+	// Events are buffered per subscriber; a slow consumer receives EventsDropped
+	// notifications instead of blocking ingestion.
+	TailEvents(*TailEventsRequest, AuditEventService_TailEventsServer) error
+}
+
+// UnimplementedAuditEventServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedAuditEventServiceServer struct {
+}
+
+func (UnimplementedAuditEventServiceServer) TailEvents(*TailEventsRequest, AuditEventService_TailEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailEvents not implemented")
+}
+
+// UnsafeAuditEventServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditEventServiceServer will
+// result in compilation errors.
+type UnsafeAuditEventServiceServer interface {
+	mustEmbedUnimplementedAuditEventServiceServer()
+}
+
+func RegisterAuditEventServiceServer(s grpc.ServiceRegistrar, srv AuditEventServiceServer) {
+	s.RegisterService(&AuditEventService_ServiceDesc, srv)
+}
+
+func _AuditEventService_TailEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuditEventServiceServer).TailEvents(m, &auditEventServiceTailEventsServer{stream})
+}
+
+type AuditEventService_TailEventsServer interface {
+	Send(*TailEventsResponse) error
+	grpc.ServerStream
+}
+
+type auditEventServiceTailEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *auditEventServiceTailEventsServer) Send(m *TailEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// AuditEventService_ServiceDesc is the grpc.ServiceDesc for AuditEventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditEventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "audit.v1.AuditEventService",
+	HandlerType: (*AuditEventServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TailEvents",
+			Handler:       _AuditEventService_TailEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "audit/v1/audit_event_service.proto",
+}
@@ -0,0 +1,115 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: audit/v1/audit_event_service.proto
+
+package auditv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AuditEventServiceName is the fully-qualified name of the AuditEventService service.
+	AuditEventServiceName = "audit.v1.AuditEventService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AuditEventServiceTailEventsProcedure is the fully-qualified name of the AuditEventService's
+	// TailEvents RPC.
+	AuditEventServiceTailEventsProcedure = "/audit.v1.AuditEventService/TailEvents"
+)
+
+// AuditEventServiceClient is a client for the audit.v1.AuditEventService service.
+type AuditEventServiceClient interface {
+	// TailEvents streams audit events as they are accepted by the correlator.
+	// Events are buffered per subscriber; a slow consumer receives EventsDropped
+	// notifications instead of blocking ingestion.
+	TailEvents(context.Context, *connect.Request[v1.TailEventsRequest]) (*connect.ServerStreamForClient[v1.TailEventsResponse], error)
+}
+
+// NewAuditEventServiceClient constructs a client for the audit.v1.AuditEventService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuditEventServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AuditEventServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	auditEventServiceMethods := v1.File_audit_v1_audit_event_service_proto.Services().ByName("AuditEventService").Methods()
+	return &auditEventServiceClient{
+		tailEvents: connect.NewClient[v1.TailEventsRequest, v1.TailEventsResponse](
+			httpClient,
+			baseURL+AuditEventServiceTailEventsProcedure,
+			connect.WithSchema(auditEventServiceMethods.ByName("TailEvents")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// auditEventServiceClient implements AuditEventServiceClient.
+type auditEventServiceClient struct {
+	tailEvents *connect.Client[v1.TailEventsRequest, v1.TailEventsResponse]
+}
+
+// TailEvents calls audit.v1.AuditEventService.TailEvents.
+func (c *auditEventServiceClient) TailEvents(ctx context.Context, req *connect.Request[v1.TailEventsRequest]) (*connect.ServerStreamForClient[v1.TailEventsResponse], error) {
+	return c.tailEvents.CallServerStream(ctx, req)
+}
+
+// AuditEventServiceHandler is an implementation of the audit.v1.AuditEventService service.
+type AuditEventServiceHandler interface {
+	// TailEvents streams audit events as they are accepted by the correlator.
+	// Events are buffered per subscriber; a slow consumer receives EventsDropped
+	// notifications instead of blocking ingestion.
+	TailEvents(context.Context, *connect.Request[v1.TailEventsRequest], *connect.ServerStream[v1.TailEventsResponse]) error
+}
+
+// NewAuditEventServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuditEventServiceHandler(svc AuditEventServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	auditEventServiceMethods := v1.File_audit_v1_audit_event_service_proto.Services().ByName("AuditEventService").Methods()
+	auditEventServiceTailEventsHandler := connect.NewServerStreamHandler(
+		AuditEventServiceTailEventsProcedure,
+		svc.TailEvents,
+		connect.WithSchema(auditEventServiceMethods.ByName("TailEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/audit.v1.AuditEventService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuditEventServiceTailEventsProcedure:
+			auditEventServiceTailEventsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuditEventServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuditEventServiceHandler struct{}
+
+func (UnimplementedAuditEventServiceHandler) TailEvents(context.Context, *connect.Request[v1.TailEventsRequest], *connect.ServerStream[v1.TailEventsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.AuditEventService.TailEvents is not implemented"))
+}
@@ -48,6 +48,42 @@ const (
 	// TopologyServiceStreamMetricsUpdatesProcedure is the fully-qualified name of the TopologyService's
 	// StreamMetricsUpdates RPC.
 	TopologyServiceStreamMetricsUpdatesProcedure = "/audit.v1.TopologyService/StreamMetricsUpdates"
+	// TopologyServiceRegisterNodeProcedure is the fully-qualified name of the TopologyService's
+	// RegisterNode RPC.
+	TopologyServiceRegisterNodeProcedure = "/audit.v1.TopologyService/RegisterNode"
+	// TopologyServiceDeregisterNodeProcedure is the fully-qualified name of the TopologyService's
+	// DeregisterNode RPC.
+	TopologyServiceDeregisterNodeProcedure = "/audit.v1.TopologyService/DeregisterNode"
+	// TopologyServiceUpdateNodeStatusProcedure is the fully-qualified name of the TopologyService's
+	// UpdateNodeStatus RPC.
+	TopologyServiceUpdateNodeStatusProcedure = "/audit.v1.TopologyService/UpdateNodeStatus"
+	// TopologyServiceRegisterEdgeProcedure is the fully-qualified name of the TopologyService's
+	// RegisterEdge RPC.
+	TopologyServiceRegisterEdgeProcedure = "/audit.v1.TopologyService/RegisterEdge"
+	// TopologyServiceDeregisterEdgeProcedure is the fully-qualified name of the TopologyService's
+	// DeregisterEdge RPC.
+	TopologyServiceDeregisterEdgeProcedure = "/audit.v1.TopologyService/DeregisterEdge"
+	// TopologyServiceUpdateEdgeStatusProcedure is the fully-qualified name of the TopologyService's
+	// UpdateEdgeStatus RPC.
+	TopologyServiceUpdateEdgeStatusProcedure = "/audit.v1.TopologyService/UpdateEdgeStatus"
+	// TopologyServiceGetTopologySnapshotProcedure is the fully-qualified name of the TopologyService's
+	// GetTopologySnapshot RPC.
+	TopologyServiceGetTopologySnapshotProcedure = "/audit.v1.TopologyService/GetTopologySnapshot"
+	// TopologyServiceDiffTopologySnapshotsProcedure is the fully-qualified name of the
+	// TopologyService's DiffTopologySnapshots RPC.
+	TopologyServiceDiffTopologySnapshotsProcedure = "/audit.v1.TopologyService/DiffTopologySnapshots"
+	// TopologyServiceGetDependenciesProcedure is the fully-qualified name of the TopologyService's
+	// GetDependencies RPC.
+	TopologyServiceGetDependenciesProcedure = "/audit.v1.TopologyService/GetDependencies"
+	// TopologyServiceGetBlastRadiusProcedure is the fully-qualified name of the TopologyService's
+	// GetBlastRadius RPC.
+	TopologyServiceGetBlastRadiusProcedure = "/audit.v1.TopologyService/GetBlastRadius"
+	// TopologyServiceAnalyzeTopologyProcedure is the fully-qualified name of the TopologyService's
+	// AnalyzeTopology RPC.
+	TopologyServiceAnalyzeTopologyProcedure = "/audit.v1.TopologyService/AnalyzeTopology"
+	// TopologyServiceGetCriticalPathsProcedure is the fully-qualified name of the TopologyService's
+	// GetCriticalPaths RPC.
+	TopologyServiceGetCriticalPathsProcedure = "/audit.v1.TopologyService/GetCriticalPaths"
 )
 
 // TopologyServiceClient is a client for the audit.v1.TopologyService service.
@@ -69,6 +105,35 @@ type TopologyServiceClient interface {
 	// Minimum update interval is 1 second (enforced server-side).
 	// Empty node_ids/edge_ids subscribes to all elements.
 	StreamMetricsUpdates(context.Context, *connect.Request[v1.StreamMetricsUpdatesRequest]) (*connect.ServerStreamForClient[v1.MetricsUpdate], error)
+	// RegisterNode adds a service node, or replaces the node with the same ID.
+	// Every change is published on StreamTopologyChanges under a new snapshot ID.
+	RegisterNode(context.Context, *connect.Request[v1.RegisterNodeRequest]) (*connect.Response[v1.RegisterNodeResponse], error)
+	// DeregisterNode removes a node and every edge to or from it.
+	DeregisterNode(context.Context, *connect.Request[v1.DeregisterNodeRequest]) (*connect.Response[v1.DeregisterNodeResponse], error)
+	// UpdateNodeStatus sets the status of a registered node.
+	UpdateNodeStatus(context.Context, *connect.Request[v1.UpdateNodeStatusRequest]) (*connect.Response[v1.UpdateNodeStatusResponse], error)
+	// RegisterEdge adds a directed edge between two registered nodes, or replaces the edge with the same ID.
+	// Edges to unknown nodes are rejected with INVALID_ARGUMENT.
+	RegisterEdge(context.Context, *connect.Request[v1.RegisterEdgeRequest]) (*connect.Response[v1.RegisterEdgeResponse], error)
+	// DeregisterEdge removes an edge.
+	DeregisterEdge(context.Context, *connect.Request[v1.DeregisterEdgeRequest]) (*connect.Response[v1.DeregisterEdgeResponse], error)
+	// UpdateEdgeStatus sets the status of a registered edge.
+	UpdateEdgeStatus(context.Context, *connect.Request[v1.UpdateEdgeStatusRequest]) (*connect.Response[v1.UpdateEdgeStatusResponse], error)
+	// GetTopologySnapshot returns the topology as of a past snapshot ID or time.
+	// Snapshots no longer retained in the history return NOT_FOUND.
+	GetTopologySnapshot(context.Context, *connect.Request[v1.GetTopologySnapshotRequest]) (*connect.Response[v1.TopologyStructureResponse], error)
+	// DiffTopologySnapshots compares two snapshots into added, removed and changed nodes and edges.
+	DiffTopologySnapshots(context.Context, *connect.Request[v1.DiffTopologySnapshotsRequest]) (*connect.Response[v1.DiffTopologySnapshotsResponse], error)
+	// GetDependencies returns the transitive upstream and downstream nodes of a node.
+	GetDependencies(context.Context, *connect.Request[v1.GetDependenciesRequest]) (*connect.Response[v1.GetDependenciesResponse], error)
+	// GetBlastRadius returns the nodes and edges affected by a node failure.
+	GetBlastRadius(context.Context, *connect.Request[v1.GetBlastRadiusRequest]) (*connect.Response[v1.GetBlastRadiusResponse], error)
+	// AnalyzeTopology returns single points of failure and dependency cycles.
+	AnalyzeTopology(context.Context, *connect.Request[v1.AnalyzeTopologyRequest]) (*connect.Response[v1.AnalyzeTopologyResponse], error)
+	// GetCriticalPaths returns the slowest dependency chains weighted by edge latency.
+	// Edges inside a cycle are left out so every path is finite. Past snapshots are weighted
+	// with recorded edge metadata only; edges without latency data are listed in the response.
+	GetCriticalPaths(context.Context, *connect.Request[v1.GetCriticalPathsRequest]) (*connect.Response[v1.GetCriticalPathsResponse], error)
 }
 
 // NewTopologyServiceClient constructs a client for the audit.v1.TopologyService service. By
@@ -112,6 +177,78 @@ func NewTopologyServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(topologyServiceMethods.ByName("StreamMetricsUpdates")),
 			connect.WithClientOptions(opts...),
 		),
+		registerNode: connect.NewClient[v1.RegisterNodeRequest, v1.RegisterNodeResponse](
+			httpClient,
+			baseURL+TopologyServiceRegisterNodeProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("RegisterNode")),
+			connect.WithClientOptions(opts...),
+		),
+		deregisterNode: connect.NewClient[v1.DeregisterNodeRequest, v1.DeregisterNodeResponse](
+			httpClient,
+			baseURL+TopologyServiceDeregisterNodeProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("DeregisterNode")),
+			connect.WithClientOptions(opts...),
+		),
+		updateNodeStatus: connect.NewClient[v1.UpdateNodeStatusRequest, v1.UpdateNodeStatusResponse](
+			httpClient,
+			baseURL+TopologyServiceUpdateNodeStatusProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("UpdateNodeStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		registerEdge: connect.NewClient[v1.RegisterEdgeRequest, v1.RegisterEdgeResponse](
+			httpClient,
+			baseURL+TopologyServiceRegisterEdgeProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("RegisterEdge")),
+			connect.WithClientOptions(opts...),
+		),
+		deregisterEdge: connect.NewClient[v1.DeregisterEdgeRequest, v1.DeregisterEdgeResponse](
+			httpClient,
+			baseURL+TopologyServiceDeregisterEdgeProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("DeregisterEdge")),
+			connect.WithClientOptions(opts...),
+		),
+		updateEdgeStatus: connect.NewClient[v1.UpdateEdgeStatusRequest, v1.UpdateEdgeStatusResponse](
+			httpClient,
+			baseURL+TopologyServiceUpdateEdgeStatusProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("UpdateEdgeStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		getTopologySnapshot: connect.NewClient[v1.GetTopologySnapshotRequest, v1.TopologyStructureResponse](
+			httpClient,
+			baseURL+TopologyServiceGetTopologySnapshotProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("GetTopologySnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		diffTopologySnapshots: connect.NewClient[v1.DiffTopologySnapshotsRequest, v1.DiffTopologySnapshotsResponse](
+			httpClient,
+			baseURL+TopologyServiceDiffTopologySnapshotsProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("DiffTopologySnapshots")),
+			connect.WithClientOptions(opts...),
+		),
+		getDependencies: connect.NewClient[v1.GetDependenciesRequest, v1.GetDependenciesResponse](
+			httpClient,
+			baseURL+TopologyServiceGetDependenciesProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("GetDependencies")),
+			connect.WithClientOptions(opts...),
+		),
+		getBlastRadius: connect.NewClient[v1.GetBlastRadiusRequest, v1.GetBlastRadiusResponse](
+			httpClient,
+			baseURL+TopologyServiceGetBlastRadiusProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("GetBlastRadius")),
+			connect.WithClientOptions(opts...),
+		),
+		analyzeTopology: connect.NewClient[v1.AnalyzeTopologyRequest, v1.AnalyzeTopologyResponse](
+			httpClient,
+			baseURL+TopologyServiceAnalyzeTopologyProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("AnalyzeTopology")),
+			connect.WithClientOptions(opts...),
+		),
+		getCriticalPaths: connect.NewClient[v1.GetCriticalPathsRequest, v1.GetCriticalPathsResponse](
+			httpClient,
+			baseURL+TopologyServiceGetCriticalPathsProcedure,
+			connect.WithSchema(topologyServiceMethods.ByName("GetCriticalPaths")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -122,6 +259,18 @@ type topologyServiceClient struct {
 	getEdgeMetadata       *connect.Client[v1.GetEdgeMetadataRequest, v1.GetEdgeMetadataResponse]
 	streamTopologyChanges *connect.Client[v1.StreamTopologyChangesRequest, v1.TopologyChange]
 	streamMetricsUpdates  *connect.Client[v1.StreamMetricsUpdatesRequest, v1.MetricsUpdate]
+	registerNode          *connect.Client[v1.RegisterNodeRequest, v1.RegisterNodeResponse]
+	deregisterNode        *connect.Client[v1.DeregisterNodeRequest, v1.DeregisterNodeResponse]
+	updateNodeStatus      *connect.Client[v1.UpdateNodeStatusRequest, v1.UpdateNodeStatusResponse]
+	registerEdge          *connect.Client[v1.RegisterEdgeRequest, v1.RegisterEdgeResponse]
+	deregisterEdge        *connect.Client[v1.DeregisterEdgeRequest, v1.DeregisterEdgeResponse]
+	updateEdgeStatus      *connect.Client[v1.UpdateEdgeStatusRequest, v1.UpdateEdgeStatusResponse]
+	getTopologySnapshot   *connect.Client[v1.GetTopologySnapshotRequest, v1.TopologyStructureResponse]
+	diffTopologySnapshots *connect.Client[v1.DiffTopologySnapshotsRequest, v1.DiffTopologySnapshotsResponse]
+	getDependencies       *connect.Client[v1.GetDependenciesRequest, v1.GetDependenciesResponse]
+	getBlastRadius        *connect.Client[v1.GetBlastRadiusRequest, v1.GetBlastRadiusResponse]
+	analyzeTopology       *connect.Client[v1.AnalyzeTopologyRequest, v1.AnalyzeTopologyResponse]
+	getCriticalPaths      *connect.Client[v1.GetCriticalPathsRequest, v1.GetCriticalPathsResponse]
 }
 
 // GetTopologyStructure calls audit.v1.TopologyService.GetTopologyStructure.
@@ -149,6 +298,66 @@ func (c *topologyServiceClient) StreamMetricsUpdates(ctx context.Context, req *c
 	return c.streamMetricsUpdates.CallServerStream(ctx, req)
 }
 
+// RegisterNode calls audit.v1.TopologyService.RegisterNode.
+func (c *topologyServiceClient) RegisterNode(ctx context.Context, req *connect.Request[v1.RegisterNodeRequest]) (*connect.Response[v1.RegisterNodeResponse], error) {
+	return c.registerNode.CallUnary(ctx, req)
+}
+
+// DeregisterNode calls audit.v1.TopologyService.DeregisterNode.
+func (c *topologyServiceClient) DeregisterNode(ctx context.Context, req *connect.Request[v1.DeregisterNodeRequest]) (*connect.Response[v1.DeregisterNodeResponse], error) {
+	return c.deregisterNode.CallUnary(ctx, req)
+}
+
+// UpdateNodeStatus calls audit.v1.TopologyService.UpdateNodeStatus.
+func (c *topologyServiceClient) UpdateNodeStatus(ctx context.Context, req *connect.Request[v1.UpdateNodeStatusRequest]) (*connect.Response[v1.UpdateNodeStatusResponse], error) {
+	return c.updateNodeStatus.CallUnary(ctx, req)
+}
+
+// RegisterEdge calls audit.v1.TopologyService.RegisterEdge.
+func (c *topologyServiceClient) RegisterEdge(ctx context.Context, req *connect.Request[v1.RegisterEdgeRequest]) (*connect.Response[v1.RegisterEdgeResponse], error) {
+	return c.registerEdge.CallUnary(ctx, req)
+}
+
+// DeregisterEdge calls audit.v1.TopologyService.DeregisterEdge.
+func (c *topologyServiceClient) DeregisterEdge(ctx context.Context, req *connect.Request[v1.DeregisterEdgeRequest]) (*connect.Response[v1.DeregisterEdgeResponse], error) {
+	return c.deregisterEdge.CallUnary(ctx, req)
+}
+
+// UpdateEdgeStatus calls audit.v1.TopologyService.UpdateEdgeStatus.
+func (c *topologyServiceClient) UpdateEdgeStatus(ctx context.Context, req *connect.Request[v1.UpdateEdgeStatusRequest]) (*connect.Response[v1.UpdateEdgeStatusResponse], error) {
+	return c.updateEdgeStatus.CallUnary(ctx, req)
+}
+
+// GetTopologySnapshot calls audit.v1.TopologyService.GetTopologySnapshot.
+func (c *topologyServiceClient) GetTopologySnapshot(ctx context.Context, req *connect.Request[v1.GetTopologySnapshotRequest]) (*connect.Response[v1.TopologyStructureResponse], error) {
+	return c.getTopologySnapshot.CallUnary(ctx, req)
+}
+
+// DiffTopologySnapshots calls audit.v1.TopologyService.DiffTopologySnapshots.
+func (c *topologyServiceClient) DiffTopologySnapshots(ctx context.Context, req *connect.Request[v1.DiffTopologySnapshotsRequest]) (*connect.Response[v1.DiffTopologySnapshotsResponse], error) {
+	return c.diffTopologySnapshots.CallUnary(ctx, req)
+}
+
+// GetDependencies calls audit.v1.TopologyService.GetDependencies.
+func (c *topologyServiceClient) GetDependencies(ctx context.Context, req *connect.Request[v1.GetDependenciesRequest]) (*connect.Response[v1.GetDependenciesResponse], error) {
+	return c.getDependencies.CallUnary(ctx, req)
+}
+
+// GetBlastRadius calls audit.v1.TopologyService.GetBlastRadius.
+func (c *topologyServiceClient) GetBlastRadius(ctx context.Context, req *connect.Request[v1.GetBlastRadiusRequest]) (*connect.Response[v1.GetBlastRadiusResponse], error) {
+	return c.getBlastRadius.CallUnary(ctx, req)
+}
+
+// AnalyzeTopology calls audit.v1.TopologyService.AnalyzeTopology.
+func (c *topologyServiceClient) AnalyzeTopology(ctx context.Context, req *connect.Request[v1.AnalyzeTopologyRequest]) (*connect.Response[v1.AnalyzeTopologyResponse], error) {
+	return c.analyzeTopology.CallUnary(ctx, req)
+}
+
+// GetCriticalPaths calls audit.v1.TopologyService.GetCriticalPaths.
+func (c *topologyServiceClient) GetCriticalPaths(ctx context.Context, req *connect.Request[v1.GetCriticalPathsRequest]) (*connect.Response[v1.GetCriticalPathsResponse], error) {
+	return c.getCriticalPaths.CallUnary(ctx, req)
+}
+
 // TopologyServiceHandler is an implementation of the audit.v1.TopologyService service.
 type TopologyServiceHandler interface {
 	// GetTopologyStructure returns lightweight topology structure for initial render.
@@ -168,6 +377,35 @@ type TopologyServiceHandler interface {
 	// Minimum update interval is 1 second (enforced server-side).
 	// Empty node_ids/edge_ids subscribes to all elements.
 	StreamMetricsUpdates(context.Context, *connect.Request[v1.StreamMetricsUpdatesRequest], *connect.ServerStream[v1.MetricsUpdate]) error
+	// RegisterNode adds a service node, or replaces the node with the same ID.
+	// Every change is published on StreamTopologyChanges under a new snapshot ID.
+	RegisterNode(context.Context, *connect.Request[v1.RegisterNodeRequest]) (*connect.Response[v1.RegisterNodeResponse], error)
+	// DeregisterNode removes a node and every edge to or from it.
+	DeregisterNode(context.Context, *connect.Request[v1.DeregisterNodeRequest]) (*connect.Response[v1.DeregisterNodeResponse], error)
+	// UpdateNodeStatus sets the status of a registered node.
+	UpdateNodeStatus(context.Context, *connect.Request[v1.UpdateNodeStatusRequest]) (*connect.Response[v1.UpdateNodeStatusResponse], error)
+	// RegisterEdge adds a directed edge between two registered nodes, or replaces the edge with the same ID.
+	// Edges to unknown nodes are rejected with INVALID_ARGUMENT.
+	RegisterEdge(context.Context, *connect.Request[v1.RegisterEdgeRequest]) (*connect.Response[v1.RegisterEdgeResponse], error)
+	// DeregisterEdge removes an edge.
+	DeregisterEdge(context.Context, *connect.Request[v1.DeregisterEdgeRequest]) (*connect.Response[v1.DeregisterEdgeResponse], error)
+	// UpdateEdgeStatus sets the status of a registered edge.
+	UpdateEdgeStatus(context.Context, *connect.Request[v1.UpdateEdgeStatusRequest]) (*connect.Response[v1.UpdateEdgeStatusResponse], error)
+	// GetTopologySnapshot returns the topology as of a past snapshot ID or time.
+	// Snapshots no longer retained in the history return NOT_FOUND.
+	GetTopologySnapshot(context.Context, *connect.Request[v1.GetTopologySnapshotRequest]) (*connect.Response[v1.TopologyStructureResponse], error)
+	// DiffTopologySnapshots compares two snapshots into added, removed and changed nodes and edges.
+	DiffTopologySnapshots(context.Context, *connect.Request[v1.DiffTopologySnapshotsRequest]) (*connect.Response[v1.DiffTopologySnapshotsResponse], error)
+	// GetDependencies returns the transitive upstream and downstream nodes of a node.
+	GetDependencies(context.Context, *connect.Request[v1.GetDependenciesRequest]) (*connect.Response[v1.GetDependenciesResponse], error)
+	// GetBlastRadius returns the nodes and edges affected by a node failure.
+	GetBlastRadius(context.Context, *connect.Request[v1.GetBlastRadiusRequest]) (*connect.Response[v1.GetBlastRadiusResponse], error)
+	// AnalyzeTopology returns single points of failure and dependency cycles.
+	AnalyzeTopology(context.Context, *connect.Request[v1.AnalyzeTopologyRequest]) (*connect.Response[v1.AnalyzeTopologyResponse], error)
+	// GetCriticalPaths returns the slowest dependency chains weighted by edge latency.
+	// Edges inside a cycle are left out so every path is finite. Past snapshots are weighted
+	// with recorded edge metadata only; edges without latency data are listed in the response.
+	GetCriticalPaths(context.Context, *connect.Request[v1.GetCriticalPathsRequest]) (*connect.Response[v1.GetCriticalPathsResponse], error)
 }
 
 // NewTopologyServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -207,6 +445,78 @@ func NewTopologyServiceHandler(svc TopologyServiceHandler, opts ...connect.Handl
 		connect.WithSchema(topologyServiceMethods.ByName("StreamMetricsUpdates")),
 		connect.WithHandlerOptions(opts...),
 	)
+	topologyServiceRegisterNodeHandler := connect.NewUnaryHandler(
+		TopologyServiceRegisterNodeProcedure,
+		svc.RegisterNode,
+		connect.WithSchema(topologyServiceMethods.ByName("RegisterNode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceDeregisterNodeHandler := connect.NewUnaryHandler(
+		TopologyServiceDeregisterNodeProcedure,
+		svc.DeregisterNode,
+		connect.WithSchema(topologyServiceMethods.ByName("DeregisterNode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceUpdateNodeStatusHandler := connect.NewUnaryHandler(
+		TopologyServiceUpdateNodeStatusProcedure,
+		svc.UpdateNodeStatus,
+		connect.WithSchema(topologyServiceMethods.ByName("UpdateNodeStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceRegisterEdgeHandler := connect.NewUnaryHandler(
+		TopologyServiceRegisterEdgeProcedure,
+		svc.RegisterEdge,
+		connect.WithSchema(topologyServiceMethods.ByName("RegisterEdge")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceDeregisterEdgeHandler := connect.NewUnaryHandler(
+		TopologyServiceDeregisterEdgeProcedure,
+		svc.DeregisterEdge,
+		connect.WithSchema(topologyServiceMethods.ByName("DeregisterEdge")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceUpdateEdgeStatusHandler := connect.NewUnaryHandler(
+		TopologyServiceUpdateEdgeStatusProcedure,
+		svc.UpdateEdgeStatus,
+		connect.WithSchema(topologyServiceMethods.ByName("UpdateEdgeStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceGetTopologySnapshotHandler := connect.NewUnaryHandler(
+		TopologyServiceGetTopologySnapshotProcedure,
+		svc.GetTopologySnapshot,
+		connect.WithSchema(topologyServiceMethods.ByName("GetTopologySnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceDiffTopologySnapshotsHandler := connect.NewUnaryHandler(
+		TopologyServiceDiffTopologySnapshotsProcedure,
+		svc.DiffTopologySnapshots,
+		connect.WithSchema(topologyServiceMethods.ByName("DiffTopologySnapshots")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceGetDependenciesHandler := connect.NewUnaryHandler(
+		TopologyServiceGetDependenciesProcedure,
+		svc.GetDependencies,
+		connect.WithSchema(topologyServiceMethods.ByName("GetDependencies")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceGetBlastRadiusHandler := connect.NewUnaryHandler(
+		TopologyServiceGetBlastRadiusProcedure,
+		svc.GetBlastRadius,
+		connect.WithSchema(topologyServiceMethods.ByName("GetBlastRadius")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceAnalyzeTopologyHandler := connect.NewUnaryHandler(
+		TopologyServiceAnalyzeTopologyProcedure,
+		svc.AnalyzeTopology,
+		connect.WithSchema(topologyServiceMethods.ByName("AnalyzeTopology")),
+		connect.WithHandlerOptions(opts...),
+	)
+	topologyServiceGetCriticalPathsHandler := connect.NewUnaryHandler(
+		TopologyServiceGetCriticalPathsProcedure,
+		svc.GetCriticalPaths,
+		connect.WithSchema(topologyServiceMethods.ByName("GetCriticalPaths")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/audit.v1.TopologyService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TopologyServiceGetTopologyStructureProcedure:
@@ -219,6 +529,30 @@ func NewTopologyServiceHandler(svc TopologyServiceHandler, opts ...connect.Handl
 			topologyServiceStreamTopologyChangesHandler.ServeHTTP(w, r)
 		case TopologyServiceStreamMetricsUpdatesProcedure:
 			topologyServiceStreamMetricsUpdatesHandler.ServeHTTP(w, r)
+		case TopologyServiceRegisterNodeProcedure:
+			topologyServiceRegisterNodeHandler.ServeHTTP(w, r)
+		case TopologyServiceDeregisterNodeProcedure:
+			topologyServiceDeregisterNodeHandler.ServeHTTP(w, r)
+		case TopologyServiceUpdateNodeStatusProcedure:
+			topologyServiceUpdateNodeStatusHandler.ServeHTTP(w, r)
+		case TopologyServiceRegisterEdgeProcedure:
+			topologyServiceRegisterEdgeHandler.ServeHTTP(w, r)
+		case TopologyServiceDeregisterEdgeProcedure:
+			topologyServiceDeregisterEdgeHandler.ServeHTTP(w, r)
+		case TopologyServiceUpdateEdgeStatusProcedure:
+			topologyServiceUpdateEdgeStatusHandler.ServeHTTP(w, r)
+		case TopologyServiceGetTopologySnapshotProcedure:
+			topologyServiceGetTopologySnapshotHandler.ServeHTTP(w, r)
+		case TopologyServiceDiffTopologySnapshotsProcedure:
+			topologyServiceDiffTopologySnapshotsHandler.ServeHTTP(w, r)
+		case TopologyServiceGetDependenciesProcedure:
+			topologyServiceGetDependenciesHandler.ServeHTTP(w, r)
+		case TopologyServiceGetBlastRadiusProcedure:
+			topologyServiceGetBlastRadiusHandler.ServeHTTP(w, r)
+		case TopologyServiceAnalyzeTopologyProcedure:
+			topologyServiceAnalyzeTopologyHandler.ServeHTTP(w, r)
+		case TopologyServiceGetCriticalPathsProcedure:
+			topologyServiceGetCriticalPathsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -247,3 +581,51 @@ func (UnimplementedTopologyServiceHandler) StreamTopologyChanges(context.Context
 func (UnimplementedTopologyServiceHandler) StreamMetricsUpdates(context.Context, *connect.Request[v1.StreamMetricsUpdatesRequest], *connect.ServerStream[v1.MetricsUpdate]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.StreamMetricsUpdates is not implemented"))
 }
+
+func (UnimplementedTopologyServiceHandler) RegisterNode(context.Context, *connect.Request[v1.RegisterNodeRequest]) (*connect.Response[v1.RegisterNodeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.RegisterNode is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) DeregisterNode(context.Context, *connect.Request[v1.DeregisterNodeRequest]) (*connect.Response[v1.DeregisterNodeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.DeregisterNode is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) UpdateNodeStatus(context.Context, *connect.Request[v1.UpdateNodeStatusRequest]) (*connect.Response[v1.UpdateNodeStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.UpdateNodeStatus is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) RegisterEdge(context.Context, *connect.Request[v1.RegisterEdgeRequest]) (*connect.Response[v1.RegisterEdgeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.RegisterEdge is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) DeregisterEdge(context.Context, *connect.Request[v1.DeregisterEdgeRequest]) (*connect.Response[v1.DeregisterEdgeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.DeregisterEdge is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) UpdateEdgeStatus(context.Context, *connect.Request[v1.UpdateEdgeStatusRequest]) (*connect.Response[v1.UpdateEdgeStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.UpdateEdgeStatus is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) GetTopologySnapshot(context.Context, *connect.Request[v1.GetTopologySnapshotRequest]) (*connect.Response[v1.TopologyStructureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.GetTopologySnapshot is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) DiffTopologySnapshots(context.Context, *connect.Request[v1.DiffTopologySnapshotsRequest]) (*connect.Response[v1.DiffTopologySnapshotsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.DiffTopologySnapshots is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) GetDependencies(context.Context, *connect.Request[v1.GetDependenciesRequest]) (*connect.Response[v1.GetDependenciesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.GetDependencies is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) GetBlastRadius(context.Context, *connect.Request[v1.GetBlastRadiusRequest]) (*connect.Response[v1.GetBlastRadiusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.GetBlastRadius is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) AnalyzeTopology(context.Context, *connect.Request[v1.AnalyzeTopologyRequest]) (*connect.Response[v1.AnalyzeTopologyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.AnalyzeTopology is not implemented"))
+}
+
+func (UnimplementedTopologyServiceHandler) GetCriticalPaths(context.Context, *connect.Request[v1.GetCriticalPathsRequest]) (*connect.Response[v1.GetCriticalPathsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit.v1.TopologyService.GetCriticalPaths is not implemented"))
+}
@@ -39,6 +39,35 @@ type TopologyServiceClient interface {
 	// Minimum update interval is 1 second (enforced server-side).
 	// Empty node_ids/edge_ids subscribes to all elements.
 	StreamMetricsUpdates(ctx context.Context, in *StreamMetricsUpdatesRequest, opts ...grpc.CallOption) (TopologyService_StreamMetricsUpdatesClient, error)
+	// RegisterNode adds a service node, or replaces the node with the same ID.
+	// Every change is published on StreamTopologyChanges under a new snapshot ID.
+	RegisterNode(ctx context.Context, in *RegisterNodeRequest, opts ...grpc.CallOption) (*RegisterNodeResponse, error)
+	// DeregisterNode removes a node and every edge to or from it.
+	DeregisterNode(ctx context.Context, in *DeregisterNodeRequest, opts ...grpc.CallOption) (*DeregisterNodeResponse, error)
+	// UpdateNodeStatus sets the status of a registered node.
+	UpdateNodeStatus(ctx context.Context, in *UpdateNodeStatusRequest, opts ...grpc.CallOption) (*UpdateNodeStatusResponse, error)
+	// RegisterEdge adds a directed edge between two registered nodes, or replaces the edge with the same ID.
+	// Edges to unknown nodes are rejected with INVALID_ARGUMENT.
+	RegisterEdge(ctx context.Context, in *RegisterEdgeRequest, opts ...grpc.CallOption) (*RegisterEdgeResponse, error)
+	// DeregisterEdge removes an edge.
+	DeregisterEdge(ctx context.Context, in *DeregisterEdgeRequest, opts ...grpc.CallOption) (*DeregisterEdgeResponse, error)
+	// UpdateEdgeStatus sets the status of a registered edge.
+	UpdateEdgeStatus(ctx context.Context, in *UpdateEdgeStatusRequest, opts ...grpc.CallOption) (*UpdateEdgeStatusResponse, error)
+	// GetTopologySnapshot returns the topology as of a past snapshot ID or time.
+	// Snapshots no longer retained in the history return NOT_FOUND.
+	GetTopologySnapshot(ctx context.Context, in *GetTopologySnapshotRequest, opts ...grpc.CallOption) (*TopologyStructureResponse, error)
+	// DiffTopologySnapshots compares two snapshots into added, removed and changed nodes and edges.
+	DiffTopologySnapshots(ctx context.Context, in *DiffTopologySnapshotsRequest, opts ...grpc.CallOption) (*DiffTopologySnapshotsResponse, error)
+	// GetDependencies returns the transitive upstream and downstream nodes of a node.
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
+	// GetBlastRadius returns the nodes and edges affected by a node failure.
+	GetBlastRadius(ctx context.Context, in *GetBlastRadiusRequest, opts ...grpc.CallOption) (*GetBlastRadiusResponse, error)
+	// AnalyzeTopology returns single points of failure and dependency cycles.
+	AnalyzeTopology(ctx context.Context, in *AnalyzeTopologyRequest, opts ...grpc.CallOption) (*AnalyzeTopologyResponse, error)
+	// GetCriticalPaths returns the slowest dependency chains weighted by edge latency.
+	// Edges inside a cycle are left out so every path is finite. Past snapshots are weighted
+	// with recorded edge metadata only; edges without latency data are listed in the response.
+	GetCriticalPaths(ctx context.Context, in *GetCriticalPathsRequest, opts ...grpc.CallOption) (*GetCriticalPathsResponse, error)
 }
 
 type topologyServiceClient struct {
@@ -140,6 +169,114 @@ func (x *topologyServiceStreamMetricsUpdatesClient) Recv() (*MetricsUpdate, erro
 	return m, nil
 }
 
+func (c *topologyServiceClient) RegisterNode(ctx context.Context, in *RegisterNodeRequest, opts ...grpc.CallOption) (*RegisterNodeResponse, error) {
+	out := new(RegisterNodeResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/RegisterNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) DeregisterNode(ctx context.Context, in *DeregisterNodeRequest, opts ...grpc.CallOption) (*DeregisterNodeResponse, error) {
+	out := new(DeregisterNodeResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/DeregisterNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) UpdateNodeStatus(ctx context.Context, in *UpdateNodeStatusRequest, opts ...grpc.CallOption) (*UpdateNodeStatusResponse, error) {
+	out := new(UpdateNodeStatusResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/UpdateNodeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) RegisterEdge(ctx context.Context, in *RegisterEdgeRequest, opts ...grpc.CallOption) (*RegisterEdgeResponse, error) {
+	out := new(RegisterEdgeResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/RegisterEdge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) DeregisterEdge(ctx context.Context, in *DeregisterEdgeRequest, opts ...grpc.CallOption) (*DeregisterEdgeResponse, error) {
+	out := new(DeregisterEdgeResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/DeregisterEdge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) UpdateEdgeStatus(ctx context.Context, in *UpdateEdgeStatusRequest, opts ...grpc.CallOption) (*UpdateEdgeStatusResponse, error) {
+	out := new(UpdateEdgeStatusResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/UpdateEdgeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) GetTopologySnapshot(ctx context.Context, in *GetTopologySnapshotRequest, opts ...grpc.CallOption) (*TopologyStructureResponse, error) {
+	out := new(TopologyStructureResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/GetTopologySnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) DiffTopologySnapshots(ctx context.Context, in *DiffTopologySnapshotsRequest, opts ...grpc.CallOption) (*DiffTopologySnapshotsResponse, error) {
+	out := new(DiffTopologySnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/DiffTopologySnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error) {
+	out := new(GetDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/GetDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) GetBlastRadius(ctx context.Context, in *GetBlastRadiusRequest, opts ...grpc.CallOption) (*GetBlastRadiusResponse, error) {
+	out := new(GetBlastRadiusResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/GetBlastRadius", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) AnalyzeTopology(ctx context.Context, in *AnalyzeTopologyRequest, opts ...grpc.CallOption) (*AnalyzeTopologyResponse, error) {
+	out := new(AnalyzeTopologyResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/AnalyzeTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topologyServiceClient) GetCriticalPaths(ctx context.Context, in *GetCriticalPathsRequest, opts ...grpc.CallOption) (*GetCriticalPathsResponse, error) {
+	out := new(GetCriticalPathsResponse)
+	err := c.cc.Invoke(ctx, "/audit.v1.TopologyService/GetCriticalPaths", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TopologyServiceServer is the server API for TopologyService service.
 // All implementations should embed UnimplementedTopologyServiceServer
 // for forward compatibility
@@ -161,6 +298,35 @@ type TopologyServiceServer interface {
 	// Minimum update interval is 1 second (enforced server-side).
 	// Empty node_ids/edge_ids subscribes to all elements.
 	StreamMetricsUpdates(*StreamMetricsUpdatesRequest, TopologyService_StreamMetricsUpdatesServer) error
+	// RegisterNode adds a service node, or replaces the node with the same ID.
+	// Every change is published on StreamTopologyChanges under a new snapshot ID.
+	RegisterNode(context.Context, *RegisterNodeRequest) (*RegisterNodeResponse, error)
+	// DeregisterNode removes a node and every edge to or from it.
+	DeregisterNode(context.Context, *DeregisterNodeRequest) (*DeregisterNodeResponse, error)
+	// UpdateNodeStatus sets the status of a registered node.
+	UpdateNodeStatus(context.Context, *UpdateNodeStatusRequest) (*UpdateNodeStatusResponse, error)
+	// RegisterEdge adds a directed edge between two registered nodes, or replaces the edge with the same ID.
+	// Edges to unknown nodes are rejected with INVALID_ARGUMENT.
+	RegisterEdge(context.Context, *RegisterEdgeRequest) (*RegisterEdgeResponse, error)
+	// DeregisterEdge removes an edge.
+	DeregisterEdge(context.Context, *DeregisterEdgeRequest) (*DeregisterEdgeResponse, error)
+	// UpdateEdgeStatus sets the status of a registered edge.
+	UpdateEdgeStatus(context.Context, *UpdateEdgeStatusRequest) (*UpdateEdgeStatusResponse, error)
+	// GetTopologySnapshot returns the topology as of a past snapshot ID or time.
+	// Snapshots no longer retained in the history return NOT_FOUND.
+	GetTopologySnapshot(context.Context, *GetTopologySnapshotRequest) (*TopologyStructureResponse, error)
+	// DiffTopologySnapshots compares two snapshots into added, removed and changed nodes and edges.
+	DiffTopologySnapshots(context.Context, *DiffTopologySnapshotsRequest) (*DiffTopologySnapshotsResponse, error)
+	// GetDependencies returns the transitive upstream and downstream nodes of a node.
+	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
+	// GetBlastRadius returns the nodes and edges affected by a node failure.
+	GetBlastRadius(context.Context, *GetBlastRadiusRequest) (*GetBlastRadiusResponse, error)
+	// AnalyzeTopology returns single points of failure and dependency cycles.
+	AnalyzeTopology(context.Context, *AnalyzeTopologyRequest) (*AnalyzeTopologyResponse, error)
+	// GetCriticalPaths returns the slowest dependency chains weighted by edge latency.
+	// Edges inside a cycle are left out so every path is finite. Past snapshots are weighted
+	// with recorded edge metadata only; edges without latency data are listed in the response.
+	GetCriticalPaths(context.Context, *GetCriticalPathsRequest) (*GetCriticalPathsResponse, error)
 }
 
 // UnimplementedTopologyServiceServer should be embedded to have forward compatible implementations.
@@ -182,6 +348,42 @@ func (UnimplementedTopologyServiceServer) StreamTopologyChanges(*StreamTopologyC
 func (UnimplementedTopologyServiceServer) StreamMetricsUpdates(*StreamMetricsUpdatesRequest, TopologyService_StreamMetricsUpdatesServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamMetricsUpdates not implemented")
 }
+func (UnimplementedTopologyServiceServer) RegisterNode(context.Context, *RegisterNodeRequest) (*RegisterNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterNode not implemented")
+}
+func (UnimplementedTopologyServiceServer) DeregisterNode(context.Context, *DeregisterNodeRequest) (*DeregisterNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterNode not implemented")
+}
+func (UnimplementedTopologyServiceServer) UpdateNodeStatus(context.Context, *UpdateNodeStatusRequest) (*UpdateNodeStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNodeStatus not implemented")
+}
+func (UnimplementedTopologyServiceServer) RegisterEdge(context.Context, *RegisterEdgeRequest) (*RegisterEdgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterEdge not implemented")
+}
+func (UnimplementedTopologyServiceServer) DeregisterEdge(context.Context, *DeregisterEdgeRequest) (*DeregisterEdgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterEdge not implemented")
+}
+func (UnimplementedTopologyServiceServer) UpdateEdgeStatus(context.Context, *UpdateEdgeStatusRequest) (*UpdateEdgeStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEdgeStatus not implemented")
+}
+func (UnimplementedTopologyServiceServer) GetTopologySnapshot(context.Context, *GetTopologySnapshotRequest) (*TopologyStructureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopologySnapshot not implemented")
+}
+func (UnimplementedTopologyServiceServer) DiffTopologySnapshots(context.Context, *DiffTopologySnapshotsRequest) (*DiffTopologySnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffTopologySnapshots not implemented")
+}
+func (UnimplementedTopologyServiceServer) GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencies not implemented")
+}
+func (UnimplementedTopologyServiceServer) GetBlastRadius(context.Context, *GetBlastRadiusRequest) (*GetBlastRadiusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlastRadius not implemented")
+}
+func (UnimplementedTopologyServiceServer) AnalyzeTopology(context.Context, *AnalyzeTopologyRequest) (*AnalyzeTopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeTopology not implemented")
+}
+func (UnimplementedTopologyServiceServer) GetCriticalPaths(context.Context, *GetCriticalPathsRequest) (*GetCriticalPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCriticalPaths not implemented")
+}
 
 // UnsafeTopologyServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TopologyServiceServer will
@@ -290,6 +492,222 @@ func (x *topologyServiceStreamMetricsUpdatesServer) Send(m *MetricsUpdate) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TopologyService_RegisterNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).RegisterNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/RegisterNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).RegisterNode(ctx, req.(*RegisterNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_DeregisterNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).DeregisterNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/DeregisterNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).DeregisterNode(ctx, req.(*DeregisterNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_UpdateNodeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).UpdateNodeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/UpdateNodeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).UpdateNodeStatus(ctx, req.(*UpdateNodeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_RegisterEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).RegisterEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/RegisterEdge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).RegisterEdge(ctx, req.(*RegisterEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_DeregisterEdge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterEdgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).DeregisterEdge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/DeregisterEdge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).DeregisterEdge(ctx, req.(*DeregisterEdgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_UpdateEdgeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEdgeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).UpdateEdgeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/UpdateEdgeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).UpdateEdgeStatus(ctx, req.(*UpdateEdgeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_GetTopologySnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopologySnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).GetTopologySnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/GetTopologySnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).GetTopologySnapshot(ctx, req.(*GetTopologySnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_DiffTopologySnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffTopologySnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).DiffTopologySnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/DiffTopologySnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).DiffTopologySnapshots(ctx, req.(*DiffTopologySnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/GetDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_GetBlastRadius_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlastRadiusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).GetBlastRadius(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/GetBlastRadius",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).GetBlastRadius(ctx, req.(*GetBlastRadiusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_AnalyzeTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).AnalyzeTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/AnalyzeTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).AnalyzeTopology(ctx, req.(*AnalyzeTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopologyService_GetCriticalPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCriticalPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopologyServiceServer).GetCriticalPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.v1.TopologyService/GetCriticalPaths",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopologyServiceServer).GetCriticalPaths(ctx, req.(*GetCriticalPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TopologyService_ServiceDesc is the grpc.ServiceDesc for TopologyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -309,6 +727,54 @@ var TopologyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEdgeMetadata",
 			Handler:    _TopologyService_GetEdgeMetadata_Handler,
 		},
+		{
+			MethodName: "RegisterNode",
+			Handler:    _TopologyService_RegisterNode_Handler,
+		},
+		{
+			MethodName: "DeregisterNode",
+			Handler:    _TopologyService_DeregisterNode_Handler,
+		},
+		{
+			MethodName: "UpdateNodeStatus",
+			Handler:    _TopologyService_UpdateNodeStatus_Handler,
+		},
+		{
+			MethodName: "RegisterEdge",
+			Handler:    _TopologyService_RegisterEdge_Handler,
+		},
+		{
+			MethodName: "DeregisterEdge",
+			Handler:    _TopologyService_DeregisterEdge_Handler,
+		},
+		{
+			MethodName: "UpdateEdgeStatus",
+			Handler:    _TopologyService_UpdateEdgeStatus_Handler,
+		},
+		{
+			MethodName: "GetTopologySnapshot",
+			Handler:    _TopologyService_GetTopologySnapshot_Handler,
+		},
+		{
+			MethodName: "DiffTopologySnapshots",
+			Handler:    _TopologyService_DiffTopologySnapshots_Handler,
+		},
+		{
+			MethodName: "GetDependencies",
+			Handler:    _TopologyService_GetDependencies_Handler,
+		},
+		{
+			MethodName: "GetBlastRadius",
+			Handler:    _TopologyService_GetBlastRadius_Handler,
+		},
+		{
+			MethodName: "AnalyzeTopology",
+			Handler:    _TopologyService_AnalyzeTopology_Handler,
+		},
+		{
+			MethodName: "GetCriticalPaths",
+			Handler:    _TopologyService_GetCriticalPaths_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -84,6 +84,10 @@ func (m *mockTopologyRepository) SaveTopologySnapshot(ctx context.Context, topol
 	return m.err
 }
 
+func (m *mockTopologyRepository) SetSnapshotID(ctx context.Context, snapshotID string) error {
+	return m.err
+}
+
 func TestGetTopologyStructureUseCase_Execute(t *testing.T) {
 	// Create test topology
 	topology := entities.NewNetworkTopology("snapshot-123")
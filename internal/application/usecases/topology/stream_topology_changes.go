@@ -9,6 +9,10 @@ import (
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
 )
 
+// maxPendingChanges bounds the changes held between throttled flushes; once reached, changes are
+// left with the publisher until the next flush
+const maxPendingChanges = 1000
+
 // StreamTopologyChangesRequest represents the request to stream topology changes
 type StreamTopologyChangesRequest struct {
 	FromSnapshotID string
@@ -51,41 +55,47 @@ func (uc *StreamTopologyChangesUseCase) Execute(ctx context.Context, req *Stream
 		return nil, fmt.Errorf("failed to subscribe to topology changes: %w", err)
 	}
 
-	// Apply rate limiting based on MinInterval
+	// Apply rate limiting based on MinInterval: changes are delivered in batches at most once
+	// per interval, keeping every change in order since clients apply them incrementally.
+	// Reading stops while maxPendingChanges are waiting, so a slow flush cannot grow the batch
+	// without bound; the publisher then applies its own policy for a full subscriber.
 	throttledChan := make(chan *ports.TopologyChangeEvent)
 	go func() {
 		defer close(throttledChan)
 		ticker := time.NewTicker(req.MinInterval)
 		defer ticker.Stop()
 
-		var pendingChange *ports.TopologyChangeEvent
+		var pending []*ports.TopologyChangeEvent
+		flush := func() bool {
+			for _, change := range pending {
+				select {
+				case throttledChan <- change:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			pending = pending[:0]
+			return true
+		}
 
 		for {
+			incoming := changeChan
+			if len(pending) >= maxPendingChanges {
+				incoming = nil
+			}
 			select {
 			case <-ctx.Done():
 				return
-			case change, ok := <-changeChan:
+			case change, ok := <-incoming:
 				if !ok {
-					// Channel closed, send any pending change and exit
-					if pendingChange != nil {
-						select {
-						case throttledChan <- pendingChange:
-						case <-ctx.Done():
-						}
-					}
+					// Channel closed, send any pending changes and exit
+					flush()
 					return
 				}
-				// Store the most recent change
-				pendingChange = change
+				pending = append(pending, change)
 			case <-ticker.C:
-				// Send pending change if available
-				if pendingChange != nil {
-					select {
-					case throttledChan <- pendingChange:
-						pendingChange = nil
-					case <-ctx.Done():
-						return
-					}
+				if !flush() {
+					return
 				}
 			}
 		}
@@ -0,0 +1,58 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// feedPublisher hands out a channel the test feeds directly
+type feedPublisher struct {
+	ports.TopologyChangePublisher
+	feed chan *ports.TopologyChangeEvent
+}
+
+func (p *feedPublisher) Subscribe(ctx context.Context, fromSnapshotID string, filters *entities.TopologyFilters) (<-chan *ports.TopologyChangeEvent, error) {
+	return p.feed, nil
+}
+
+func TestStreamTopologyChangesUseCase_BoundsPendingChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	publisher := &feedPublisher{feed: make(chan *ports.TopologyChangeEvent)}
+
+	changes, err := NewStreamTopologyChangesUseCase(publisher).Execute(ctx, &StreamTopologyChangesRequest{MinInterval: time.Second})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// A full batch is accepted before the first flush, but no more
+	for i := 0; i < maxPendingChanges; i++ {
+		select {
+		case publisher.feed <- &ports.TopologyChangeEvent{ChangeID: fmt.Sprintf("change-%d", i)}:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected change %d to be accepted", i)
+		}
+	}
+	select {
+	case publisher.feed <- &ports.TopologyChangeEvent{ChangeID: "overflow"}:
+		t.Fatal("Expected the stream to stop reading with a full batch pending")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The batch is delivered in order at the next flush
+	for i := 0; i < maxPendingChanges; i++ {
+		select {
+		case change := <-changes:
+			if change.ChangeID != fmt.Sprintf("change-%d", i) {
+				t.Fatalf("Expected change-%d, got %s", i, change.ChangeID)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("Expected change %d to be delivered", i)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -30,10 +31,52 @@ type Config struct {
 	// Service Discovery
 	HealthCheckInterval time.Duration
 
+	// Topology discovery from the service registry
+	DiscoveryInterval     time.Duration
+	DiscoveryStaleAfter   time.Duration
+	DiscoveryDeadAfter    time.Duration
+	DiscoveryServiceNames []string
+
+	// Active health probing of topology nodes and critical edges
+	HealthProbeEnabled       bool
+	HealthProbeInterval      time.Duration
+	HealthProbeTimeout       time.Duration
+	HealthProbeSlowThreshold time.Duration
+	HealthProbeDegradedAfter int
+	HealthProbeDeadAfter     int
+	HealthProbeRecoverAfter  int
+
+	// Topology edges inferred from traces
+	EdgeInferenceInterval time.Duration
+	EdgeInferenceWindow   time.Duration
+
 	// Client Configuration
 	RequestTimeout time.Duration
 	CacheTTL       time.Duration
 
+	// Validation
+	AssertionSuitesPath string
+	RiskScenariosPath   string
+
+	// Correlation
+	BusinessKeysPath string
+
+	// Notifications
+	SavedQueriesPath      string
+	NotificationFilesPath string
+	// NotificationSinkHosts allow-lists webhook and Slack sink hosts; empty allows public addresses only
+	NotificationSinkHosts []string
+
+	// Retention
+	RetentionPoliciesPath  string
+	ArchivePath            string
+	RetentionSweepInterval time.Duration
+
+	// Access audit
+	AccessLogPath  string
+	AccessLogKey   string // HMAC key for the access log chain; empty chains with plain SHA-256
+	AdminAuthToken string
+
 	// Logging
 	LogLevel string
 
@@ -63,10 +106,58 @@ func Load() *Config {
 		// Service Discovery
 		HealthCheckInterval: getEnvAsDuration("HEALTH_CHECK_INTERVAL", 30*time.Second),
 
+		// Topology discovery
+		DiscoveryInterval:   getEnvAsDuration("DISCOVERY_INTERVAL", 30*time.Second),
+		DiscoveryStaleAfter: getEnvAsDuration("DISCOVERY_STALE_AFTER", 90*time.Second),
+		DiscoveryDeadAfter:  getEnvAsDuration("DISCOVERY_DEAD_AFTER", 5*time.Minute),
+		DiscoveryServiceNames: getEnvAsList("DISCOVERY_SERVICE_NAMES", []string{
+			"audit-correlator",
+			"exchange-simulator",
+			"custodian-simulator",
+			"market-data-simulator",
+			"risk-monitor",
+			"trading-system-engine",
+		}),
+
+		// Health probing
+		HealthProbeEnabled:       getEnvAsBool("HEALTH_PROBE_ENABLED", true),
+		HealthProbeInterval:      getEnvAsDuration("HEALTH_PROBE_INTERVAL", 15*time.Second),
+		HealthProbeTimeout:       getEnvAsDuration("HEALTH_PROBE_TIMEOUT", 2*time.Second),
+		HealthProbeSlowThreshold: getEnvAsDuration("HEALTH_PROBE_SLOW_THRESHOLD", time.Second),
+		HealthProbeDegradedAfter: getEnvAsInt("HEALTH_PROBE_DEGRADED_AFTER", 1),
+		HealthProbeDeadAfter:     getEnvAsInt("HEALTH_PROBE_DEAD_AFTER", 3),
+		HealthProbeRecoverAfter:  getEnvAsInt("HEALTH_PROBE_RECOVER_AFTER", 2),
+
+		// Edge inference
+		EdgeInferenceInterval: getEnvAsDuration("EDGE_INFERENCE_INTERVAL", 5*time.Minute),
+		EdgeInferenceWindow:   getEnvAsDuration("EDGE_INFERENCE_WINDOW", time.Hour),
+
 		// Client Configuration
 		RequestTimeout: getEnvAsDuration("REQUEST_TIMEOUT", 10*time.Second),
 		CacheTTL:       getEnvAsDuration("CACHE_TTL", 5*time.Minute),
 
+		// Validation
+		AssertionSuitesPath: getEnv("ASSERTION_SUITES_PATH", "/app/config/assertions.json"),
+		RiskScenariosPath:   getEnv("RISK_SCENARIOS_PATH", "/app/config/risk_scenarios.json"),
+
+		// Correlation
+		BusinessKeysPath: getEnv("BUSINESS_KEYS_PATH", "/app/config/business_keys.json"),
+
+		// Notifications
+		SavedQueriesPath:      getEnv("SAVED_QUERIES_PATH", "/app/config/saved_queries.json"),
+		NotificationFilesPath: getEnv("NOTIFICATION_FILES_PATH", "/app/data/notifications"),
+		NotificationSinkHosts: getEnvAsList("NOTIFICATION_SINK_ALLOWED_HOSTS", nil),
+
+		// Retention
+		RetentionPoliciesPath:  getEnv("RETENTION_POLICIES_PATH", "/app/config/retention.json"),
+		ArchivePath:            getEnv("ARCHIVE_PATH", "/app/data/archive"),
+		RetentionSweepInterval: getEnvAsDuration("RETENTION_SWEEP_INTERVAL", time.Hour),
+
+		// Access audit
+		AccessLogPath:  getEnv("ACCESS_LOG_PATH", "/app/data/access/access.log"),
+		AccessLogKey:   getEnv("ACCESS_LOG_HMAC_KEY", ""),
+		AdminAuthToken: getEnv("AUDIT_ADMIN_TOKEN", ""),
+
 		// Logging
 		LogLevel: getEnv("LOG_LEVEL", "info"),
 
@@ -130,6 +221,30 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList splits a comma-separated variable, ignoring empty entries
+func getEnvAsList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // InitializeDataAdapter initializes the DataAdapter with logging
 func (c *Config) InitializeDataAdapter(ctx context.Context, logger *logrus.Logger) error {
 	if c.dataAdapter != nil {
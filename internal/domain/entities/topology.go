@@ -1,6 +1,10 @@
 package entities
 
 import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -85,6 +89,23 @@ func (n *ServiceNode) IsHealthy() bool {
 	return n.Status == NodeStatusLive
 }
 
+// Clone returns a copy of the node that shares no state with it
+func (n *ServiceNode) Clone() *ServiceNode {
+	clone := *n
+	clone.Labels = cloneLabels(n.Labels)
+	return &clone
+}
+
+// SameAs reports whether two versions of a node differ at most in their timestamps
+func (n *ServiceNode) SameAs(other *ServiceNode) bool {
+	return n.ID == other.ID &&
+		n.Name == other.Name &&
+		n.ServiceType == other.ServiceType &&
+		n.InstanceName == other.InstanceName &&
+		n.Status == other.Status &&
+		maps.Equal(n.Labels, other.Labels)
+}
+
 // ServiceConnection represents a connection between two service nodes
 type ServiceConnection struct {
 	ID         string
@@ -139,6 +160,50 @@ func (c *ServiceConnection) IsHealthy() bool {
 	return c.Status == EdgeStatusActive
 }
 
+// Clone returns a copy of the connection that shares no state with it
+func (c *ServiceConnection) Clone() *ServiceConnection {
+	clone := *c
+	clone.Labels = cloneLabels(c.Labels)
+	return &clone
+}
+
+// SameAs reports whether two versions of a connection differ at most in their timestamps
+func (c *ServiceConnection) SameAs(other *ServiceConnection) bool {
+	return c.ID == other.ID &&
+		c.SourceID == other.SourceID &&
+		c.TargetID == other.TargetID &&
+		c.Type == other.Type &&
+		c.Status == other.Status &&
+		c.IsCritical == other.IsCritical &&
+		maps.Equal(c.Labels, other.Labels)
+}
+
+func cloneLabels(labels map[string]string) map[string]string {
+	clone := make(map[string]string, len(labels))
+	for key, value := range labels {
+		clone[key] = value
+	}
+	return clone
+}
+
+// snapshotIDPrefix prefixes the version number in snapshot IDs
+const snapshotIDPrefix = "snapshot-"
+
+// SnapshotIDForVersion returns the snapshot ID of a topology version
+func SnapshotIDForVersion(version uint64) string {
+	return snapshotIDPrefix + strconv.FormatUint(version, 10)
+}
+
+// ParseSnapshotVersion returns the topology version of a snapshot ID
+// Snapshot IDs must be compared by version: "snapshot-10" sorts before "snapshot-9" as a string
+func ParseSnapshotVersion(snapshotID string) (uint64, error) {
+	version, err := strconv.ParseUint(strings.TrimPrefix(snapshotID, snapshotIDPrefix), 10, 64)
+	if err != nil || !strings.HasPrefix(snapshotID, snapshotIDPrefix) {
+		return 0, fmt.Errorf("invalid snapshot ID: %q", snapshotID)
+	}
+	return version, nil
+}
+
 // NetworkTopology represents the complete network topology
 type NetworkTopology struct {
 	SnapshotID   string
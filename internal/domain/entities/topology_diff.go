@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// NodeChange is a node present in both snapshots of a diff with different contents
+type NodeChange struct {
+	Before *ServiceNode
+	After  *ServiceNode
+}
+
+// ConnectionChange is a connection present in both snapshots of a diff with different contents
+type ConnectionChange struct {
+	Before *ServiceConnection
+	After  *ServiceConnection
+}
+
+// TopologyDiff describes how the topology changed between two snapshots
+// Nodes and connections are sorted by ID; differences only in timestamps are not changes
+type TopologyDiff struct {
+	FromSnapshotID   string
+	ToSnapshotID     string
+	FromSnapshotTime time.Time
+	ToSnapshotTime   time.Time
+
+	AddedNodes   []*ServiceNode
+	RemovedNodes []*ServiceNode
+	ChangedNodes []NodeChange
+
+	AddedConnections   []*ServiceConnection
+	RemovedConnections []*ServiceConnection
+	ChangedConnections []ConnectionChange
+}
+
+// DiffTopologies compares two topology snapshots
+func DiffTopologies(from, to *NetworkTopology) *TopologyDiff {
+	diff := &TopologyDiff{
+		FromSnapshotID:   from.SnapshotID,
+		ToSnapshotID:     to.SnapshotID,
+		FromSnapshotTime: from.SnapshotTime,
+		ToSnapshotTime:   to.SnapshotTime,
+	}
+
+	for _, id := range sortedKeys(to.Nodes) {
+		after := to.Nodes[id]
+		before, exists := from.Nodes[id]
+		switch {
+		case !exists:
+			diff.AddedNodes = append(diff.AddedNodes, after)
+		case !before.SameAs(after):
+			diff.ChangedNodes = append(diff.ChangedNodes, NodeChange{Before: before, After: after})
+		}
+	}
+	for _, id := range sortedKeys(from.Nodes) {
+		if _, exists := to.Nodes[id]; !exists {
+			diff.RemovedNodes = append(diff.RemovedNodes, from.Nodes[id])
+		}
+	}
+
+	for _, id := range sortedKeys(to.Connections) {
+		after := to.Connections[id]
+		before, exists := from.Connections[id]
+		switch {
+		case !exists:
+			diff.AddedConnections = append(diff.AddedConnections, after)
+		case !before.SameAs(after):
+			diff.ChangedConnections = append(diff.ChangedConnections, ConnectionChange{Before: before, After: after})
+		}
+	}
+	for _, id := range sortedKeys(from.Connections) {
+		if _, exists := to.Connections[id]; !exists {
+			diff.RemovedConnections = append(diff.RemovedConnections, from.Connections[id])
+		}
+	}
+
+	return diff
+}
+
+// IsEmpty reports whether the two snapshots have the same structure
+func (d *TopologyDiff) IsEmpty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 && len(d.ChangedNodes) == 0 &&
+		len(d.AddedConnections) == 0 && len(d.RemovedConnections) == 0 && len(d.ChangedConnections) == 0
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,317 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// Graph analytics over a topology snapshot
+// A connection from source to target means the source depends on the target, so a target's
+// failure propagates upstream to its sources. Connections to nodes missing from the snapshot
+// are ignored.
+
+// BlastRadius describes what a node failure affects
+type BlastRadius struct {
+	NodeID string
+	// AffectedNodes depend on the failed node, directly or transitively
+	AffectedNodes []string
+	// AffectedConnections lead from an affected node to the failed node or another affected node
+	AffectedConnections []string
+	// CriticalConnections are the affected connections marked critical
+	CriticalConnections []string
+	// AffectedServiceTypes are the service types of the affected nodes
+	AffectedServiceTypes []string
+}
+
+// CriticalPath is the slowest dependency chain starting at an entry node
+type CriticalPath struct {
+	NodeIDs       []string
+	ConnectionIDs []string
+	TotalLatency  time.Duration
+}
+
+// topologyGraph is the adjacency of a snapshot with deterministic neighbour order
+type topologyGraph struct {
+	nodeIDs []string
+	out     map[string][]*ServiceConnection
+	in      map[string][]*ServiceConnection
+}
+
+func (t *NetworkTopology) graph() *topologyGraph {
+	g := &topologyGraph{
+		nodeIDs: sortedKeys(t.Nodes),
+		out:     make(map[string][]*ServiceConnection),
+		in:      make(map[string][]*ServiceConnection),
+	}
+	for _, connID := range sortedKeys(t.Connections) {
+		conn := t.Connections[connID]
+		if t.Nodes[conn.SourceID] == nil || t.Nodes[conn.TargetID] == nil {
+			continue
+		}
+		g.out[conn.SourceID] = append(g.out[conn.SourceID], conn)
+		g.in[conn.TargetID] = append(g.in[conn.TargetID], conn)
+	}
+	return g
+}
+
+// Downstream returns the nodes a node depends on, directly or transitively, sorted by ID
+func (t *NetworkTopology) Downstream(nodeID string) []string {
+	g := t.graph()
+	return sortedKeys(g.reachable(nodeID, g.out, func(conn *ServiceConnection) string { return conn.TargetID }))
+}
+
+// Upstream returns the nodes that depend on a node, directly or transitively, sorted by ID
+func (t *NetworkTopology) Upstream(nodeID string) []string {
+	g := t.graph()
+	return sortedKeys(g.reachable(nodeID, g.in, func(conn *ServiceConnection) string { return conn.SourceID }))
+}
+
+// reachable walks the edges from a node, excluding the node itself
+func (g *topologyGraph) reachable(nodeID string, edges map[string][]*ServiceConnection, next func(*ServiceConnection) string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := []string{nodeID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, conn := range edges[current] {
+			neighbour := next(conn)
+			if neighbour == nodeID || seen[neighbour] {
+				continue
+			}
+			seen[neighbour] = true
+			queue = append(queue, neighbour)
+		}
+	}
+	return seen
+}
+
+// BlastRadius returns what fails with a node: everything depending on it and the connections
+// along which the failure propagates
+func (t *NetworkTopology) BlastRadius(nodeID string) *BlastRadius {
+	g := t.graph()
+	affected := g.reachable(nodeID, g.in, func(conn *ServiceConnection) string { return conn.SourceID })
+
+	radius := &BlastRadius{NodeID: nodeID, AffectedNodes: sortedKeys(affected)}
+	serviceTypes := make(map[string]bool)
+	for _, id := range radius.AffectedNodes {
+		if serviceType := t.Nodes[id].ServiceType; serviceType != "" {
+			serviceTypes[serviceType] = true
+		}
+	}
+	radius.AffectedServiceTypes = sortedKeys(serviceTypes)
+
+	for _, connID := range sortedKeys(t.Connections) {
+		conn := t.Connections[connID]
+		if !affected[conn.SourceID] || (conn.TargetID != nodeID && !affected[conn.TargetID]) {
+			continue
+		}
+		radius.AffectedConnections = append(radius.AffectedConnections, conn.ID)
+		if conn.IsCritical {
+			radius.CriticalConnections = append(radius.CriticalConnections, conn.ID)
+		}
+	}
+	return radius
+}
+
+// SinglePointsOfFailure returns the nodes whose removal disconnects the topology, regardless of
+// connection direction (the articulation points), sorted by ID
+func (t *NetworkTopology) SinglePointsOfFailure() []string {
+	g := t.graph()
+	neighbours := make(map[string][]string, len(g.nodeIDs))
+	for _, id := range g.nodeIDs {
+		for _, conn := range g.out[id] {
+			if conn.SourceID == conn.TargetID {
+				continue
+			}
+			neighbours[conn.SourceID] = append(neighbours[conn.SourceID], conn.TargetID)
+			neighbours[conn.TargetID] = append(neighbours[conn.TargetID], conn.SourceID)
+		}
+	}
+
+	discovered := make(map[string]int)
+	low := make(map[string]int)
+	points := make(map[string]bool)
+	counter := 0
+
+	var visit func(id, parent string)
+	visit = func(id, parent string) {
+		counter++
+		discovered[id], low[id] = counter, counter
+		children := 0
+		skippedParent := false
+		for _, neighbour := range neighbours[id] {
+			// Parallel connections to the parent are a real second path; skip the tree edge once
+			if neighbour == parent && !skippedParent {
+				skippedParent = true
+				continue
+			}
+			if discovered[neighbour] != 0 {
+				low[id] = min(low[id], discovered[neighbour])
+				continue
+			}
+			children++
+			visit(neighbour, id)
+			low[id] = min(low[id], low[neighbour])
+			if parent != "" && low[neighbour] >= discovered[id] {
+				points[id] = true
+			}
+		}
+		if parent == "" && children > 1 {
+			points[id] = true
+		}
+	}
+	for _, id := range g.nodeIDs {
+		if discovered[id] == 0 {
+			visit(id, "")
+		}
+	}
+	return sortedKeys(points)
+}
+
+// Cycles returns the groups of nodes that depend on each other in a cycle (the strongly
+// connected components with a cycle), each sorted by ID and ordered by their first node
+func (t *NetworkTopology) Cycles() [][]string {
+	g := t.graph()
+	var cycles [][]string
+	for _, component := range g.stronglyConnected() {
+		if len(component) > 1 || g.hasSelfLoop(component[0]) {
+			cycles = append(cycles, component)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+func (g *topologyGraph) hasSelfLoop(id string) bool {
+	for _, conn := range g.out[id] {
+		if conn.TargetID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// stronglyConnected returns the strongly connected components, each sorted by ID (Tarjan)
+func (g *topologyGraph) stronglyConnected() [][]string {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+	counter := 0
+
+	var visit func(id string)
+	visit = func(id string) {
+		counter++
+		index[id], low[id] = counter, counter
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, conn := range g.out[id] {
+			target := conn.TargetID
+			if index[target] == 0 {
+				visit(target)
+				low[id] = min(low[id], low[target])
+			} else if onStack[target] {
+				low[id] = min(low[id], index[target])
+			}
+		}
+
+		if low[id] == index[id] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == id {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+	for _, id := range g.nodeIDs {
+		if index[id] == 0 {
+			visit(id)
+		}
+	}
+	return components
+}
+
+// CriticalPaths returns, for every entry node (one nothing depends on), its slowest dependency
+// chain, sorted by total latency and then by length; limit caps the number of paths when positive
+// Latencies come from the latency function, so callers choose between averages and percentiles.
+// Connections within a cycle are left out so every path is finite.
+func (t *NetworkTopology) CriticalPaths(latency func(*ServiceConnection) time.Duration, limit int) []CriticalPath {
+	g := t.graph()
+	component := make(map[string]int)
+	for i, members := range g.stronglyConnected() {
+		for _, id := range members {
+			component[id] = i
+		}
+	}
+	acyclic := func(conn *ServiceConnection) bool {
+		return component[conn.SourceID] != component[conn.TargetID]
+	}
+
+	// Longest path from each node, computed on demand; the graph without cycle edges is a DAG
+	type best struct {
+		total time.Duration
+		hops  int
+		next  *ServiceConnection
+	}
+	longest := make(map[string]*best)
+	var from func(id string) *best
+	from = func(id string) *best {
+		if result, ok := longest[id]; ok {
+			return result
+		}
+		result := &best{}
+		for _, conn := range g.out[id] {
+			if !acyclic(conn) {
+				continue
+			}
+			tail := from(conn.TargetID)
+			total := latency(conn) + tail.total
+			if result.next == nil || total > result.total || (total == result.total && tail.hops+1 > result.hops) {
+				result.total, result.hops, result.next = total, tail.hops+1, conn
+			}
+		}
+		longest[id] = result
+		return result
+	}
+
+	var paths []CriticalPath
+	for _, id := range g.nodeIDs {
+		hasDependents := false
+		for _, conn := range g.in[id] {
+			if acyclic(conn) {
+				hasDependents = true
+				break
+			}
+		}
+		if hasDependents || from(id).next == nil {
+			continue
+		}
+
+		path := CriticalPath{NodeIDs: []string{id}, TotalLatency: longest[id].total}
+		for step := longest[id]; step.next != nil; step = longest[step.next.TargetID] {
+			path.ConnectionIDs = append(path.ConnectionIDs, step.next.ID)
+			path.NodeIDs = append(path.NodeIDs, step.next.TargetID)
+		}
+		paths = append(paths, path)
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		if paths[i].TotalLatency != paths[j].TotalLatency {
+			return paths[i].TotalLatency > paths[j].TotalLatency
+		}
+		return len(paths[i].NodeIDs) > len(paths[j].NodeIDs)
+	})
+	if limit > 0 && len(paths) > limit {
+		paths = paths[:limit]
+	}
+	return paths
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newAnalyticsTopology builds trading -> {risk, exchange}, risk -> exchange,
+// exchange -> {custodian, md1} and the cycle md1 <-> md2
+func newAnalyticsTopology() *NetworkTopology {
+	topology := NewNetworkTopology("snapshot-1")
+	for _, id := range []string{"trading", "risk", "exchange", "custodian", "md1", "md2"} {
+		topology.AddNode(NewServiceNode(id, id, id+"-service", id))
+	}
+	for _, edge := range []struct {
+		id, source, target string
+		critical           bool
+	}{
+		{"trading-exchange", "trading", "exchange", true},
+		{"trading-risk", "trading", "risk", false},
+		{"risk-exchange", "risk", "exchange", false},
+		{"exchange-custodian", "exchange", "custodian", false},
+		{"exchange-md1", "exchange", "md1", false},
+		{"md1-md2", "md1", "md2", false},
+		{"md2-md1", "md2", "md1", false},
+		{"orphan", "trading", "missing", false},
+	} {
+		conn := NewServiceConnection(edge.id, edge.source, edge.target, ConnectionTypeGRPC)
+		conn.IsCritical = edge.critical
+		topology.AddConnection(conn)
+	}
+	return topology
+}
+
+func TestNetworkTopology_DependencySets(t *testing.T) {
+	topology := newAnalyticsTopology()
+
+	if got, want := topology.Downstream("trading"), []string{"custodian", "exchange", "md1", "md2", "risk"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Downstream(trading) = %v, want %v", got, want)
+	}
+	if got, want := topology.Upstream("exchange"), []string{"risk", "trading"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Upstream(exchange) = %v, want %v", got, want)
+	}
+	if got, want := topology.Upstream("md1"), []string{"exchange", "md2", "risk", "trading"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Upstream(md1) = %v, want %v", got, want)
+	}
+}
+
+func TestNetworkTopology_BlastRadius(t *testing.T) {
+	radius := newAnalyticsTopology().BlastRadius("exchange")
+
+	if want := []string{"risk", "trading"}; !reflect.DeepEqual(radius.AffectedNodes, want) {
+		t.Errorf("AffectedNodes = %v, want %v", radius.AffectedNodes, want)
+	}
+	if want := []string{"risk-exchange", "trading-exchange", "trading-risk"}; !reflect.DeepEqual(radius.AffectedConnections, want) {
+		t.Errorf("AffectedConnections = %v, want %v", radius.AffectedConnections, want)
+	}
+	if want := []string{"trading-exchange"}; !reflect.DeepEqual(radius.CriticalConnections, want) {
+		t.Errorf("CriticalConnections = %v, want %v", radius.CriticalConnections, want)
+	}
+	if want := []string{"risk-service", "trading-service"}; !reflect.DeepEqual(radius.AffectedServiceTypes, want) {
+		t.Errorf("AffectedServiceTypes = %v, want %v", radius.AffectedServiceTypes, want)
+	}
+}
+
+func TestNetworkTopology_SinglePointsOfFailureAndCycles(t *testing.T) {
+	topology := newAnalyticsTopology()
+
+	// The trading/risk/exchange triangle survives any one of them failing; exchange and md1 do not
+	if got, want := topology.SinglePointsOfFailure(), []string{"exchange", "md1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SinglePointsOfFailure() = %v, want %v", got, want)
+	}
+	if got, want := topology.Cycles(), [][]string{{"md1", "md2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+}
+
+func TestNetworkTopology_CriticalPaths(t *testing.T) {
+	topology := newAnalyticsTopology()
+	latencies := map[string]time.Duration{
+		"trading-exchange":   10 * time.Millisecond,
+		"trading-risk":       5 * time.Millisecond,
+		"risk-exchange":      20 * time.Millisecond,
+		"exchange-custodian": 30 * time.Millisecond,
+		"exchange-md1":       time.Millisecond,
+		"md1-md2":            time.Hour, // inside the cycle, so never on a path
+	}
+
+	paths := topology.CriticalPaths(func(conn *ServiceConnection) time.Duration { return latencies[conn.ID] }, 0)
+	if len(paths) != 1 {
+		t.Fatalf("Expected one path from the only entry node, got %+v", paths)
+	}
+	if want := []string{"trading", "risk", "exchange", "custodian"}; !reflect.DeepEqual(paths[0].NodeIDs, want) {
+		t.Errorf("NodeIDs = %v, want %v", paths[0].NodeIDs, want)
+	}
+	if want := []string{"trading-risk", "risk-exchange", "exchange-custodian"}; !reflect.DeepEqual(paths[0].ConnectionIDs, want) {
+		t.Errorf("ConnectionIDs = %v, want %v", paths[0].ConnectionIDs, want)
+	}
+	if paths[0].TotalLatency != 55*time.Millisecond {
+		t.Errorf("TotalLatency = %v, want 55ms", paths[0].TotalLatency)
+	}
+
+	// Without latencies the longest chain wins
+	paths = topology.CriticalPaths(func(*ServiceConnection) time.Duration { return 0 }, 1)
+	if len(paths) != 1 || len(paths[0].NodeIDs) != 4 {
+		t.Errorf("Expected the four node chain without latencies, got %+v", paths)
+	}
+}
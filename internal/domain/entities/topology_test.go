@@ -355,3 +355,21 @@ func TestNetworkTopology_CountHealthyNodesAndConnections(t *testing.T) {
 		t.Errorf("Expected 1 healthy connection, got %d", healthyConns)
 	}
 }
+
+func TestSnapshotVersion(t *testing.T) {
+	id := SnapshotIDForVersion(10)
+	if id != "snapshot-10" {
+		t.Errorf("Expected snapshot-10, got %s", id)
+	}
+
+	version, err := ParseSnapshotVersion(id)
+	if err != nil || version != 10 {
+		t.Errorf("Expected version 10, got %d (%v)", version, err)
+	}
+
+	for _, invalid := range []string{"", "snapshot-", "snapshot-new", "10"} {
+		if _, err := ParseSnapshotVersion(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
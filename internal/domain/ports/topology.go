@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
@@ -25,6 +26,7 @@ type TopologyRepository interface {
 	// Snapshot operations
 	GetTopologySnapshot(ctx context.Context) (*entities.NetworkTopology, error)
 	SaveTopologySnapshot(ctx context.Context, topology *entities.NetworkTopology) error
+	SetSnapshotID(ctx context.Context, snapshotID string) error
 }
 
 // MetadataRepository defines the port for persisting and querying metadata
@@ -61,6 +63,11 @@ type TopologyChangeEvent struct {
 	SnapshotID string
 	Node       *entities.ServiceNode
 	Connection *entities.ServiceConnection
+
+	// Status changes carry the status before the change
+	PreviousNodeStatus entities.NodeStatus
+	PreviousEdgeStatus entities.EdgeStatus
+	Reason             string
 }
 
 // TopologyChangePublisher defines the port for publishing topology changes
@@ -75,6 +82,28 @@ type TopologyChangePublisher interface {
 	Unsubscribe(ctx context.Context, subscriptionID string) error
 }
 
+// ErrSnapshotNotFound is returned for a snapshot that was never recorded or is no longer retained
+var ErrSnapshotNotFound = errors.New("topology snapshot not found")
+
+// TopologyHistoryRepository defines the port for the versioned history of topology changes
+// It reconstructs the topology as of any retained snapshot version
+type TopologyHistoryRepository interface {
+	// Initialize sets the topology the history starts from, discarding any recorded changes
+	Initialize(ctx context.Context, topology *entities.NetworkTopology, version uint64) error
+
+	// AppendChange records a change; changes are appended in snapshot version order
+	AppendChange(ctx context.Context, event *TopologyChangeEvent) error
+
+	// GetTopologyAtVersion returns the topology as of a snapshot version
+	GetTopologyAtVersion(ctx context.Context, version uint64) (*entities.NetworkTopology, error)
+
+	// GetVersionAt returns the latest snapshot version recorded at or before a time
+	GetVersionAt(ctx context.Context, at time.Time) (uint64, error)
+
+	// GetChanges returns the changes recorded after from and at or before to, oldest first
+	GetChanges(ctx context.Context, from, to time.Time) ([]*TopologyChangeEvent, error)
+}
+
 // MetricsUpdate represents a metrics update for a node or edge
 type MetricsUpdate struct {
 	UpdateID  string
@@ -0,0 +1,421 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// DefaultTopologyTracker implements TopologyTracker over the topology ports
+// Every structural change is saved through the repository, advances the snapshot version by one
+// and is published as a TopologyChangeEvent carrying the new snapshot ID.
+// Stored nodes and connections are never modified in place: changes save an updated copy, so
+// values returned by queries or carried by published events stay consistent.
+// Changes are also recorded in the history, which answers queries for past snapshots.
+type DefaultTopologyTracker struct {
+	// mu serialises changes so snapshot versions and published events follow repository order
+	mu           sync.Mutex
+	repo         ports.TopologyRepository
+	metadataRepo ports.MetadataRepository
+	publisher    ports.TopologyChangePublisher
+	history      ports.TopologyHistoryRepository
+	version      uint64
+}
+
+// NewTopologyTracker creates a tracker continuing from the repository's current snapshot version
+// The history starts from the repository's current topology. An error means the history could
+// not be seeded; the tracker still works, with history starting from its next change.
+func NewTopologyTracker(repo ports.TopologyRepository, metadataRepo ports.MetadataRepository, publisher ports.TopologyChangePublisher, history ports.TopologyHistoryRepository) (*DefaultTopologyTracker, error) {
+	tracker := &DefaultTopologyTracker{
+		repo:         repo,
+		metadataRepo: metadataRepo,
+		publisher:    publisher,
+		history:      history,
+	}
+	ctx := context.Background()
+	snapshot, err := repo.GetTopologySnapshot(ctx)
+	if err != nil {
+		return tracker, fmt.Errorf("failed to read topology to seed history: %w", err)
+	}
+	if version, err := entities.ParseSnapshotVersion(snapshot.SnapshotID); err == nil {
+		tracker.version = version
+	}
+	snapshot.SnapshotID = entities.SnapshotIDForVersion(tracker.version)
+	if err := history.Initialize(ctx, snapshot, tracker.version); err != nil {
+		return tracker, fmt.Errorf("failed to seed topology history: %w", err)
+	}
+	return tracker, nil
+}
+
+// SnapshotID returns the ID of the current topology snapshot
+func (t *DefaultTopologyTracker) SnapshotID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return entities.SnapshotIDForVersion(t.version)
+}
+
+// RegisterNode adds a node, or replaces a registered node with the same ID
+// Re-registering an unchanged node only refreshes its last-seen time and publishes nothing
+func (t *DefaultTopologyTracker) RegisterNode(ctx context.Context, node *entities.ServiceNode) (string, error) {
+	if node == nil || node.ID == "" {
+		return "", fmt.Errorf("%w: node with an ID is required", ErrInvalidTopology)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	updated := node.Clone()
+	if updated.LastSeenAt.IsZero() {
+		updated.LastSeenAt = now
+	}
+
+	existing, err := t.repo.GetNode(ctx, node.ID)
+	if err != nil {
+		if updated.RegisteredAt.IsZero() {
+			updated.RegisteredAt = now
+		}
+		if err := t.repo.SaveNode(ctx, updated); err != nil {
+			return "", fmt.Errorf("failed to save node: %w", err)
+		}
+		return t.commitLocked(ctx, &ports.TopologyChangeEvent{ChangeType: ports.TopologyChangeTypeNodeAdded, Node: updated})
+	}
+
+	updated.RegisteredAt = existing.RegisteredAt
+	if err := t.repo.SaveNode(ctx, updated); err != nil {
+		return "", fmt.Errorf("failed to save node: %w", err)
+	}
+	if existing.SameAs(updated) {
+		return entities.SnapshotIDForVersion(t.version), nil
+	}
+	return t.commitLocked(ctx, &ports.TopologyChangeEvent{
+		ChangeType:         ports.TopologyChangeTypeNodeUpdated,
+		Node:               updated,
+		PreviousNodeStatus: existing.Status,
+	})
+}
+
+// DeregisterNode removes a node together with every connection to or from it
+func (t *DefaultTopologyTracker) DeregisterNode(ctx context.Context, nodeID string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	node, err := t.getNode(ctx, nodeID)
+	if err != nil {
+		return "", err
+	}
+
+	connections, err := t.repo.GetConnections(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to list connections: %w", err)
+	}
+	for _, conn := range connections {
+		if conn.SourceID != nodeID && conn.TargetID != nodeID {
+			continue
+		}
+		if err := t.repo.DeleteConnection(ctx, conn.ID); err != nil {
+			return "", fmt.Errorf("failed to remove connection %s: %w", conn.ID, err)
+		}
+		if _, err := t.commitLocked(ctx, &ports.TopologyChangeEvent{
+			ChangeType: ports.TopologyChangeTypeEdgeRemoved,
+			Connection: conn,
+			Reason:     "node_removed",
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	if err := t.repo.DeleteNode(ctx, nodeID); err != nil {
+		return "", fmt.Errorf("failed to remove node: %w", err)
+	}
+	return t.commitLocked(ctx, &ports.TopologyChangeEvent{
+		ChangeType: ports.TopologyChangeTypeNodeRemoved,
+		Node:       node,
+		Reason:     "deregistered",
+	})
+}
+
+// UpdateNodeStatus sets a node's status and last-seen time
+// An unchanged status only refreshes the last-seen time and publishes nothing
+func (t *DefaultTopologyTracker) UpdateNodeStatus(ctx context.Context, nodeID string, status entities.NodeStatus) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	existing, err := t.getNode(ctx, nodeID)
+	if err != nil {
+		return "", err
+	}
+
+	updated := existing.Clone()
+	updated.UpdateStatus(status)
+	if err := t.repo.SaveNode(ctx, updated); err != nil {
+		return "", fmt.Errorf("failed to save node: %w", err)
+	}
+	if existing.Status == status {
+		return entities.SnapshotIDForVersion(t.version), nil
+	}
+	return t.commitLocked(ctx, &ports.TopologyChangeEvent{
+		ChangeType:         ports.TopologyChangeTypeNodeUpdated,
+		Node:               updated,
+		PreviousNodeStatus: existing.Status,
+	})
+}
+
+// GetNode returns a registered node
+func (t *DefaultTopologyTracker) GetNode(ctx context.Context, nodeID string) (*entities.ServiceNode, error) {
+	return t.getNode(ctx, nodeID)
+}
+
+// GetNodes returns the registered nodes matching filters
+func (t *DefaultTopologyTracker) GetNodes(ctx context.Context, filters *entities.TopologyFilters) ([]*entities.ServiceNode, error) {
+	return t.repo.GetNodes(ctx, filters)
+}
+
+// RegisterConnection adds a connection between two registered nodes, or replaces a registered
+// connection with the same ID; re-registering an unchanged connection publishes nothing
+func (t *DefaultTopologyTracker) RegisterConnection(ctx context.Context, conn *entities.ServiceConnection) (string, error) {
+	if conn == nil || conn.ID == "" {
+		return "", fmt.Errorf("%w: connection with an ID is required", ErrInvalidTopology)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if conn.SourceID == conn.TargetID {
+		return "", fmt.Errorf("%w: connection %s connects node %q to itself", ErrInvalidTopology, conn.ID, conn.SourceID)
+	}
+	for _, nodeID := range []string{conn.SourceID, conn.TargetID} {
+		if _, err := t.repo.GetNode(ctx, nodeID); err != nil {
+			return "", fmt.Errorf("%w: connection %s endpoint %q is not registered", ErrInvalidTopology, conn.ID, nodeID)
+		}
+	}
+
+	now := time.Now()
+	updated := conn.Clone()
+	if updated.UpdatedAt.IsZero() {
+		updated.UpdatedAt = now
+	}
+
+	existing, err := t.repo.GetConnection(ctx, conn.ID)
+	if err != nil {
+		if updated.CreatedAt.IsZero() {
+			updated.CreatedAt = now
+		}
+		if err := t.repo.SaveConnection(ctx, updated); err != nil {
+			return "", fmt.Errorf("failed to save connection: %w", err)
+		}
+		return t.commitLocked(ctx, &ports.TopologyChangeEvent{ChangeType: ports.TopologyChangeTypeEdgeAdded, Connection: updated})
+	}
+
+	if existing.SameAs(updated) {
+		return entities.SnapshotIDForVersion(t.version), nil
+	}
+	updated.CreatedAt = existing.CreatedAt
+	if err := t.repo.SaveConnection(ctx, updated); err != nil {
+		return "", fmt.Errorf("failed to save connection: %w", err)
+	}
+	return t.commitLocked(ctx, &ports.TopologyChangeEvent{
+		ChangeType:         ports.TopologyChangeTypeEdgeUpdated,
+		Connection:         updated,
+		PreviousEdgeStatus: existing.Status,
+	})
+}
+
+// DeregisterConnection removes a connection
+func (t *DefaultTopologyTracker) DeregisterConnection(ctx context.Context, connID string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	conn, err := t.getConnection(ctx, connID)
+	if err != nil {
+		return "", err
+	}
+	if err := t.repo.DeleteConnection(ctx, connID); err != nil {
+		return "", fmt.Errorf("failed to remove connection: %w", err)
+	}
+	return t.commitLocked(ctx, &ports.TopologyChangeEvent{
+		ChangeType: ports.TopologyChangeTypeEdgeRemoved,
+		Connection: conn,
+		Reason:     "deregistered",
+	})
+}
+
+// UpdateConnectionStatus sets a connection's status; an unchanged status publishes nothing
+func (t *DefaultTopologyTracker) UpdateConnectionStatus(ctx context.Context, connID string, status entities.EdgeStatus) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	existing, err := t.getConnection(ctx, connID)
+	if err != nil {
+		return "", err
+	}
+	if existing.Status == status {
+		return entities.SnapshotIDForVersion(t.version), nil
+	}
+
+	updated := existing.Clone()
+	updated.UpdateStatus(status)
+	if err := t.repo.SaveConnection(ctx, updated); err != nil {
+		return "", fmt.Errorf("failed to save connection: %w", err)
+	}
+	return t.commitLocked(ctx, &ports.TopologyChangeEvent{
+		ChangeType:         ports.TopologyChangeTypeEdgeUpdated,
+		Connection:         updated,
+		PreviousEdgeStatus: existing.Status,
+	})
+}
+
+// GetConnection returns a registered connection
+func (t *DefaultTopologyTracker) GetConnection(ctx context.Context, connID string) (*entities.ServiceConnection, error) {
+	return t.getConnection(ctx, connID)
+}
+
+// GetConnections returns the registered connections matching filters
+func (t *DefaultTopologyTracker) GetConnections(ctx context.Context, filters *entities.TopologyFilters) ([]*entities.ServiceConnection, error) {
+	return t.repo.GetConnections(ctx, filters)
+}
+
+// GetTopology returns the nodes matching filters and the matching connections between them
+func (t *DefaultTopologyTracker) GetTopology(ctx context.Context, filters *entities.TopologyFilters) (*entities.NetworkTopology, error) {
+	snapshot, err := t.repo.GetTopologySnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if filters == nil {
+		return snapshot, nil
+	}
+
+	topology := entities.NewNetworkTopology(snapshot.SnapshotID)
+	topology.SnapshotTime = snapshot.SnapshotTime
+	for _, node := range snapshot.Nodes {
+		if filters.MatchesNode(node) {
+			topology.AddNode(node)
+		}
+	}
+	for _, conn := range snapshot.Connections {
+		_, sourceIncluded := topology.GetNode(conn.SourceID)
+		_, targetIncluded := topology.GetNode(conn.TargetID)
+		if sourceIncluded && targetIncluded && filters.MatchesConnection(conn) {
+			topology.AddConnection(conn)
+		}
+	}
+	return topology, nil
+}
+
+// GetTopologySnapshot returns the whole current topology
+func (t *DefaultTopologyTracker) GetTopologySnapshot(ctx context.Context) (*entities.NetworkTopology, error) {
+	return t.repo.GetTopologySnapshot(ctx)
+}
+
+// GetTopologyAt returns the whole topology as of a snapshot
+func (t *DefaultTopologyTracker) GetTopologyAt(ctx context.Context, snapshotID string) (*entities.NetworkTopology, error) {
+	version, err := entities.ParseSnapshotVersion(snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSnapshotID, snapshotID)
+	}
+	return t.history.GetTopologyAtVersion(ctx, version)
+}
+
+// GetTopologyAsOf returns the whole topology as it was at a time
+func (t *DefaultTopologyTracker) GetTopologyAsOf(ctx context.Context, at time.Time) (*entities.NetworkTopology, error) {
+	version, err := t.history.GetVersionAt(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	return t.history.GetTopologyAtVersion(ctx, version)
+}
+
+// GetTopologyChanges returns the recorded changes after from and at or before to, oldest first
+func (t *DefaultTopologyTracker) GetTopologyChanges(ctx context.Context, from, to time.Time) ([]*ports.TopologyChangeEvent, error) {
+	return t.history.GetChanges(ctx, from, to)
+}
+
+// DiffTopology compares the topology at two snapshots
+func (t *DefaultTopologyTracker) DiffTopology(ctx context.Context, fromSnapshotID, toSnapshotID string) (*entities.TopologyDiff, error) {
+	from, err := t.GetTopologyAt(ctx, fromSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := t.GetTopologyAt(ctx, toSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return entities.DiffTopologies(from, to), nil
+}
+
+// GetNodeMetadata returns the metadata of a node
+func (t *DefaultTopologyTracker) GetNodeMetadata(ctx context.Context, nodeID string) (*entities.NodeMetadata, error) {
+	return t.metadataRepo.GetNodeMetadata(ctx, nodeID)
+}
+
+// GetEdgeMetadata returns the metadata of a connection
+func (t *DefaultTopologyTracker) GetEdgeMetadata(ctx context.Context, edgeID string) (*entities.EdgeMetadata, error) {
+	return t.metadataRepo.GetEdgeMetadata(ctx, edgeID)
+}
+
+// UpdateNodeMetadata saves the metadata of a registered node
+// Metadata is not part of the topology structure, so it does not advance the snapshot version
+func (t *DefaultTopologyTracker) UpdateNodeMetadata(ctx context.Context, metadata *entities.NodeMetadata) error {
+	if metadata == nil {
+		return fmt.Errorf("metadata cannot be nil")
+	}
+	if _, err := t.getNode(ctx, metadata.NodeID); err != nil {
+		return err
+	}
+	return t.metadataRepo.SaveNodeMetadata(ctx, metadata)
+}
+
+// UpdateEdgeMetadata saves the metadata of a registered connection
+func (t *DefaultTopologyTracker) UpdateEdgeMetadata(ctx context.Context, metadata *entities.EdgeMetadata) error {
+	if metadata == nil {
+		return fmt.Errorf("metadata cannot be nil")
+	}
+	if _, err := t.getConnection(ctx, metadata.EdgeID); err != nil {
+		return err
+	}
+	return t.metadataRepo.SaveEdgeMetadata(ctx, metadata)
+}
+
+// getNode looks up a node, reporting a missing one as ErrNodeNotFound
+func (t *DefaultTopologyTracker) getNode(ctx context.Context, nodeID string) (*entities.ServiceNode, error) {
+	node, err := t.repo.GetNode(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+	return node, nil
+}
+
+// getConnection looks up a connection, reporting a missing one as ErrConnectionNotFound
+func (t *DefaultTopologyTracker) getConnection(ctx context.Context, connID string) (*entities.ServiceConnection, error) {
+	conn, err := t.repo.GetConnection(ctx, connID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrConnectionNotFound, connID)
+	}
+	return conn, nil
+}
+
+// commitLocked advances the snapshot version, records the change in the history and publishes it,
+// returning the committed snapshot ID
+func (t *DefaultTopologyTracker) commitLocked(ctx context.Context, event *ports.TopologyChangeEvent) (string, error) {
+	t.version++
+	event.SnapshotID = entities.SnapshotIDForVersion(t.version)
+	event.ChangeID = fmt.Sprintf("change-%d", t.version)
+	event.Timestamp = time.Now()
+
+	if err := t.repo.SetSnapshotID(ctx, event.SnapshotID); err != nil {
+		return "", fmt.Errorf("failed to record snapshot version: %w", err)
+	}
+	if err := t.history.AppendChange(ctx, event); err != nil {
+		return "", fmt.Errorf("failed to record topology history: %w", err)
+	}
+	if err := t.publisher.PublishChange(ctx, event); err != nil {
+		return "", fmt.Errorf("failed to publish topology change: %w", err)
+	}
+	return event.SnapshotID, nil
+}
+
+var _ TopologyTracker = (*DefaultTopologyTracker)(nil)
@@ -0,0 +1,275 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
+	infratopology "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/infrastructure/topology"
+)
+
+func newTestTracker(t *testing.T) (*services.DefaultTopologyTracker, *infratopology.MemoryTopologyRepository, <-chan *ports.TopologyChangeEvent) {
+	t.Helper()
+	repo := infratopology.NewMemoryTopologyRepository()
+	publisher := infratopology.NewChannelChangePublisher()
+	tracker, err := services.NewTopologyTracker(repo, infratopology.NewMemoryMetadataRepository(), publisher, infratopology.NewMemoryTopologyHistory())
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	changes, err := publisher.Subscribe(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	return tracker, repo, changes
+}
+
+func expectChange(t *testing.T, changes <-chan *ports.TopologyChangeEvent, changeType ports.TopologyChangeType, snapshotID string) *ports.TopologyChangeEvent {
+	t.Helper()
+	select {
+	case change := <-changes:
+		if change.ChangeType != changeType || change.SnapshotID != snapshotID {
+			t.Fatalf("Expected change %d at %s, got %d at %s", changeType, snapshotID, change.ChangeType, change.SnapshotID)
+		}
+		return change
+	case <-time.After(time.Second):
+		t.Fatalf("Expected change %d at %s, got none", changeType, snapshotID)
+		return nil
+	}
+}
+
+func expectNoChange(t *testing.T, changes <-chan *ports.TopologyChangeEvent) {
+	t.Helper()
+	select {
+	case change := <-changes:
+		t.Fatalf("Expected no change, got %d at %s", change.ChangeType, change.SnapshotID)
+	default:
+	}
+}
+
+func TestTopologyTracker_PublishesVersionedChanges(t *testing.T) {
+	tracker, repo, changes := newTestTracker(t)
+	ctx := context.Background()
+
+	exchange := entities.NewServiceNode("exchange", "exchange", "exchange-simulator-go", "exchange-okx")
+	custodian := entities.NewServiceNode("custodian", "custodian", "custodian-simulator-go", "custodian-komainu")
+	for _, node := range []*entities.ServiceNode{exchange, custodian} {
+		if _, err := tracker.RegisterNode(ctx, node); err != nil {
+			t.Fatalf("Failed to register node: %v", err)
+		}
+	}
+	expectChange(t, changes, ports.TopologyChangeTypeNodeAdded, "snapshot-1")
+	expectChange(t, changes, ports.TopologyChangeTypeNodeAdded, "snapshot-2")
+
+	conn := entities.NewServiceConnection("exchange-custodian", "exchange", "custodian", entities.ConnectionTypeGRPC)
+	snapshotID, err := tracker.RegisterConnection(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to register connection: %v", err)
+	}
+	if snapshotID != "snapshot-3" {
+		t.Errorf("Expected the connection committed at snapshot-3, got %s", snapshotID)
+	}
+	expectChange(t, changes, ports.TopologyChangeTypeEdgeAdded, "snapshot-3")
+
+	// Unchanged re-registration and status are not changes, and report the current snapshot
+	if snapshotID, err = tracker.RegisterNode(ctx, exchange); err != nil || snapshotID != "snapshot-3" {
+		t.Fatalf("Expected re-registration to report snapshot-3, got %s (%v)", snapshotID, err)
+	}
+	if _, err := tracker.UpdateConnectionStatus(ctx, conn.ID, entities.EdgeStatusActive); err != nil {
+		t.Fatalf("Failed to update connection status: %v", err)
+	}
+	expectNoChange(t, changes)
+
+	if _, err := tracker.UpdateNodeStatus(ctx, "exchange", entities.NodeStatusDegraded); err != nil {
+		t.Fatalf("Failed to update node status: %v", err)
+	}
+	change := expectChange(t, changes, ports.TopologyChangeTypeNodeUpdated, "snapshot-4")
+	if change.PreviousNodeStatus != entities.NodeStatusLive || change.Node.Status != entities.NodeStatusDegraded {
+		t.Errorf("Expected a live to degraded change, got %v to %v", change.PreviousNodeStatus, change.Node.Status)
+	}
+	if exchange.Status != entities.NodeStatusLive {
+		t.Errorf("Expected the registered node value not to be modified")
+	}
+
+	if _, err := tracker.UpdateConnectionStatus(ctx, conn.ID, entities.EdgeStatusFailed); err != nil {
+		t.Fatalf("Failed to update connection status: %v", err)
+	}
+	change = expectChange(t, changes, ports.TopologyChangeTypeEdgeUpdated, "snapshot-5")
+	if change.PreviousEdgeStatus != entities.EdgeStatusActive {
+		t.Errorf("Expected the previous edge status to be active, got %v", change.PreviousEdgeStatus)
+	}
+
+	// Removing a node removes its connections first
+	if snapshotID, err = tracker.DeregisterNode(ctx, "custodian"); err != nil || snapshotID != "snapshot-7" {
+		t.Fatalf("Expected deregistration committed at snapshot-7, got %s (%v)", snapshotID, err)
+	}
+	change = expectChange(t, changes, ports.TopologyChangeTypeEdgeRemoved, "snapshot-6")
+	if change.Reason != "node_removed" {
+		t.Errorf("Expected the edge removal to be attributed to the node removal, got %q", change.Reason)
+	}
+	expectChange(t, changes, ports.TopologyChangeTypeNodeRemoved, "snapshot-7")
+
+	snapshot, err := repo.GetTopologySnapshot(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get snapshot: %v", err)
+	}
+	if snapshot.SnapshotID != "snapshot-7" || len(snapshot.Nodes) != 1 || len(snapshot.Connections) != 0 {
+		t.Errorf("Unexpected snapshot %s with %d nodes and %d connections", snapshot.SnapshotID, len(snapshot.Nodes), len(snapshot.Connections))
+	}
+}
+
+func TestTopologyTracker_RejectsDanglingConnections(t *testing.T) {
+	tracker, _, changes := newTestTracker(t)
+	ctx := context.Background()
+
+	if _, err := tracker.RegisterNode(ctx, entities.NewServiceNode("exchange", "exchange", "exchange-simulator-go", "exchange-okx")); err != nil {
+		t.Fatalf("Failed to register node: %v", err)
+	}
+	<-changes
+
+	conn := entities.NewServiceConnection("exchange-custodian", "exchange", "custodian", entities.ConnectionTypeGRPC)
+	if _, err := tracker.RegisterConnection(ctx, conn); !errors.Is(err, services.ErrInvalidTopology) {
+		t.Errorf("Expected a connection to an unregistered node to be rejected, got %v", err)
+	}
+	loop := entities.NewServiceConnection("exchange-exchange", "exchange", "exchange", entities.ConnectionTypeGRPC)
+	if _, err := tracker.RegisterConnection(ctx, loop); !errors.Is(err, services.ErrInvalidTopology) {
+		t.Errorf("Expected a connection from a node to itself to be rejected, got %v", err)
+	}
+	if _, err := tracker.UpdateNodeStatus(ctx, "custodian", entities.NodeStatusDead); !errors.Is(err, services.ErrNodeNotFound) {
+		t.Errorf("Expected a status update of an unregistered node to fail, got %v", err)
+	}
+	expectNoChange(t, changes)
+}
+
+func TestTopologyTracker_CatchUpFromSnapshot(t *testing.T) {
+	repo := infratopology.NewMemoryTopologyRepository()
+	publisher := infratopology.NewChannelChangePublisher()
+	tracker, err := services.NewTopologyTracker(repo, infratopology.NewMemoryMetadataRepository(), publisher, infratopology.NewMemoryTopologyHistory())
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"} {
+		if _, err := tracker.RegisterNode(ctx, entities.NewServiceNode(id, id, "svc", id)); err != nil {
+			t.Fatalf("Failed to register node: %v", err)
+		}
+	}
+
+	// Versions compare numerically, so snapshot-10 and snapshot-11 follow snapshot-9
+	changes, err := publisher.Subscribe(ctx, "snapshot-9", nil)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	expectChange(t, changes, ports.TopologyChangeTypeNodeAdded, "snapshot-10")
+	expectChange(t, changes, ports.TopologyChangeTypeNodeAdded, "snapshot-11")
+	expectNoChange(t, changes)
+
+	// A new tracker over the same repository continues the version sequence
+	resumed, err := services.NewTopologyTracker(repo, infratopology.NewMemoryMetadataRepository(), publisher, infratopology.NewMemoryTopologyHistory())
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	if resumed.SnapshotID() != "snapshot-11" {
+		t.Errorf("Expected the tracker to resume at snapshot-11, got %s", resumed.SnapshotID())
+	}
+
+	cancel()
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Error("Expected no further changes after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the subscription to close when its context is cancelled")
+	}
+}
+
+func TestTopologyTracker_TimeTravelAndDiff(t *testing.T) {
+	tracker, _, _ := newTestTracker(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"exchange", "custodian"} {
+		if _, err := tracker.RegisterNode(ctx, entities.NewServiceNode(id, id, "svc", id)); err != nil {
+			t.Fatalf("Failed to register node: %v", err)
+		}
+	}
+	conn := entities.NewServiceConnection("exchange-custodian", "exchange", "custodian", entities.ConnectionTypeGRPC)
+	if _, err := tracker.RegisterConnection(ctx, conn); err != nil {
+		t.Fatalf("Failed to register connection: %v", err)
+	}
+	before := tracker.SnapshotID()
+	beforeTime := time.Now()
+
+	if _, err := tracker.UpdateNodeStatus(ctx, "exchange", entities.NodeStatusDead); err != nil {
+		t.Fatalf("Failed to update node status: %v", err)
+	}
+	if _, err := tracker.DeregisterNode(ctx, "custodian"); err != nil {
+		t.Fatalf("Failed to deregister node: %v", err)
+	}
+	if _, err := tracker.RegisterNode(ctx, entities.NewServiceNode("risk", "risk", "svc", "risk")); err != nil {
+		t.Fatalf("Failed to register node: %v", err)
+	}
+
+	past, err := tracker.GetTopologyAt(ctx, before)
+	if err != nil {
+		t.Fatalf("Failed to get past topology: %v", err)
+	}
+	if past.SnapshotID != before || len(past.Nodes) != 2 || len(past.Connections) != 1 || past.Nodes["exchange"].Status != entities.NodeStatusLive {
+		t.Errorf("Unexpected topology at %s: %d nodes, %d connections", before, len(past.Nodes), len(past.Connections))
+	}
+	asOf, err := tracker.GetTopologyAsOf(ctx, beforeTime)
+	if err != nil || asOf.SnapshotID != before {
+		t.Errorf("Expected the topology as of the time to be %s, got %v", before, err)
+	}
+
+	diff, err := tracker.DiffTopology(ctx, before, tracker.SnapshotID())
+	if err != nil {
+		t.Fatalf("Failed to diff topology: %v", err)
+	}
+	if len(diff.AddedNodes) != 1 || diff.AddedNodes[0].ID != "risk" {
+		t.Errorf("Expected risk to be added, got %+v", diff.AddedNodes)
+	}
+	if len(diff.RemovedNodes) != 1 || diff.RemovedNodes[0].ID != "custodian" {
+		t.Errorf("Expected custodian to be removed, got %+v", diff.RemovedNodes)
+	}
+	if len(diff.ChangedNodes) != 1 || diff.ChangedNodes[0].Before.Status != entities.NodeStatusLive || diff.ChangedNodes[0].After.Status != entities.NodeStatusDead {
+		t.Errorf("Expected exchange to change from live to dead, got %+v", diff.ChangedNodes)
+	}
+	if len(diff.RemovedConnections) != 1 || len(diff.AddedConnections) != 0 || len(diff.ChangedConnections) != 0 {
+		t.Errorf("Expected only the connection removal, got %+v", diff)
+	}
+
+	if _, err := tracker.GetTopologyAt(ctx, "snapshot-99"); !errors.Is(err, services.ErrSnapshotNotFound) {
+		t.Errorf("Expected a future snapshot not to be found, got %v", err)
+	}
+	if _, err := tracker.GetTopologyAt(ctx, "latest"); !errors.Is(err, services.ErrInvalidSnapshotID) {
+		t.Errorf("Expected a malformed snapshot ID to be rejected, got %v", err)
+	}
+}
+
+// failingHistory rejects being seeded
+type failingHistory struct {
+	ports.TopologyHistoryRepository
+}
+
+func (failingHistory) Initialize(ctx context.Context, topology *entities.NetworkTopology, version uint64) error {
+	return errors.New("history unavailable")
+}
+
+func TestTopologyTracker_ReportsHistorySeedFailure(t *testing.T) {
+	tracker, err := services.NewTopologyTracker(infratopology.NewMemoryTopologyRepository(), infratopology.NewMemoryMetadataRepository(), infratopology.NewChannelChangePublisher(), failingHistory{})
+	if err == nil {
+		t.Fatal("Expected the history seed failure to be reported")
+	}
+	if tracker == nil || tracker.SnapshotID() != "snapshot-0" {
+		t.Errorf("Expected a usable tracker at snapshot-0, got %v", tracker)
+	}
+}
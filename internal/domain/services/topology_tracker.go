@@ -2,30 +2,50 @@ package services
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// Errors returned by TopologyTracker implementations
+var (
+	ErrNodeNotFound       = errors.New("node not found")
+	ErrConnectionNotFound = errors.New("connection not found")
+	ErrInvalidTopology    = errors.New("invalid topology change")
+	ErrInvalidSnapshotID  = errors.New("invalid snapshot ID")
+	ErrSnapshotNotFound   = ports.ErrSnapshotNotFound
 )
 
 // TopologyTracker defines the domain service interface for topology management
 // This is a pure domain interface with no infrastructure dependencies
+// Mutations return the snapshot ID after the change, or the current one when nothing changed
 type TopologyTracker interface {
 	// Node operations
-	RegisterNode(ctx context.Context, node *entities.ServiceNode) error
-	DeregisterNode(ctx context.Context, nodeID string) error
-	UpdateNodeStatus(ctx context.Context, nodeID string, status entities.NodeStatus) error
+	RegisterNode(ctx context.Context, node *entities.ServiceNode) (string, error)
+	DeregisterNode(ctx context.Context, nodeID string) (string, error)
+	UpdateNodeStatus(ctx context.Context, nodeID string, status entities.NodeStatus) (string, error)
 	GetNode(ctx context.Context, nodeID string) (*entities.ServiceNode, error)
 	GetNodes(ctx context.Context, filters *entities.TopologyFilters) ([]*entities.ServiceNode, error)
 
 	// Connection operations
-	RegisterConnection(ctx context.Context, conn *entities.ServiceConnection) error
-	DeregisterConnection(ctx context.Context, connID string) error
-	UpdateConnectionStatus(ctx context.Context, connID string, status entities.EdgeStatus) error
+	RegisterConnection(ctx context.Context, conn *entities.ServiceConnection) (string, error)
+	DeregisterConnection(ctx context.Context, connID string) (string, error)
+	UpdateConnectionStatus(ctx context.Context, connID string, status entities.EdgeStatus) (string, error)
 	GetConnection(ctx context.Context, connID string) (*entities.ServiceConnection, error)
 	GetConnections(ctx context.Context, filters *entities.TopologyFilters) ([]*entities.ServiceConnection, error)
 
 	// Topology queries
 	GetTopology(ctx context.Context, filters *entities.TopologyFilters) (*entities.NetworkTopology, error)
 	GetTopologySnapshot(ctx context.Context) (*entities.NetworkTopology, error)
+	SnapshotID() string
+
+	// History queries
+	GetTopologyAt(ctx context.Context, snapshotID string) (*entities.NetworkTopology, error)
+	GetTopologyAsOf(ctx context.Context, at time.Time) (*entities.NetworkTopology, error)
+	DiffTopology(ctx context.Context, fromSnapshotID, toSnapshotID string) (*entities.TopologyDiff, error)
+	GetTopologyChanges(ctx context.Context, from, to time.Time) ([]*ports.TopologyChangeEvent, error)
 
 	// Metadata operations
 	GetNodeMetadata(ctx context.Context, nodeID string) (*entities.NodeMetadata, error)
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+const (
+	// accessResultCountKey is the context key read handlers set to the number of records returned
+	accessResultCountKey = "access.result_count"
+	// maxAccessBodyBytes bounds the request body kept in an access record
+	maxAccessBodyBytes = 4096
+)
+
+// setAccessResultCount reports how many audit records a read returned to the access log
+func setAccessResultCount(c *gin.Context, count int) {
+	c.Set(accessResultCountKey, count)
+}
+
+// AccessAuditMiddleware records every request to a read endpoint of audit data in the access log
+// An intent record is written before the handler runs and a completion record after it; a read
+// whose intent cannot be recorded is refused with 503 rather than served unrecorded.
+// Caller identity comes from X-Caller-ID (or X-User), X-Client and X-Client-Version; an
+// Authorization header is recorded only as a fingerprint
+func AccessAuditMiddleware(accessLog *services.AccessLog, resource string, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		var body string
+		if c.Request.Body != nil && c.Request.Method != http.MethodGet {
+			data, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(data))
+			body = string(data[:min(len(data), maxAccessBodyBytes)])
+		}
+
+		record := services.AccessRecord{
+			Phase:     services.AccessPhaseIntent,
+			Timestamp: start.UTC(),
+			Caller:    callerIdentity(c),
+			Resource:  resource,
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			Path:      c.Request.URL.Path,
+			Body:      body,
+		}
+		if query := c.Request.URL.Query(); len(query) > 0 {
+			record.Query = make(map[string]string, len(query))
+			for name, values := range query {
+				record.Query[name] = strings.Join(values, ",")
+			}
+		}
+		if params := c.Params; len(params) > 0 {
+			if record.Query == nil {
+				record.Query = make(map[string]string, len(params))
+			}
+			for _, param := range params {
+				record.Query[param.Key] = param.Value
+			}
+		}
+
+		intent, err := accessLog.Record(record)
+		if err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"resource": resource,
+				"caller":   record.Caller.ID,
+			}).Error("Failed to record audit data access, refusing the read")
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Access log unavailable, audit data cannot be read"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		record.Phase = services.AccessPhaseCompleted
+		record.Intent = intent.Sequence
+		record.Body = ""
+		record.Status = c.Writer.Status()
+		record.ResultCount = c.GetInt(accessResultCountKey)
+		record.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+		if _, err := accessLog.Record(record); err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"resource": resource,
+				"caller":   record.Caller.ID,
+				"intent":   intent.Sequence,
+			}).Error("Failed to record completion of audit data access")
+		}
+	}
+}
+
+func callerIdentity(c *gin.Context) services.CallerIdentity {
+	caller := services.CallerIdentity{
+		ID:            c.GetHeader("X-Caller-ID"),
+		Client:        c.GetHeader("X-Client"),
+		ClientVersion: c.GetHeader("X-Client-Version"),
+		RemoteAddr:    c.ClientIP(),
+		UserAgent:     c.Request.UserAgent(),
+	}
+	if caller.ID == "" {
+		caller.ID = c.GetHeader("X-User")
+	}
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		caller.CredentialFingerprint = services.CredentialFingerprint(auth)
+	}
+	return caller
+}
+
+// RequireAdminToken allows only requests bearing the admin token; with no token configured
+// every request is refused
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access is not configured"})
+			c.Abort()
+			return
+		}
+		presented := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid admin token"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+type AccessLogHandler struct {
+	accessLog *services.AccessLog
+	logger    *logrus.Logger
+}
+
+func NewAccessLogHandler(accessLog *services.AccessLog, logger *logrus.Logger) *AccessLogHandler {
+	return &AccessLogHandler{
+		accessLog: accessLog,
+		logger:    logger,
+	}
+}
+
+// QueryAccessLog returns access records, newest first
+// Query parameters: caller, resource, start_time/end_time (RFC3339), limit
+// Reads of the access log are deliberately not themselves recorded
+func (h *AccessLogHandler) QueryAccessLog(c *gin.Context) {
+	window, err := windowFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query := services.AccessLogQuery{
+		CallerID:  c.Query("caller"),
+		Resource:  c.Query("resource"),
+		StartTime: window.StartTime,
+		EndTime:   window.EndTime,
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer"})
+			return
+		}
+		query.Limit = parsed
+	}
+
+	records, err := h.accessLog.Query(query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"records": records,
+		"count":   len(records),
+	})
+}
+
+// VerifyAccessLog checks the hash chain of the whole access log file
+func (h *AccessLogHandler) VerifyAccessLog(c *gin.Context) {
+	verification, err := h.accessLog.Verify()
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to verify access log")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify access log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":       "success",
+		"verification": verification,
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type AggregationHandler struct {
+	aggregationService *services.AggregationService
+	logger             *logrus.Logger
+}
+
+func NewAggregationHandler(aggregationService *services.AggregationService, logger *logrus.Logger) *AggregationHandler {
+	return &AggregationHandler{
+		aggregationService: aggregationService,
+		logger:             logger,
+	}
+}
+
+// Aggregate returns time-bucketed event counts grouped by the requested dimensions
+// GET reads filter, start_time, end_time or time_window, interval, group_by and percentiles
+// (comma separated) and field from the query string; POST reads the same fields from a JSON body
+func (h *AggregationHandler) Aggregate(c *gin.Context) {
+	var req services.AggregationRequest
+
+	if c.Request.Method == http.MethodPost {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		window, err := windowFromQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if window.StartTime == nil && window.TimeWindow != "" {
+			start, end, ok := window.resolve()
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time window"})
+				return
+			}
+			window.StartTime, window.EndTime = &start, &end
+		}
+		req.StartTime = window.StartTime
+		req.EndTime = window.EndTime
+		req.Filter = c.Query("filter")
+		req.Interval = c.Query("interval")
+		req.Field = c.Query("field")
+		if groupBy := c.Query("group_by"); groupBy != "" {
+			req.GroupBy = strings.Split(groupBy, ",")
+		}
+		if percentiles := c.Query("percentiles"); percentiles != "" {
+			for _, value := range strings.Split(percentiles, ",") {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "percentiles must be numbers"})
+					return
+				}
+				req.Percentiles = append(req.Percentiles, parsed)
+			}
+		}
+	}
+
+	result, err := h.aggregationService.Aggregate(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to aggregate events")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to aggregate events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"result": result,
+	})
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type AssertionHandler struct {
+	assertionService *services.AssertionService
+	logger           *logrus.Logger
+}
+
+func NewAssertionHandler(assertionService *services.AssertionService, logger *logrus.Logger) *AssertionHandler {
+	return &AssertionHandler{
+		assertionService: assertionService,
+		logger:           logger,
+	}
+}
+
+// evaluationWindow is the time window an evaluation runs over
+// When start_time is omitted the window is time_window (default 1h) ending at end_time (default now)
+type evaluationWindow struct {
+	StartTime  *time.Time `json:"start_time"`
+	EndTime    *time.Time `json:"end_time"`
+	TimeWindow string     `json:"time_window"`
+}
+
+func (w evaluationWindow) resolve() (time.Time, time.Time, bool) {
+	end := time.Now()
+	if w.EndTime != nil {
+		end = *w.EndTime
+	}
+
+	if w.StartTime != nil {
+		return *w.StartTime, end, !w.StartTime.After(end)
+	}
+
+	window := 1 * time.Hour
+	if w.TimeWindow != "" {
+		parsed, err := time.ParseDuration(w.TimeWindow)
+		if err != nil || parsed <= 0 {
+			return time.Time{}, time.Time{}, false
+		}
+		window = parsed
+	}
+	return end.Add(-window), end, true
+}
+
+// respondWindowTooLarge answers 422 when a window holds more events than can be loaded at once
+func respondWindowTooLarge(c *gin.Context, err error) bool {
+	if !errors.Is(err, services.ErrWindowTruncated) {
+		return false
+	}
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error() + "; use a shorter window"})
+	return true
+}
+
+// windowFromQuery reads start_time/end_time (RFC3339) and time_window from the query string
+func windowFromQuery(c *gin.Context) (evaluationWindow, error) {
+	window := evaluationWindow{TimeWindow: c.Query("time_window")}
+
+	if value := c.Query("start_time"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return window, fmt.Errorf("start_time must be RFC3339")
+		}
+		window.StartTime = &parsed
+	}
+	if value := c.Query("end_time"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return window, fmt.Errorf("end_time must be RFC3339")
+		}
+		window.EndTime = &parsed
+	}
+	return window, nil
+}
+
+// ListSuites returns all registered assertion suites
+func (h *AssertionHandler) ListSuites(c *gin.Context) {
+	suites := h.assertionService.ListSuites()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"suites": suites,
+		"count":  len(suites),
+	})
+}
+
+// RegisterSuite registers (or replaces) an assertion suite
+func (h *AssertionHandler) RegisterSuite(c *gin.Context) {
+	var suite services.AssertionSuite
+	if err := c.ShouldBindJSON(&suite); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.assertionService.RegisterSuite(&suite); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":   "success",
+		"suite_id": suite.ID,
+	})
+}
+
+// GetSuite returns a registered assertion suite
+func (h *AssertionHandler) GetSuite(c *gin.Context) {
+	suite, err := h.assertionService.GetSuite(c.Param("suite_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"suite":  suite,
+	})
+}
+
+// EvaluateSuite evaluates a registered suite against stored events
+func (h *AssertionHandler) EvaluateSuite(c *gin.Context) {
+	suite, err := h.assertionService.GetSuite(c.Param("suite_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var window evaluationWindow
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&window); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	h.evaluate(c, suite, window, h.assertionService.EvaluateStored)
+}
+
+// EvaluateInline evaluates a suite submitted in the request body without registering it
+func (h *AssertionHandler) EvaluateInline(c *gin.Context) {
+	var req struct {
+		evaluationWindow
+		Suite *services.AssertionSuite `json:"suite" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.evaluate(c, req.Suite, req.evaluationWindow, h.assertionService.EvaluateInline)
+}
+
+func (h *AssertionHandler) evaluate(c *gin.Context, suite *services.AssertionSuite, window evaluationWindow, evaluate func(*services.AssertionSuite, time.Time, time.Time) (*services.SuiteResult, error)) {
+	start, end, ok := window.resolve()
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid evaluation window"})
+		return
+	}
+
+	if err := suite.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := evaluate(suite, start, end)
+	if respondWindowTooLarge(c, err) {
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to evaluate assertion suite")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate assertions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"result": result,
+	})
+}
+
+// GetSuiteResults returns previous evaluations of a suite
+func (h *AssertionHandler) GetSuiteResults(c *gin.Context) {
+	suiteID := c.Param("suite_id")
+	results := h.assertionService.GetSuiteResults(suiteID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"suite_id": suiteID,
+		"results":  results,
+		"count":    len(results),
+	})
+}
+
+// StartLiveRun starts collecting ingested events for a suite
+func (h *AssertionHandler) StartLiveRun(c *gin.Context) {
+	var req struct {
+		Duration string `json:"duration" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid duration"})
+		return
+	}
+
+	run, err := h.assertionService.StartLiveRun(c.Param("suite_id"), duration)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "success",
+		"run":    run,
+	})
+}
+
+// GetLiveRun returns the current state and results of a live run
+func (h *AssertionHandler) GetLiveRun(c *gin.Context) {
+	run, err := h.assertionService.GetLiveRun(c.Param("run_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"run":    run,
+	})
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
 )
 
 type AuditHandler struct {
@@ -47,6 +50,51 @@ func (h *AuditHandler) LogEvent(c *gin.Context) {
 	})
 }
 
+// IngestEvent handles audit events reported by other services via HTTP
+func (h *AuditHandler) IngestEvent(c *gin.Context) {
+	var req struct {
+		ID          string          `json:"id"`
+		TraceID     string          `json:"trace_id"`
+		SpanID      string          `json:"span_id"`
+		ServiceName string          `json:"service_name" binding:"required"`
+		EventType   string          `json:"event_type" binding:"required"`
+		Timestamp   time.Time       `json:"timestamp"`
+		Metadata    json.RawMessage `json:"metadata"`
+		Tags        []string        `json:"tags"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event := &models.AuditEvent{
+		ID:          req.ID,
+		TraceID:     req.TraceID,
+		SpanID:      req.SpanID,
+		ServiceName: req.ServiceName,
+		EventType:   req.EventType,
+		Timestamp:   req.Timestamp,
+		Metadata:    req.Metadata,
+		Tags:        req.Tags,
+	}
+
+	if err := h.auditService.IngestEvent(event); err != nil {
+		if errors.Is(err, services.ErrInvalidEvent) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to ingest audit event")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ingest audit event"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":   "success",
+		"event_id": event.ID,
+	})
+}
+
 // CorrelateEvents handles event correlation requests
 func (h *AuditHandler) CorrelateEvents(c *gin.Context) {
 	timeWindow := c.Query("time_window")
@@ -61,6 +109,7 @@ func (h *AuditHandler) CorrelateEvents(c *gin.Context) {
 		return
 	}
 
+	setAccessResultCount(c, len(correlations))
 	c.JSON(http.StatusOK, gin.H{
 		"status":       "success",
 		"correlations": correlations,
@@ -84,6 +133,7 @@ func (h *AuditHandler) GetEventsByTraceID(c *gin.Context) {
 		return
 	}
 
+	setAccessResultCount(c, len(events))
 	c.JSON(http.StatusOK, gin.H{
 		"status":   "success",
 		"trace_id": traceID,
@@ -92,6 +142,41 @@ func (h *AuditHandler) GetEventsByTraceID(c *gin.Context) {
 	})
 }
 
+// GetTraceTimeline returns the events of a trace ordered on the correlator clock
+func (h *AuditHandler) GetTraceTimeline(c *gin.Context) {
+	traceID := c.Param("trace_id")
+	if traceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "trace_id parameter is required"})
+		return
+	}
+
+	timeline, err := h.auditService.GetTraceTimeline(traceID)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to build trace timeline")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build timeline"})
+		return
+	}
+
+	setAccessResultCount(c, len(timeline))
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"trace_id": traceID,
+		"timeline": timeline,
+		"count":    len(timeline),
+	})
+}
+
+// GetClockOffsets returns the estimated clock offset of every observed service
+func (h *AuditHandler) GetClockOffsets(c *gin.Context) {
+	offsets := h.auditService.GetClockOffsets()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"offsets": offsets,
+		"count":   len(offsets),
+	})
+}
+
 // GetEventsByServiceType retrieves events for a service and event type
 func (h *AuditHandler) GetEventsByServiceType(c *gin.Context) {
 	serviceName := c.Query("service_name")
@@ -118,6 +203,7 @@ func (h *AuditHandler) GetEventsByServiceType(c *gin.Context) {
 		return
 	}
 
+	setAccessResultCount(c, len(events))
 	c.JSON(http.StatusOK, gin.H{
 		"status":       "success",
 		"service_name": serviceName,
@@ -0,0 +1,55 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/handlers"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+func TestAuditHandler_IngestEvent(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	handler := handlers.NewAuditHandler(services.NewAuditService(logger), logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/events/ingest", handler.IngestEvent)
+
+	future := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"valid_event", `{"service_name": "exchange-simulator", "event_type": "fill", "metadata": {"order_id": "o1"}}`, http.StatusCreated},
+		{"missing_service_name", `{"event_type": "fill"}`, http.StatusBadRequest},
+		{"malformed_timestamp", `{"service_name": "exchange-simulator", "event_type": "fill", "timestamp": "yesterday"}`, http.StatusBadRequest},
+		{"timestamp_far_ahead_of_receipt", `{"service_name": "exchange-simulator", "event_type": "fill", "timestamp": "` + future + `"}`, http.StatusBadRequest},
+		{"metadata_not_an_object", `{"service_name": "exchange-simulator", "event_type": "fill", "metadata": "order o1"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: the event is posted
+			req := httptest.NewRequest(http.MethodPost, "/events/ingest", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Then: client mistakes are rejected as bad requests, not server errors
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
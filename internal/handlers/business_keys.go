@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type BusinessKeyHandler struct {
+	businessKeyService *services.BusinessKeyService
+	logger             *logrus.Logger
+}
+
+func NewBusinessKeyHandler(businessKeyService *services.BusinessKeyService, logger *logrus.Logger) *BusinessKeyHandler {
+	return &BusinessKeyHandler{
+		businessKeyService: businessKeyService,
+		logger:             logger,
+	}
+}
+
+// ListKeys returns the configured extractors and index statistics per key
+func (h *BusinessKeyHandler) ListKeys(c *gin.Context) {
+	keys := h.businessKeyService.ListKeys()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":     "success",
+		"keys":       keys,
+		"extractors": h.businessKeyService.ListExtractors(),
+		"count":      len(keys),
+	})
+}
+
+// AddExtractor registers a business key extractor
+func (h *BusinessKeyHandler) AddExtractor(c *gin.Context) {
+	var extractor services.KeyExtractor
+	if err := c.ShouldBindJSON(&extractor); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.businessKeyService.AddExtractor(&extractor); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":    "success",
+		"extractor": extractor,
+	})
+}
+
+// Reindex indexes stored events in a window by business key
+func (h *BusinessKeyHandler) Reindex(c *gin.Context) {
+	var window evaluationWindow
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&window); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	start, end, ok := window.resolve()
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reindex window"})
+		return
+	}
+
+	indexed, err := h.businessKeyService.IndexWindow(start, end)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to index events by business key")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to index events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":         "success",
+		"events_indexed": indexed,
+		"keys":           h.businessKeyService.ListKeys(),
+	})
+}
+
+// CorrelateByKey groups indexed events by the values of a business key (min_events defaults to 2)
+func (h *BusinessKeyHandler) CorrelateByKey(c *gin.Context) {
+	minEvents := 2
+	if value := c.Query("min_events"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_events must be a positive integer"})
+			return
+		}
+		minEvents = parsed
+	}
+
+	key := c.Param("key")
+	groups := h.businessKeyService.Correlate(key, minEvents)
+	setAccessResultCount(c, len(groups))
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":       "success",
+		"key":          key,
+		"correlations": groups,
+		"count":        len(groups),
+	})
+}
+
+// GetEventsByKey returns events carrying a business key value
+func (h *BusinessKeyHandler) GetEventsByKey(c *gin.Context) {
+	key, value := c.Param("key"), c.Param("value")
+	events := h.businessKeyService.GetEvents(key, value)
+	setAccessResultCount(c, len(events))
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"key":    key,
+		"value":  value,
+		"events": events,
+		"count":  len(events),
+	})
+}
+
+// GetTimelineByKey returns the normalized timeline of events carrying a business key value
+func (h *BusinessKeyHandler) GetTimelineByKey(c *gin.Context) {
+	key, value := c.Param("key"), c.Param("value")
+	timeline := h.businessKeyService.GetTimeline(key, value)
+	setAccessResultCount(c, len(timeline))
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"key":      key,
+		"value":    value,
+		"timeline": timeline,
+		"count":    len(timeline),
+	})
+}
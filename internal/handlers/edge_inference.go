@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type EdgeInferenceHandler struct {
+	edgeInferenceService *services.EdgeInferenceService
+	logger               *logrus.Logger
+}
+
+func NewEdgeInferenceHandler(edgeInferenceService *services.EdgeInferenceService, logger *logrus.Logger) *EdgeInferenceHandler {
+	return &EdgeInferenceHandler{
+		edgeInferenceService: edgeInferenceService,
+		logger:               logger,
+	}
+}
+
+// GetLatest returns the result of the most recent edge inference run
+func (h *EdgeInferenceHandler) GetLatest(c *gin.Context) {
+	result := h.edgeInferenceService.Latest()
+	if result == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Edge inference has not run yet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"result": result,
+	})
+}
+
+// RunInference infers topology edges from traces in a window, by default the configured one
+func (h *EdgeInferenceHandler) RunInference(c *gin.Context) {
+	var window evaluationWindow
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&window); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if window.StartTime == nil && window.TimeWindow == "" {
+		window.TimeWindow = h.edgeInferenceService.Window().String()
+	}
+
+	start, end, ok := window.resolve()
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid inference window"})
+		return
+	}
+
+	result, err := h.edgeInferenceService.Infer(c.Request.Context(), start, end)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to infer topology edges")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to infer topology edges"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"result": result,
+	})
+}
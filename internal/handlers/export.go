@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type ExportHandler struct {
+	exportService *services.ExportService
+	logger        *logrus.Logger
+}
+
+func NewExportHandler(exportService *services.ExportService, logger *logrus.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// ExportEvents streams matching events as NDJSON, CSV or Parquet
+// GET reads filter, start_time, end_time, format, columns (comma separated) and limit from
+// the query string; POST reads the same fields from a JSON body
+func (h *ExportHandler) ExportEvents(c *gin.Context) {
+	var req services.ExportRequest
+
+	if c.Request.Method == http.MethodPost {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		window, err := windowFromQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.StartTime = window.StartTime
+		req.EndTime = window.EndTime
+		req.Filter = c.Query("filter")
+		req.Format = c.Query("format")
+		if columns := c.Query("columns"); columns != "" {
+			req.Columns = strings.Split(columns, ",")
+		}
+		if limit := c.Query("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+				return
+			}
+			req.Limit = parsed
+		}
+	}
+
+	job, err := h.exportService.Prepare(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to prepare event export")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export events"})
+		return
+	}
+
+	filename := fmt.Sprintf("audit-events-%s.%s", time.Now().UTC().Format("20060102T150405Z"), job.Extension())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Content-Type", job.ContentType())
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure can only be logged and the response cut short
+	exported, err := job.Stream(c.Request.Context(), c.Writer)
+	setAccessResultCount(c, exported)
+	if err != nil {
+		h.logger.WithError(err).WithField("exported", exported).Error("Event export aborted")
+		c.Abort()
+		return
+	}
+	h.logger.WithFields(logrus.Fields{
+		"format":   job.Extension(),
+		"exported": exported,
+	}).Info("Event export completed")
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+type LifecycleHandler struct {
+	processor *services.EventLifecycleProcessor
+	logger    *logrus.Logger
+}
+
+func NewLifecycleHandler(processor *services.EventLifecycleProcessor, logger *logrus.Logger) *LifecycleHandler {
+	return &LifecycleHandler{
+		processor: processor,
+		logger:    logger,
+	}
+}
+
+// ListEvents returns lifecycle records, optionally filtered by the status query parameter
+func (h *LifecycleHandler) ListEvents(c *gin.Context) {
+	var status models.AuditEventStatus
+	if value := c.Query("status"); value != "" {
+		parsed, err := services.ParseEventStatus(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		status = parsed
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	records := h.processor.ListByStatus(status, limit)
+	setAccessResultCount(c, len(records))
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"records": records,
+		"count":   len(records),
+	})
+}
+
+// GetEvent returns the lifecycle record of one event
+func (h *LifecycleHandler) GetEvent(c *gin.Context) {
+	record, err := h.processor.GetRecord(c.Param("event_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	setAccessResultCount(c, 1)
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"record": record,
+	})
+}
+
+// RequeueEvent queues a failed event for another processing attempt
+func (h *LifecycleHandler) RequeueEvent(c *gin.Context) {
+	eventID := c.Param("event_id")
+	if _, err := h.processor.GetRecord(eventID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	record, err := h.processor.Requeue(eventID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.WithField("event_id", eventID).Info("Requeued failed audit event")
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"record": record,
+	})
+}
+
+// RequeueFailed queues every failed event for another processing attempt
+func (h *LifecycleHandler) RequeueFailed(c *gin.Context) {
+	requeued := h.processor.RequeueFailed()
+
+	h.logger.WithField("requeued", requeued).Info("Requeued failed audit events")
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"requeued": requeued,
+	})
+}
+
+// Stats returns the number of tracked events in each status and how many could not be tracked
+func (h *LifecycleHandler) Stats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"counts":   h.processor.StatusCounts(),
+		"rejected": h.processor.Rejected(),
+	})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type OrderHandler struct {
+	orderService *services.OrderLifecycleService
+	logger       *logrus.Logger
+}
+
+func NewOrderHandler(orderService *services.OrderLifecycleService, logger *logrus.Logger) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+		logger:       logger,
+	}
+}
+
+// orderTimeWindow parses the time_window query parameter (default 24h)
+func orderTimeWindow(c *gin.Context) time.Duration {
+	timeWindow := 24 * time.Hour
+	if parsed, err := time.ParseDuration(c.Query("time_window")); err == nil && parsed > 0 {
+		timeWindow = parsed
+	}
+	return timeWindow
+}
+
+// GetOrderLifecycle answers "what happened to order X"
+func (h *OrderHandler) GetOrderLifecycle(c *gin.Context) {
+	orderID := c.Param("order_id")
+	if orderID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order_id parameter is required"})
+		return
+	}
+
+	lifecycle, err := h.orderService.GetOrderLifecycle(orderID, orderTimeWindow(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	setAccessResultCount(c, len(lifecycle.Events))
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"lifecycle": lifecycle,
+	})
+}
+
+// GetOrderViolations returns orders with illegal transitions, overfills or missing acknowledgements
+func (h *OrderHandler) GetOrderViolations(c *gin.Context) {
+	timeWindow := orderTimeWindow(c)
+
+	orders, err := h.orderService.GetOrderViolations(timeWindow)
+	if respondWindowTooLarge(c, err) {
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to reconstruct order lifecycles")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reconstruct order lifecycles"})
+		return
+	}
+
+	setAccessResultCount(c, len(orders))
+	c.JSON(http.StatusOK, gin.H{
+		"status":      "success",
+		"time_window": timeWindow.String(),
+		"orders":      orders,
+		"count":       len(orders),
+	})
+}
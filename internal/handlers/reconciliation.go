@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService *services.ReconciliationService
+	logger                *logrus.Logger
+}
+
+func NewReconciliationHandler(reconciliationService *services.ReconciliationService, logger *logrus.Logger) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+		logger:                logger,
+	}
+}
+
+// RunReconciliation reconciles trades in a window, optionally overriding the default tolerances
+func (h *ReconciliationHandler) RunReconciliation(c *gin.Context) {
+	var req struct {
+		evaluationWindow
+		Config *services.ReconciliationConfig `json:"config"`
+	}
+
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	start, end, ok := req.resolve()
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reconciliation window"})
+		return
+	}
+
+	config := h.reconciliationService.Config()
+	if req.Config != nil {
+		config = *req.Config
+	}
+
+	run, err := h.reconciliationService.Reconcile(start, end, config)
+	if respondWindowTooLarge(c, err) {
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to reconcile trades")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reconcile trades"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"run":    run,
+	})
+}
+
+// ListRuns returns previous reconciliation runs
+func (h *ReconciliationHandler) ListRuns(c *gin.Context) {
+	runs := h.reconciliationService.ListRuns()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"runs":   runs,
+		"count":  len(runs),
+	})
+}
+
+// ListBreaks returns trade breaks filtered by type, status, order_id, since (RFC3339) and limit
+func (h *ReconciliationHandler) ListBreaks(c *gin.Context) {
+	filter := services.TradeBreakFilter{
+		Type:    c.Query("type"),
+		Status:  c.Query("status"),
+		OrderID: c.Query("order_id"),
+	}
+
+	if since := c.Query("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be RFC3339"})
+			return
+		}
+		filter.Since = parsed
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer"})
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	breaks := h.reconciliationService.ListBreaks(filter)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"breaks": breaks,
+		"count":  len(breaks),
+	})
+}
+
+// GetBreak returns a single trade break
+func (h *ReconciliationHandler) GetBreak(c *gin.Context) {
+	tradeBreak, err := h.reconciliationService.GetBreak(c.Param("break_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"break":  tradeBreak,
+	})
+}
+
+// ResolveBreak marks a trade break as resolved
+func (h *ReconciliationHandler) ResolveBreak(c *gin.Context) {
+	var req struct {
+		Resolution string `json:"resolution" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tradeBreak, err := h.reconciliationService.ResolveBreak(c.Param("break_id"), req.Resolution)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"break":  tradeBreak,
+	})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type ReportHandler struct {
+	reportService *services.ReportService
+	logger        *logrus.Logger
+}
+
+func NewReportHandler(reportService *services.ReportService, logger *logrus.Logger) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		logger:        logger,
+	}
+}
+
+// GenerateReport builds a correlation report and returns it as a downloadable document
+// Query parameters: format (markdown|html|json), start_time/end_time (RFC3339), time_window, scenario_id, max_timelines
+func (h *ReportHandler) GenerateReport(c *gin.Context) {
+	format, err := services.ParseReportFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := services.ReportRequest{ScenarioID: c.Query("scenario_id")}
+
+	if endStr := c.Query("end_time"); endStr != "" {
+		if req.EndTime, err = time.Parse(time.RFC3339, endStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be RFC3339"})
+			return
+		}
+	}
+	if startStr := c.Query("start_time"); startStr != "" {
+		if req.StartTime, err = time.Parse(time.RFC3339, startStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "start_time must be RFC3339"})
+			return
+		}
+	} else if windowStr := c.Query("time_window"); windowStr != "" {
+		window, err := time.ParseDuration(windowStr)
+		if err != nil || window <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time_window"})
+			return
+		}
+		end := req.EndTime
+		if end.IsZero() {
+			end = time.Now()
+		}
+		req.StartTime = end.Add(-window)
+		req.EndTime = end
+	}
+	if maxStr := c.Query("max_timelines"); maxStr != "" {
+		if req.MaxTimelines, err = strconv.Atoi(maxStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_timelines must be an integer"})
+			return
+		}
+	}
+
+	report, err := h.reportService.Generate(req)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to generate correlation report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate report"})
+		return
+	}
+
+	data, contentType, extension, err := services.RenderReport(report, format)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to render correlation report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render report"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("correlation-report-%s.%s", report.GeneratedAt.UTC().Format("20060102T150405Z"), extension)))
+	setAccessResultCount(c, report.Summary.TotalEvents)
+	c.Data(http.StatusOK, contentType, data)
+}
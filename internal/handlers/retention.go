@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type RetentionHandler struct {
+	retentionService *services.RetentionService
+	logger           *logrus.Logger
+}
+
+func NewRetentionHandler(retentionService *services.RetentionService, logger *logrus.Logger) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+		logger:           logger,
+	}
+}
+
+// ListPolicies returns the retention policies in match order
+func (h *RetentionHandler) ListPolicies(c *gin.Context) {
+	policies := h.retentionService.ListPolicies()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"policies": policies,
+		"count":    len(policies),
+	})
+}
+
+// SetPolicies replaces the retention policies ({"policies": [...]})
+func (h *RetentionHandler) SetPolicies(c *gin.Context) {
+	var req struct {
+		Policies []*services.RetentionPolicy `json:"policies"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.retentionService.SetPolicies(req.Policies); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.WithField("policies", len(req.Policies)).Info("Retention policies updated")
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"policies": h.retentionService.ListPolicies(),
+	})
+}
+
+// Sweep runs a retention sweep immediately
+func (h *RetentionHandler) Sweep(c *gin.Context) {
+	result, err := h.retentionService.Sweep(c.Request.Context())
+	if err != nil {
+		h.logger.WithError(err).Error("Retention sweep failed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Retention sweep failed", "result": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"result": result,
+	})
+}
+
+// ListSegments returns the archive segment manifests
+func (h *RetentionHandler) ListSegments(c *gin.Context) {
+	segments := h.retentionService.ListSegments()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"segments": segments,
+		"count":    len(segments),
+	})
+}
+
+// ListLegalHolds returns the active legal holds
+func (h *RetentionHandler) ListLegalHolds(c *gin.Context) {
+	holds := h.retentionService.ListLegalHolds()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":      "success",
+		"legal_holds": holds,
+		"count":       len(holds),
+	})
+}
+
+// AddLegalHold places a legal hold on events matching a filter expression
+func (h *RetentionHandler) AddLegalHold(c *gin.Context) {
+	var req struct {
+		Filter string `json:"filter"`
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hold, err := h.retentionService.AddLegalHold(req.Filter, req.Reason)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to place legal hold")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to place legal hold"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":     "success",
+		"legal_hold": hold,
+	})
+}
+
+// ReleaseLegalHold removes a legal hold
+func (h *RetentionHandler) ReleaseLegalHold(c *gin.Context) {
+	if err := h.retentionService.ReleaseLegalHold(c.Param("hold_id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// QueryEvents searches hot storage and the archive together
+// GET reads filter, start_time, end_time and limit from the query string; POST reads a JSON body
+func (h *RetentionHandler) QueryEvents(c *gin.Context) {
+	var req services.ArchiveQueryRequest
+
+	if c.Request.Method == http.MethodPost {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		window, err := windowFromQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.StartTime = window.StartTime
+		req.EndTime = window.EndTime
+		req.Filter = c.Query("filter")
+		if limit := c.Query("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+				return
+			}
+			req.Limit = parsed
+		}
+	}
+
+	result, err := h.retentionService.Query(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to query archived events")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query events"})
+		return
+	}
+
+	setAccessResultCount(c, result.Count)
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"result": result,
+	})
+}
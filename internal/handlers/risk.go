@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type RiskHandler struct {
+	coverageTracker *services.RiskCoverageTracker
+	effectiveness   *services.AlertEffectivenessService
+	logger          *logrus.Logger
+}
+
+func NewRiskHandler(coverageTracker *services.RiskCoverageTracker, effectiveness *services.AlertEffectivenessService, logger *logrus.Logger) *RiskHandler {
+	return &RiskHandler{
+		coverageTracker: coverageTracker,
+		effectiveness:   effectiveness,
+		logger:          logger,
+	}
+}
+
+// ListScenarios returns the risk scenario catalogue
+func (h *RiskHandler) ListScenarios(c *gin.Context) {
+	scenarios := h.coverageTracker.ListScenarios()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"scenarios": scenarios,
+		"count":     len(scenarios),
+	})
+}
+
+// GetScenarioRuns returns the recorded runs of a scenario
+func (h *RiskHandler) GetScenarioRuns(c *gin.Context) {
+	scenarioID := c.Param("scenario_id")
+
+	runs, err := h.coverageTracker.GetRuns(scenarioID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":      "success",
+		"scenario_id": scenarioID,
+		"runs":        runs,
+		"count":       len(runs),
+	})
+}
+
+// RecordScenarioRun records the start of a scenario run reported by a test coordinator
+func (h *RiskHandler) RecordScenarioRun(c *gin.Context) {
+	var req struct {
+		RunID     string    `json:"run_id"`
+		StartedAt time.Time `json:"started_at"`
+	}
+
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.StartedAt.IsZero() {
+		req.StartedAt = time.Now()
+	}
+
+	run, err := h.coverageTracker.RecordRun(c.Param("scenario_id"), req.RunID, req.StartedAt)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"run":    run,
+	})
+}
+
+// GetCoverageMatrix returns the aggregate scenario coverage matrix
+func (h *RiskHandler) GetCoverageMatrix(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"coverage": h.coverageTracker.CoverageMatrix(),
+	})
+}
+
+// ScanCoverage replays stored events in a window to record runs and detections
+func (h *RiskHandler) ScanCoverage(c *gin.Context) {
+	var window evaluationWindow
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&window); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	start, end, ok := window.resolve()
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan window"})
+		return
+	}
+
+	scanned, err := h.coverageTracker.ScanWindow(start, end)
+	if respondWindowTooLarge(c, err) {
+		return
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to scan events for risk coverage")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":         "success",
+		"events_scanned": scanned,
+		"coverage":       h.coverageTracker.CoverageMatrix(),
+	})
+}
+
+// GetAlertEffectiveness scores risk alerts against injected scenarios over a window
+// Query parameters: start_time/end_time (RFC3339) or time_window (default 1h)
+func (h *RiskHandler) GetAlertEffectiveness(c *gin.Context) {
+	window, err := windowFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, end, ok := window.resolve()
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scoring window"})
+		return
+	}
+
+	report, err := h.effectiveness.Score(start, end)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "success",
+		"effectiveness": report,
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type RootCauseHandler struct {
+	rootCauseService *services.RootCauseService
+	logger           *logrus.Logger
+}
+
+func NewRootCauseHandler(rootCauseService *services.RootCauseService, logger *logrus.Logger) *RootCauseHandler {
+	return &RootCauseHandler{
+		rootCauseService: rootCauseService,
+		logger:           logger,
+	}
+}
+
+// ListAnalyses returns root-cause analyses newest first, optionally filtered by node_id
+func (h *RootCauseHandler) ListAnalyses(c *gin.Context) {
+	analyses := h.rootCauseService.ListAnalyses(c.Query("node_id"))
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"analyses": analyses,
+		"count":    len(analyses),
+	})
+}
+
+// GetAnalysis returns a root-cause analysis with its ranked hypotheses
+func (h *RootCauseHandler) GetAnalysis(c *gin.Context) {
+	analysis, err := h.rootCauseService.GetAnalysis(c.Param("analysis_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"analysis": analysis,
+	})
+}
+
+// AnalyzeNode ranks root causes for a node on demand; changed_at defaults to now
+func (h *RootCauseHandler) AnalyzeNode(c *gin.Context) {
+	var req struct {
+		NodeID    string     `json:"node_id" binding:"required"`
+		ChangedAt *time.Time `json:"changed_at"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changedAt := time.Now()
+	if req.ChangedAt != nil {
+		changedAt = *req.ChangedAt
+	}
+
+	analysis, err := h.rootCauseService.Analyze(req.NodeID, changedAt)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"analysis": analysis,
+	})
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type SavedQueryHandler struct {
+	savedQueryService *services.SavedQueryService
+	logger            *logrus.Logger
+}
+
+func NewSavedQueryHandler(savedQueryService *services.SavedQueryService, logger *logrus.Logger) *SavedQueryHandler {
+	return &SavedQueryHandler{
+		savedQueryService: savedQueryService,
+		logger:            logger,
+	}
+}
+
+// notFoundOrBadRequest maps lookup failures to 404 and validation failures to 400
+func notFoundOrBadRequest(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+// ListQueries returns all saved queries with their evaluation state
+func (h *SavedQueryHandler) ListQueries(c *gin.Context) {
+	queries := h.savedQueryService.ListQueries()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"queries": queries,
+		"count":   len(queries),
+	})
+}
+
+// CreateQuery registers a saved query
+func (h *SavedQueryHandler) CreateQuery(c *gin.Context) {
+	var query services.SavedQuery
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.saveQuery(c, &query, http.StatusCreated)
+}
+
+// UpdateQuery replaces the saved query named in the path
+func (h *SavedQueryHandler) UpdateQuery(c *gin.Context) {
+	var query services.SavedQuery
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query.ID = c.Param("query_id")
+	h.saveQuery(c, &query, http.StatusOK)
+}
+
+func (h *SavedQueryHandler) saveQuery(c *gin.Context, query *services.SavedQuery, status int) {
+	if err := h.savedQueryService.RegisterQuery(query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	saved, err := h.savedQueryService.GetQuery(query.ID)
+	if err != nil {
+		notFoundOrBadRequest(c, err)
+		return
+	}
+
+	h.logger.WithField("query_id", query.ID).Info("Saved query registered")
+	c.JSON(status, gin.H{
+		"status": "success",
+		"query":  saved,
+	})
+}
+
+// GetQuery returns one saved query with its evaluation state
+func (h *SavedQueryHandler) GetQuery(c *gin.Context) {
+	query, err := h.savedQueryService.GetQuery(c.Param("query_id"))
+	if err != nil {
+		notFoundOrBadRequest(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"query":  query,
+	})
+}
+
+// DeleteQuery removes a saved query
+func (h *SavedQueryHandler) DeleteQuery(c *gin.Context) {
+	if err := h.savedQueryService.DeleteQuery(c.Param("query_id")); err != nil {
+		notFoundOrBadRequest(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// RunQuery evaluates a saved query now, notifying its sinks if it fires
+func (h *SavedQueryHandler) RunQuery(c *gin.Context) {
+	run, err := h.savedQueryService.RunQuery(c.Request.Context(), c.Param("query_id"))
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to run saved query")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run saved query"})
+		return
+	}
+
+	setAccessResultCount(c, run.MatchCount)
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"run":    run,
+	})
+}
+
+// GetNotifications returns the notifications a saved query has fired, newest first
+func (h *SavedQueryHandler) GetNotifications(c *gin.Context) {
+	notifications, err := h.savedQueryService.GetNotifications(c.Param("query_id"))
+	if err != nil {
+		notFoundOrBadRequest(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "success",
+		"notifications": notifications,
+		"count":         len(notifications),
+	})
+}
+
+// ListSinks returns the notification sinks
+func (h *SavedQueryHandler) ListSinks(c *gin.Context) {
+	sinks := h.savedQueryService.ListSinks()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"sinks":  sinks,
+		"count":  len(sinks),
+	})
+}
+
+// CreateSink registers a notification sink (replacing any sink with the same ID)
+func (h *SavedQueryHandler) CreateSink(c *gin.Context) {
+	var sink services.NotificationSink
+	if err := c.ShouldBindJSON(&sink); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.savedQueryService.RegisterSink(&sink); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"sink_id": sink.ID,
+		"type":    sink.Type,
+	}).Info("Notification sink registered")
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"sink":   sink,
+	})
+}
+
+// DeleteSink removes a notification sink that no saved query uses
+func (h *SavedQueryHandler) DeleteSink(c *gin.Context) {
+	if err := h.savedQueryService.DeleteSink(c.Param("sink_id")); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// TestSink sends a sample notification to a sink and reports the outcome
+func (h *SavedQueryHandler) TestSink(c *gin.Context) {
+	if err := h.savedQueryService.TestSink(c.Request.Context(), c.Param("sink_id")); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
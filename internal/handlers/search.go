@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+type SearchHandler struct {
+	searchService *services.SearchService
+	logger        *logrus.Logger
+}
+
+func NewSearchHandler(searchService *services.SearchService, logger *logrus.Logger) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		logger:        logger,
+	}
+}
+
+// SearchEvents searches stored events with a filter expression
+// GET reads filter, start_time, end_time, sort_by, sort_order, fields (comma separated),
+// limit and cursor from the query string; POST reads the same fields from a JSON body
+func (h *SearchHandler) SearchEvents(c *gin.Context) {
+	var req services.SearchRequest
+
+	if c.Request.Method == http.MethodPost {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		window, err := windowFromQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.StartTime = window.StartTime
+		req.EndTime = window.EndTime
+		req.Filter = c.Query("filter")
+		req.SortBy = c.Query("sort_by")
+		req.SortOrder = c.Query("sort_order")
+		req.Cursor = c.Query("cursor")
+		if fields := c.Query("fields"); fields != "" {
+			req.Fields = strings.Split(fields, ",")
+		}
+		if limit := c.Query("limit"); limit != "" {
+			parsed, err := strconv.Atoi(limit)
+			if err != nil || parsed < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+				return
+			}
+			req.Limit = parsed
+		}
+	}
+
+	result, err := h.searchService.Search(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to search events")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search events"})
+		return
+	}
+
+	setAccessResultCount(c, result.Count)
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"result": result,
+	})
+}
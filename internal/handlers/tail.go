@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+// tailHeartbeatInterval keeps idle tails alive through proxies
+const tailHeartbeatInterval = 15 * time.Second
+
+type TailHandler struct {
+	tailService *services.EventTailService
+	logger      *logrus.Logger
+}
+
+func NewTailHandler(tailService *services.EventTailService, logger *logrus.Logger) *TailHandler {
+	return &TailHandler{
+		tailService: tailService,
+		logger:      logger,
+	}
+}
+
+// tailFrame is the JSON payload of a WebSocket message or SSE event
+type tailFrame struct {
+	Type         string      `json:"type"` // event, dropped or heartbeat
+	Event        interface{} `json:"event,omitempty"`
+	Dropped      int64       `json:"dropped,omitempty"`
+	TotalDropped int64       `json:"total_dropped,omitempty"`
+}
+
+func newTailFrame(msg services.TailMessage) tailFrame {
+	if msg.Event == nil {
+		return tailFrame{Type: "dropped", Dropped: msg.Dropped, TotalDropped: msg.TotalDropped}
+	}
+	return tailFrame{Type: "event", Event: msg.Event}
+}
+
+// subscribe starts a tail from the filter and buffer_size query parameters, writing any error response
+func (h *TailHandler) subscribe(c *gin.Context) (*services.TailSubscription, bool) {
+	bufferSize := 0
+	if value := c.Query("buffer_size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "buffer_size must be a non-negative integer"})
+			return nil, false
+		}
+		bufferSize = parsed
+	}
+
+	sub, err := h.tailService.Subscribe(c.Query("filter"), bufferSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidSearch):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrTailCapacity):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		default:
+			h.logger.WithError(err).Error("Failed to start live tail")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start live tail"})
+		}
+		return nil, false
+	}
+	return sub, true
+}
+
+// nextTailMessage waits for the next message, returning ok=false when a heartbeat is due
+func nextTailMessage(ctx context.Context, sub *services.TailSubscription) (services.TailMessage, bool, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, tailHeartbeatInterval)
+	defer cancel()
+
+	msg, err := sub.Next(waitCtx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return msg, false, ctx.Err()
+		}
+		return msg, false, nil
+	}
+	return msg, true, nil
+}
+
+// StreamSSE streams matching events as Server-Sent Events
+// Events are sent as "event", drops as "dropped" and idle periods as "heartbeat"
+func (h *TailHandler) StreamSSE(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer h.tailService.Unsubscribe(sub.ID)
+	sent := 0
+	defer func() { setAccessResultCount(c, sent) }()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.SSEvent("subscribed", gin.H{"subscription_id": sub.ID, "filter": sub.Filter})
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	for {
+		msg, ok, err := nextTailMessage(ctx, sub)
+		if err != nil {
+			return
+		}
+		if !ok {
+			c.SSEvent("heartbeat", gin.H{"time": time.Now().UTC()})
+		} else {
+			frame := newTailFrame(msg)
+			c.SSEvent(frame.Type, frame)
+			if msg.Event != nil {
+				sent++
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// StreamWebSocket streams matching events as JSON WebSocket messages
+func (h *TailHandler) StreamWebSocket(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer h.tailService.Unsubscribe(sub.ID)
+	sent := 0
+	defer func() { setAccessResultCount(c, sent) }()
+
+	// Origins are not checked, matching the CORS policy of the HTTP API
+	server := websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			defer cancel()
+
+			// Client messages are ignored; a read error means the client went away
+			go func() {
+				defer cancel()
+				var discard []byte
+				for {
+					if err := websocket.Message.Receive(conn, &discard); err != nil {
+						return
+					}
+				}
+			}()
+
+			if err := websocket.JSON.Send(conn, gin.H{"type": "subscribed", "subscription_id": sub.ID, "filter": sub.Filter}); err != nil {
+				return
+			}
+			for {
+				msg, ok, err := nextTailMessage(ctx, sub)
+				if err != nil {
+					return
+				}
+				frame := tailFrame{Type: "heartbeat"}
+				if ok {
+					frame = newTailFrame(msg)
+				}
+				if err := websocket.JSON.Send(conn, frame); err != nil {
+					h.logger.WithError(err).Debug("Live tail WebSocket closed")
+					return
+				}
+				if frame.Type == "event" {
+					sent++
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// ListSubscriptions returns the active live tails
+func (h *TailHandler) ListSubscriptions(c *gin.Context) {
+	subscriptions := h.tailService.ListSubscriptions()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        "success",
+		"subscriptions": subscriptions,
+		"count":         len(subscriptions),
+	})
+}
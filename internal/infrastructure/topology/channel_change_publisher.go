@@ -11,10 +11,9 @@ import (
 
 // subscription represents an active subscription to topology changes
 type subscription struct {
-	id       string
-	channel  chan *ports.TopologyChangeEvent
-	filters  *entities.TopologyFilters
-	fromSnap string
+	id      string
+	channel chan *ports.TopologyChangeEvent
+	filters *entities.TopologyFilters
 }
 
 // ChannelChangePublisher implements TopologyChangePublisher using Go channels
@@ -24,6 +23,7 @@ type ChannelChangePublisher struct {
 	subscriptions map[string]*subscription
 	events        []*ports.TopologyChangeEvent // Store recent events for catch-up
 	maxEvents     int
+	nextID        int
 }
 
 // NewChannelChangePublisher creates a new channel-based change publisher
@@ -70,26 +70,50 @@ func (p *ChannelChangePublisher) PublishChange(ctx context.Context, event *ports
 }
 
 // Subscribe subscribes to topology changes from a specific snapshot
+// The subscription ends, closing the channel, when ctx is cancelled
 func (p *ChannelChangePublisher) Subscribe(ctx context.Context, fromSnapshotID string, filters *entities.TopologyFilters) (<-chan *ports.TopologyChangeEvent, error) {
+	// Catch up on events after the requested snapshot
+	var fromVersion uint64
+	if fromSnapshotID != "" {
+		version, err := entities.ParseSnapshotVersion(fromSnapshotID)
+		if err != nil {
+			return nil, err
+		}
+		fromVersion = version
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	// Create new subscription
-	subID := fmt.Sprintf("sub-%d", len(p.subscriptions))
-	eventChan := make(chan *ports.TopologyChangeEvent, 100) // Buffered channel
+	var catchUp []*ports.TopologyChangeEvent
+	if fromSnapshotID != "" {
+		for _, event := range p.events {
+			version, err := entities.ParseSnapshotVersion(event.SnapshotID)
+			if err == nil && version > fromVersion && p.eventMatchesFilters(event, filters) {
+				catchUp = append(catchUp, event)
+			}
+		}
+	}
 
-	sub := &subscription{
-		id:       subID,
-		channel:  eventChan,
-		filters:  filters,
-		fromSnap: fromSnapshotID,
+	// Create new subscription, buffered to hold the catch-up ahead of live events
+	p.nextID++
+	subID := fmt.Sprintf("sub-%d", p.nextID)
+	eventChan := make(chan *ports.TopologyChangeEvent, 100+len(catchUp))
+	for _, event := range catchUp {
+		eventChan <- event
 	}
 
-	p.subscriptions[subID] = sub
+	p.subscriptions[subID] = &subscription{
+		id:      subID,
+		channel: eventChan,
+		filters: filters,
+	}
 
-	// Send historical events if requested (catch-up from snapshot)
-	if fromSnapshotID != "" {
-		go p.sendHistoricalEvents(ctx, sub)
+	if done := ctx.Done(); done != nil {
+		go func() {
+			<-done
+			p.Unsubscribe(context.Background(), subID)
+		}()
 	}
 
 	return eventChan, nil
@@ -111,27 +135,6 @@ func (p *ChannelChangePublisher) Unsubscribe(ctx context.Context, subscriptionID
 	return nil
 }
 
-// sendHistoricalEvents sends historical events to a new subscriber (catch-up)
-func (p *ChannelChangePublisher) sendHistoricalEvents(ctx context.Context, sub *subscription) {
-	p.mu.RLock()
-	events := p.events // Get a reference to events
-	p.mu.RUnlock()
-
-	for _, event := range events {
-		// Check if event is after the requested snapshot
-		if event.SnapshotID > sub.fromSnap {
-			if p.eventMatchesFilters(event, sub.filters) {
-				select {
-				case sub.channel <- event:
-					// Event sent
-				case <-ctx.Done():
-					return
-				}
-			}
-		}
-	}
-}
-
 // eventMatchesFilters checks if an event matches the subscriber's filters
 func (p *ChannelChangePublisher) eventMatchesFilters(event *ports.TopologyChangeEvent, filters *entities.TopologyFilters) bool {
 	if filters == nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
 )
 
 // TopologyConfig represents the JSON configuration format
@@ -61,15 +62,15 @@ type MetricsConfig struct {
 
 // ConfigLoader loads topology from JSON configuration file
 type ConfigLoader struct {
-	logger     *logrus.Logger
-	repository *MemoryTopologyRepository
+	logger  *logrus.Logger
+	tracker services.TopologyTracker
 }
 
-// NewConfigLoader creates a new configuration loader
-func NewConfigLoader(repository *MemoryTopologyRepository, logger *logrus.Logger) *ConfigLoader {
+// NewConfigLoader creates a new configuration loader registering topology through the tracker
+func NewConfigLoader(tracker services.TopologyTracker, logger *logrus.Logger) *ConfigLoader {
 	return &ConfigLoader{
-		logger:     logger,
-		repository: repository,
+		logger:  logger,
+		tracker: tracker,
 	}
 }
 
@@ -135,10 +136,15 @@ func (l *ConfigLoader) loadNode(config NodeConfig) error {
 		config.Name, // instance name (use name for now)
 	)
 
-	// Save node to repository
+	// Endpoints become labels such as endpoint.grpc and endpoint.http for the health prober
+	for protocol, endpoint := range config.Endpoints {
+		node.Labels["endpoint."+protocol] = endpoint
+	}
+
+	// Register node
 	ctx := context.Background()
-	if err := l.repository.SaveNode(ctx, node); err != nil {
-		return fmt.Errorf("failed to save node to repository: %w", err)
+	if _, err := l.tracker.RegisterNode(ctx, node); err != nil {
+		return fmt.Errorf("failed to register node: %w", err)
 	}
 
 	l.logger.WithFields(logrus.Fields{
@@ -171,10 +177,10 @@ func (l *ConfigLoader) loadEdge(config EdgeConfig) error {
 		connType,
 	)
 
-	// Save edge to repository
+	// Register edge
 	ctx := context.Background()
-	if err := l.repository.SaveConnection(ctx, edge); err != nil {
-		return fmt.Errorf("failed to save edge to repository: %w", err)
+	if _, err := l.tracker.RegisterConnection(ctx, edge); err != nil {
+		return fmt.Errorf("failed to register edge: %w", err)
 	}
 
 	l.logger.WithFields(logrus.Fields{
@@ -0,0 +1,542 @@
+package topology
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
+)
+
+const (
+	// Node labels holding probe endpoints; registry discovery sets host and the ports instead
+	GRPCEndpointLabel = "endpoint.grpc"
+	HTTPEndpointLabel = "endpoint.http"
+
+	// healthPath is the HTTP health endpoint every service in the ecosystem serves
+	healthPath = "/api/v1/health"
+)
+
+// probeOutcome is the result of probing one node or edge
+type probeOutcome int
+
+const (
+	probeHealthy probeOutcome = iota
+	probeDegraded
+	probeFailed
+)
+
+func (o probeOutcome) String() string {
+	switch o {
+	case probeHealthy:
+		return "healthy"
+	case probeDegraded:
+		return "degraded"
+	default:
+		return "failed"
+	}
+}
+
+// healthLevel orders statuses from healthy to down for both nodes and edges
+type healthLevel int
+
+const (
+	levelUnknown healthLevel = iota
+	levelHealthy
+	levelDegraded
+	levelDown
+)
+
+// HealthProberOptions configures probing and the hysteresis applied to status changes
+type HealthProberOptions struct {
+	// Timeout bounds each probe (default 2s)
+	Timeout time.Duration
+	// SlowThreshold is the latency above which a successful probe counts as degraded (default 1s)
+	SlowThreshold time.Duration
+	// DegradedAfter is the number of consecutive unhealthy probes that degrade a live target (default 1)
+	DegradedAfter int
+	// DeadAfter is the number of consecutive failed probes that mark a target dead or failed (default 3)
+	DeadAfter int
+	// RecoverAfter is the number of consecutive better probes needed to improve a status (default 2)
+	RecoverAfter int
+	// Statuses combines the probe status with the registry heartbeat status when both run
+	Statuses *NodeStatusArbiter
+}
+
+// probeState counts consecutive probe outcomes for one node or edge
+type probeState struct {
+	failures   int // consecutive failed probes
+	unhealthy  int // consecutive degraded or failed probes
+	responding int // consecutive degraded or healthy probes
+	healthy    int // consecutive healthy probes
+}
+
+// observe records an outcome and returns the level the target should have
+// Worsening follows DegradedAfter and DeadAfter; improving always needs RecoverAfter probes
+func (s *probeState) observe(outcome probeOutcome, current healthLevel, options HealthProberOptions) healthLevel {
+	switch outcome {
+	case probeFailed:
+		s.failures++
+		s.unhealthy++
+		s.responding, s.healthy = 0, 0
+	case probeDegraded:
+		s.unhealthy++
+		s.responding++
+		s.failures, s.healthy = 0, 0
+	default:
+		s.responding++
+		s.healthy++
+		s.failures, s.unhealthy = 0, 0
+	}
+
+	switch {
+	case current == levelUnknown:
+		// A target without a known status takes the first outcome as it is
+		return map[probeOutcome]healthLevel{probeHealthy: levelHealthy, probeDegraded: levelDegraded, probeFailed: levelDown}[outcome]
+	case s.failures >= options.DeadAfter:
+		return levelDown
+	case current == levelHealthy && s.unhealthy >= options.DegradedAfter:
+		return levelDegraded
+	case current == levelDown && s.responding >= options.RecoverAfter:
+		if s.healthy >= options.RecoverAfter {
+			return levelHealthy
+		}
+		return levelDegraded
+	case current == levelDegraded && s.healthy >= options.RecoverAfter:
+		return levelHealthy
+	default:
+		return current
+	}
+}
+
+// HealthProbeResult summarises one probing round
+type HealthProbeResult struct {
+	ProbedAt      time.Time `json:"probed_at"`
+	NodesProbed   int       `json:"nodes_probed"`
+	NodesSkipped  int       `json:"nodes_skipped"`
+	EdgesProbed   int       `json:"edges_probed"`
+	StatusChanges int       `json:"status_changes"`
+	Errors        []string  `json:"errors,omitempty"`
+}
+
+// HealthProber actively probes nodes over gRPC health checks and HTTP health endpoints and
+// updates node and critical edge statuses through the tracker, which publishes the changes
+type HealthProber struct {
+	tracker services.TopologyTracker
+	options HealthProberOptions
+	logger  *logrus.Logger
+	client  *http.Client
+
+	probeMu   sync.Mutex
+	nodes     map[string]*probeState
+	edges     map[string]*probeState
+	connsMu   sync.Mutex
+	grpcConns map[string]*grpc.ClientConn
+}
+
+// NewHealthProber creates a prober updating statuses through the tracker
+func NewHealthProber(tracker services.TopologyTracker, options HealthProberOptions, logger *logrus.Logger) *HealthProber {
+	if options.Timeout <= 0 {
+		options.Timeout = 2 * time.Second
+	}
+	if options.SlowThreshold <= 0 {
+		options.SlowThreshold = time.Second
+	}
+	if options.DegradedAfter <= 0 {
+		options.DegradedAfter = 1
+	}
+	if options.DeadAfter <= 0 {
+		options.DeadAfter = 3
+	}
+	if options.RecoverAfter <= 0 {
+		options.RecoverAfter = 2
+	}
+	return &HealthProber{
+		tracker:   tracker,
+		options:   options,
+		logger:    logger,
+		client:    &http.Client{Timeout: options.Timeout},
+		nodes:     make(map[string]*probeState),
+		edges:     make(map[string]*probeState),
+		grpcConns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Run probes every interval until the context is cancelled, then closes its connections
+func (p *HealthProber) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer p.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if result, err := p.Probe(ctx); err != nil {
+				p.logger.WithError(err).Warn("Health probing failed")
+			} else if len(result.Errors) > 0 {
+				p.logger.WithField("errors", result.Errors).Warn("Health probing could not update every status")
+			}
+		}
+	}
+}
+
+// Close closes the gRPC connections kept between rounds
+func (p *HealthProber) Close() {
+	p.connsMu.Lock()
+	defer p.connsMu.Unlock()
+	for address, conn := range p.grpcConns {
+		conn.Close()
+		delete(p.grpcConns, address)
+	}
+}
+
+// Probe probes every node with an endpoint and every critical edge once
+func (p *HealthProber) Probe(ctx context.Context) (*HealthProbeResult, error) {
+	p.probeMu.Lock()
+	defer p.probeMu.Unlock()
+
+	result := &HealthProbeResult{ProbedAt: time.Now().UTC()}
+	nodes, err := p.tracker.GetNodes(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topology nodes: %w", err)
+	}
+	connections, err := p.tracker.GetConnections(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topology connections: %w", err)
+	}
+
+	// Outcomes per node and protocol, shared by the probes of edges into the node
+	grpcOutcomes := make(map[string]probeOutcome)
+	httpOutcomes := make(map[string]probeOutcome)
+	nodeLevels := make(map[string]healthLevel, len(nodes))
+
+	var wg sync.WaitGroup
+	var outcomesMu sync.Mutex
+	for _, node := range nodes {
+		grpcAddress, httpURL := nodeEndpoints(node)
+		if grpcAddress == "" && httpURL == "" {
+			result.NodesSkipped++
+			continue
+		}
+		result.NodesProbed++
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			var grpcOutcome, httpOutcome probeOutcome
+			if grpcAddress != "" {
+				grpcOutcome = p.probeGRPC(ctx, grpcAddress)
+			}
+			if httpURL != "" {
+				httpOutcome = p.probeHTTP(ctx, httpURL)
+			}
+			outcomesMu.Lock()
+			defer outcomesMu.Unlock()
+			if grpcAddress != "" {
+				grpcOutcomes[nodeID] = grpcOutcome
+			}
+			if httpURL != "" {
+				httpOutcomes[nodeID] = httpOutcome
+			}
+		}(node.ID)
+	}
+	wg.Wait()
+
+	for _, node := range nodes {
+		nodeLevels[node.ID] = nodeLevel(node.Status)
+		outcomes := make([]probeOutcome, 0, 2)
+		if outcome, ok := grpcOutcomes[node.ID]; ok {
+			outcomes = append(outcomes, outcome)
+		}
+		if outcome, ok := httpOutcomes[node.ID]; ok {
+			outcomes = append(outcomes, outcome)
+		}
+		if len(outcomes) == 0 {
+			continue
+		}
+
+		// The node status also reflects heartbeats when shared, so hysteresis follows the probe's own verdict
+		current := nodeLevels[node.ID]
+		if p.options.Statuses != nil {
+			reported, _ := p.options.Statuses.Reported(StatusSourceProbe, node.ID)
+			current = nodeLevel(reported)
+		}
+		level := p.stateFor(p.nodes, node.ID).observe(combineOutcomes(outcomes), current, p.options)
+		status := p.options.Statuses.Report(StatusSourceProbe, node.ID, nodeStatusFor(level))
+		nodeLevels[node.ID] = nodeLevel(status)
+		if status == node.Status {
+			continue
+		}
+		if _, err := p.tracker.UpdateNodeStatus(ctx, node.ID, status); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", node.ID, err))
+			continue
+		}
+		result.StatusChanges++
+		p.logger.WithFields(logrus.Fields{
+			"node_id": node.ID,
+			"from":    node.Status,
+			"to":      status,
+		}).Info("Node status changed by health probe")
+	}
+
+	for _, conn := range connections {
+		if !conn.IsCritical {
+			continue
+		}
+		outcome, ok := edgeOutcome(conn, grpcOutcomes, httpOutcomes)
+		if !ok {
+			continue
+		}
+		// An edge cannot carry traffic from a source that is down
+		if nodeLevels[conn.SourceID] == levelDown {
+			outcome = probeFailed
+		}
+		result.EdgesProbed++
+
+		current := edgeLevel(conn.Status)
+		level := p.stateFor(p.edges, conn.ID).observe(outcome, current, p.options)
+		if level == current {
+			continue
+		}
+		if _, err := p.tracker.UpdateConnectionStatus(ctx, conn.ID, edgeStatusFor(level)); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", conn.ID, err))
+			continue
+		}
+		result.StatusChanges++
+		p.logger.WithFields(logrus.Fields{
+			"edge_id": conn.ID,
+			"from":    conn.Status,
+			"to":      edgeStatusFor(level),
+		}).Info("Edge status changed by health probe")
+	}
+
+	p.forgetRemoved(nodes, connections)
+	return result, nil
+}
+
+// probeGRPC calls the standard gRPC health check for the whole server
+func (p *HealthProber) probeGRPC(ctx context.Context, address string) probeOutcome {
+	conn, err := p.grpcConn(address)
+	if err != nil {
+		return probeFailed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.options.Timeout)
+	defer cancel()
+	start := time.Now()
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return probeFailed
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return probeDegraded
+	}
+	return p.latencyOutcome(time.Since(start))
+}
+
+// probeHTTP calls the HTTP health endpoint; a response that is not a success, or reports a
+// status other than healthy, means the service runs but is degraded
+func (p *HealthProber) probeHTTP(ctx context.Context, baseURL string) probeOutcome {
+	ctx, cancel := context.WithTimeout(ctx, p.options.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+healthPath, nil)
+	if err != nil {
+		return probeFailed
+	}
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return probeFailed
+	}
+	defer resp.Body.Close()
+	latency := time.Since(start)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return probeDegraded
+	}
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Status != "" && !strings.EqualFold(body.Status, "healthy") {
+		return probeDegraded
+	}
+	return p.latencyOutcome(latency)
+}
+
+func (p *HealthProber) latencyOutcome(latency time.Duration) probeOutcome {
+	if latency > p.options.SlowThreshold {
+		return probeDegraded
+	}
+	return probeHealthy
+}
+
+// grpcConn returns the connection to an address, created on first use and kept between rounds
+func (p *HealthProber) grpcConn(address string) (*grpc.ClientConn, error) {
+	p.connsMu.Lock()
+	defer p.connsMu.Unlock()
+
+	if conn, ok := p.grpcConns[address]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	p.grpcConns[address] = conn
+	return conn, nil
+}
+
+func (p *HealthProber) stateFor(states map[string]*probeState, id string) *probeState {
+	state, ok := states[id]
+	if !ok {
+		state = &probeState{}
+		states[id] = state
+	}
+	return state
+}
+
+// forgetRemoved drops probe state of nodes and edges no longer in the topology
+func (p *HealthProber) forgetRemoved(nodes []*entities.ServiceNode, connections []*entities.ServiceConnection) {
+	present := make(map[string]bool, len(nodes)+len(connections))
+	for _, node := range nodes {
+		present["node/"+node.ID] = true
+	}
+	for _, conn := range connections {
+		present["edge/"+conn.ID] = true
+	}
+	for id := range p.nodes {
+		if !present["node/"+id] {
+			delete(p.nodes, id)
+		}
+	}
+	p.options.Statuses.Retain(nodes)
+	for id := range p.edges {
+		if !present["edge/"+id] {
+			delete(p.edges, id)
+		}
+	}
+}
+
+// nodeEndpoints resolves a node's gRPC address and HTTP base URL from its labels
+func nodeEndpoints(node *entities.ServiceNode) (grpcAddress, httpURL string) {
+	grpcAddress = node.Labels[GRPCEndpointLabel]
+	if grpcAddress == "" && node.Labels["host"] != "" && node.Labels["grpc_port"] != "" {
+		grpcAddress = net.JoinHostPort(node.Labels["host"], node.Labels["grpc_port"])
+	}
+
+	httpURL = node.Labels[HTTPEndpointLabel]
+	if httpURL == "" && node.Labels["host"] != "" && node.Labels["http_port"] != "" {
+		httpURL = "http://" + net.JoinHostPort(node.Labels["host"], node.Labels["http_port"])
+	}
+	if httpURL != "" && !strings.Contains(httpURL, "://") {
+		httpURL = "http://" + httpURL
+	}
+	return grpcAddress, strings.TrimSuffix(httpURL, "/")
+}
+
+// combineOutcomes is healthy when every endpoint is, failed when none responds and degraded otherwise
+func combineOutcomes(outcomes []probeOutcome) probeOutcome {
+	healthy, failed := 0, 0
+	for _, outcome := range outcomes {
+		switch outcome {
+		case probeHealthy:
+			healthy++
+		case probeFailed:
+			failed++
+		}
+	}
+	switch {
+	case healthy == len(outcomes):
+		return probeHealthy
+	case failed == len(outcomes):
+		return probeFailed
+	default:
+		return probeDegraded
+	}
+}
+
+// edgeOutcome is the probe of the edge's target over the edge's protocol; data flow edges
+// use whichever endpoints the target has
+func edgeOutcome(conn *entities.ServiceConnection, grpcOutcomes, httpOutcomes map[string]probeOutcome) (probeOutcome, bool) {
+	grpcOutcome, hasGRPC := grpcOutcomes[conn.TargetID]
+	httpOutcome, hasHTTP := httpOutcomes[conn.TargetID]
+	switch {
+	case conn.Type == entities.ConnectionTypeGRPC && hasGRPC:
+		return grpcOutcome, true
+	case conn.Type == entities.ConnectionTypeHTTP && hasHTTP:
+		return httpOutcome, true
+	case hasGRPC && hasHTTP:
+		return combineOutcomes([]probeOutcome{grpcOutcome, httpOutcome}), true
+	case hasGRPC:
+		return grpcOutcome, true
+	case hasHTTP:
+		return httpOutcome, true
+	default:
+		return probeFailed, false
+	}
+}
+
+func nodeLevel(status entities.NodeStatus) healthLevel {
+	switch status {
+	case entities.NodeStatusLive:
+		return levelHealthy
+	case entities.NodeStatusDegraded:
+		return levelDegraded
+	case entities.NodeStatusDead:
+		return levelDown
+	default:
+		return levelUnknown
+	}
+}
+
+func nodeStatusFor(level healthLevel) entities.NodeStatus {
+	switch level {
+	case levelHealthy:
+		return entities.NodeStatusLive
+	case levelDegraded:
+		return entities.NodeStatusDegraded
+	case levelDown:
+		return entities.NodeStatusDead
+	default:
+		return entities.NodeStatusUnspecified
+	}
+}
+
+func edgeLevel(status entities.EdgeStatus) healthLevel {
+	switch status {
+	case entities.EdgeStatusActive:
+		return levelHealthy
+	case entities.EdgeStatusDegraded:
+		return levelDegraded
+	case entities.EdgeStatusFailed:
+		return levelDown
+	default:
+		return levelUnknown
+	}
+}
+
+func edgeStatusFor(level healthLevel) entities.EdgeStatus {
+	switch level {
+	case levelHealthy:
+		return entities.EdgeStatusActive
+	case levelDegraded:
+		return entities.EdgeStatusDegraded
+	case levelDown:
+		return entities.EdgeStatusFailed
+	default:
+		return entities.EdgeStatusUnspecified
+	}
+}
@@ -0,0 +1,231 @@
+package topology
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
+)
+
+func startHealthServer(t *testing.T) (*health.Server, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return healthServer, lis.Addr().String()
+}
+
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	address := lis.Addr().String()
+	lis.Close()
+	return address
+}
+
+func TestHealthProber_UpdatesNodeAndEdgeStatusWithHysteresis(t *testing.T) {
+	ctx := context.Background()
+	publisher := NewChannelChangePublisher()
+	tracker, err := services.NewTopologyTracker(NewMemoryTopologyRepository(), NewMemoryMetadataRepository(), publisher, NewMemoryTopologyHistory())
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	healthServer, grpcAddress := startHealthServer(t)
+	var httpHealthy atomic.Bool
+	httpHealthy.Store(true)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != healthPath {
+			http.NotFound(w, r)
+			return
+		}
+		if httpHealthy.Load() {
+			w.Write([]byte(`{"status":"healthy"}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer httpServer.Close()
+
+	exchange := entities.NewServiceNode("exchange", "Exchange", "exchange-simulator", "exchange")
+	exchange.AddLabel(GRPCEndpointLabel, grpcAddress)
+	exchange.AddLabel(HTTPEndpointLabel, httpServer.URL)
+	risk := entities.NewServiceNode("risk", "Risk", "risk-monitor", "risk")
+	risk.AddLabel(GRPCEndpointLabel, closedAddress(t))
+	unprobed := entities.NewServiceNode("unprobed", "Unprobed", "custodian-simulator", "unprobed")
+	for _, node := range []*entities.ServiceNode{exchange, risk, unprobed} {
+		if _, err := tracker.RegisterNode(ctx, node); err != nil {
+			t.Fatalf("Failed to register node: %v", err)
+		}
+	}
+	critical := entities.NewServiceConnection("risk-to-exchange", "risk", "exchange", entities.ConnectionTypeGRPC)
+	critical.IsCritical = true
+	other := entities.NewServiceConnection("exchange-to-risk", "exchange", "risk", entities.ConnectionTypeGRPC)
+	for _, conn := range []*entities.ServiceConnection{critical, other} {
+		if _, err := tracker.RegisterConnection(ctx, conn); err != nil {
+			t.Fatalf("Failed to register connection: %v", err)
+		}
+	}
+
+	changes, err := publisher.Subscribe(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	prober := NewHealthProber(tracker, HealthProberOptions{
+		Timeout:       time.Second,
+		SlowThreshold: time.Second,
+		DegradedAfter: 1,
+		DeadAfter:     2,
+		RecoverAfter:  2,
+	}, logger)
+	defer prober.Close()
+
+	nodeStatus := func(id string) entities.NodeStatus {
+		t.Helper()
+		node, err := tracker.GetNode(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get node %s: %v", id, err)
+		}
+		return node.Status
+	}
+	edgeStatus := func(id string) entities.EdgeStatus {
+		t.Helper()
+		conns, err := tracker.GetConnections(ctx, nil)
+		if err != nil {
+			t.Fatalf("Failed to get connections: %v", err)
+		}
+		for _, conn := range conns {
+			if conn.ID == id {
+				return conn.Status
+			}
+		}
+		t.Fatalf("Connection %s not found", id)
+		return entities.EdgeStatusUnspecified
+	}
+	probe := func() *HealthProbeResult {
+		t.Helper()
+		result, err := prober.Probe(ctx)
+		if err != nil {
+			t.Fatalf("Probe failed: %v", err)
+		}
+		return result
+	}
+
+	// The unreachable node degrades first and dies once DeadAfter failures accumulate
+	result := probe()
+	if result.NodesProbed != 2 || result.NodesSkipped != 1 || result.EdgesProbed != 1 {
+		t.Errorf("Unexpected probe result %+v", result)
+	}
+	if got := nodeStatus("risk"); got != entities.NodeStatusDegraded {
+		t.Errorf("Expected risk to be degraded after one failure, got %v", got)
+	}
+	if got := nodeStatus("exchange"); got != entities.NodeStatusLive {
+		t.Errorf("Expected exchange to stay live, got %v", got)
+	}
+	probe()
+	if got := nodeStatus("risk"); got != entities.NodeStatusDead {
+		t.Errorf("Expected risk to be dead after two failures, got %v", got)
+	}
+
+	// The critical edge fails with its dead source; the non-critical edge is not probed
+	probe()
+	if got := edgeStatus("risk-to-exchange"); got != entities.EdgeStatusFailed {
+		t.Errorf("Expected critical edge from a dead source to fail, got %v", got)
+	}
+	if got := edgeStatus("exchange-to-risk"); got != entities.EdgeStatusActive {
+		t.Errorf("Expected non-critical edge to be left alone, got %v", got)
+	}
+
+	// A failing HTTP endpoint with a serving gRPC endpoint degrades the node
+	httpHealthy.Store(false)
+	probe()
+	if got := nodeStatus("exchange"); got != entities.NodeStatusDegraded {
+		t.Errorf("Expected exchange to be degraded, got %v", got)
+	}
+
+	// Recovery needs RecoverAfter healthy probes in a row
+	httpHealthy.Store(true)
+	probe()
+	if got := nodeStatus("exchange"); got != entities.NodeStatusDegraded {
+		t.Errorf("Expected exchange to stay degraded after one healthy probe, got %v", got)
+	}
+	probe()
+	if got := nodeStatus("exchange"); got != entities.NodeStatusLive {
+		t.Errorf("Expected exchange to recover, got %v", got)
+	}
+
+	// NOT_SERVING over gRPC means the service responds but is degraded
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	probe()
+	if got := nodeStatus("exchange"); got != entities.NodeStatusDegraded {
+		t.Errorf("Expected NOT_SERVING to degrade exchange, got %v", got)
+	}
+
+	// Every status change was published with the status it replaced
+	var sawDeath bool
+	for {
+		select {
+		case event := <-changes:
+			if event.ChangeType == ports.TopologyChangeTypeNodeUpdated && event.Node != nil && event.Node.ID == "risk" &&
+				event.Node.Status == entities.NodeStatusDead && event.PreviousNodeStatus == entities.NodeStatusDegraded {
+				sawDeath = true
+			}
+			continue
+		default:
+		}
+		break
+	}
+	if !sawDeath {
+		t.Error("Expected a published change for risk going from degraded to dead")
+	}
+}
+
+func TestProbeState_Hysteresis(t *testing.T) {
+	options := HealthProberOptions{DegradedAfter: 2, DeadAfter: 3, RecoverAfter: 2}
+	state := &probeState{}
+	level := levelHealthy
+
+	steps := []struct {
+		outcome probeOutcome
+		want    healthLevel
+	}{
+		{probeFailed, levelHealthy},  // one failure is tolerated
+		{probeHealthy, levelHealthy}, // and forgotten
+		{probeFailed, levelHealthy},
+		{probeFailed, levelDegraded},
+		{probeFailed, levelDown},
+		{probeDegraded, levelDown}, // one response is not enough to come back
+		{probeHealthy, levelDegraded},
+		{probeHealthy, levelHealthy},
+	}
+	for i, step := range steps {
+		level = state.observe(step.outcome, level, options)
+		if level != step.want {
+			t.Fatalf("Step %d (%v): expected level %d, got %d", i, step.outcome, step.want, level)
+		}
+	}
+}
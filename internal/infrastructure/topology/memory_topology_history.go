@@ -0,0 +1,205 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// topologyState is the set of nodes and connections at one version
+// Nodes and connections are shared between states and never modified
+type topologyState struct {
+	nodes       map[string]*entities.ServiceNode
+	connections map[string]*entities.ServiceConnection
+}
+
+func (s topologyState) clone() topologyState {
+	return topologyState{nodes: maps.Clone(s.nodes), connections: maps.Clone(s.connections)}
+}
+
+// apply updates the state with a change
+func (s topologyState) apply(event *ports.TopologyChangeEvent) {
+	switch event.ChangeType {
+	case ports.TopologyChangeTypeNodeAdded, ports.TopologyChangeTypeNodeUpdated:
+		s.nodes[event.Node.ID] = event.Node
+	case ports.TopologyChangeTypeNodeRemoved:
+		delete(s.nodes, event.Node.ID)
+	case ports.TopologyChangeTypeEdgeAdded, ports.TopologyChangeTypeEdgeUpdated:
+		s.connections[event.Connection.ID] = event.Connection
+	case ports.TopologyChangeTypeEdgeRemoved:
+		delete(s.connections, event.Connection.ID)
+	}
+}
+
+// historyEntry is a recorded change with its parsed snapshot version
+type historyEntry struct {
+	version uint64
+	event   *ports.TopologyChangeEvent
+}
+
+// MemoryTopologyHistory implements TopologyHistoryRepository in memory
+// The topology at a version is rebuilt by replaying changes from the nearest checkpoint, a full
+// state kept every checkpointInterval changes. Beyond maxChanges the oldest changes are folded
+// into the base state, so versions before the base are no longer available.
+type MemoryTopologyHistory struct {
+	mu                 sync.RWMutex
+	base               topologyState
+	baseVersion        uint64
+	baseTime           time.Time
+	changes            []historyEntry
+	checkpoints        []topologyState // checkpoints[k] is the state after changes[(k+1)*checkpointInterval-1]
+	head               topologyState
+	checkpointInterval int
+	maxChanges         int
+}
+
+// NewMemoryTopologyHistory creates an empty in-memory topology history
+func NewMemoryTopologyHistory() *MemoryTopologyHistory {
+	h := &MemoryTopologyHistory{
+		checkpointInterval: 256,
+		maxChanges:         100000, // Keep the last 100000 changes
+	}
+	h.reset(entities.NewNetworkTopology(entities.SnapshotIDForVersion(0)), 0)
+	return h
+}
+
+// Initialize sets the topology the history starts from, discarding any recorded changes
+func (h *MemoryTopologyHistory) Initialize(ctx context.Context, topology *entities.NetworkTopology, version uint64) error {
+	if topology == nil {
+		return fmt.Errorf("topology cannot be nil")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.reset(topology, version)
+	return nil
+}
+
+func (h *MemoryTopologyHistory) reset(topology *entities.NetworkTopology, version uint64) {
+	h.base = topologyState{nodes: maps.Clone(topology.Nodes), connections: maps.Clone(topology.Connections)}
+	h.baseVersion = version
+	h.baseTime = topology.SnapshotTime
+	h.changes = nil
+	h.checkpoints = nil
+	h.head = h.base.clone()
+}
+
+// AppendChange records a change
+// Versions may skip numbers, for example after a failed commit, but must increase
+func (h *MemoryTopologyHistory) AppendChange(ctx context.Context, event *ports.TopologyChangeEvent) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+	version, err := entities.ParseSnapshotVersion(event.SnapshotID)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if latest := h.latestVersionLocked(); version <= latest {
+		return fmt.Errorf("change at %s does not follow %s", event.SnapshotID, entities.SnapshotIDForVersion(latest))
+	}
+
+	h.changes = append(h.changes, historyEntry{version: version, event: event})
+	h.head.apply(event)
+	if len(h.changes)%h.checkpointInterval == 0 {
+		h.checkpoints = append(h.checkpoints, h.head.clone())
+	}
+
+	for len(h.changes) > h.maxChanges && len(h.checkpoints) > 0 {
+		folded := h.changes[h.checkpointInterval-1]
+		h.base = h.checkpoints[0]
+		h.baseVersion = folded.version
+		h.baseTime = folded.event.Timestamp
+		h.changes = append([]historyEntry(nil), h.changes[h.checkpointInterval:]...)
+		h.checkpoints = h.checkpoints[1:]
+	}
+	return nil
+}
+
+// GetTopologyAtVersion returns the topology as of a snapshot version
+func (h *MemoryTopologyHistory) GetTopologyAtVersion(ctx context.Context, version uint64) (*entities.NetworkTopology, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if version < h.baseVersion || version > h.latestVersionLocked() {
+		return nil, fmt.Errorf("%w: %s", ports.ErrSnapshotNotFound, entities.SnapshotIDForVersion(version))
+	}
+
+	// count is the number of changes up to and including the version
+	count := sort.Search(len(h.changes), func(i int) bool {
+		return h.changes[i].version > version
+	})
+
+	state, start := h.base, 0
+	if k := count/h.checkpointInterval - 1; k >= 0 {
+		state, start = h.checkpoints[k], (k+1)*h.checkpointInterval
+	}
+	state = state.clone()
+	for _, entry := range h.changes[start:count] {
+		state.apply(entry.event)
+	}
+
+	topology := entities.NewNetworkTopology(entities.SnapshotIDForVersion(version))
+	topology.Nodes = state.nodes
+	topology.Connections = state.connections
+	topology.SnapshotTime = h.baseTime
+	if count > 0 {
+		topology.SnapshotTime = h.changes[count-1].event.Timestamp
+	}
+	return topology, nil
+}
+
+// GetVersionAt returns the latest snapshot version recorded at or before a time
+func (h *MemoryTopologyHistory) GetVersionAt(ctx context.Context, at time.Time) (uint64, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if at.Before(h.baseTime) {
+		return 0, fmt.Errorf("%w: history starts at %s", ports.ErrSnapshotNotFound, h.baseTime.Format(time.RFC3339))
+	}
+	count := sort.Search(len(h.changes), func(i int) bool {
+		return h.changes[i].event.Timestamp.After(at)
+	})
+	if count == 0 {
+		return h.baseVersion, nil
+	}
+	return h.changes[count-1].version, nil
+}
+
+// GetChanges returns the changes recorded after from and at or before to, oldest first
+// Changes folded into the base state are no longer available
+func (h *MemoryTopologyHistory) GetChanges(ctx context.Context, from, to time.Time) ([]*ports.TopologyChangeEvent, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	first := sort.Search(len(h.changes), func(i int) bool {
+		return h.changes[i].event.Timestamp.After(from)
+	})
+	last := sort.Search(len(h.changes), func(i int) bool {
+		return h.changes[i].event.Timestamp.After(to)
+	})
+	changes := make([]*ports.TopologyChangeEvent, 0, max(last-first, 0))
+	for _, entry := range h.changes[first:max(first, last)] {
+		changes = append(changes, entry.event)
+	}
+	return changes, nil
+}
+
+func (h *MemoryTopologyHistory) latestVersionLocked() uint64 {
+	if len(h.changes) == 0 {
+		return h.baseVersion
+	}
+	return h.changes[len(h.changes)-1].version
+}
+
+var _ ports.TopologyHistoryRepository = (*MemoryTopologyHistory)(nil)
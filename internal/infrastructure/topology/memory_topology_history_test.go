@@ -0,0 +1,112 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// appendNodeStatus records a change setting node "n" to a status at a version
+func appendNodeStatus(t *testing.T, history *MemoryTopologyHistory, version uint64, at time.Time, status entities.NodeStatus) {
+	t.Helper()
+	node := entities.NewServiceNode("n", "n", "svc", "n")
+	node.Status = status
+	changeType := ports.TopologyChangeTypeNodeUpdated
+	if version == 1 {
+		changeType = ports.TopologyChangeTypeNodeAdded
+	}
+	err := history.AppendChange(context.Background(), &ports.TopologyChangeEvent{
+		ChangeType: changeType,
+		SnapshotID: entities.SnapshotIDForVersion(version),
+		Timestamp:  at,
+		Node:       node,
+	})
+	if err != nil {
+		t.Fatalf("Failed to append change %d: %v", version, err)
+	}
+}
+
+func TestMemoryTopologyHistory_ReplaysFromCheckpoints(t *testing.T) {
+	history := NewMemoryTopologyHistory()
+	history.checkpointInterval = 4
+	ctx := context.Background()
+
+	start := time.Now()
+	statuses := []entities.NodeStatus{entities.NodeStatusLive, entities.NodeStatusDegraded, entities.NodeStatusDead}
+	for version := uint64(1); version <= 10; version++ {
+		appendNodeStatus(t, history, version, start.Add(time.Duration(version)*time.Minute), statuses[version%3])
+	}
+
+	for version := uint64(1); version <= 10; version++ {
+		topology, err := history.GetTopologyAtVersion(ctx, version)
+		if err != nil {
+			t.Fatalf("Failed to get version %d: %v", version, err)
+		}
+		if node := topology.Nodes["n"]; node == nil || node.Status != statuses[version%3] {
+			t.Errorf("Expected status %v at version %d, got %+v", statuses[version%3], version, node)
+		}
+		if topology.SnapshotID != fmt.Sprintf("snapshot-%d", version) {
+			t.Errorf("Expected snapshot-%d, got %s", version, topology.SnapshotID)
+		}
+	}
+	if topology, err := history.GetTopologyAtVersion(ctx, 0); err != nil || len(topology.Nodes) != 0 {
+		t.Errorf("Expected an empty topology before the first change, got %v", err)
+	}
+	if _, err := history.GetTopologyAtVersion(ctx, 11); !errors.Is(err, ports.ErrSnapshotNotFound) {
+		t.Errorf("Expected a future version not to be found, got %v", err)
+	}
+
+	// Times between changes resolve to the earlier change
+	if version, err := history.GetVersionAt(ctx, start.Add(5*time.Minute+30*time.Second)); err != nil || version != 5 {
+		t.Errorf("Expected version 5, got %d (%v)", version, err)
+	}
+	if _, err := history.GetVersionAt(ctx, start.Add(-time.Hour)); !errors.Is(err, ports.ErrSnapshotNotFound) {
+		t.Errorf("Expected a time before the history not to be found, got %v", err)
+	}
+
+	if err := history.AppendChange(ctx, &ports.TopologyChangeEvent{SnapshotID: "snapshot-10"}); err == nil {
+		t.Error("Expected an out of order change to be rejected")
+	}
+}
+
+func TestMemoryTopologyHistory_CompactsOldestChanges(t *testing.T) {
+	history := NewMemoryTopologyHistory()
+	history.checkpointInterval = 4
+	history.maxChanges = 8
+	ctx := context.Background()
+
+	start := time.Now()
+	for version := uint64(1); version <= 12; version++ {
+		status := entities.NodeStatusLive
+		if version%2 == 0 {
+			status = entities.NodeStatusDead
+		}
+		appendNodeStatus(t, history, version, start.Add(time.Duration(version)*time.Minute), status)
+	}
+
+	// Versions 1 to 3 are folded into the base state at version 4
+	if _, err := history.GetTopologyAtVersion(ctx, 3); !errors.Is(err, ports.ErrSnapshotNotFound) {
+		t.Errorf("Expected a compacted version not to be found, got %v", err)
+	}
+	for version := uint64(4); version <= 12; version++ {
+		topology, err := history.GetTopologyAtVersion(ctx, version)
+		if err != nil {
+			t.Fatalf("Failed to get version %d: %v", version, err)
+		}
+		expected := entities.NodeStatusLive
+		if version%2 == 0 {
+			expected = entities.NodeStatusDead
+		}
+		if topology.Nodes["n"].Status != expected {
+			t.Errorf("Expected status %v at version %d, got %v", expected, version, topology.Nodes["n"].Status)
+		}
+	}
+	if topology, _ := history.GetTopologyAtVersion(ctx, 4); !topology.SnapshotTime.Equal(start.Add(4 * time.Minute)) {
+		t.Errorf("Expected the base to keep the time of version 4, got %v", topology.SnapshotTime)
+	}
+}
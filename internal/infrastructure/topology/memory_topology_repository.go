@@ -183,4 +183,13 @@ func (r *MemoryTopologyRepository) SaveTopologySnapshot(ctx context.Context, top
 	return nil
 }
 
+// SetSnapshotID records the snapshot ID of the current topology
+func (r *MemoryTopologyRepository) SetSnapshotID(ctx context.Context, snapshotID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.snapshotID = snapshotID
+	return nil
+}
+
 var _ ports.TopologyRepository = (*MemoryTopologyRepository)(nil)
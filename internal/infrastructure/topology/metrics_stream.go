@@ -0,0 +1,91 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// streamMetricsUpdates collects node and edge metrics from the collector every interval until the
+// context is cancelled; nodes and edges whose metrics cannot be collected are skipped for the round
+func streamMetricsUpdates(ctx context.Context, collector ports.MetricsCollector, nodeIDs []string, edgeIDs []string, interval time.Duration) <-chan *ports.MetricsUpdate {
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	updateChan := make(chan *ports.MetricsUpdate, 10)
+
+	go func() {
+		defer close(updateChan)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		updateID := 0
+		send := func(update *ports.MetricsUpdate) bool {
+			update.UpdateID = fmt.Sprintf("update-%d", updateID)
+			select {
+			case updateChan <- update:
+				updateID++
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, nodeID := range nodeIDs {
+					metadata, err := collector.CollectNodeMetrics(ctx, nodeID)
+					if err != nil {
+						continue
+					}
+					if !send(&ports.MetricsUpdate{
+						Timestamp: time.Now(),
+						NodeID:    nodeID,
+						Metrics: map[string]interface{}{
+							"health_score":   metadata.HealthScore,
+							"request_rate":   metadata.RequestRate,
+							"error_rate":     metadata.ErrorRate,
+							"latency_ms":     metadata.Latency.Milliseconds(),
+							"cpu_usage":      metadata.CPUUsage,
+							"memory_usage":   metadata.MemoryUsage,
+							"active_conns":   metadata.ActiveConns,
+							"total_requests": metadata.TotalReqs,
+						},
+					}) {
+						return
+					}
+				}
+
+				for _, edgeID := range edgeIDs {
+					metadata, err := collector.CollectEdgeMetrics(ctx, edgeID)
+					if err != nil {
+						continue
+					}
+					if !send(&ports.MetricsUpdate{
+						Timestamp: time.Now(),
+						EdgeID:    edgeID,
+						Metrics: map[string]interface{}{
+							"throughput":     metadata.Throughput,
+							"error_rate":     metadata.ErrorRate,
+							"avg_latency_ms": metadata.AvgLatency.Milliseconds(),
+							"p99_latency_ms": metadata.P99Latency.Milliseconds(),
+							"total_messages": metadata.TotalMessages,
+							"total_errors":   metadata.TotalErrors,
+							"last_active":    metadata.LastActiveTime.Unix(),
+						},
+					}) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return updateChan
+}
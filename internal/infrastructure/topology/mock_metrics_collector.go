@@ -2,7 +2,6 @@ package topology
 
 import (
 	"context"
-	"fmt"
 	"math/rand"
 	"time"
 
@@ -60,88 +59,7 @@ func (c *MockMetricsCollector) CollectEdgeMetrics(ctx context.Context, edgeID st
 
 // StreamMetrics streams periodic metrics updates
 func (c *MockMetricsCollector) StreamMetrics(ctx context.Context, nodeIDs []string, edgeIDs []string, interval time.Duration) (<-chan *ports.MetricsUpdate, error) {
-	if interval < time.Second {
-		interval = time.Second
-	}
-
-	updateChan := make(chan *ports.MetricsUpdate, 10)
-
-	go func() {
-		defer close(updateChan)
-		ticker := time.NewTicker(interval)
-		defer ticker.Stop()
-
-		updateID := 0
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				// Generate updates for nodes
-				for _, nodeID := range nodeIDs {
-					metadata, err := c.CollectNodeMetrics(ctx, nodeID)
-					if err != nil {
-						continue
-					}
-
-					update := &ports.MetricsUpdate{
-						UpdateID:  fmt.Sprintf("update-%d", updateID),
-						Timestamp: time.Now(),
-						NodeID:    nodeID,
-						Metrics: map[string]interface{}{
-							"health_score":   metadata.HealthScore,
-							"request_rate":   metadata.RequestRate,
-							"error_rate":     metadata.ErrorRate,
-							"latency_ms":     metadata.Latency.Milliseconds(),
-							"cpu_usage":      metadata.CPUUsage,
-							"memory_usage":   metadata.MemoryUsage,
-							"active_conns":   metadata.ActiveConns,
-							"total_requests": metadata.TotalReqs,
-						},
-					}
-
-					select {
-					case updateChan <- update:
-						updateID++
-					case <-ctx.Done():
-						return
-					}
-				}
-
-				// Generate updates for edges
-				for _, edgeID := range edgeIDs {
-					metadata, err := c.CollectEdgeMetrics(ctx, edgeID)
-					if err != nil {
-						continue
-					}
-
-					update := &ports.MetricsUpdate{
-						UpdateID:  fmt.Sprintf("update-%d", updateID),
-						Timestamp: time.Now(),
-						EdgeID:    edgeID,
-						Metrics: map[string]interface{}{
-							"throughput":      metadata.Throughput,
-							"error_rate":      metadata.ErrorRate,
-							"avg_latency_ms":  metadata.AvgLatency.Milliseconds(),
-							"p99_latency_ms":  metadata.P99Latency.Milliseconds(),
-							"total_messages":  metadata.TotalMessages,
-							"total_errors":    metadata.TotalErrors,
-							"last_active":     metadata.LastActiveTime.Unix(),
-						},
-					}
-
-					select {
-					case updateChan <- update:
-						updateID++
-					case <-ctx.Done():
-						return
-					}
-				}
-			}
-		}
-	}()
-
-	return updateChan, nil
+	return streamMetricsUpdates(ctx, c, nodeIDs, edgeIDs, interval), nil
 }
 
 var _ ports.MetricsCollector = (*MockMetricsCollector)(nil)
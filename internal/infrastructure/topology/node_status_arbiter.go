@@ -0,0 +1,80 @@
+package topology
+
+import (
+	"sync"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+)
+
+// Sources of node status combined by a NodeStatusArbiter
+const (
+	StatusSourceHeartbeat = "heartbeat"
+	StatusSourceProbe     = "probe"
+)
+
+// NodeStatusArbiter combines the node statuses reported by registry heartbeats and health
+// probes; a node takes the worst status any source reports, so a stale heartbeat degrades a
+// node that still answers probes and a failing probe degrades a node that still heartbeats
+// A nil arbiter leaves each source the sole owner of the statuses it reports
+type NodeStatusArbiter struct {
+	mu      sync.Mutex
+	reports map[string]map[string]entities.NodeStatus // node ID -> source -> status
+}
+
+// NewNodeStatusArbiter creates an arbiter without reports
+func NewNodeStatusArbiter() *NodeStatusArbiter {
+	return &NodeStatusArbiter{reports: make(map[string]map[string]entities.NodeStatus)}
+}
+
+// Report records the status a source sees for a node and returns the combined status
+func (a *NodeStatusArbiter) Report(source, nodeID string, status entities.NodeStatus) entities.NodeStatus {
+	if a == nil {
+		return status
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sources, ok := a.reports[nodeID]
+	if !ok {
+		sources = make(map[string]entities.NodeStatus)
+		a.reports[nodeID] = sources
+	}
+	sources[source] = status
+
+	combined := status
+	for _, reported := range sources {
+		if nodeLevel(reported) > nodeLevel(combined) {
+			combined = reported
+		}
+	}
+	return combined
+}
+
+// Reported returns the last status a source reported for a node
+func (a *NodeStatusArbiter) Reported(source, nodeID string) (entities.NodeStatus, bool) {
+	if a == nil {
+		return entities.NodeStatusUnspecified, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	status, ok := a.reports[nodeID][source]
+	return status, ok
+}
+
+// Retain drops the reports of nodes no longer in the topology
+func (a *NodeStatusArbiter) Retain(nodes []*entities.ServiceNode) {
+	if a == nil {
+		return
+	}
+	present := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		present[node.ID] = true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id := range a.reports {
+		if !present[id] {
+			delete(a.reports, id)
+		}
+	}
+}
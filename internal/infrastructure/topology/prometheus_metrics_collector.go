@@ -0,0 +1,529 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+const (
+	// MetricsEndpointLabel overrides the metrics URL, which otherwise is the HTTP endpoint's /metrics
+	MetricsEndpointLabel = "endpoint.metrics"
+
+	metricsPath = "/metrics"
+
+	// metricsAccept prefers OpenMetrics and falls back to the Prometheus text format
+	metricsAccept = "application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5,*/*;q=0.1"
+)
+
+// redFamily names the counters and histogram that make up RED metrics for one protocol
+type redFamily struct {
+	requests string
+	// errors is a dedicated error counter; without one, errors are the requests isError matches
+	errors   string
+	duration string
+	isError  func(labels map[string]string) bool
+}
+
+var (
+	// Server side metrics of a node, as exported by the ecosystem's RED middleware and go-grpc-prometheus
+	nodeREDFamilies = []redFamily{
+		{requests: "http_requests_total", errors: "http_request_errors_total", duration: "http_request_duration_seconds", isError: httpServerError},
+		{requests: "grpc_server_handled_total", duration: "grpc_server_handling_seconds", isError: grpcError},
+	}
+
+	// Client side metrics of an edge's source, attributed to the edge through a peer label
+	edgeREDFamilies = []redFamily{
+		{requests: "http_client_requests_total", duration: "http_client_request_duration_seconds", isError: httpServerError},
+		{requests: "grpc_client_handled_total", duration: "grpc_client_handling_seconds", isError: grpcError},
+	}
+
+	// edgePeerLabels are the client metric labels that may name the called service
+	edgePeerLabels = []string{"target", "target_service", "peer_service", "peer", "server", "host"}
+)
+
+func httpServerError(labels map[string]string) bool {
+	code, err := strconv.Atoi(firstLabel(labels, "code", "status", "status_code"))
+	return err == nil && code >= 500
+}
+
+func grpcError(labels map[string]string) bool {
+	code := labels["grpc_code"]
+	return code != "" && code != "OK"
+}
+
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := labels[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// PrometheusCollectorOptions configures scraping
+type PrometheusCollectorOptions struct {
+	// Timeout bounds each scrape (default 5s)
+	Timeout time.Duration
+	// CacheFor reuses a scrape of the same exporter, so one round of node and edge collection
+	// scrapes each exporter once (default 1s)
+	CacheFor time.Duration
+}
+
+// metricsScrape is one parsed scrape of an exporter
+type metricsScrape struct {
+	at      time.Time
+	samples map[string][]promSample
+}
+
+// redTotals are cumulative RED counters summed over the matching samples
+type redTotals struct {
+	found         bool
+	requests      float64
+	errors        float64
+	durationSum   float64
+	durationCount float64
+	// histograms maps each family's duration histogram to its upper bounds and cumulative counts;
+	// families bucket durations differently, so they are only merged when their bounds match
+	histograms map[string]map[float64]float64
+}
+
+// redRates are RED metrics over the window between two scrapes
+type redRates struct {
+	requestRate float64
+	errorRate   float64
+	cpuRate     float64
+	avgLatency  time.Duration
+	p99Latency  time.Duration
+}
+
+// redWindow keeps the previous totals of a node or edge to turn counters into rates
+type redWindow struct {
+	at         time.Time
+	totals     redTotals
+	cpuSeconds float64
+	rates      redRates
+	lastActive time.Time
+}
+
+// PrometheusMetricsCollector implements MetricsCollector by scraping each node's Prometheus
+// or OpenMetrics endpoint and mapping RED metrics into node and edge metadata
+// Rates are computed between consecutive collections of the same node or edge; the first
+// collection reports totals and latencies since the exporter started, with zero rates
+type PrometheusMetricsCollector struct {
+	repo    ports.TopologyRepository
+	client  *http.Client
+	options PrometheusCollectorOptions
+
+	mu      sync.Mutex
+	scrapes map[string]*metricsScrape
+	windows map[string]*redWindow
+}
+
+// NewPrometheusMetricsCollector creates a collector resolving node endpoints from the repository
+func NewPrometheusMetricsCollector(repo ports.TopologyRepository, options PrometheusCollectorOptions) *PrometheusMetricsCollector {
+	if options.Timeout <= 0 {
+		options.Timeout = 5 * time.Second
+	}
+	if options.CacheFor <= 0 {
+		options.CacheFor = time.Second
+	}
+	return &PrometheusMetricsCollector{
+		repo:    repo,
+		client:  &http.Client{Timeout: options.Timeout},
+		options: options,
+		scrapes: make(map[string]*metricsScrape),
+		windows: make(map[string]*redWindow),
+	}
+}
+
+// CollectNodeMetrics scrapes a node and maps its server side RED and process metrics
+func (c *PrometheusMetricsCollector) CollectNodeMetrics(ctx context.Context, nodeID string) (*entities.NodeMetadata, error) {
+	node, err := c.repo.GetNode(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", nodeID, err)
+	}
+	url := metricsURL(node)
+	if url == "" {
+		return nil, fmt.Errorf("node %s has no metrics endpoint", nodeID)
+	}
+	scrape, err := c.scrape(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape node %s: %w", nodeID, err)
+	}
+
+	totals := sumRED(scrape.samples, nodeREDFamilies, nil)
+	cpuSeconds, _ := sumSamples(scrape.samples, "process_cpu_seconds_total", nil)
+	window := c.observe("node/"+nodeID, scrape.at, totals, cpuSeconds)
+
+	metadata := entities.NewNodeMetadata(nodeID)
+	metadata.RequestRate = window.rates.requestRate
+	metadata.ErrorRate = window.rates.errorRate
+	metadata.Latency = window.rates.avgLatency
+	metadata.CPUUsage = window.rates.cpuRate * 100
+	metadata.TotalReqs = int64(totals.requests)
+	metadata.UpdateHealthScore(100 * (1 - errorRatio(totals, window.rates)))
+
+	if start, ok := sumSamples(scrape.samples, "process_start_time_seconds", nil); ok && start > 0 {
+		startTime := time.Unix(0, int64(start*float64(time.Second)))
+		metadata.Uptime = scrape.at.Sub(startTime).Truncate(time.Second)
+	}
+	if resident, ok := sumSamples(scrape.samples, "process_resident_memory_bytes", nil); ok {
+		metadata.AddCustomField("resident_memory_bytes", resident)
+		if limit, ok := sumSamples(scrape.samples, "process_virtual_memory_max_bytes", nil); ok && limit > 0 {
+			metadata.MemoryUsage = 100 * resident / limit
+		}
+	}
+
+	// In-flight requests stand in for active connections
+	inFlight, _ := sumSamples(scrape.samples, "http_requests_in_flight", nil)
+	if started, ok := sumSamples(scrape.samples, "grpc_server_started_total", nil); ok {
+		handled, _ := sumSamples(scrape.samples, "grpc_server_handled_total", nil)
+		inFlight += max(started-handled, 0)
+	}
+	metadata.ActiveConns = int64(inFlight)
+
+	metadata.AddCustomField("metrics_url", url)
+	metadata.AddCustomField("red_metrics_found", totals.found)
+	return metadata, nil
+}
+
+// CollectEdgeMetrics scrapes an edge's source and maps the client side RED metrics of calls to
+// the edge's target; sources that do not label client metrics with the peer report no traffic
+func (c *PrometheusMetricsCollector) CollectEdgeMetrics(ctx context.Context, edgeID string) (*entities.EdgeMetadata, error) {
+	conn, err := c.repo.GetConnection(ctx, edgeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection %s: %w", edgeID, err)
+	}
+	source, err := c.repo.GetNode(ctx, conn.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source node %s: %w", conn.SourceID, err)
+	}
+	target, err := c.repo.GetNode(ctx, conn.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target node %s: %w", conn.TargetID, err)
+	}
+	url := metricsURL(source)
+	if url == "" {
+		return nil, fmt.Errorf("source node %s has no metrics endpoint", source.ID)
+	}
+	scrape, err := c.scrape(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape source node %s: %w", source.ID, err)
+	}
+
+	targetNames := nodeIdentities(target)
+	totals := sumRED(scrape.samples, edgeREDFamilies, func(labels map[string]string) bool {
+		for _, key := range edgePeerLabels {
+			if value := labels[key]; value != "" && targetNames[value] {
+				return true
+			}
+		}
+		return false
+	})
+	window := c.observe("edge/"+edgeID, scrape.at, totals, 0)
+
+	metadata := entities.NewEdgeMetadata(edgeID)
+	metadata.Protocol = protocolName(conn.Type)
+	metadata.Throughput = window.rates.requestRate
+	metadata.ErrorRate = window.rates.errorRate
+	metadata.AvgLatency = window.rates.avgLatency
+	metadata.P99Latency = window.rates.p99Latency
+	metadata.TotalMessages = int64(totals.requests)
+	metadata.TotalErrors = int64(totals.errors)
+	metadata.LastActiveTime = window.lastActive
+	metadata.AddCustomField("metrics_url", url)
+	metadata.AddCustomField("red_metrics_found", totals.found)
+	return metadata, nil
+}
+
+// StreamMetrics streams periodic metrics updates scraped from the exporters
+func (c *PrometheusMetricsCollector) StreamMetrics(ctx context.Context, nodeIDs []string, edgeIDs []string, interval time.Duration) (<-chan *ports.MetricsUpdate, error) {
+	return streamMetricsUpdates(ctx, c, nodeIDs, edgeIDs, interval), nil
+}
+
+// scrape fetches and parses an exporter, reusing a scrape younger than CacheFor
+func (c *PrometheusMetricsCollector) scrape(ctx context.Context, url string) (*metricsScrape, error) {
+	c.mu.Lock()
+	cached, ok := c.scrapes[url]
+	c.mu.Unlock()
+	if ok && time.Since(cached.at) < c.options.CacheFor {
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", metricsAccept)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	samples, err := parsePrometheusText(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics from %s: %w", url, err)
+	}
+	scrape := &metricsScrape{at: time.Now(), samples: samples}
+
+	c.mu.Lock()
+	c.scrapes[url] = scrape
+	c.mu.Unlock()
+	return scrape, nil
+}
+
+// observe turns cumulative totals into rates over the window since the previous observation
+func (c *PrometheusMetricsCollector) observe(key string, at time.Time, totals redTotals, cpuSeconds float64) redWindow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous, ok := c.windows[key]
+	if ok && !at.After(previous.at) {
+		// Same cached scrape as last time
+		return *previous
+	}
+
+	window := &redWindow{at: at, totals: totals, cpuSeconds: cpuSeconds}
+	if !ok {
+		// Without a previous scrape, latencies cover everything since the exporter started
+		if totals.durationCount > 0 {
+			window.rates.avgLatency = secondsToDuration(totals.durationSum / totals.durationCount)
+		}
+		window.rates.p99Latency = secondsToDuration(histogramsQuantile(0.99, totals.histograms))
+		if totals.requests > 0 {
+			window.lastActive = at
+		}
+		c.windows[key] = window
+		return *window
+	}
+
+	elapsed := at.Sub(previous.at).Seconds()
+	requests := counterDelta(totals.requests, previous.totals.requests)
+	window.rates.requestRate = requests / elapsed
+	window.rates.errorRate = counterDelta(totals.errors, previous.totals.errors) / elapsed
+	window.rates.cpuRate = counterDelta(cpuSeconds, previous.cpuSeconds) / elapsed
+	if count := counterDelta(totals.durationCount, previous.totals.durationCount); count > 0 {
+		window.rates.avgLatency = secondsToDuration(counterDelta(totals.durationSum, previous.totals.durationSum) / count)
+	}
+	histograms := make(map[string]map[float64]float64, len(totals.histograms))
+	for name, buckets := range totals.histograms {
+		deltas := make(map[float64]float64, len(buckets))
+		for bound, count := range buckets {
+			deltas[bound] = counterDelta(count, previous.totals.histograms[name][bound])
+		}
+		histograms[name] = deltas
+	}
+	window.rates.p99Latency = secondsToDuration(histogramsQuantile(0.99, histograms))
+
+	window.lastActive = previous.lastActive
+	if requests > 0 {
+		window.lastActive = at
+	}
+	c.windows[key] = window
+	return *window
+}
+
+// counterDelta is the increase of a counter, treating a decrease as a restart from zero
+func counterDelta(current, previous float64) float64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
+
+// errorRatio is the share of failed requests in the window, or since start before there is one
+func errorRatio(totals redTotals, rates redRates) float64 {
+	if rates.requestRate > 0 {
+		return min(rates.errorRate/rates.requestRate, 1)
+	}
+	if totals.requests > 0 {
+		return min(totals.errors/totals.requests, 1)
+	}
+	return 0
+}
+
+// sumRED adds up the RED counters and duration histograms of the families over matching samples
+func sumRED(samples map[string][]promSample, families []redFamily, match func(map[string]string) bool) redTotals {
+	totals := redTotals{histograms: make(map[string]map[float64]float64)}
+	for _, family := range families {
+		requests, ok := sumSamples(samples, family.requests, match)
+		if !ok {
+			continue
+		}
+		totals.found = true
+		totals.requests += requests
+
+		if failed, ok := sumSamples(samples, family.errors, match); ok {
+			totals.errors += failed
+		} else {
+			totals.errors, _ = addSamples(totals.errors, samples, family.requests, func(labels map[string]string) bool {
+				return family.isError(labels) && (match == nil || match(labels))
+			})
+		}
+
+		sum, _ := sumSamples(samples, family.duration+"_sum", match)
+		count, _ := sumSamples(samples, family.duration+"_count", match)
+		totals.durationSum += sum
+		totals.durationCount += count
+		for _, sample := range samples[family.duration+"_bucket"] {
+			if match != nil && !match(sample.Labels) {
+				continue
+			}
+			bound, err := strconv.ParseFloat(sample.Labels["le"], 64)
+			if err != nil {
+				continue
+			}
+			buckets, ok := totals.histograms[family.duration]
+			if !ok {
+				buckets = make(map[float64]float64)
+				totals.histograms[family.duration] = buckets
+			}
+			buckets[bound] += sample.Value
+		}
+	}
+	return totals
+}
+
+// sumSamples sums the samples with a name that match, reporting whether any did
+func sumSamples(samples map[string][]promSample, name string, match func(map[string]string) bool) (float64, bool) {
+	return addSamples(0, samples, name, match)
+}
+
+func addSamples(total float64, samples map[string][]promSample, name string, match func(map[string]string) bool) (float64, bool) {
+	found := false
+	for _, sample := range samples[name] {
+		if match != nil && !match(sample.Labels) {
+			continue
+		}
+		if math.IsNaN(sample.Value) {
+			continue
+		}
+		total += sample.Value
+		found = true
+	}
+	return total, found
+}
+
+// histogramsQuantile estimates a quantile over several histograms: histograms with the same
+// bucket bounds are merged, and the highest quantile of the distinct layouts is returned, as
+// interpolating across differently bucketed counts would mix unrelated bucket ranks
+func histogramsQuantile(q float64, histograms map[string]map[float64]float64) float64 {
+	merged := make(map[string]map[float64]float64)
+	for _, buckets := range histograms {
+		bounds := make([]float64, 0, len(buckets))
+		for bound := range buckets {
+			bounds = append(bounds, bound)
+		}
+		slices.Sort(bounds)
+		layout := fmt.Sprint(bounds)
+		if merged[layout] == nil {
+			merged[layout] = make(map[float64]float64, len(buckets))
+		}
+		for bound, count := range buckets {
+			merged[layout][bound] += count
+		}
+	}
+
+	result := 0.0
+	for _, buckets := range merged {
+		result = max(result, histogramQuantile(q, buckets))
+	}
+	return result
+}
+
+// histogramQuantile estimates a quantile from cumulative buckets by linear interpolation within
+// the bucket holding it, as Prometheus' histogram_quantile does
+func histogramQuantile(q float64, buckets map[float64]float64) float64 {
+	if len(buckets) == 0 {
+		return 0
+	}
+	bounds := make([]float64, 0, len(buckets))
+	for bound := range buckets {
+		bounds = append(bounds, bound)
+	}
+	slices.Sort(bounds)
+
+	total := buckets[bounds[len(bounds)-1]]
+	if total <= 0 {
+		return 0
+	}
+	rank := q * total
+
+	lowerBound, lowerCount := 0.0, 0.0
+	for _, bound := range bounds {
+		count := buckets[bound]
+		if count >= rank {
+			if math.IsInf(bound, 1) {
+				// The quantile lies above the highest finite bucket
+				return lowerBound
+			}
+			if count == lowerCount {
+				return bound
+			}
+			return lowerBound + (bound-lowerBound)*(rank-lowerCount)/(count-lowerCount)
+		}
+		lowerBound, lowerCount = bound, count
+	}
+	return lowerBound
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// metricsURL is the node's metrics endpoint label, or /metrics on its HTTP endpoint
+func metricsURL(node *entities.ServiceNode) string {
+	if url := node.Labels[MetricsEndpointLabel]; url != "" {
+		if !strings.Contains(url, "://") {
+			url = "http://" + url
+		}
+		return url
+	}
+	if _, httpURL := nodeEndpoints(node); httpURL != "" {
+		return httpURL + metricsPath
+	}
+	return ""
+}
+
+// nodeIdentities are the names other services may use for a node in their metric labels
+func nodeIdentities(node *entities.ServiceNode) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range []string{node.ID, node.Name, node.InstanceName, node.ServiceType, node.Labels["host"]} {
+		if name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func protocolName(connType entities.ConnectionType) string {
+	switch connType {
+	case entities.ConnectionTypeGRPC:
+		return "gRPC"
+	case entities.ConnectionTypeHTTP:
+		return "HTTP"
+	case entities.ConnectionTypeDataFlow:
+		return "data_flow"
+	default:
+		return "unspecified"
+	}
+}
+
+var _ ports.MetricsCollector = (*PrometheusMetricsCollector)(nil)
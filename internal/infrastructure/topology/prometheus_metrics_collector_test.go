@@ -0,0 +1,255 @@
+package topology
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+)
+
+// fakeExporter serves a replaceable metrics page
+type fakeExporter struct {
+	mu          sync.Mutex
+	body        string
+	contentType string
+	accept      string
+}
+
+func (e *fakeExporter) set(body string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.body = body
+}
+
+func (e *fakeExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if r.URL.Path != metricsPath {
+		http.NotFound(w, r)
+		return
+	}
+	e.accept = r.Header.Get("Accept")
+	w.Header().Set("Content-Type", e.contentType)
+	w.Write([]byte(e.body))
+}
+
+const exchangeMetricsText = `# HELP http_requests_total Total HTTP requests
+# TYPE http_requests_total counter
+http_requests_total{method="GET",route="/api/v1/orders",code="200"} 90
+http_requests_total{method="POST",route="/api/v1/orders",code="500"} 10
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{le="0.1"} 80
+http_request_duration_seconds_bucket{le="0.5"} 99
+http_request_duration_seconds_bucket{le="+Inf"} 100
+http_request_duration_seconds_sum 5
+http_request_duration_seconds_count 100
+process_cpu_seconds_total 12.5
+process_start_time_seconds %START%
+process_resident_memory_bytes 2.5e+07
+http_requests_in_flight 3
+`
+
+const riskMetricsOpenMetrics = `# TYPE grpc_client_handled counter
+grpc_client_handled_total{grpc_service="exchange.v1.OrderService",grpc_code="OK",peer_service="exchange-simulator"} 40 # {trace_id="abc"} 1 1700000000.0
+grpc_client_handled_total{grpc_service="exchange.v1.OrderService",grpc_code="Unavailable",peer_service="exchange-simulator"} 2
+grpc_client_handled_total{grpc_service="custody.v1.CustodyService",grpc_code="OK",peer_service="custodian-simulator"} 500
+# TYPE grpc_client_handling_seconds histogram
+grpc_client_handling_seconds_bucket{peer_service="exchange-simulator",le="0.01"} 10
+grpc_client_handling_seconds_bucket{peer_service="exchange-simulator",le="0.1"} 42
+grpc_client_handling_seconds_bucket{peer_service="exchange-simulator",le="+Inf"} 42
+grpc_client_handling_seconds_sum{peer_service="exchange-simulator"} 2.1
+grpc_client_handling_seconds_count{peer_service="exchange-simulator"} 42
+# EOF
+`
+
+func TestPrometheusMetricsCollector_NodeAndEdgeMetrics(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryTopologyRepository()
+
+	started := time.Now().Add(-time.Hour)
+	exchangeExporter := &fakeExporter{contentType: "text/plain; version=0.0.4"}
+	exchangeExporter.set(strings.Replace(exchangeMetricsText, "%START%", formatSeconds(started), 1))
+	exchangeServer := httptest.NewServer(exchangeExporter)
+	defer exchangeServer.Close()
+
+	riskExporter := &fakeExporter{contentType: "application/openmetrics-text; version=1.0.0"}
+	riskExporter.set(riskMetricsOpenMetrics)
+	riskServer := httptest.NewServer(riskExporter)
+	defer riskServer.Close()
+
+	exchange := entities.NewServiceNode("exchange", "Exchange", "exchange-simulator", "exchange")
+	exchange.AddLabel(HTTPEndpointLabel, exchangeServer.URL)
+	risk := entities.NewServiceNode("risk", "Risk", "risk-monitor", "risk")
+	risk.AddLabel(MetricsEndpointLabel, riskServer.URL+metricsPath)
+	bare := entities.NewServiceNode("bare", "Bare", "custodian-simulator", "bare")
+	for _, node := range []*entities.ServiceNode{exchange, risk, bare} {
+		if err := repo.SaveNode(ctx, node); err != nil {
+			t.Fatalf("Failed to save node: %v", err)
+		}
+	}
+	edge := entities.NewServiceConnection("risk-to-exchange", "risk", "exchange", entities.ConnectionTypeGRPC)
+	if err := repo.SaveConnection(ctx, edge); err != nil {
+		t.Fatalf("Failed to save connection: %v", err)
+	}
+
+	collector := NewPrometheusMetricsCollector(repo, PrometheusCollectorOptions{CacheFor: time.Nanosecond})
+
+	// The first scrape reports totals and latencies since start, but no rates yet
+	node, err := collector.CollectNodeMetrics(ctx, "exchange")
+	if err != nil {
+		t.Fatalf("CollectNodeMetrics failed: %v", err)
+	}
+	if node.TotalReqs != 100 || node.RequestRate != 0 {
+		t.Errorf("Expected 100 total requests and no rate yet, got %d and %v", node.TotalReqs, node.RequestRate)
+	}
+	if node.Latency != 50*time.Millisecond {
+		t.Errorf("Expected average latency of 50ms, got %v", node.Latency)
+	}
+	if node.HealthScore != 90 {
+		t.Errorf("Expected health score 90 from a 10%% error ratio, got %v", node.HealthScore)
+	}
+	if node.ActiveConns != 3 {
+		t.Errorf("Expected 3 in-flight requests, got %d", node.ActiveConns)
+	}
+	if node.Uptime < 59*time.Minute || node.Uptime > 61*time.Minute {
+		t.Errorf("Expected an uptime of about an hour, got %v", node.Uptime)
+	}
+	if !strings.HasPrefix(exchangeExporter.accept, "application/openmetrics-text") {
+		t.Errorf("Expected the scrape to prefer OpenMetrics, got Accept %q", exchangeExporter.accept)
+	}
+
+	// The second scrape turns counter increases into rates; only the 200s grew
+	time.Sleep(50 * time.Millisecond)
+	exchangeExporter.set(strings.NewReplacer(
+		`code="200"} 90`, `code="200"} 190`,
+		"%START%", formatSeconds(started),
+	).Replace(exchangeMetricsText))
+	node, err = collector.CollectNodeMetrics(ctx, "exchange")
+	if err != nil {
+		t.Fatalf("CollectNodeMetrics failed: %v", err)
+	}
+	if node.RequestRate <= 0 || node.ErrorRate != 0 {
+		t.Errorf("Expected a request rate without errors, got %v and %v", node.RequestRate, node.ErrorRate)
+	}
+	if node.HealthScore != 100 {
+		t.Errorf("Expected full health without errors in the window, got %v", node.HealthScore)
+	}
+
+	if _, err := collector.CollectNodeMetrics(ctx, "bare"); err == nil {
+		t.Error("Expected an error for a node without a metrics endpoint")
+	}
+
+	// Edge metrics come from the source's client metrics labelled with the target
+	edgeMetrics, err := collector.CollectEdgeMetrics(ctx, "risk-to-exchange")
+	if err != nil {
+		t.Fatalf("CollectEdgeMetrics failed: %v", err)
+	}
+	if edgeMetrics.TotalMessages != 42 || edgeMetrics.TotalErrors != 2 {
+		t.Errorf("Expected 42 messages and 2 errors to the exchange, got %d and %d", edgeMetrics.TotalMessages, edgeMetrics.TotalErrors)
+	}
+	if edgeMetrics.AvgLatency != 50*time.Millisecond {
+		t.Errorf("Expected average edge latency of 50ms, got %v", edgeMetrics.AvgLatency)
+	}
+	if edgeMetrics.P99Latency <= 90*time.Millisecond || edgeMetrics.P99Latency > 100*time.Millisecond {
+		t.Errorf("Expected p99 edge latency in the 0.1s bucket, got %v", edgeMetrics.P99Latency)
+	}
+	if edgeMetrics.Protocol != "gRPC" {
+		t.Errorf("Expected gRPC protocol, got %s", edgeMetrics.Protocol)
+	}
+}
+
+func TestPrometheusMetricsCollector_StreamMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := NewMemoryTopologyRepository()
+
+	exporter := &fakeExporter{contentType: "text/plain; version=0.0.4"}
+	exporter.set("http_requests_total{code=\"200\"} 7\n")
+	server := httptest.NewServer(exporter)
+	defer server.Close()
+
+	node := entities.NewServiceNode("exchange", "Exchange", "exchange-simulator", "exchange")
+	node.AddLabel(HTTPEndpointLabel, server.URL)
+	if err := repo.SaveNode(ctx, node); err != nil {
+		t.Fatalf("Failed to save node: %v", err)
+	}
+
+	collector := NewPrometheusMetricsCollector(repo, PrometheusCollectorOptions{})
+	updates, err := collector.StreamMetrics(ctx, []string{"exchange", "missing"}, nil, time.Second)
+	if err != nil {
+		t.Fatalf("StreamMetrics failed: %v", err)
+	}
+
+	select {
+	case update := <-updates:
+		if update.NodeID != "exchange" || update.Metrics["total_requests"] != int64(7) {
+			t.Errorf("Unexpected update %+v", update)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected a metrics update")
+	}
+}
+
+func TestSumRED_QuantilePerBucketLayout(t *testing.T) {
+	samples, err := parsePrometheusText(strings.NewReader(`http_client_requests_total{target="exchange",code="200"} 100
+http_client_request_duration_seconds_bucket{target="exchange",le="0.1"} 100
+http_client_request_duration_seconds_bucket{target="exchange",le="1"} 100
+http_client_request_duration_seconds_bucket{target="exchange",le="+Inf"} 100
+grpc_client_handled_total{target="exchange",grpc_code="OK"} 100
+grpc_client_handling_seconds_bucket{target="exchange",le="0.005"} 99
+grpc_client_handling_seconds_bucket{target="exchange",le="0.5"} 100
+grpc_client_handling_seconds_bucket{target="exchange",le="+Inf"} 100
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Merged by bound, the fast gRPC calls would pull the HTTP p99 of ~99ms down to ~5ms
+	totals := sumRED(samples, edgeREDFamilies, nil)
+	if p99 := histogramsQuantile(0.99, totals.histograms); math.Abs(p99-0.099) > 1e-9 {
+		t.Errorf("Expected the p99 of the slower layout, 0.099s, got %v", p99)
+	}
+
+	// Histograms sharing a layout are merged before the quantile is taken
+	same := map[string]map[float64]float64{
+		"a": {0.1: 50, 1: 50, math.Inf(1): 50},
+		"b": {0.1: 0, 1: 50, math.Inf(1): 50},
+	}
+	if p50 := histogramsQuantile(0.5, same); math.Abs(p50-0.1) > 1e-9 {
+		t.Errorf("Expected the merged median at 0.1s, got %v", p50)
+	}
+}
+
+func TestParsePrometheusText(t *testing.T) {
+	samples, err := parsePrometheusText(strings.NewReader(`# HELP x A metric
+x{a="1",b="with \"quotes\", commas"} 1.5 1700000000000
+y +Inf
+z{} 3
+# EOF
+ignored 1
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := samples["x"]; len(got) != 1 || got[0].Value != 1.5 || got[0].Labels["b"] != `with "quotes", commas` {
+		t.Errorf("Unexpected x samples %+v", got)
+	}
+	if len(samples["y"]) != 1 || len(samples["z"]) != 1 || len(samples["ignored"]) != 0 {
+		t.Errorf("Unexpected samples %+v", samples)
+	}
+
+	if _, err := parsePrometheusText(strings.NewReader("broken{a=1} 2\n")); err == nil {
+		t.Error("Expected an error for an unquoted label value")
+	}
+}
+
+func formatSeconds(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', 3, 64)
+}
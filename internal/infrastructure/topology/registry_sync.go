@@ -0,0 +1,241 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+const (
+	// DiscoverySourceLabel marks nodes created or updated from the service registry
+	DiscoverySourceLabel = "discovery.source"
+	discoverySourceValue = "registry"
+)
+
+// ServiceRegistry lists the instances registered under a service name
+// The audit data adapter satisfies it
+type ServiceRegistry interface {
+	GetServicesByName(ctx context.Context, name string) ([]*models.ServiceRegistration, error)
+}
+
+// RegistrySyncOptions configures topology discovery from the service registry
+type RegistrySyncOptions struct {
+	// ServiceNames are polled in addition to the service types of nodes already in the topology
+	ServiceNames []string
+	// StaleAfter is the heartbeat age after which a node is degraded (default 90s)
+	StaleAfter time.Duration
+	// DeadAfter is the heartbeat age after which a node is dead (default 5m)
+	DeadAfter time.Duration
+	// Statuses combines the heartbeat status with the health probe status when both run
+	Statuses *NodeStatusArbiter
+}
+
+// RegistrySyncResult summarises one sync
+type RegistrySyncResult struct {
+	SyncedAt   time.Time `json:"synced_at"`
+	Registered int       `json:"registered"`
+	Live       int       `json:"live"`
+	Degraded   int       `json:"degraded"`
+	Dead       int       `json:"dead"`
+	// Missing counts discovered nodes no longer in the registry, which are marked dead
+	Missing int      `json:"missing"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// RegistrySync keeps topology nodes in line with the service registry
+// Each registered instance becomes a node labelled with its registration metadata, and its
+// status follows the age of its last heartbeat
+type RegistrySync struct {
+	registry ServiceRegistry
+	tracker  services.TopologyTracker
+	options  RegistrySyncOptions
+	logger   *logrus.Logger
+	syncMu   sync.Mutex
+}
+
+// NewRegistrySync creates a registry sync registering nodes through the tracker
+func NewRegistrySync(registry ServiceRegistry, tracker services.TopologyTracker, options RegistrySyncOptions, logger *logrus.Logger) *RegistrySync {
+	if options.StaleAfter <= 0 {
+		options.StaleAfter = 90 * time.Second
+	}
+	if options.DeadAfter <= options.StaleAfter {
+		options.DeadAfter = max(5*time.Minute, 2*options.StaleAfter)
+	}
+	return &RegistrySync{
+		registry: registry,
+		tracker:  tracker,
+		options:  options,
+		logger:   logger,
+	}
+}
+
+// Run syncs immediately and then every interval until the context is cancelled
+func (s *RegistrySync) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if result, err := s.Sync(ctx); err != nil {
+			s.logger.WithError(err).Warn("Topology discovery from the service registry failed")
+		} else if len(result.Errors) > 0 {
+			s.logger.WithField("errors", result.Errors).Warn("Topology discovery from the service registry was incomplete")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync registers or updates a node for every registered instance and marks nodes whose
+// heartbeats went stale as degraded or dead
+func (s *RegistrySync) Sync(ctx context.Context) (*RegistrySyncResult, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	now := time.Now()
+	result := &RegistrySyncResult{SyncedAt: now.UTC()}
+
+	nodes, err := s.tracker.GetNodes(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topology nodes: %w", err)
+	}
+	known := make(map[string]*entities.ServiceNode, len(nodes))
+	names := make(map[string]bool)
+	for _, name := range s.options.ServiceNames {
+		names[name] = true
+	}
+	for _, node := range nodes {
+		known[node.ID] = node
+		if node.ServiceType != "" {
+			names[node.ServiceType] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	failed := make(map[string]bool)
+	for name := range names {
+		registrations, err := s.registry.GetServicesByName(ctx, name)
+		if err != nil {
+			failed[name] = true
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		for _, registration := range registrations {
+			if registration == nil || registration.ID == "" || seen[registration.ID] {
+				continue
+			}
+			seen[registration.ID] = true
+			result.Registered++
+
+			node := s.nodeFromRegistration(registration, known[registration.ID], now)
+			if _, err := s.tracker.RegisterNode(ctx, node); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", registration.ID, err))
+				continue
+			}
+			switch node.Status {
+			case entities.NodeStatusLive:
+				result.Live++
+			case entities.NodeStatusDegraded:
+				result.Degraded++
+			default:
+				result.Dead++
+			}
+		}
+	}
+
+	// Discovered nodes that left the registry, through deregistration or an expired entry, are dead
+	for _, node := range nodes {
+		if seen[node.ID] || failed[node.ServiceType] || node.Labels[DiscoverySourceLabel] != discoverySourceValue {
+			continue
+		}
+		result.Missing++
+		status := s.options.Statuses.Report(StatusSourceHeartbeat, node.ID, entities.NodeStatusDead)
+		if node.Status == status {
+			continue
+		}
+		if _, err := s.tracker.UpdateNodeStatus(ctx, node.ID, status); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", node.ID, err))
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"registered": result.Registered,
+		"live":       result.Live,
+		"degraded":   result.Degraded,
+		"dead":       result.Dead,
+		"missing":    result.Missing,
+	}).Debug("Topology synced from the service registry")
+	return result, nil
+}
+
+// nodeFromRegistration builds the node for a registration, keeping the display name and
+// labels of an existing node with the same ID
+func (s *RegistrySync) nodeFromRegistration(registration *models.ServiceRegistration, existing *entities.ServiceNode, now time.Time) *entities.ServiceNode {
+	serviceType := registration.Metadata["service_type"]
+	if serviceType == "" {
+		serviceType = registration.Name
+	}
+	instanceName := registration.Metadata["instance_name"]
+	if instanceName == "" {
+		instanceName = registration.ID
+	}
+	name := instanceName
+	if existing != nil && existing.Name != "" {
+		name = existing.Name
+	}
+
+	node := entities.NewServiceNode(registration.ID, name, serviceType, instanceName)
+	if existing != nil {
+		for key, value := range existing.Labels {
+			node.AddLabel(key, value)
+		}
+	}
+	for key, value := range registration.Metadata {
+		node.AddLabel(key, value)
+	}
+	node.AddLabel(DiscoverySourceLabel, discoverySourceValue)
+	if registration.Version != "" {
+		node.AddLabel("version", registration.Version)
+	}
+	if registration.Host != "" {
+		node.AddLabel("host", registration.Host)
+	}
+	if registration.GRPCPort > 0 {
+		node.AddLabel("grpc_port", strconv.Itoa(registration.GRPCPort))
+	}
+	if registration.HTTPPort > 0 {
+		node.AddLabel("http_port", strconv.Itoa(registration.HTTPPort))
+	}
+
+	node.Status = s.options.Statuses.Report(StatusSourceHeartbeat, registration.ID, s.heartbeatStatus(registration, now))
+	node.LastSeenAt = registration.LastSeen
+	node.RegisteredAt = registration.RegisteredAt
+	return node
+}
+
+// heartbeatStatus derives a node status from the heartbeat age and the reported status
+func (s *RegistrySync) heartbeatStatus(registration *models.ServiceRegistration, now time.Time) entities.NodeStatus {
+	age := now.Sub(registration.LastSeen)
+	switch {
+	case registration.LastSeen.IsZero() || age > s.options.DeadAfter:
+		return entities.NodeStatusDead
+	case age > s.options.StaleAfter:
+		return entities.NodeStatusDegraded
+	case registration.Status != "" && !strings.EqualFold(registration.Status, "healthy"):
+		return entities.NodeStatusDegraded
+	default:
+		return entities.NodeStatusLive
+	}
+}
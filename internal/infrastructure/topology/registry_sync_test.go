@@ -0,0 +1,170 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+type fakeServiceRegistry struct {
+	services map[string][]*models.ServiceRegistration
+	failing  map[string]bool
+}
+
+func (r *fakeServiceRegistry) GetServicesByName(ctx context.Context, name string) ([]*models.ServiceRegistration, error) {
+	if r.failing[name] {
+		return nil, errors.New("registry unavailable")
+	}
+	return r.services[name], nil
+}
+
+func registration(id, name string, lastSeen time.Time) *models.ServiceRegistration {
+	return &models.ServiceRegistration{
+		ID:       id,
+		Name:     name,
+		Version:  "1.2.0",
+		Host:     id + ".local",
+		GRPCPort: 50051,
+		Status:   "healthy",
+		Metadata: map[string]string{"service_type": name, "instance_name": id, "environment": "test"},
+		LastSeen: lastSeen,
+	}
+}
+
+func TestRegistrySync_DiscoversNodesAndTracksHeartbeats(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryTopologyRepository()
+	tracker, err := services.NewTopologyTracker(repo, NewMemoryMetadataRepository(), NewChannelChangePublisher(), NewMemoryTopologyHistory())
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	// A node from the topology config keeps its display name when discovered
+	configured := entities.NewServiceNode("risk-monitor-lh", "Risk Monitor LH", "risk-monitor", "risk-monitor-lh")
+	configured.AddLabel("team", "risk")
+	if _, err := tracker.RegisterNode(ctx, configured); err != nil {
+		t.Fatalf("Failed to register node: %v", err)
+	}
+
+	now := time.Now()
+	registry := &fakeServiceRegistry{services: map[string][]*models.ServiceRegistration{
+		"exchange-simulator": {
+			registration("exchange-okx", "exchange-simulator", now),
+			registration("exchange-binance", "exchange-simulator", now.Add(-2*time.Minute)),
+		},
+		"risk-monitor": {registration("risk-monitor-lh", "risk-monitor", now.Add(-10*time.Minute))},
+	}}
+	registrySync := NewRegistrySync(registry, tracker, RegistrySyncOptions{
+		ServiceNames: []string{"exchange-simulator"},
+		StaleAfter:   time.Minute,
+		DeadAfter:    5 * time.Minute,
+	}, logger)
+
+	result, err := registrySync.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Registered != 3 || result.Live != 1 || result.Degraded != 1 || result.Dead != 1 {
+		t.Errorf("Unexpected sync result %+v", result)
+	}
+
+	okx, err := tracker.GetNode(ctx, "exchange-okx")
+	if err != nil {
+		t.Fatalf("Expected exchange-okx to be discovered: %v", err)
+	}
+	if okx.Status != entities.NodeStatusLive || okx.ServiceType != "exchange-simulator" ||
+		okx.Labels["environment"] != "test" || okx.Labels["grpc_port"] != "50051" || okx.Labels[DiscoverySourceLabel] != "registry" {
+		t.Errorf("Unexpected discovered node %+v", okx)
+	}
+	if binance, _ := tracker.GetNode(ctx, "exchange-binance"); binance.Status != entities.NodeStatusDegraded {
+		t.Errorf("Expected a stale heartbeat to degrade the node, got %v", binance.Status)
+	}
+	risk, _ := tracker.GetNode(ctx, "risk-monitor-lh")
+	if risk.Status != entities.NodeStatusDead || risk.Name != "Risk Monitor LH" || risk.Labels["team"] != "risk" {
+		t.Errorf("Expected the configured node to be dead with its name and labels kept, got %+v", risk)
+	}
+
+	// A failing lookup leaves its nodes alone; an instance gone from the registry is dead
+	registry.services["exchange-simulator"] = []*models.ServiceRegistration{registration("exchange-okx", "exchange-simulator", now)}
+	registry.failing = map[string]bool{"risk-monitor": true}
+	result, err = registrySync.Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Missing != 1 || len(result.Errors) != 1 {
+		t.Errorf("Expected one missing node and one error, got %+v", result)
+	}
+	if binance, _ := tracker.GetNode(ctx, "exchange-binance"); binance.Status != entities.NodeStatusDead {
+		t.Errorf("Expected a deregistered node to be dead, got %v", binance.Status)
+	}
+	if _, err := tracker.GetNode(ctx, "risk-monitor-lh"); err != nil {
+		t.Errorf("Expected the node behind a failing lookup to remain: %v", err)
+	}
+}
+
+func TestRegistrySync_CombinesHeartbeatAndProbeStatus(t *testing.T) {
+	ctx := context.Background()
+	tracker, err := services.NewTopologyTracker(NewMemoryTopologyRepository(), NewMemoryMetadataRepository(), NewChannelChangePublisher(), NewMemoryTopologyHistory())
+	if err != nil {
+		t.Fatalf("Failed to create tracker: %v", err)
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	now := time.Now()
+	registry := &fakeServiceRegistry{services: map[string][]*models.ServiceRegistration{
+		"exchange-simulator": {registration("exchange-okx", "exchange-simulator", now)},
+	}}
+	statuses := NewNodeStatusArbiter()
+	registrySync := NewRegistrySync(registry, tracker, RegistrySyncOptions{
+		ServiceNames: []string{"exchange-simulator"},
+		StaleAfter:   time.Minute,
+		DeadAfter:    5 * time.Minute,
+		Statuses:     statuses,
+	}, logger)
+	status := func() entities.NodeStatus {
+		t.Helper()
+		node, err := tracker.GetNode(ctx, "exchange-okx")
+		if err != nil {
+			t.Fatalf("Failed to get node: %v", err)
+		}
+		return node.Status
+	}
+
+	// A failing probe degrades a node that still heartbeats
+	statuses.Report(StatusSourceProbe, "exchange-okx", entities.NodeStatusDegraded)
+	if _, err := registrySync.Sync(ctx); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := status(); got != entities.NodeStatusDegraded {
+		t.Errorf("Expected the probe status to win over a fresh heartbeat, got %v", got)
+	}
+
+	// A stale heartbeat degrades a node that still answers probes
+	statuses.Report(StatusSourceProbe, "exchange-okx", entities.NodeStatusLive)
+	registry.services["exchange-simulator"][0].LastSeen = now.Add(-2 * time.Minute)
+	if _, err := registrySync.Sync(ctx); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := status(); got != entities.NodeStatusDegraded {
+		t.Errorf("Expected a stale heartbeat to degrade a node that answers probes, got %v", got)
+	}
+
+	// Both healthy again
+	registry.services["exchange-simulator"][0].LastSeen = now
+	if _, err := registrySync.Sync(ctx); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := status(); got != entities.NodeStatusLive {
+		t.Errorf("Expected the node to recover once both sources are healthy, got %v", got)
+	}
+}
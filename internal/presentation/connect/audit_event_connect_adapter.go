@@ -0,0 +1,78 @@
+package connectpresentation
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	auditv1 "github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1/auditv1connect"
+	grpcservices "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/presentation/grpc/services"
+)
+
+// AuditEventConnectAdapter adapts the gRPC AuditEventService to Connect protocol
+// Implements auditv1connect.AuditEventServiceHandler interface
+type AuditEventConnectAdapter struct {
+	grpcServer *grpcservices.AuditEventServiceServer
+}
+
+// Ensure AuditEventConnectAdapter implements AuditEventServiceHandler
+var _ auditv1connect.AuditEventServiceHandler = (*AuditEventConnectAdapter)(nil)
+
+// NewAuditEventConnectAdapter creates a new Connect-compatible audit event adapter
+func NewAuditEventConnectAdapter(grpcServer *grpcservices.AuditEventServiceServer) *AuditEventConnectAdapter {
+	return &AuditEventConnectAdapter{
+		grpcServer: grpcServer,
+	}
+}
+
+// TailEvents implements the Connect handler for TailEvents
+func (h *AuditEventConnectAdapter) TailEvents(
+	ctx context.Context,
+	req *connect.Request[auditv1.TailEventsRequest],
+	stream *connect.ServerStream[auditv1.TailEventsResponse],
+) error {
+	// Request headers and the peer are passed on as gRPC metadata so the caller is identified alike
+	md := metadata.MD{}
+	for name, values := range req.Header() {
+		md.Append(name, values...)
+	}
+	ctx = metadata.NewIncomingContext(ctx, md)
+	if addr, err := net.ResolveTCPAddr("tcp", req.Peer().Addr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+
+	streamAdapter := &tailEventsStreamAdapter{stream: stream, ctx: ctx}
+	err := h.grpcServer.TailEvents(req.Msg, streamAdapter)
+	// gRPC and Connect share status codes, so keep the code the server chose
+	if st, ok := status.FromError(err); ok && err != nil {
+		return connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	}
+	return err
+}
+
+// tailEventsStreamAdapter adapts Connect ServerStream to gRPC stream
+type tailEventsStreamAdapter struct {
+	stream *connect.ServerStream[auditv1.TailEventsResponse]
+	ctx    context.Context
+}
+
+func (s *tailEventsStreamAdapter) Send(msg *auditv1.TailEventsResponse) error {
+	return s.stream.Send(msg)
+}
+
+func (s *tailEventsStreamAdapter) Context() context.Context {
+	return s.ctx
+}
+
+// Implement required gRPC stream methods (unused but needed for interface)
+func (s *tailEventsStreamAdapter) SetHeader(md metadata.MD) error  { return nil }
+func (s *tailEventsStreamAdapter) SendHeader(md metadata.MD) error { return nil }
+func (s *tailEventsStreamAdapter) SetTrailer(md metadata.MD)       {}
+func (s *tailEventsStreamAdapter) SendMsg(m interface{}) error     { return nil }
+func (s *tailEventsStreamAdapter) RecvMsg(m interface{}) error     { return nil }
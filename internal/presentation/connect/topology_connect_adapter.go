@@ -2,9 +2,11 @@ package connectpresentation
 
 import (
 	"context"
+	"errors"
 
 	"connectrpc.com/connect"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	auditv1 "github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1"
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1/auditv1connect"
@@ -85,6 +87,158 @@ func (h *TopologyConnectAdapter) StreamMetricsUpdates(
 	return h.grpcServer.StreamMetricsUpdates(req.Msg, streamAdapter)
 }
 
+// RegisterNode implements the Connect handler for RegisterNode
+func (h *TopologyConnectAdapter) RegisterNode(
+	ctx context.Context,
+	req *connect.Request[auditv1.RegisterNodeRequest],
+) (*connect.Response[auditv1.RegisterNodeResponse], error) {
+	resp, err := h.grpcServer.RegisterNode(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeregisterNode implements the Connect handler for DeregisterNode
+func (h *TopologyConnectAdapter) DeregisterNode(
+	ctx context.Context,
+	req *connect.Request[auditv1.DeregisterNodeRequest],
+) (*connect.Response[auditv1.DeregisterNodeResponse], error) {
+	resp, err := h.grpcServer.DeregisterNode(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateNodeStatus implements the Connect handler for UpdateNodeStatus
+func (h *TopologyConnectAdapter) UpdateNodeStatus(
+	ctx context.Context,
+	req *connect.Request[auditv1.UpdateNodeStatusRequest],
+) (*connect.Response[auditv1.UpdateNodeStatusResponse], error) {
+	resp, err := h.grpcServer.UpdateNodeStatus(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// RegisterEdge implements the Connect handler for RegisterEdge
+func (h *TopologyConnectAdapter) RegisterEdge(
+	ctx context.Context,
+	req *connect.Request[auditv1.RegisterEdgeRequest],
+) (*connect.Response[auditv1.RegisterEdgeResponse], error) {
+	resp, err := h.grpcServer.RegisterEdge(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeregisterEdge implements the Connect handler for DeregisterEdge
+func (h *TopologyConnectAdapter) DeregisterEdge(
+	ctx context.Context,
+	req *connect.Request[auditv1.DeregisterEdgeRequest],
+) (*connect.Response[auditv1.DeregisterEdgeResponse], error) {
+	resp, err := h.grpcServer.DeregisterEdge(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateEdgeStatus implements the Connect handler for UpdateEdgeStatus
+func (h *TopologyConnectAdapter) UpdateEdgeStatus(
+	ctx context.Context,
+	req *connect.Request[auditv1.UpdateEdgeStatusRequest],
+) (*connect.Response[auditv1.UpdateEdgeStatusResponse], error) {
+	resp, err := h.grpcServer.UpdateEdgeStatus(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetTopologySnapshot implements the Connect handler for GetTopologySnapshot
+func (h *TopologyConnectAdapter) GetTopologySnapshot(
+	ctx context.Context,
+	req *connect.Request[auditv1.GetTopologySnapshotRequest],
+) (*connect.Response[auditv1.TopologyStructureResponse], error) {
+	resp, err := h.grpcServer.GetTopologySnapshot(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DiffTopologySnapshots implements the Connect handler for DiffTopologySnapshots
+func (h *TopologyConnectAdapter) DiffTopologySnapshots(
+	ctx context.Context,
+	req *connect.Request[auditv1.DiffTopologySnapshotsRequest],
+) (*connect.Response[auditv1.DiffTopologySnapshotsResponse], error) {
+	resp, err := h.grpcServer.DiffTopologySnapshots(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetDependencies implements the Connect handler for GetDependencies
+func (h *TopologyConnectAdapter) GetDependencies(
+	ctx context.Context,
+	req *connect.Request[auditv1.GetDependenciesRequest],
+) (*connect.Response[auditv1.GetDependenciesResponse], error) {
+	resp, err := h.grpcServer.GetDependencies(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetBlastRadius implements the Connect handler for GetBlastRadius
+func (h *TopologyConnectAdapter) GetBlastRadius(
+	ctx context.Context,
+	req *connect.Request[auditv1.GetBlastRadiusRequest],
+) (*connect.Response[auditv1.GetBlastRadiusResponse], error) {
+	resp, err := h.grpcServer.GetBlastRadius(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// AnalyzeTopology implements the Connect handler for AnalyzeTopology
+func (h *TopologyConnectAdapter) AnalyzeTopology(
+	ctx context.Context,
+	req *connect.Request[auditv1.AnalyzeTopologyRequest],
+) (*connect.Response[auditv1.AnalyzeTopologyResponse], error) {
+	resp, err := h.grpcServer.AnalyzeTopology(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetCriticalPaths implements the Connect handler for GetCriticalPaths
+func (h *TopologyConnectAdapter) GetCriticalPaths(
+	ctx context.Context,
+	req *connect.Request[auditv1.GetCriticalPathsRequest],
+) (*connect.Response[auditv1.GetCriticalPathsResponse], error) {
+	resp, err := h.grpcServer.GetCriticalPaths(ctx, req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// connectError keeps the status code chosen by the gRPC server, which Connect shares
+func connectError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	}
+	return err
+}
+
 // topologyChangeStreamAdapter adapts Connect ServerStream to gRPC stream
 type topologyChangeStreamAdapter struct {
 	stream *connect.ServerStream[auditv1.TopologyChange]
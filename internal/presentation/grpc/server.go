@@ -24,6 +24,8 @@ type AuditGRPCServer struct {
 	healthSrv    *health.Server
 	auditSvc     *services.AuditService
 	topologySvc  *services.TopologyService
+	tailSvc      *services.EventTailService
+	eventSrv     *grpcservices.AuditEventServiceServer
 	logger       *logrus.Logger
 
 	// Metrics tracking
@@ -43,6 +45,12 @@ type ServerMetrics struct {
 
 // NewAuditGRPCServer creates a new gRPC server instance with health service
 func NewAuditGRPCServer(cfg *config.Config, auditService *services.AuditService, logger *logrus.Logger) *AuditGRPCServer {
+	return NewAuditGRPCServerWithTopology(cfg, auditService, nil, logger)
+}
+
+// NewAuditGRPCServerWithTopology creates a new gRPC server sharing an existing topology service
+// A nil topology service results in a new, empty one being created
+func NewAuditGRPCServerWithTopology(cfg *config.Config, auditService *services.AuditService, topologyService *services.TopologyService, logger *logrus.Logger) *AuditGRPCServer {
 	if logger == nil {
 		// Create a default logger if none provided (for testing)
 		logger = logrus.New()
@@ -55,7 +63,9 @@ func NewAuditGRPCServer(cfg *config.Config, auditService *services.AuditService,
 	}
 
 	// Initialize topology service
-	topologyService := services.NewTopologyService(logger)
+	if topologyService == nil {
+		topologyService = services.NewTopologyService(logger)
+	}
 
 	server := &AuditGRPCServer{
 		config:      cfg,
@@ -63,6 +73,7 @@ func NewAuditGRPCServer(cfg *config.Config, auditService *services.AuditService,
 		healthSrv:   health.NewServer(),
 		auditSvc:    auditService,
 		topologySvc: topologyService,
+		tailSvc:     services.NewEventTailService(auditService, logger),
 		logger:      logger,
 		startTime:   time.Now(),
 	}
@@ -74,6 +85,10 @@ func NewAuditGRPCServer(cfg *config.Config, auditService *services.AuditService,
 	topologyServer := grpcservices.NewTopologyServiceServer(topologyService, logger)
 	auditv1.RegisterTopologyServiceServer(server.server, topologyServer)
 
+	// Register audit event service (live tail)
+	server.eventSrv = grpcservices.NewAuditEventServiceServer(server.tailSvc, logger)
+	auditv1.RegisterAuditEventServiceServer(server.server, server.eventSrv)
+
 	// Register reflection service (enables grpcurl and other tools)
 	reflection.Register(server.server)
 
@@ -81,12 +96,28 @@ func NewAuditGRPCServer(cfg *config.Config, auditService *services.AuditService,
 	server.healthSrv.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	server.healthSrv.SetServingStatus(cfg.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
 	server.healthSrv.SetServingStatus("audit.v1.TopologyService", grpc_health_v1.HealthCheckResponse_SERVING)
+	server.healthSrv.SetServingStatus("audit.v1.AuditEventService", grpc_health_v1.HealthCheckResponse_SERVING)
 
 	logger.Info("gRPC server initialized with reflection support")
 
 	return server
 }
 
+// TopologyService returns the topology service backing the gRPC topology API
+func (s *AuditGRPCServer) TopologyService() *services.TopologyService {
+	return s.topologySvc
+}
+
+// EventTailService returns the live tail service shared by the gRPC, Connect and HTTP APIs
+func (s *AuditGRPCServer) EventTailService() *services.EventTailService {
+	return s.tailSvc
+}
+
+// SetAccessLog records reads of audit data over gRPC in the access log
+func (s *AuditGRPCServer) SetAccessLog(accessLog *services.AccessLog) {
+	s.eventSrv.SetAccessLog(accessLog)
+}
+
 // Serve starts the gRPC server on the provided listener
 func (s *AuditGRPCServer) Serve(lis net.Listener) error {
 	s.logger.WithField("address", lis.Addr().String()).Info("Starting gRPC server")
@@ -102,6 +133,7 @@ func (s *AuditGRPCServer) GracefulStop() {
 	s.healthSrv.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	s.healthSrv.SetServingStatus(s.config.ServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	s.healthSrv.SetServingStatus("audit.v1.TopologyService", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	s.healthSrv.SetServingStatus("audit.v1.AuditEventService", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 
 	// Graceful stop
 	s.server.GracefulStop()
@@ -117,6 +149,7 @@ func (s *AuditGRPCServer) GetMetrics() ServerMetrics {
 	status["health_service"] = "serving"
 	status["audit_service"] = "serving"
 	status["topology_service"] = "serving"
+	status["audit_event_service"] = "serving"
 
 	return ServerMetrics{
 		ActiveConnections: s.activeConnections,
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+
+	auditv1 "github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/services"
+)
+
+// AuditEventServiceServer implements the gRPC AuditEventService
+type AuditEventServiceServer struct {
+	auditv1.UnimplementedAuditEventServiceServer
+	tailService *services.EventTailService
+	accessLog   *services.AccessLog
+	logger      *logrus.Logger
+}
+
+// tailEventsRoute is the full method name recorded in the access log for TailEvents
+const tailEventsRoute = "/audit.v1.AuditEventService/TailEvents"
+
+// NewAuditEventServiceServer creates a new AuditEventServiceServer
+func NewAuditEventServiceServer(tailService *services.EventTailService, logger *logrus.Logger) *AuditEventServiceServer {
+	return &AuditEventServiceServer{
+		tailService: tailService,
+		logger:      logger,
+	}
+}
+
+// SetAccessLog records every tail in the access log, like the HTTP reads of audit data
+func (s *AuditEventServiceServer) SetAccessLog(accessLog *services.AccessLog) {
+	s.accessLog = accessLog
+}
+
+// TailEvents streams events as they are accepted, with drop notifications for slow consumers
+func (s *AuditEventServiceServer) TailEvents(
+	req *auditv1.TailEventsRequest,
+	stream auditv1.AuditEventService_TailEventsServer,
+) (err error) {
+	s.logger.WithFields(logrus.Fields{
+		"request_id":  req.RequestId,
+		"filter":      req.Filter,
+		"buffer_size": req.BufferSize,
+	}).Debug("TailEvents called")
+
+	start := time.Now()
+	sent := 0
+	intent, err := s.recordIntent(stream.Context(), req, start)
+	if err != nil {
+		return status.Error(codes.Unavailable, "access log unavailable, audit data cannot be read")
+	}
+	defer func() { s.recordCompletion(stream.Context(), req, start, intent, sent, err) }()
+
+	sub, err := s.tailService.Subscribe(req.Filter, int(req.BufferSize))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidSearch):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, services.ErrTailCapacity):
+			return status.Error(codes.ResourceExhausted, err.Error())
+		default:
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	defer s.tailService.Unsubscribe(sub.ID)
+
+	ctx := stream.Context()
+	for {
+		msg, err := sub.Next(ctx)
+		if err != nil {
+			s.logger.Debug("TailEvents context cancelled")
+			return ctx.Err()
+		}
+		if err := stream.Send(convertTailMessageToProto(msg)); err != nil {
+			s.logger.WithError(err).Error("Failed to send tailed event")
+			return err
+		}
+		if msg.Event != nil {
+			sent++
+		}
+	}
+}
+
+// recordIntent records a tail in the access log before any event is streamed
+// It returns the intent's sequence, zero when no access log is configured
+func (s *AuditEventServiceServer) recordIntent(ctx context.Context, req *auditv1.TailEventsRequest, start time.Time) (int64, error) {
+	if s.accessLog == nil {
+		return 0, nil
+	}
+
+	intent, err := s.accessLog.Record(tailAccessRecord(ctx, req, start))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to record audit data access, refusing the tail")
+		return 0, err
+	}
+	return intent.Sequence, nil
+}
+
+// recordCompletion closes a tail's intent; the status is the gRPC code the stream ended with
+func (s *AuditEventServiceServer) recordCompletion(ctx context.Context, req *auditv1.TailEventsRequest, start time.Time, intent int64, sent int, err error) {
+	if s.accessLog == nil {
+		return
+	}
+
+	record := tailAccessRecord(ctx, req, start)
+	record.Phase = services.AccessPhaseCompleted
+	record.Intent = intent
+	record.Status = int(status.Code(err))
+	record.ResultCount = sent
+	record.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+	// A client going away is the normal end of a tail
+	if errors.Is(err, context.Canceled) {
+		record.Status = int(codes.OK)
+	}
+
+	if _, err := s.accessLog.Record(record); err != nil {
+		s.logger.WithError(err).WithField("caller", record.Caller.ID).Error("Failed to record completion of audit data access")
+	}
+}
+
+func tailAccessRecord(ctx context.Context, req *auditv1.TailEventsRequest, start time.Time) services.AccessRecord {
+	record := services.AccessRecord{
+		Phase:     services.AccessPhaseIntent,
+		Timestamp: start.UTC(),
+		Caller:    rpcCallerIdentity(ctx),
+		Resource:  "tail",
+		Method:    "RPC",
+		Route:     tailEventsRoute,
+		Path:      tailEventsRoute,
+	}
+	if req.Filter != "" {
+		record.Query = map[string]string{"filter": req.Filter}
+	}
+	return record
+}
+
+// rpcCallerIdentity reads the caller from request metadata, using the same headers as the HTTP API
+func rpcCallerIdentity(ctx context.Context) services.CallerIdentity {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	caller := services.CallerIdentity{
+		ID:            first("x-caller-id"),
+		Client:        first("x-client"),
+		ClientVersion: first("x-client-version"),
+		UserAgent:     first("user-agent"),
+	}
+	if caller.ID == "" {
+		caller.ID = first("x-user")
+	}
+	if auth := first("authorization"); auth != "" {
+		caller.CredentialFingerprint = services.CredentialFingerprint(auth)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		caller.RemoteAddr = p.Addr.String()
+	}
+	return caller
+}
+
+// convertTailMessageToProto converts a tail message into a stream response
+func convertTailMessageToProto(msg services.TailMessage) *auditv1.TailEventsResponse {
+	if msg.Event == nil {
+		return &auditv1.TailEventsResponse{
+			Payload: &auditv1.TailEventsResponse_Dropped{
+				Dropped: &auditv1.EventsDropped{Count: msg.Dropped, Total: msg.TotalDropped},
+			},
+		}
+	}
+	return &auditv1.TailEventsResponse{
+		Payload: &auditv1.TailEventsResponse_Event{Event: convertAuditEventToProto(msg.Event)},
+	}
+}
+
+// convertAuditEventToProto converts a stored audit event to proto
+func convertAuditEventToProto(event *models.AuditEvent) *auditv1.AuditEvent {
+	return &auditv1.AuditEvent{
+		Id:           event.ID,
+		TraceId:      event.TraceID,
+		SpanId:       event.SpanID,
+		ServiceName:  event.ServiceName,
+		EventType:    event.EventType,
+		Timestamp:    timestamppb.New(event.Timestamp),
+		Status:       string(event.Status),
+		MetadataJson: string(event.Metadata),
+		Tags:         event.Tags,
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditv1 "github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	domainservices "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
+)
+
+// liveLatencyTimeout bounds scraping live edge latencies for critical paths
+const liveLatencyTimeout = 2 * time.Second
+
+// GetDependencies returns the transitive upstream and downstream nodes of a node
+func (s *TopologyServiceServer) GetDependencies(ctx context.Context, req *auditv1.GetDependenciesRequest) (*auditv1.GetDependenciesResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"request_id": req.RequestId,
+		"node_id":    req.NodeId,
+	}).Debug("GetDependencies called")
+
+	snapshot, err := s.analyzedNode(ctx, "GetDependencies", req.NodeId, req.SnapshotId, req.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditv1.GetDependenciesResponse{
+		NodeId:            req.NodeId,
+		DownstreamNodeIds: snapshot.Downstream(req.NodeId),
+		UpstreamNodeIds:   snapshot.Upstream(req.NodeId),
+		SnapshotId:        snapshot.SnapshotID,
+		RequestId:         req.RequestId,
+	}, nil
+}
+
+// GetBlastRadius returns the nodes and edges affected by a node failure
+func (s *TopologyServiceServer) GetBlastRadius(ctx context.Context, req *auditv1.GetBlastRadiusRequest) (*auditv1.GetBlastRadiusResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"request_id": req.RequestId,
+		"node_id":    req.NodeId,
+	}).Debug("GetBlastRadius called")
+
+	snapshot, err := s.analyzedNode(ctx, "GetBlastRadius", req.NodeId, req.SnapshotId, req.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	radius := snapshot.BlastRadius(req.NodeId)
+
+	return &auditv1.GetBlastRadiusResponse{
+		NodeId:               radius.NodeID,
+		AffectedNodeIds:      radius.AffectedNodes,
+		AffectedEdgeIds:      radius.AffectedConnections,
+		CriticalEdgeIds:      radius.CriticalConnections,
+		AffectedServiceTypes: radius.AffectedServiceTypes,
+		SnapshotId:           snapshot.SnapshotID,
+		RequestId:            req.RequestId,
+	}, nil
+}
+
+// AnalyzeTopology returns single points of failure and dependency cycles
+func (s *TopologyServiceServer) AnalyzeTopology(ctx context.Context, req *auditv1.AnalyzeTopologyRequest) (*auditv1.AnalyzeTopologyResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"request_id":  req.RequestId,
+		"snapshot_id": req.SnapshotId,
+	}).Debug("AnalyzeTopology called")
+
+	snapshot, err := s.topologyAt(ctx, req.SnapshotId, req.AsOf)
+	if err != nil {
+		return nil, s.trackerError("AnalyzeTopology", err)
+	}
+
+	resp := &auditv1.AnalyzeTopologyResponse{
+		SinglePointsOfFailure: snapshot.SinglePointsOfFailure(),
+		SnapshotId:            snapshot.SnapshotID,
+		RequestId:             req.RequestId,
+	}
+	for _, cycle := range snapshot.Cycles() {
+		resp.Cycles = append(resp.Cycles, &auditv1.NodeCycle{NodeIds: cycle})
+	}
+	return resp, nil
+}
+
+// GetCriticalPaths returns the slowest dependency chains weighted by edge latency
+// Latencies come from recorded edge metadata, or else from the live metrics collector when the
+// current topology is analyzed; a live scrape says nothing about a past snapshot and would
+// advance the collector's rate windows
+func (s *TopologyServiceServer) GetCriticalPaths(ctx context.Context, req *auditv1.GetCriticalPathsRequest) (*auditv1.GetCriticalPathsResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"request_id":      req.RequestId,
+		"snapshot_id":     req.SnapshotId,
+		"limit":           req.Limit,
+		"use_p99_latency": req.UseP99Latency,
+	}).Debug("GetCriticalPaths called")
+
+	snapshot, err := s.topologyAt(ctx, req.SnapshotId, req.AsOf)
+	if err != nil {
+		return nil, s.trackerError("GetCriticalPaths", err)
+	}
+	live := req.SnapshotId == "" && req.AsOf == nil
+	latencies, unknown := s.edgeLatencies(ctx, snapshot, req.UseP99Latency, live)
+
+	paths := snapshot.CriticalPaths(func(conn *entities.ServiceConnection) time.Duration {
+		return latencies[conn.ID]
+	}, int(req.Limit))
+
+	resp := &auditv1.GetCriticalPathsResponse{
+		SnapshotId:          snapshot.SnapshotID,
+		EdgesWithoutLatency: unknown,
+		RequestId:           req.RequestId,
+	}
+	for _, path := range paths {
+		resp.Paths = append(resp.Paths, &auditv1.CriticalPath{
+			NodeIds:        path.NodeIDs,
+			EdgeIds:        path.ConnectionIDs,
+			TotalLatencyMs: float64(path.TotalLatency) / float64(time.Millisecond),
+		})
+	}
+	return resp, nil
+}
+
+// analyzedNode resolves the snapshot to analyze and checks the node is part of it
+func (s *TopologyServiceServer) analyzedNode(ctx context.Context, method, nodeID, snapshotID string, at *timestamppb.Timestamp) (*entities.NetworkTopology, error) {
+	if nodeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "node_id is required")
+	}
+	snapshot, err := s.topologyAt(ctx, snapshotID, at)
+	if err != nil {
+		return nil, s.trackerError(method, err)
+	}
+	if _, ok := snapshot.GetNode(nodeID); !ok {
+		return nil, s.trackerError(method, fmt.Errorf("%w: %s", domainservices.ErrNodeNotFound, nodeID))
+	}
+	return snapshot, nil
+}
+
+// edgeLatencies returns the latency of each connection in the snapshot and the sorted IDs of
+// connections without any; with live set, connections without recorded metadata are collected
+// live, in parallel and within liveLatencyTimeout
+func (s *TopologyServiceServer) edgeLatencies(ctx context.Context, snapshot *entities.NetworkTopology, useP99, live bool) (map[string]time.Duration, []string) {
+	latencyOf := func(metadata *entities.EdgeMetadata) time.Duration {
+		if useP99 {
+			return metadata.P99Latency
+		}
+		return metadata.AvgLatency
+	}
+
+	latencies := make(map[string]time.Duration, len(snapshot.Connections))
+	var missing []string
+	for id := range snapshot.Connections {
+		metadata, err := s.topologyService.MetadataRepository().GetEdgeMetadata(ctx, id)
+		if err == nil && metadata != nil && latencyOf(metadata) > 0 {
+			latencies[id] = latencyOf(metadata)
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	if live {
+		ctx, cancel := context.WithTimeout(ctx, liveLatencyTimeout)
+		defer cancel()
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, id := range missing {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				metadata, err := s.topologyService.MetricsCollector().CollectEdgeMetrics(ctx, id)
+				if err != nil || latencyOf(metadata) <= 0 {
+					return
+				}
+				mu.Lock()
+				latencies[id] = latencyOf(metadata)
+				mu.Unlock()
+			}(id)
+		}
+		wg.Wait()
+	}
+
+	unknown := make([]string, 0, len(missing))
+	for _, id := range missing {
+		if _, ok := latencies[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	return latencies, unknown
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditv1 "github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+)
+
+// GetTopologySnapshot returns the topology as of a past snapshot ID or time
+// With neither set it returns the current topology
+func (s *TopologyServiceServer) GetTopologySnapshot(ctx context.Context, req *auditv1.GetTopologySnapshotRequest) (*auditv1.TopologyStructureResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"request_id":  req.RequestId,
+		"snapshot_id": req.SnapshotId,
+	}).Debug("GetTopologySnapshot called")
+
+	snapshot, err := s.topologyAt(ctx, req.SnapshotId, req.AsOf)
+	if err != nil {
+		return nil, s.trackerError("GetTopologySnapshot", err)
+	}
+
+	return &auditv1.TopologyStructureResponse{
+		Nodes:        convertNodesToProto(sortedNodes(snapshot.Nodes)),
+		Edges:        convertEdgesToProto(sortedConnections(snapshot.Connections)),
+		SnapshotTime: timestamppb.New(snapshot.SnapshotTime),
+		SnapshotId:   snapshot.SnapshotID,
+		RequestId:    req.RequestId,
+	}, nil
+}
+
+// DiffTopologySnapshots compares two snapshots into added, removed and changed nodes and edges
+func (s *TopologyServiceServer) DiffTopologySnapshots(ctx context.Context, req *auditv1.DiffTopologySnapshotsRequest) (*auditv1.DiffTopologySnapshotsResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"request_id":       req.RequestId,
+		"from_snapshot_id": req.FromSnapshotId,
+		"to_snapshot_id":   req.ToSnapshotId,
+	}).Debug("DiffTopologySnapshots called")
+
+	from, err := s.topologyAt(ctx, req.FromSnapshotId, req.FromTime)
+	if err != nil {
+		return nil, s.trackerError("DiffTopologySnapshots", err)
+	}
+	to, err := s.topologyAt(ctx, req.ToSnapshotId, req.ToTime)
+	if err != nil {
+		return nil, s.trackerError("DiffTopologySnapshots", err)
+	}
+	diff := entities.DiffTopologies(from, to)
+
+	resp := &auditv1.DiffTopologySnapshotsResponse{
+		FromSnapshotId:   diff.FromSnapshotID,
+		FromSnapshotTime: timestamppb.New(diff.FromSnapshotTime),
+		ToSnapshotId:     diff.ToSnapshotID,
+		ToSnapshotTime:   timestamppb.New(diff.ToSnapshotTime),
+		AddedNodes:       convertNodesToProto(diff.AddedNodes),
+		RemovedNodes:     convertNodesToProto(diff.RemovedNodes),
+		AddedEdges:       convertEdgesToProto(diff.AddedConnections),
+		RemovedEdges:     convertEdgesToProto(diff.RemovedConnections),
+		RequestId:        req.RequestId,
+	}
+	for _, change := range diff.ChangedNodes {
+		nodes := convertNodesToProto([]*entities.ServiceNode{change.Before, change.After})
+		resp.ChangedNodes = append(resp.ChangedNodes, &auditv1.NodeDiff{Before: nodes[0], After: nodes[1]})
+	}
+	for _, change := range diff.ChangedConnections {
+		edges := convertEdgesToProto([]*entities.ServiceConnection{change.Before, change.After})
+		resp.ChangedEdges = append(resp.ChangedEdges, &auditv1.EdgeDiff{Before: edges[0], After: edges[1]})
+	}
+	return resp, nil
+}
+
+// topologyAt resolves a snapshot ID, or else a time, or else the current snapshot
+func (s *TopologyServiceServer) topologyAt(ctx context.Context, snapshotID string, at *timestamppb.Timestamp) (*entities.NetworkTopology, error) {
+	tracker := s.topologyService.Tracker()
+	switch {
+	case snapshotID != "":
+		return tracker.GetTopologyAt(ctx, snapshotID)
+	case at != nil:
+		return tracker.GetTopologyAsOf(ctx, at.AsTime())
+	default:
+		return tracker.GetTopologyAt(ctx, tracker.SnapshotID())
+	}
+}
+
+func sortedNodes(nodes map[string]*entities.ServiceNode) []*entities.ServiceNode {
+	result := make([]*entities.ServiceNode, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, node)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func sortedConnections(connections map[string]*entities.ServiceConnection) []*entities.ServiceConnection {
+	result := make([]*entities.ServiceConnection, 0, len(connections))
+	for _, conn := range connections {
+		result = append(result, conn)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
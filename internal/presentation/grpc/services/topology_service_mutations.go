@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditv1 "github.com/quantfidential/trading-ecosystem/audit-correlator-go/gen/go/audit/v1"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	domainservices "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
+)
+
+// RegisterNode adds a service node, or replaces the node with the same ID
+func (s *TopologyServiceServer) RegisterNode(ctx context.Context, req *auditv1.RegisterNodeRequest) (*auditv1.RegisterNodeResponse, error) {
+	node := req.GetNode()
+	if node.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "node.id is required")
+	}
+	nodeStatus := entities.NodeStatusLive
+	if node.Status != auditv1.NodeStatus_NODE_STATUS_UNSPECIFIED {
+		converted, err := convertProtoNodeStatus(node.Status)
+		if err != nil {
+			return nil, err
+		}
+		nodeStatus = converted
+	}
+
+	registered := entities.NewServiceNode(node.Id, node.Name, node.ServiceType, node.InstanceName)
+	registered.Status = nodeStatus
+	for key, value := range node.Labels {
+		registered.AddLabel(key, value)
+	}
+
+	tracker := s.topologyService.Tracker()
+	snapshotID, err := tracker.RegisterNode(ctx, registered)
+	if err != nil {
+		return nil, s.trackerError("RegisterNode", err)
+	}
+	current, err := tracker.GetNode(ctx, node.Id)
+	if err != nil {
+		return nil, s.trackerError("RegisterNode", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"request_id": req.RequestId,
+		"node_id":    node.Id,
+	}).Info("Topology node registered")
+	return &auditv1.RegisterNodeResponse{
+		Node:       convertNodesToProto([]*entities.ServiceNode{current})[0],
+		SnapshotId: snapshotID,
+		RequestId:  req.RequestId,
+	}, nil
+}
+
+// DeregisterNode removes a node and every edge to or from it
+func (s *TopologyServiceServer) DeregisterNode(ctx context.Context, req *auditv1.DeregisterNodeRequest) (*auditv1.DeregisterNodeResponse, error) {
+	if req.NodeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "node_id is required")
+	}
+
+	tracker := s.topologyService.Tracker()
+	snapshotID, err := tracker.DeregisterNode(ctx, req.NodeId)
+	if err != nil {
+		return nil, s.trackerError("DeregisterNode", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"request_id": req.RequestId,
+		"node_id":    req.NodeId,
+	}).Info("Topology node deregistered")
+	return &auditv1.DeregisterNodeResponse{
+		SnapshotId: snapshotID,
+		RequestId:  req.RequestId,
+	}, nil
+}
+
+// UpdateNodeStatus sets the status of a registered node
+func (s *TopologyServiceServer) UpdateNodeStatus(ctx context.Context, req *auditv1.UpdateNodeStatusRequest) (*auditv1.UpdateNodeStatusResponse, error) {
+	if req.NodeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "node_id is required")
+	}
+	nodeStatus, err := convertProtoNodeStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := s.topologyService.Tracker()
+	snapshotID, err := tracker.UpdateNodeStatus(ctx, req.NodeId, nodeStatus)
+	if err != nil {
+		return nil, s.trackerError("UpdateNodeStatus", err)
+	}
+	current, err := tracker.GetNode(ctx, req.NodeId)
+	if err != nil {
+		return nil, s.trackerError("UpdateNodeStatus", err)
+	}
+
+	return &auditv1.UpdateNodeStatusResponse{
+		Node:       convertNodesToProto([]*entities.ServiceNode{current})[0],
+		SnapshotId: snapshotID,
+		RequestId:  req.RequestId,
+	}, nil
+}
+
+// RegisterEdge adds a directed edge between two registered nodes, or replaces the edge with the same ID
+func (s *TopologyServiceServer) RegisterEdge(ctx context.Context, req *auditv1.RegisterEdgeRequest) (*auditv1.RegisterEdgeResponse, error) {
+	edge := req.GetEdge()
+	if edge.GetId() == "" || edge.GetSourceId() == "" || edge.GetTargetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "edge.id, edge.source_id and edge.target_id are required")
+	}
+	edgeStatus := entities.EdgeStatusActive
+	if edge.Status != auditv1.EdgeStatus_EDGE_STATUS_UNSPECIFIED {
+		converted, err := convertProtoEdgeStatus(edge.Status)
+		if err != nil {
+			return nil, err
+		}
+		edgeStatus = converted
+	}
+	connType, err := convertProtoConnectionType(edge.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	registered := entities.NewServiceConnection(edge.Id, edge.SourceId, edge.TargetId, connType)
+	registered.Status = edgeStatus
+	registered.IsCritical = edge.IsCritical
+
+	tracker := s.topologyService.Tracker()
+	snapshotID, err := tracker.RegisterConnection(ctx, registered)
+	if err != nil {
+		return nil, s.trackerError("RegisterEdge", err)
+	}
+	current, err := tracker.GetConnection(ctx, edge.Id)
+	if err != nil {
+		return nil, s.trackerError("RegisterEdge", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"request_id": req.RequestId,
+		"edge_id":    edge.Id,
+		"source_id":  edge.SourceId,
+		"target_id":  edge.TargetId,
+	}).Info("Topology edge registered")
+	return &auditv1.RegisterEdgeResponse{
+		Edge:       convertEdgesToProto([]*entities.ServiceConnection{current})[0],
+		SnapshotId: snapshotID,
+		RequestId:  req.RequestId,
+	}, nil
+}
+
+// DeregisterEdge removes an edge
+func (s *TopologyServiceServer) DeregisterEdge(ctx context.Context, req *auditv1.DeregisterEdgeRequest) (*auditv1.DeregisterEdgeResponse, error) {
+	if req.EdgeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "edge_id is required")
+	}
+
+	tracker := s.topologyService.Tracker()
+	snapshotID, err := tracker.DeregisterConnection(ctx, req.EdgeId)
+	if err != nil {
+		return nil, s.trackerError("DeregisterEdge", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"request_id": req.RequestId,
+		"edge_id":    req.EdgeId,
+	}).Info("Topology edge deregistered")
+	return &auditv1.DeregisterEdgeResponse{
+		SnapshotId: snapshotID,
+		RequestId:  req.RequestId,
+	}, nil
+}
+
+// UpdateEdgeStatus sets the status of a registered edge
+func (s *TopologyServiceServer) UpdateEdgeStatus(ctx context.Context, req *auditv1.UpdateEdgeStatusRequest) (*auditv1.UpdateEdgeStatusResponse, error) {
+	if req.EdgeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "edge_id is required")
+	}
+	edgeStatus, err := convertProtoEdgeStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := s.topologyService.Tracker()
+	snapshotID, err := tracker.UpdateConnectionStatus(ctx, req.EdgeId, edgeStatus)
+	if err != nil {
+		return nil, s.trackerError("UpdateEdgeStatus", err)
+	}
+	current, err := tracker.GetConnection(ctx, req.EdgeId)
+	if err != nil {
+		return nil, s.trackerError("UpdateEdgeStatus", err)
+	}
+
+	return &auditv1.UpdateEdgeStatusResponse{
+		Edge:       convertEdgesToProto([]*entities.ServiceConnection{current})[0],
+		SnapshotId: snapshotID,
+		RequestId:  req.RequestId,
+	}, nil
+}
+
+// trackerError maps topology tracker errors to gRPC status codes
+func (s *TopologyServiceServer) trackerError(method string, err error) error {
+	switch {
+	case errors.Is(err, domainservices.ErrNodeNotFound), errors.Is(err, domainservices.ErrConnectionNotFound),
+		errors.Is(err, domainservices.ErrSnapshotNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domainservices.ErrInvalidTopology), errors.Is(err, domainservices.ErrInvalidSnapshotID):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.logger.WithError(err).WithField("method", method).Error("Topology request failed")
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func convertProtoNodeStatus(nodeStatus auditv1.NodeStatus) (entities.NodeStatus, error) {
+	switch nodeStatus {
+	case auditv1.NodeStatus_NODE_STATUS_LIVE:
+		return entities.NodeStatusLive, nil
+	case auditv1.NodeStatus_NODE_STATUS_DEGRADED:
+		return entities.NodeStatusDegraded, nil
+	case auditv1.NodeStatus_NODE_STATUS_DEAD:
+		return entities.NodeStatusDead, nil
+	default:
+		return entities.NodeStatusUnspecified, status.Errorf(codes.InvalidArgument, "invalid node status %s", nodeStatus)
+	}
+}
+
+func convertProtoEdgeStatus(edgeStatus auditv1.EdgeStatus) (entities.EdgeStatus, error) {
+	switch edgeStatus {
+	case auditv1.EdgeStatus_EDGE_STATUS_ACTIVE:
+		return entities.EdgeStatusActive, nil
+	case auditv1.EdgeStatus_EDGE_STATUS_DEGRADED:
+		return entities.EdgeStatusDegraded, nil
+	case auditv1.EdgeStatus_EDGE_STATUS_FAILED:
+		return entities.EdgeStatusFailed, nil
+	default:
+		return entities.EdgeStatusUnspecified, status.Errorf(codes.InvalidArgument, "invalid edge status %s", edgeStatus)
+	}
+}
+
+// convertProtoConnectionType maps an unspecified type to gRPC, as the topology config loader does
+func convertProtoConnectionType(connType auditv1.ConnectionType) (entities.ConnectionType, error) {
+	switch connType {
+	case auditv1.ConnectionType_CONNECTION_TYPE_UNSPECIFIED, auditv1.ConnectionType_CONNECTION_TYPE_GRPC:
+		return entities.ConnectionTypeGRPC, nil
+	case auditv1.ConnectionType_CONNECTION_TYPE_HTTP:
+		return entities.ConnectionTypeHTTP, nil
+	case auditv1.ConnectionType_CONNECTION_TYPE_DATA_FLOW:
+		return entities.ConnectionTypeDataFlow, nil
+	default:
+		return entities.ConnectionTypeUnspecified, status.Errorf(codes.InvalidArgument, "invalid connection type %s", connType)
+	}
+}
@@ -292,7 +292,7 @@ func convertEdgesToProto(edges []*entities.ServiceConnection) []*auditv1.EdgeSum
 			TargetId:   edge.TargetID,
 			Type:       convertConnectionTypeToProto(edge.Type),
 			Status:     convertEdgeStatusToProto(edge.Status),
-			IsCritical: false, // TODO: Add this field to domain entity
+			IsCritical: edge.IsCritical,
 		})
 	}
 	return result
@@ -374,41 +374,75 @@ func convertTopologyChangeToProto(event *ports.TopologyChangeEvent) *auditv1.Top
 	}
 
 	switch event.ChangeType {
-	case ports.TopologyChangeTypeNodeAdded:
-		if event.Node != nil {
-			change.Change = &auditv1.TopologyChange_NodeAdded{
-				NodeAdded: &auditv1.NodeAdded{
-					Node: &auditv1.NodeSummary{
-						Id:           event.Node.ID,
-						Name:         event.Node.Name,
-						ServiceType:  event.Node.ServiceType,
-						InstanceName: event.Node.InstanceName,
-						Status:       convertNodeStatusToProto(event.Node.Status),
-						Labels:       event.Node.Labels,
-					},
+	case ports.TopologyChangeTypeNodeAdded, ports.TopologyChangeTypeNodeUpdated:
+		if event.Node == nil {
+			break
+		}
+		// Status changes have their own message; other updates resend the node for clients to upsert
+		if event.ChangeType == ports.TopologyChangeTypeNodeUpdated && event.PreviousNodeStatus != event.Node.Status {
+			change.Change = &auditv1.TopologyChange_NodeStatusChanged{
+				NodeStatusChanged: &auditv1.NodeStatusChanged{
+					NodeId:    event.Node.ID,
+					OldStatus: convertNodeStatusToProto(event.PreviousNodeStatus),
+					NewStatus: convertNodeStatusToProto(event.Node.Status),
+					Reason:    event.Reason,
 				},
 			}
+			break
+		}
+		change.Change = &auditv1.TopologyChange_NodeAdded{
+			NodeAdded: &auditv1.NodeAdded{
+				Node: &auditv1.NodeSummary{
+					Id:           event.Node.ID,
+					Name:         event.Node.Name,
+					ServiceType:  event.Node.ServiceType,
+					InstanceName: event.Node.InstanceName,
+					Status:       convertNodeStatusToProto(event.Node.Status),
+					Labels:       event.Node.Labels,
+				},
+			},
 		}
 	case ports.TopologyChangeTypeNodeRemoved:
 		if event.Node != nil {
 			change.Change = &auditv1.TopologyChange_NodeRemoved{
 				NodeRemoved: &auditv1.NodeRemoved{
 					NodeId: event.Node.ID,
-					Reason: "removed",
+					Reason: changeReason(event, "removed"),
 				},
 			}
 		}
-	case ports.TopologyChangeTypeEdgeAdded:
+	case ports.TopologyChangeTypeEdgeAdded, ports.TopologyChangeTypeEdgeUpdated:
+		if event.Connection == nil {
+			break
+		}
+		if event.ChangeType == ports.TopologyChangeTypeEdgeUpdated && event.PreviousEdgeStatus != event.Connection.Status {
+			change.Change = &auditv1.TopologyChange_EdgeStatusChanged{
+				EdgeStatusChanged: &auditv1.EdgeStatusChanged{
+					EdgeId:    event.Connection.ID,
+					OldStatus: convertEdgeStatusToProto(event.PreviousEdgeStatus),
+					NewStatus: convertEdgeStatusToProto(event.Connection.Status),
+					Reason:    event.Reason,
+				},
+			}
+			break
+		}
+		change.Change = &auditv1.TopologyChange_EdgeAdded{
+			EdgeAdded: &auditv1.EdgeAdded{
+				Edge: &auditv1.EdgeSummary{
+					Id:       event.Connection.ID,
+					SourceId: event.Connection.SourceID,
+					TargetId: event.Connection.TargetID,
+					Type:     convertConnectionTypeToProto(event.Connection.Type),
+					Status:   convertEdgeStatusToProto(event.Connection.Status),
+				},
+			},
+		}
+	case ports.TopologyChangeTypeEdgeRemoved:
 		if event.Connection != nil {
-			change.Change = &auditv1.TopologyChange_EdgeAdded{
-				EdgeAdded: &auditv1.EdgeAdded{
-					Edge: &auditv1.EdgeSummary{
-						Id:       event.Connection.ID,
-						SourceId: event.Connection.SourceID,
-						TargetId: event.Connection.TargetID,
-						Type:     convertConnectionTypeToProto(event.Connection.Type),
-						Status:   convertEdgeStatusToProto(event.Connection.Status),
-					},
+			change.Change = &auditv1.TopologyChange_EdgeRemoved{
+				EdgeRemoved: &auditv1.EdgeRemoved{
+					EdgeId: event.Connection.ID,
+					Reason: changeReason(event, "removed"),
 				},
 			}
 		}
@@ -417,6 +451,13 @@ func convertTopologyChangeToProto(event *ports.TopologyChangeEvent) *auditv1.Top
 	return change
 }
 
+func changeReason(event *ports.TopologyChangeEvent, fallback string) string {
+	if event.Reason != "" {
+		return event.Reason
+	}
+	return fallback
+}
+
 func convertMetricsUpdateToProto(update *ports.MetricsUpdate) *auditv1.MetricsUpdate {
 	result := &auditv1.MetricsUpdate{
 		Timestamp: timestamppb.New(update.Timestamp),
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxAccessRecordsInMemory bounds the recent records kept for queries; the file keeps all
+	maxAccessRecordsInMemory = 100000
+	defaultAccessQueryLimit  = 100
+	maxAccessQueryLimit      = 1000
+)
+
+// Access record phases: an intent is written before audit data is served, and a
+// completion once the response is known
+const (
+	AccessPhaseIntent    = "intent"
+	AccessPhaseCompleted = "completed"
+)
+
+// ErrAccessLogUnavailable marks a read of audit data that could not be recorded; such reads
+// must be refused rather than served unrecorded
+var ErrAccessLogUnavailable = errors.New("access log unavailable")
+
+// CallerIdentity identifies who read audit data
+type CallerIdentity struct {
+	ID                    string `json:"id,omitempty"`
+	Client                string `json:"client,omitempty"`
+	ClientVersion         string `json:"client_version,omitempty"`
+	RemoteAddr            string `json:"remote_addr"`
+	UserAgent             string `json:"user_agent,omitempty"`
+	CredentialFingerprint string `json:"credential_fingerprint,omitempty"` // never the credential itself
+}
+
+// AccessRecord is one read of audit data
+// Records are chained by hash so any edit or removal in the log file is detectable
+type AccessRecord struct {
+	Sequence    int64             `json:"sequence"`
+	Phase       string            `json:"phase"`
+	Intent      int64             `json:"intent,omitempty"` // sequence of the intent a completion closes
+	Timestamp   time.Time         `json:"timestamp"`
+	Caller      CallerIdentity    `json:"caller"`
+	Resource    string            `json:"resource"` // search, trace, export, report, archive, tail, lifecycle, saved_query, correlation, business_key or order
+	Method      string            `json:"method"`
+	Route       string            `json:"route"`
+	Path        string            `json:"path"`
+	Query       map[string]string `json:"query,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	Status      int               `json:"status"`
+	ResultCount int               `json:"result_count"`
+	DurationMs  float64           `json:"duration_ms"`
+	PrevHash    string            `json:"prev_hash"`
+	Hash        string            `json:"hash"`
+}
+
+// CredentialFingerprint identifies a credential in access records without revealing it
+func CredentialFingerprint(credential string) string {
+	sum := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(sum[:8])
+}
+
+// computeHash hashes the record with its Hash field cleared, keyed with HMAC when key is set
+// so the chain cannot be recomputed by someone able to edit the file
+func (r AccessRecord) computeHash(key []byte) (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	if len(key) > 0 {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(data)
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// accessLogHead is the chain head anchored outside the log file, so removing records from
+// the end of the file is detectable even though the remaining chain is intact
+type accessLogHead struct {
+	Sequence int64  `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+// AccessLogQuery filters access records; empty fields match any
+type AccessLogQuery struct {
+	CallerID  string
+	Resource  string
+	StartTime *time.Time
+	EndTime   *time.Time
+	Limit     int
+}
+
+// AccessLogVerification reports whether the hash chain in the log file is intact
+type AccessLogVerification struct {
+	Valid      bool   `json:"valid"`
+	Records    int64  `json:"records"`
+	BrokenAt   int64  `json:"broken_at,omitempty"` // sequence of the first record failing verification
+	Reason     string `json:"reason,omitempty"`
+	HeadHash   string `json:"head_hash"`
+	VerifiedAt string `json:"verified_at"`
+}
+
+// AccessLog is an append-only, hash-chained log of reads of audit data
+// It is kept apart from the audit event store so reading it never produces audit events,
+// and it records nothing about reads of itself
+type AccessLog struct {
+	path   string
+	key    []byte
+	logger *logrus.Logger
+
+	mu       sync.RWMutex
+	file     *os.File
+	sequence int64
+	headHash string
+	recent   []AccessRecord
+}
+
+// NewAccessLog creates an access log appending to the file at path
+// A non-empty key chains records with HMAC-SHA256 instead of plain SHA-256
+func NewAccessLog(path string, key []byte, logger *logrus.Logger) *AccessLog {
+	return &AccessLog{
+		path:   path,
+		key:    key,
+		logger: logger,
+	}
+}
+
+// headPath is where the chain head is anchored
+func (l *AccessLog) headPath() string {
+	return l.path + ".head"
+}
+
+// Open loads existing records, verifying the chain, and opens the file for appending
+// A broken chain is reported but does not stop new records being appended after it
+func (l *AccessLog) Open() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create access log directory: %w", err)
+	}
+
+	verification, err := l.scan(func(record AccessRecord) {
+		l.recent = append(l.recent, record)
+		if len(l.recent) > maxAccessRecordsInMemory {
+			l.recent = l.recent[len(l.recent)-maxAccessRecordsInMemory:]
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !verification.Valid {
+		l.logger.WithFields(logrus.Fields{
+			"broken_at": verification.BrokenAt,
+			"reason":    verification.Reason,
+		}).Error("Access log hash chain is broken")
+	}
+
+	head, err := l.readHead()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open access log: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.file = file
+	if len(l.recent) > 0 {
+		last := l.recent[len(l.recent)-1]
+		l.sequence = last.Sequence
+		l.headHash = last.Hash
+	}
+	// Records cut from the end of the file are not silently re-used: the chain continues
+	// from the anchored head, leaving the gap visible to Verify
+	if head != nil && head.Sequence > l.sequence {
+		l.sequence = head.Sequence
+		l.headHash = head.Hash
+	}
+
+	l.logger.WithFields(logrus.Fields{
+		"path":    l.path,
+		"records": l.sequence,
+	}).Info("Opened access log")
+	return nil
+}
+
+// Close closes the log file
+func (l *AccessLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Record chains and durably appends a record, filling in Sequence, PrevHash and Hash
+// Every failure wraps ErrAccessLogUnavailable
+func (l *AccessLog) Record(record AccessRecord) (*AccessRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil, fmt.Errorf("%w: not open", ErrAccessLogUnavailable)
+	}
+
+	record.Sequence = l.sequence + 1
+	record.PrevHash = l.headHash
+	hash, err := record.computeHash(l.key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to hash access record: %v", ErrAccessLogUnavailable, err)
+	}
+	record.Hash = hash
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode access record: %v", ErrAccessLogUnavailable, err)
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("%w: failed to append access record: %v", ErrAccessLogUnavailable, err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return nil, fmt.Errorf("%w: failed to sync access log: %v", ErrAccessLogUnavailable, err)
+	}
+	if err := l.writeHead(accessLogHead{Sequence: record.Sequence, Hash: record.Hash}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAccessLogUnavailable, err)
+	}
+
+	l.sequence = record.Sequence
+	l.headHash = record.Hash
+	l.recent = append(l.recent, record)
+	if len(l.recent) > maxAccessRecordsInMemory {
+		l.recent = l.recent[len(l.recent)-maxAccessRecordsInMemory:]
+	}
+	return &record, nil
+}
+
+// writeHead atomically replaces the anchored chain head
+func (l *AccessLog) writeHead(head accessLogHead) error {
+	data, err := json.Marshal(head)
+	if err != nil {
+		return fmt.Errorf("failed to encode access log head: %w", err)
+	}
+	tmp := l.headPath() + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write access log head: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write access log head: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync access log head: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write access log head: %w", err)
+	}
+	if err := os.Rename(tmp, l.headPath()); err != nil {
+		return fmt.Errorf("failed to replace access log head: %w", err)
+	}
+	return nil
+}
+
+// readHead returns the anchored chain head, or nil when none has been written
+func (l *AccessLog) readHead() (*accessLogHead, error) {
+	data, err := os.ReadFile(l.headPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read access log head: %w", err)
+	}
+	var head accessLogHead
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("failed to decode access log head: %w", err)
+	}
+	return &head, nil
+}
+
+// Query returns matching records among the most recent ones, newest first
+func (l *AccessLog) Query(query AccessLogQuery) ([]AccessRecord, error) {
+	limit := query.Limit
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidSearch)
+	}
+	if limit == 0 {
+		limit = defaultAccessQueryLimit
+	}
+	limit = min(limit, maxAccessQueryLimit)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	records := make([]AccessRecord, 0, min(limit, len(l.recent)))
+	for i := len(l.recent) - 1; i >= 0 && len(records) < limit; i-- {
+		record := l.recent[i]
+		switch {
+		case query.CallerID != "" && record.Caller.ID != query.CallerID,
+			query.Resource != "" && record.Resource != query.Resource,
+			query.StartTime != nil && record.Timestamp.Before(*query.StartTime),
+			query.EndTime != nil && record.Timestamp.After(*query.EndTime):
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Verify re-reads the log file and checks every record's hash and chain link
+func (l *AccessLog) Verify() (*AccessLogVerification, error) {
+	// Holding the read lock keeps appends out while the file is scanned
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.scan(nil)
+}
+
+// scan reads the log file, verifying the chain and handing each record to fn
+// The file must also reach the anchored head, so records cut from its end are detected
+func (l *AccessLog) scan(fn func(record AccessRecord)) (*AccessLogVerification, error) {
+	verification := &AccessLogVerification{Valid: true, VerifiedAt: time.Now().UTC().Format(time.RFC3339)}
+
+	head, err := l.readHead()
+	if err != nil {
+		return nil, err
+	}
+	checkHead := func() {
+		if head != nil && verification.Records < head.Sequence {
+			verification.fail(verification.Records+1, "log ends before its anchored head")
+		}
+	}
+
+	file, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			checkHead()
+			return verification, nil
+		}
+		return nil, fmt.Errorf("failed to read access log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var record AccessRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			verification.fail(verification.Records+1, "record is not valid JSON")
+			continue
+		}
+		verification.Records++
+		if verification.Valid {
+			hash, err := record.computeHash(l.key)
+			switch {
+			case err != nil || hash != record.Hash:
+				verification.fail(record.Sequence, "record hash does not match its contents")
+			case record.PrevHash != verification.HeadHash:
+				verification.fail(record.Sequence, "record does not chain to the previous record")
+			case record.Sequence != verification.Records:
+				verification.fail(record.Sequence, "sequence gap")
+			case head != nil && record.Sequence == head.Sequence && record.Hash != head.Hash:
+				verification.fail(record.Sequence, "record does not match the anchored head")
+			}
+		}
+		verification.HeadHash = record.Hash
+		if fn != nil {
+			fn(record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read access log: %w", err)
+	}
+	checkHead()
+	return verification, nil
+}
+
+func (v *AccessLogVerification) fail(sequence int64, reason string) {
+	if v.Valid {
+		v.Valid = false
+		v.BrokenAt = sequence
+		v.Reason = reason
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestAccessLog(t *testing.T, path string) *AccessLog {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+	accessLog := NewAccessLog(path, nil, logger)
+	if err := accessLog.Open(); err != nil {
+		t.Fatalf("Failed to open access log: %v", err)
+	}
+	t.Cleanup(func() { accessLog.Close() })
+	return accessLog
+}
+
+func TestAccessLog_RecordQueryAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access", "access.log")
+	accessLog := newTestAccessLog(t, path)
+
+	base := time.Now().UTC()
+	for i, record := range []AccessRecord{
+		{Caller: CallerIdentity{ID: "alice"}, Resource: "search", ResultCount: 3},
+		{Caller: CallerIdentity{ID: "bob"}, Resource: "export", ResultCount: 100},
+		{Caller: CallerIdentity{ID: "alice"}, Resource: "trace", ResultCount: 1},
+	} {
+		record.Timestamp = base.Add(time.Duration(i) * time.Second)
+		if _, err := accessLog.Record(record); err != nil {
+			t.Fatalf("Failed to record access: %v", err)
+		}
+	}
+
+	records, err := accessLog.Query(AccessLogQuery{CallerID: "alice"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 2 || records[0].Resource != "trace" || records[1].Resource != "search" {
+		t.Fatalf("Expected alice's records newest first, got %+v", records)
+	}
+	start := base.Add(time.Second)
+	if records, _ := accessLog.Query(AccessLogQuery{StartTime: &start, Resource: "export"}); len(records) != 1 || records[0].ResultCount != 100 {
+		t.Errorf("Expected the export record, got %+v", records)
+	}
+
+	accessLog.Close()
+	reopened := newTestAccessLog(t, path)
+	record, err := reopened.Record(AccessRecord{Caller: CallerIdentity{ID: "carol"}, Resource: "report"})
+	if err != nil {
+		t.Fatalf("Failed to record after reopen: %v", err)
+	}
+	if record.Sequence != 4 || record.PrevHash != records[0].Hash {
+		t.Errorf("Expected the chain to continue after reopen, got sequence %d", record.Sequence)
+	}
+
+	verification, err := reopened.Verify()
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !verification.Valid || verification.Records != 4 || verification.HeadHash != record.Hash {
+		t.Errorf("Expected an intact chain of 4 records, got %+v", verification)
+	}
+}
+
+func TestAccessLog_VerifyDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	accessLog := newTestAccessLog(t, path)
+	for _, caller := range []string{"alice", "bob", "carol"} {
+		if _, err := accessLog.Record(AccessRecord{Caller: CallerIdentity{ID: caller}, Resource: "search"}); err != nil {
+			t.Fatalf("Failed to record access: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+
+	// Editing a record breaks its hash
+	edited := bytes.Replace(data, []byte(`"id":"bob"`), []byte(`"id":"eve"`), 1)
+	if err := os.WriteFile(path, edited, 0o600); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+	if verification, _ := accessLog.Verify(); verification.Valid || verification.BrokenAt != 2 {
+		t.Errorf("Expected the edit to be detected at record 2, got %+v", verification)
+	}
+
+	// Removing a record breaks the chain
+	removed := append(append(append([]byte{}, lines[0]...), '\n'), append(lines[2], '\n')...)
+	if err := os.WriteFile(path, removed, 0o600); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+	if verification, _ := accessLog.Verify(); verification.Valid || verification.BrokenAt != 3 {
+		t.Errorf("Expected the removal to be detected at record 3, got %+v", verification)
+	}
+}
+
+func TestAccessLog_DetectsTruncationAndRequiresTheKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	// A log that is not open refuses records instead of dropping them
+	if _, err := NewAccessLog(path, []byte("k1"), logger).Record(AccessRecord{Resource: "search"}); !errors.Is(err, ErrAccessLogUnavailable) {
+		t.Fatalf("Expected ErrAccessLogUnavailable before Open, got %v", err)
+	}
+
+	accessLog := NewAccessLog(path, []byte("k1"), logger)
+	if err := accessLog.Open(); err != nil {
+		t.Fatalf("Failed to open access log: %v", err)
+	}
+	for _, caller := range []string{"alice", "bob", "carol"} {
+		if _, err := accessLog.Record(AccessRecord{Caller: CallerIdentity{ID: caller}, Resource: "search"}); err != nil {
+			t.Fatalf("Failed to record access: %v", err)
+		}
+	}
+	if verification, _ := accessLog.Verify(); !verification.Valid {
+		t.Fatalf("Expected a keyed chain to verify with its key, got %+v", verification)
+	}
+
+	// A chain read with another key fails, so it cannot be rebuilt without the key
+	if verification, _ := NewAccessLog(path, []byte("k2"), logger).Verify(); verification.Valid || verification.BrokenAt != 1 {
+		t.Errorf("Expected a wrong key to fail at record 1, got %+v", verification)
+	}
+
+	// Cutting the last record leaves an intact chain that no longer reaches the anchored head
+	accessLog.Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+	lines := bytes.SplitAfter(bytes.TrimSpace(data), []byte("\n"))
+	if err := os.WriteFile(path, bytes.Join(lines[:2], nil), 0o600); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+	if verification, _ := accessLog.Verify(); verification.Valid || verification.BrokenAt != 3 {
+		t.Errorf("Expected truncation to be detected at record 3, got %+v", verification)
+	}
+
+	// Reopening continues after the anchored head rather than re-using the cut sequence
+	reopened := NewAccessLog(path, []byte("k1"), logger)
+	if err := reopened.Open(); err != nil {
+		t.Fatalf("Failed to reopen access log: %v", err)
+	}
+	defer reopened.Close()
+	record, err := reopened.Record(AccessRecord{Caller: CallerIdentity{ID: "mallory"}, Resource: "search"})
+	if err != nil {
+		t.Fatalf("Failed to record after reopen: %v", err)
+	}
+	if record.Sequence != 4 {
+		t.Errorf("Expected sequence 4 after the anchored head, got %d", record.Sequence)
+	}
+	if verification, _ := reopened.Verify(); verification.Valid || verification.BrokenAt != 4 {
+		t.Errorf("Expected record 4 to chain to the missing record 3, got %+v", verification)
+	}
+}
@@ -0,0 +1,472 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+const (
+	defaultAggregationInterval = time.Minute
+	minAggregationInterval     = time.Second
+	// maxAggregationBuckets bounds the buckets per series (window / interval)
+	maxAggregationBuckets = 1440
+	// maxAggregationSeries bounds the distinct group combinations returned
+	maxAggregationSeries = 500
+	// maxFieldSamples bounds the field values kept per bucket and series for percentiles;
+	// beyond it percentiles come from a uniform sample while count, min, max and sum stay exact
+	maxFieldSamples = 10000
+)
+
+// defaultAggregationPercentiles are reported for the value field when none are requested
+var defaultAggregationPercentiles = []float64{50, 90, 95, 99}
+
+// AggregationRequest describes a time-bucketed aggregation
+// GroupBy accepts service_name (service), event_type (type), status, tag,
+// key.<business key> and metadata.<path>; Field is a numeric metadata path for percentiles
+type AggregationRequest struct {
+	Filter      string     `json:"filter"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	Interval    string     `json:"interval"`
+	GroupBy     []string   `json:"group_by"`
+	Field       string     `json:"field"`
+	Percentiles []float64  `json:"percentiles"`
+}
+
+// FieldStats summarises the numeric values of the aggregated field
+type FieldStats struct {
+	Count       int                `json:"count"`
+	Min         float64            `json:"min"`
+	Max         float64            `json:"max"`
+	Avg         float64            `json:"avg"`
+	Sum         float64            `json:"sum"`
+	Percentiles map[string]float64 `json:"percentiles"`
+	Sampled     bool               `json:"sampled,omitempty"` // percentiles come from a sample of maxFieldSamples values
+}
+
+// AggregationBucket holds one interval of a series
+type AggregationBucket struct {
+	Start      time.Time   `json:"start"`
+	Count      int         `json:"count"`
+	RatePerSec float64     `json:"rate_per_sec"`
+	Field      *FieldStats `json:"field,omitempty"`
+}
+
+// AggregationSeries is the histogram of one group combination
+type AggregationSeries struct {
+	Group   map[string]string   `json:"group"`
+	Count   int                 `json:"count"`
+	Field   *FieldStats         `json:"field,omitempty"`
+	Buckets []AggregationBucket `json:"buckets"`
+}
+
+// AggregationResult is the response of an aggregation
+type AggregationResult struct {
+	WindowStart time.Time           `json:"window_start"`
+	WindowEnd   time.Time           `json:"window_end"`
+	IntervalMs  int64               `json:"interval_ms"`
+	GroupBy     []string            `json:"group_by"`
+	Field       string              `json:"field,omitempty"`
+	TotalEvents int                 `json:"total_events"`
+	Scanned     int                 `json:"scanned"`
+	Series      []AggregationSeries `json:"series"`
+}
+
+// AggregationService computes time-bucketed counts, rates and percentiles over audit events
+type AggregationService struct {
+	auditService *AuditService
+	businessKeys *BusinessKeyService
+	logger       *logrus.Logger
+}
+
+// NewAggregationService creates a new aggregation service; businessKeys may be nil
+func NewAggregationService(auditService *AuditService, businessKeys *BusinessKeyService, logger *logrus.Logger) *AggregationService {
+	return &AggregationService{
+		auditService: auditService,
+		businessKeys: businessKeys,
+		logger:       logger,
+	}
+}
+
+// aggregationDimension extracts the values of one group-by dimension from an event
+type aggregationDimension struct {
+	name    string
+	extract func(target *aggregationTarget) []string
+}
+
+// aggregationTarget decodes metadata and business keys at most once per event
+type aggregationTarget struct {
+	event       *models.AuditEvent
+	metadata    map[string]interface{}
+	keys        map[string][]string
+	extractKeys BusinessKeyFunc
+}
+
+func (t *aggregationTarget) businessKeys() map[string][]string {
+	if t.keys == nil && t.extractKeys != nil {
+		t.keys = t.extractKeys(t.event)
+	}
+	return t.keys
+}
+
+// Aggregate buckets matching events in the request window
+// Events are streamed from storage and accumulated page by page, so any window is read in full
+func (s *AggregationService) Aggregate(req AggregationRequest) (*AggregationResult, error) {
+	filter, err := CompileEventFilter(req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+
+	interval := defaultAggregationInterval
+	if req.Interval != "" {
+		interval, err = time.ParseDuration(req.Interval)
+		if err != nil || interval < minAggregationInterval {
+			return nil, fmt.Errorf("%w: interval must be a duration of at least %s", ErrInvalidSearch, minAggregationInterval)
+		}
+	}
+
+	end := time.Now()
+	if req.EndTime != nil {
+		end = *req.EndTime
+	}
+	start := end.Add(-time.Hour)
+	if req.StartTime != nil {
+		start = *req.StartTime
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("%w: window start must be before end", ErrInvalidSearch)
+	}
+	start = start.Truncate(interval)
+	bucketCount := int((end.Sub(start) + interval - 1) / interval)
+	if bucketCount > maxAggregationBuckets {
+		return nil, fmt.Errorf("%w: window spans %d buckets, the limit is %d", ErrInvalidSearch, bucketCount, maxAggregationBuckets)
+	}
+
+	dimensions, err := s.dimensions(req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var field *KeyExtractor
+	if req.Field != "" {
+		field = &KeyExtractor{Key: req.Field, Path: "$." + strings.TrimPrefix(req.Field, "metadata.")}
+		if err := field.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: invalid field: %v", ErrInvalidSearch, err)
+		}
+	}
+	percentiles := req.Percentiles
+	if len(percentiles) == 0 {
+		percentiles = defaultAggregationPercentiles
+	}
+	for _, p := range percentiles {
+		if p <= 0 || p > 100 {
+			return nil, fmt.Errorf("%w: percentiles must be in (0, 100]", ErrInvalidSearch)
+		}
+	}
+
+	var extractKeys BusinessKeyFunc
+	if s.businessKeys != nil {
+		extractKeys = s.businessKeys.ExtractKeys
+	}
+	aggregator := newEventAggregator(filter, dimensions, field, extractKeys,
+		s.auditService.ClockSkewEstimator().Offsets(), start, end, interval)
+
+	pushdown, _, _ := filter.pushdown()
+	scanned := 0
+	err = s.auditService.WalkEventsInWindow(context.Background(), pushdown, start, end, func(page []*models.AuditEvent) error {
+		scanned += len(page)
+		for _, event := range page {
+			aggregator.add(event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := aggregator.result(percentiles)
+	result.GroupBy = req.GroupBy
+	if result.GroupBy == nil {
+		result.GroupBy = []string{}
+	}
+	result.Field = req.Field
+	result.Scanned = scanned
+
+	s.logger.WithFields(logrus.Fields{
+		"group_by": req.GroupBy,
+		"interval": interval,
+		"events":   result.TotalEvents,
+		"series":   len(result.Series),
+	}).Debug("Event aggregation completed")
+
+	return result, nil
+}
+
+// dimensions resolves group-by names into extractors
+func (s *AggregationService) dimensions(groupBy []string) ([]aggregationDimension, error) {
+	dimensions := make([]aggregationDimension, 0, len(groupBy))
+	for _, name := range groupBy {
+		dimension := aggregationDimension{name: name}
+		switch {
+		case name == "service" || name == "service_name":
+			dimension.extract = func(t *aggregationTarget) []string { return []string{t.event.ServiceName} }
+		case name == "type" || name == "event_type":
+			dimension.extract = func(t *aggregationTarget) []string { return []string{t.event.EventType} }
+		case name == "status":
+			dimension.extract = func(t *aggregationTarget) []string { return []string{string(t.event.Status)} }
+		case name == "tag" || name == "tags":
+			dimension.extract = func(t *aggregationTarget) []string { return t.event.Tags }
+		case strings.HasPrefix(name, "key."):
+			if s.businessKeys == nil {
+				return nil, fmt.Errorf("%w: business keys are not configured", ErrInvalidSearch)
+			}
+			key := strings.TrimPrefix(name, "key.")
+			dimension.extract = func(t *aggregationTarget) []string { return t.businessKeys()[key] }
+		case strings.HasPrefix(name, "metadata."):
+			extractor := &KeyExtractor{Key: name, Path: "$." + strings.TrimPrefix(name, "metadata.")}
+			if err := extractor.Validate(); err != nil {
+				return nil, fmt.Errorf("%w: invalid group_by %q: %v", ErrInvalidSearch, name, err)
+			}
+			dimension.extract = func(t *aggregationTarget) []string { return extractor.Extract(t.metadata) }
+		default:
+			return nil, fmt.Errorf("%w: cannot group by %q", ErrInvalidSearch, name)
+		}
+		dimensions = append(dimensions, dimension)
+	}
+	return dimensions, nil
+}
+
+// aggregationSeriesState accumulates one group combination
+type aggregationSeriesState struct {
+	group   map[string]string
+	counts  []int
+	values  []fieldAccumulator
+	overall fieldAccumulator
+	total   int
+}
+
+// eventAggregator buckets events on the correlator clock and groups them by dimension values
+// Events missing a dimension are grouped under an empty value
+type eventAggregator struct {
+	filter      *EventFilter
+	dimensions  []aggregationDimension
+	field       *KeyExtractor
+	extractKeys BusinessKeyFunc
+	offsets     map[string]ClockOffset
+	start       time.Time
+	end         time.Time
+	interval    time.Duration
+	bucketCount int
+	series      map[string]*aggregationSeriesState
+	total       int
+}
+
+func newEventAggregator(filter *EventFilter, dimensions []aggregationDimension, field *KeyExtractor,
+	extractKeys BusinessKeyFunc, offsets map[string]ClockOffset, start, end time.Time, interval time.Duration) *eventAggregator {
+	return &eventAggregator{
+		filter:      filter,
+		dimensions:  dimensions,
+		field:       field,
+		extractKeys: extractKeys,
+		offsets:     offsets,
+		start:       start,
+		end:         end,
+		interval:    interval,
+		bucketCount: int((end.Sub(start) + interval - 1) / interval),
+		series:      make(map[string]*aggregationSeriesState),
+	}
+}
+
+// add accumulates one event
+func (a *eventAggregator) add(event *models.AuditEvent) {
+	if !a.filter.Matches(event) {
+		return
+	}
+	ts := NormalizeTimestamp(event, a.offsets)
+	if ts.Before(a.start) || ts.After(a.end) {
+		return
+	}
+	index := min(int(ts.Sub(a.start)/a.interval), a.bucketCount-1)
+	a.total++
+
+	target := &aggregationTarget{event: event, metadata: decodeMetadata(event), extractKeys: a.extractKeys}
+	value, hasValue := 0.0, false
+	if a.field != nil {
+		for _, raw := range a.field.Extract(target.metadata) {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				value, hasValue = parsed, true
+				break
+			}
+		}
+	}
+
+	for _, group := range groupCombinations(target, a.dimensions) {
+		key := groupKey(group, a.dimensions)
+		state, exists := a.series[key]
+		if !exists {
+			if len(a.series) >= maxAggregationSeries {
+				continue
+			}
+			state = &aggregationSeriesState{group: group, counts: make([]int, a.bucketCount), values: make([]fieldAccumulator, a.bucketCount)}
+			a.series[key] = state
+		}
+		state.counts[index]++
+		state.total++
+		if hasValue {
+			state.values[index].add(value)
+			state.overall.add(value)
+		}
+	}
+}
+
+// result builds the series accumulated so far, largest first
+func (a *eventAggregator) result(percentiles []float64) *AggregationResult {
+	result := &AggregationResult{
+		WindowStart: a.start,
+		WindowEnd:   a.end,
+		IntervalMs:  a.interval.Milliseconds(),
+		TotalEvents: a.total,
+		Series:      []AggregationSeries{},
+	}
+
+	seconds := a.interval.Seconds()
+	for _, state := range a.series {
+		out := AggregationSeries{Group: state.group, Count: state.total, Buckets: make([]AggregationBucket, 0, a.bucketCount)}
+		if a.field != nil {
+			out.Field = state.overall.stats(percentiles)
+		}
+		for i := 0; i < a.bucketCount; i++ {
+			bucket := AggregationBucket{
+				Start:      a.start.Add(time.Duration(i) * a.interval),
+				Count:      state.counts[i],
+				RatePerSec: float64(state.counts[i]) / seconds,
+			}
+			if a.field != nil && state.values[i].count > 0 {
+				bucket.Field = state.values[i].stats(percentiles)
+			}
+			out.Buckets = append(out.Buckets, bucket)
+		}
+		result.Series = append(result.Series, out)
+	}
+
+	sort.Slice(result.Series, func(i, j int) bool {
+		if result.Series[i].Count != result.Series[j].Count {
+			return result.Series[i].Count > result.Series[j].Count
+		}
+		return groupKey(result.Series[i].Group, a.dimensions) < groupKey(result.Series[j].Group, a.dimensions)
+	})
+	return result
+}
+
+// aggregateEvents buckets a slice of events
+func aggregateEvents(events []*models.AuditEvent, filter *EventFilter, dimensions []aggregationDimension, field *KeyExtractor,
+	percentiles []float64, extractKeys BusinessKeyFunc, offsets map[string]ClockOffset, start, end time.Time, interval time.Duration) *AggregationResult {
+	aggregator := newEventAggregator(filter, dimensions, field, extractKeys, offsets, start, end, interval)
+	for _, event := range events {
+		aggregator.add(event)
+	}
+	return aggregator.result(percentiles)
+}
+
+// fieldAccumulator keeps the exact count, min, max and sum of field values and a uniform
+// reservoir sample of at most maxFieldSamples values for percentiles
+type fieldAccumulator struct {
+	count   int
+	min     float64
+	max     float64
+	sum     float64
+	samples []float64
+}
+
+func (f *fieldAccumulator) add(value float64) {
+	if f.count == 0 || value < f.min {
+		f.min = value
+	}
+	if f.count == 0 || value > f.max {
+		f.max = value
+	}
+	f.count++
+	f.sum += value
+	if len(f.samples) < maxFieldSamples {
+		f.samples = append(f.samples, value)
+	} else if i := rand.IntN(f.count); i < maxFieldSamples {
+		f.samples[i] = value
+	}
+}
+
+func (f *fieldAccumulator) stats(percentiles []float64) *FieldStats {
+	stats := computeFieldStats(f.samples, percentiles)
+	if f.count > 0 {
+		stats.Count = f.count
+		stats.Min = f.min
+		stats.Max = f.max
+		stats.Sum = f.sum
+		stats.Avg = f.sum / float64(f.count)
+		stats.Sampled = f.count > len(f.samples)
+	}
+	return stats
+}
+
+// groupCombinations returns every combination of dimension values for an event
+func groupCombinations(target *aggregationTarget, dimensions []aggregationDimension) []map[string]string {
+	combinations := []map[string]string{{}}
+	for _, dimension := range dimensions {
+		values := dimension.extract(target)
+		if len(values) == 0 {
+			values = []string{""}
+		}
+
+		next := make([]map[string]string, 0, len(combinations)*len(values))
+		for _, combination := range combinations {
+			for _, value := range values {
+				group := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					group[k] = v
+				}
+				group[dimension.name] = value
+				next = append(next, group)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+func groupKey(group map[string]string, dimensions []aggregationDimension) string {
+	parts := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		parts = append(parts, dimension.name+"="+group[dimension.name])
+	}
+	return strings.Join(parts, "\x1f")
+}
+
+// computeFieldStats summarises values with nearest-rank percentiles
+func computeFieldStats(values []float64, percentiles []float64) *FieldStats {
+	stats := &FieldStats{Count: len(values), Percentiles: make(map[string]float64, len(percentiles))}
+	if len(values) == 0 {
+		return stats
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	for _, value := range sorted {
+		stats.Sum += value
+	}
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+	stats.Avg = stats.Sum / float64(len(sorted))
+	for _, p := range percentiles {
+		stats.Percentiles["p"+strconv.FormatFloat(p, 'f', -1, 64)] = percentile(sorted, p)
+	}
+	return stats
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func TestAggregateEvents_BucketsAndGroups(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "exchange", "order_filled", start.Add(10*time.Second), `{"latency_ms": 10}`),
+		newTypedEvent("e2", "exchange", "order_filled", start.Add(20*time.Second), `{"latency_ms": 30}`),
+		newTypedEvent("e3", "exchange", "order_filled", start.Add(70*time.Second), `{"latency_ms": 50}`),
+		newTypedEvent("e4", "custodian", "settlement", start.Add(90*time.Second), `{}`),
+		newTypedEvent("e5", "exchange", "order_filled", start.Add(-time.Second), `{"latency_ms": 99}`),
+	}
+	events[0].Tags = []string{"btc", "spot"}
+
+	service := NewAggregationService(NewAuditService(logrus.New()), nil, logrus.New())
+	dimensions, err := service.dimensions([]string{"service", "tag"})
+	if err != nil {
+		t.Fatalf("Failed to resolve dimensions: %v", err)
+	}
+	filter, _ := CompileEventFilter("")
+	field := &KeyExtractor{Key: "latency_ms", Path: "$.latency_ms"}
+	if err := field.Validate(); err != nil {
+		t.Fatalf("Failed to compile field: %v", err)
+	}
+
+	result := aggregateEvents(events, filter, dimensions, field, []float64{50, 99}, nil, nil,
+		start, start.Add(2*time.Minute), time.Minute)
+
+	if result.TotalEvents != 4 {
+		t.Errorf("Expected 4 events in the window, got %d", result.TotalEvents)
+	}
+	// e1 carries two tags so it appears in two series
+	counts := make(map[string]int)
+	for _, series := range result.Series {
+		counts[series.Group["service"]+"/"+series.Group["tag"]] = series.Count
+		if len(series.Buckets) != 2 {
+			t.Errorf("Expected 2 dense buckets, got %d", len(series.Buckets))
+		}
+	}
+	expected := map[string]int{"exchange/": 2, "exchange/btc": 1, "exchange/spot": 1, "custodian/": 1}
+	for key, count := range expected {
+		if counts[key] != count {
+			t.Errorf("Expected %s to count %d, got %d", key, count, counts[key])
+		}
+	}
+
+	top := result.Series[0]
+	if top.Group["service"] != "exchange" || top.Group["tag"] != "" {
+		t.Fatalf("Expected the untagged exchange series first, got %v", top.Group)
+	}
+	if top.Buckets[0].Count != 1 || top.Buckets[1].Count != 1 {
+		t.Errorf("Unexpected bucket counts: %+v", top.Buckets)
+	}
+	if top.Buckets[0].RatePerSec != 1.0/60 {
+		t.Errorf("Expected rate of 1/60 per second, got %v", top.Buckets[0].RatePerSec)
+	}
+	if top.Field == nil || top.Field.Min != 30 || top.Field.Max != 50 || top.Field.Percentiles["p50"] != 30 {
+		t.Errorf("Unexpected field stats: %+v", top.Field)
+	}
+	// Ties are ordered by group, so custodian precedes the tagged exchange series
+	if custodian := result.Series[1]; custodian.Group["service"] != "custodian" || custodian.Field == nil || custodian.Field.Count != 0 {
+		t.Errorf("Expected the custodian series with empty field stats, got %+v", custodian)
+	}
+}
+
+func TestAggregateEvents_BusinessKeyDimension(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "exchange", "order_filled", start.Add(time.Second), `{"instrument": "BTC-USD"}`),
+		newTypedEvent("e2", "exchange", "order_filled", start.Add(2*time.Second), `{"instrument": "BTC-USD"}`),
+		newTypedEvent("e3", "exchange", "order_filled", start.Add(3*time.Second), `{"instrument": "ETH-USD"}`),
+	}
+
+	keys := newTestBusinessKeyService()
+	service := NewAggregationService(NewAuditService(logrus.New()), keys, logrus.New())
+	dimensions, err := service.dimensions([]string{"key.instrument"})
+	if err != nil {
+		t.Fatalf("Failed to resolve dimensions: %v", err)
+	}
+	filter, _ := CompileEventFilter("type = order_filled")
+
+	result := aggregateEvents(events, filter, dimensions, nil, nil, keys.ExtractKeys, nil,
+		start, start.Add(time.Minute), 10*time.Second)
+	if len(result.Series) != 2 || result.Series[0].Group["key.instrument"] != "BTC-USD" || result.Series[0].Count != 2 {
+		t.Errorf("Unexpected series: %+v", result.Series)
+	}
+	if len(result.Series[0].Buckets) != 6 {
+		t.Errorf("Expected 6 buckets, got %d", len(result.Series[0].Buckets))
+	}
+}
+
+func TestAggregationService_StreamsTheWholeWindow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var events []*models.AuditEvent
+	for i := 0; i < 3*eventPageSize; i++ {
+		ts := start.Add(time.Duration(i) * 100 * time.Millisecond)
+		events = append(events, newTypedEvent(fmt.Sprintf("e%04d", i), "exchange", "order_filled", ts, fmt.Sprintf(`{"latency_ms": %d}`, i)))
+	}
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	service := NewAggregationService(NewAuditServiceWithDataAdapter(newMemoryDataAdapter(events...), logger), nil, logger)
+
+	end := start.Add(150 * time.Second)
+	result, err := service.Aggregate(AggregationRequest{StartTime: &start, EndTime: &end, Interval: "50s", Field: "latency_ms"})
+	if err != nil {
+		t.Fatalf("Aggregate failed: %v", err)
+	}
+	if result.Scanned != len(events) || result.TotalEvents != len(events) {
+		t.Fatalf("Expected all %d events across pages, scanned %d and counted %d", len(events), result.Scanned, result.TotalEvents)
+	}
+	buckets := result.Series[0].Buckets
+	if len(buckets) != 3 || buckets[0].Count != 500 || buckets[2].Count != 500 {
+		t.Errorf("Expected the most recent bucket filled like the first, got %+v", buckets)
+	}
+	if field := result.Series[0].Field; field.Max != float64(len(events)-1) || field.Sampled {
+		t.Errorf("Unexpected field stats: %+v", field)
+	}
+}
+
+func TestFieldAccumulator_SamplesBeyondTheLimit(t *testing.T) {
+	var accumulator fieldAccumulator
+	total := 3 * maxFieldSamples
+	for i := 1; i <= total; i++ {
+		accumulator.add(float64(i))
+	}
+
+	stats := accumulator.stats([]float64{50})
+	if stats.Count != total || stats.Min != 1 || stats.Max != float64(total) || stats.Avg != float64(total+1)/2 || !stats.Sampled {
+		t.Errorf("Expected exact count, min, max and average with sampled percentiles, got %+v", stats)
+	}
+	if median := stats.Percentiles["p50"]; median < 0.45*float64(total) || median > 0.55*float64(total) {
+		t.Errorf("Expected a sampled median near %d, got %v", total/2, median)
+	}
+}
+
+func TestAggregationService_RejectsInvalidRequests(t *testing.T) {
+	service := NewAggregationService(NewAuditService(logrus.New()), nil, logrus.New())
+	end := time.Now()
+	longStart := end.Add(-48 * time.Hour)
+
+	for name, req := range map[string]AggregationRequest{
+		"unknown group":    {GroupBy: []string{"venue"}},
+		"business key":     {GroupBy: []string{"key.order_id"}},
+		"short interval":   {Interval: "100ms"},
+		"too many buckets": {StartTime: &longStart, EndTime: &end, Interval: "1m"},
+		"bad percentile":   {Field: "latency_ms", Percentiles: []float64{150}},
+		"bad filter":       {Filter: "service ="},
+	} {
+		if _, err := service.Aggregate(req); !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("%s: expected an invalid request error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// latencyBucketBoundsMs are the upper bounds of the detection latency distribution
+var latencyBucketBoundsMs = []float64{100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000}
+
+// LatencyBucket counts detections with latency at or below LeMs (the last bucket is unbounded)
+type LatencyBucket struct {
+	LeMs  float64 `json:"le_ms"` // 0 for the +Inf bucket
+	Inf   bool    `json:"inf,omitempty"`
+	Count int     `json:"count"`
+}
+
+// AlertTypeEffectiveness scores the risk monitor for one alert type
+type AlertTypeEffectiveness struct {
+	AlertType         string          `json:"alert_type"`
+	TruePositives     int             `json:"true_positives"`
+	FalsePositives    int             `json:"false_positives"`
+	MissedDetections  int             `json:"missed_detections"`
+	PendingRuns       int             `json:"pending_runs"`
+	Precision         float64         `json:"precision"`
+	Recall            float64         `json:"recall"`
+	F1                float64         `json:"f1"`
+	Latency           LatencyStats    `json:"latency"`
+	LatencyHistogram  []LatencyBucket `json:"latency_histogram"`
+	FalsePositiveIDs  []string        `json:"false_positive_event_ids"`
+	MissedScenarioIDs []string        `json:"missed_scenario_ids"`
+}
+
+// AlertEffectivenessReport scores all alert types over a window
+type AlertEffectivenessReport struct {
+	WindowStart time.Time                `json:"window_start"`
+	WindowEnd   time.Time                `json:"window_end"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Overall     AlertTypeEffectiveness   `json:"overall"`
+	AlertTypes  []AlertTypeEffectiveness `json:"alert_types"`
+}
+
+// AlertEffectivenessService scores risk alerts against injected scenarios
+type AlertEffectivenessService struct {
+	tracker *RiskCoverageTracker
+	metrics ports.MetricsPort
+	logger  *logrus.Logger
+}
+
+// NewAlertEffectivenessService creates a new effectiveness service; metrics may be nil
+func NewAlertEffectivenessService(tracker *RiskCoverageTracker, metrics ports.MetricsPort, logger *logrus.Logger) *AlertEffectivenessService {
+	return &AlertEffectivenessService{
+		tracker: tracker,
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+// Score computes true/false positives, missed detections, precision/recall and latency
+// distributions per alert type for runs started and alerts raised in [start, end]
+func (s *AlertEffectivenessService) Score(start, end time.Time) (*AlertEffectivenessReport, error) {
+	if start.After(end) {
+		return nil, fmt.Errorf("scoring window start must not be after end")
+	}
+	byType := make(map[string]*AlertTypeEffectiveness)
+	latencies := make(map[string][]float64)
+	get := func(alertType string) *AlertTypeEffectiveness {
+		score, exists := byType[alertType]
+		if !exists {
+			score = &AlertTypeEffectiveness{AlertType: alertType, FalsePositiveIDs: []string{}, MissedScenarioIDs: []string{}}
+			byType[alertType] = score
+		}
+		return score
+	}
+
+	snapshot := s.tracker.Snapshot(start, end)
+	for _, run := range snapshot.Runs {
+		alertType := snapshot.ExpectedAlertTypes[run.ScenarioID]
+		score := get(alertType)
+		switch run.Status {
+		case ScenarioRunDetected:
+			score.TruePositives++
+			latencies[alertType] = append(latencies[alertType], run.DetectionLatencyMs)
+		case ScenarioRunMissed:
+			score.MissedDetections++
+			score.MissedScenarioIDs = append(score.MissedScenarioIDs, run.ScenarioID)
+		default:
+			score.PendingRuns++
+		}
+	}
+	for _, alert := range snapshot.Alerts {
+		if alert.MatchedRunID != "" {
+			continue
+		}
+		score := get(alert.AlertType)
+		score.FalsePositives++
+		score.FalsePositiveIDs = append(score.FalsePositiveIDs, alert.EventID)
+	}
+
+	report := &AlertEffectivenessReport{
+		WindowStart: start,
+		WindowEnd:   end,
+		GeneratedAt: time.Now(),
+		Overall:     AlertTypeEffectiveness{AlertType: "all", FalsePositiveIDs: []string{}, MissedScenarioIDs: []string{}},
+		AlertTypes:  []AlertTypeEffectiveness{},
+	}
+
+	var allLatencies []float64
+	for alertType, score := range byType {
+		finalizeEffectiveness(score, latencies[alertType])
+		report.AlertTypes = append(report.AlertTypes, *score)
+
+		report.Overall.TruePositives += score.TruePositives
+		report.Overall.FalsePositives += score.FalsePositives
+		report.Overall.MissedDetections += score.MissedDetections
+		report.Overall.PendingRuns += score.PendingRuns
+		report.Overall.FalsePositiveIDs = append(report.Overall.FalsePositiveIDs, score.FalsePositiveIDs...)
+		report.Overall.MissedScenarioIDs = append(report.Overall.MissedScenarioIDs, score.MissedScenarioIDs...)
+		allLatencies = append(allLatencies, latencies[alertType]...)
+	}
+	finalizeEffectiveness(&report.Overall, allLatencies)
+	sort.Slice(report.AlertTypes, func(i, j int) bool { return report.AlertTypes[i].AlertType < report.AlertTypes[j].AlertType })
+
+	return report, nil
+}
+
+func finalizeEffectiveness(score *AlertTypeEffectiveness, latencies []float64) {
+	if detected := score.TruePositives + score.FalsePositives; detected > 0 {
+		score.Precision = float64(score.TruePositives) / float64(detected)
+	}
+	if expected := score.TruePositives + score.MissedDetections; expected > 0 {
+		score.Recall = float64(score.TruePositives) / float64(expected)
+	}
+	if score.Precision+score.Recall > 0 {
+		score.F1 = 2 * score.Precision * score.Recall / (score.Precision + score.Recall)
+	}
+	score.Latency = ComputeLatencyStats(latencies)
+	score.LatencyHistogram = latencyHistogram(latencies)
+}
+
+// latencyHistogram returns cumulative latency buckets, Prometheus style
+func latencyHistogram(latencies []float64) []LatencyBucket {
+	buckets := make([]LatencyBucket, 0, len(latencyBucketBoundsMs)+1)
+	for _, bound := range latencyBucketBoundsMs {
+		bucket := LatencyBucket{LeMs: bound}
+		for _, latency := range latencies {
+			if latency <= bound {
+				bucket.Count++
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+	return append(buckets, LatencyBucket{Inf: true, Count: len(latencies)})
+}
+
+// PublishMetrics scores the trailing window and exports the result as gauges
+func (s *AlertEffectivenessService) PublishMetrics(window time.Duration) (*AlertEffectivenessReport, error) {
+	end := time.Now()
+	report, err := s.Score(end.Add(-window), end)
+	if err != nil {
+		return nil, err
+	}
+	if s.metrics == nil {
+		return report, nil
+	}
+
+	for _, score := range append(report.AlertTypes, report.Overall) {
+		labels := map[string]string{"alert_type": score.AlertType}
+		s.metrics.SetGauge("risk_alert_precision", score.Precision, labels)
+		s.metrics.SetGauge("risk_alert_recall", score.Recall, labels)
+		s.metrics.SetGauge("risk_alert_true_positives", float64(score.TruePositives), labels)
+		s.metrics.SetGauge("risk_alert_false_positives", float64(score.FalsePositives), labels)
+		s.metrics.SetGauge("risk_alert_missed_detections", float64(score.MissedDetections), labels)
+		s.metrics.SetGauge("risk_alert_detection_latency_p95_seconds", score.Latency.P95Ms/1000, labels)
+	}
+	return report, nil
+}
+
+// Run periodically expires overdue scenario runs and publishes effectiveness gauges for the
+// trailing window until ctx is cancelled
+func (s *AlertEffectivenessService) Run(ctx context.Context, interval, window time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Overdue runs are counted as missed as they expire, not only when someone asks
+			s.tracker.ExpirePendingRuns(time.Now())
+			if _, err := s.PublishMetrics(window); err != nil {
+				s.logger.WithError(err).Warn("Failed to publish risk alert effectiveness metrics")
+			}
+		}
+	}
+}
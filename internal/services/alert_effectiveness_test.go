@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// recordingMetrics captures gauges and counters set through MetricsPort
+type recordingMetrics struct {
+	gauges   map[string]float64
+	counters map[string]int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{gauges: make(map[string]float64), counters: make(map[string]int)}
+}
+
+func (m *recordingMetrics) IncCounter(name string, labels map[string]string) {
+	m.counters[name+"/"+labels["alert_type"]]++
+}
+
+func (m *recordingMetrics) ObserveHistogram(name string, value float64, labels map[string]string) {}
+
+func (m *recordingMetrics) SetGauge(name string, value float64, labels map[string]string) {
+	m.gauges[name+"/"+labels["alert_type"]] = value
+}
+
+func (m *recordingMetrics) GetHTTPHandler() http.Handler { return http.NotFoundHandler() }
+
+func TestAlertEffectivenessService_Score(t *testing.T) {
+	tracker := newTestCoverageTracker(t)
+	metrics := newRecordingMetrics()
+	tracker.SetMetricsPort(metrics)
+	base := time.Now().Add(-30 * time.Minute)
+
+	tracker.ScanEvents([]*models.AuditEvent{
+		newTypedEvent("s1", "test-coordinator", "scenario_started", base, `{"scenario_id": "position-limit"}`),
+		newTypedEvent("a1", "risk-monitor", "risk_alert", base.Add(1500*time.Millisecond), `{"alert_type": "position_limit"}`),
+		// Missed: nothing raised within the 10s timeout, and the late alert is a false positive
+		newTypedEvent("s2", "test-coordinator", "scenario_started", base.Add(time.Minute), `{"scenario_id": "position-limit"}`),
+		newTypedEvent("a2", "risk-monitor", "risk_alert", base.Add(2*time.Minute), `{"alert_type": "position_limit"}`),
+		newTypedEvent("s3", "test-coordinator", "chaos_injected", base, `{"scenario_id": "flash-crash"}`),
+		newTypedEvent("a3", "risk-monitor", "alert_raised", base.Add(200*time.Millisecond), `{"alert_type": "price_shock"}`),
+	}, nil)
+
+	service := NewAlertEffectivenessService(tracker, metrics, tracker.logger)
+	report, err := service.Score(base.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to score alerts: %v", err)
+	}
+
+	if len(report.AlertTypes) != 2 {
+		t.Fatalf("Expected 2 alert types, got %d", len(report.AlertTypes))
+	}
+
+	position := report.AlertTypes[0]
+	if position.AlertType != "position_limit" || position.TruePositives != 1 || position.FalsePositives != 1 || position.MissedDetections != 1 {
+		t.Errorf("Unexpected position_limit counts: %+v", position)
+	}
+	if position.Precision != 0.5 || position.Recall != 0.5 {
+		t.Errorf("Expected precision and recall of 0.5, got %v/%v", position.Precision, position.Recall)
+	}
+	if len(position.FalsePositiveIDs) != 1 || position.FalsePositiveIDs[0] != "a2" {
+		t.Errorf("Expected a2 as false positive, got %v", position.FalsePositiveIDs)
+	}
+
+	shock := report.AlertTypes[1]
+	if shock.Precision != 1 || shock.Recall != 1 || shock.Latency.MaxMs != 200 {
+		t.Errorf("Unexpected price_shock score: %+v", shock)
+	}
+	if shock.LatencyHistogram[0].Count != 0 || shock.LatencyHistogram[1].Count != 1 {
+		t.Errorf("Expected 200ms detection in the 250ms bucket, got %+v", shock.LatencyHistogram)
+	}
+
+	if report.Overall.TruePositives != 2 || report.Overall.FalsePositives != 1 || report.Overall.MissedDetections != 1 {
+		t.Errorf("Unexpected overall counts: %+v", report.Overall)
+	}
+
+	if metrics.counters["risk_alerts_total/position_limit"] != 2 || metrics.counters["risk_scenario_missed_total/position_limit"] != 1 {
+		t.Errorf("Unexpected alert counters: %v", metrics.counters)
+	}
+}
+
+func TestAlertEffectivenessService_PublishMetrics(t *testing.T) {
+	tracker := newTestCoverageTracker(t)
+	metrics := newRecordingMetrics()
+	base := time.Now().Add(-time.Minute)
+
+	tracker.ScanEvents([]*models.AuditEvent{
+		newTypedEvent("s1", "test-coordinator", "scenario_started", base, `{"scenario_id": "flash-crash"}`),
+		newTypedEvent("a1", "risk-monitor", "risk_alert", base.Add(time.Second), `{"alert_type": "price_shock"}`),
+		newTypedEvent("a2", "risk-monitor", "risk_alert", base.Add(2*time.Second), `{"alert_type": "stale_market_data"}`),
+	}, nil)
+
+	service := NewAlertEffectivenessService(tracker, metrics, tracker.logger)
+	if _, err := service.PublishMetrics(time.Hour); err != nil {
+		t.Fatalf("Failed to publish metrics: %v", err)
+	}
+
+	if metrics.gauges["risk_alert_precision/price_shock"] != 1 || metrics.gauges["risk_alert_detection_latency_p95_seconds/price_shock"] != 1 {
+		t.Errorf("Unexpected price_shock gauges: %v", metrics.gauges)
+	}
+	if metrics.gauges["risk_alert_false_positives/stale_market_data"] != 1 || metrics.gauges["risk_alert_precision/all"] != 0.5 {
+		t.Errorf("Unexpected false positive gauges: %v", metrics.gauges)
+	}
+}
+
+func TestAlertEffectivenessService_RunCountsMissedDetections(t *testing.T) {
+	tracker := newTestCoverageTracker(t)
+	metrics := newRecordingMetrics()
+	tracker.SetMetricsPort(metrics)
+
+	// A run whose 10s detection timeout has long passed, with nobody querying coverage
+	if _, err := tracker.RecordRun("position-limit", "r1", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Failed to record run: %v", err)
+	}
+
+	service := NewAlertEffectivenessService(tracker, metrics, tracker.logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.Run(ctx, 10*time.Millisecond, time.Hour)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	if metrics.counters["risk_scenario_missed_total/position_limit"] != 1 {
+		t.Errorf("Expected the periodic run to count the missed detection once, got %v", metrics.counters)
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+const (
+	// segmentBlockEvents is the number of events per independently compressed block
+	segmentBlockEvents = 1000
+	segmentDataSuffix  = ".ndjson.gz"
+	segmentManifestExt = ".manifest.json"
+)
+
+// SegmentBlock indexes one gzip member of a segment file
+type SegmentBlock struct {
+	Offset    int64     `json:"offset"`
+	Length    int64     `json:"length"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Count     int       `json:"count"`
+}
+
+// SegmentManifest describes an archive segment: a gzip NDJSON file of events in
+// chronological order, split into blocks so time-bounded reads skip unrelated data
+type SegmentManifest struct {
+	ID         string         `json:"id"`
+	Policy     string         `json:"policy"`
+	File       string         `json:"file"`
+	CreatedAt  time.Time      `json:"created_at"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	EventCount int            `json:"event_count"`
+	Services   []string       `json:"services"`
+	EventTypes []string       `json:"event_types"`
+	SizeBytes  int64          `json:"size_bytes"`
+	SHA256     string         `json:"sha256"`
+	ExpiresAt  *time.Time     `json:"expires_at,omitempty"`
+	Blocks     []SegmentBlock `json:"blocks"`
+}
+
+// overlaps reports whether the segment may hold events in [start, end]
+func (m *SegmentManifest) overlaps(start, end time.Time) bool {
+	return !m.EndTime.Before(start) && !m.StartTime.After(end)
+}
+
+// mayContain uses the manifest index to rule out segments for a pushed-down filter
+func (m *SegmentManifest) mayContain(filter WindowFilter) bool {
+	if filter.ServiceName != "" && !containsString(m.Services, filter.ServiceName) {
+		return false
+	}
+	return filter.EventType == "" || containsString(m.EventTypes, filter.EventType)
+}
+
+// writeSegment archives chronologically ordered events into dir and returns the manifest
+// The manifest is written last so a segment without one is known to be incomplete
+func writeSegment(dir, id, policy string, events []*models.AuditEvent, expiresAt *time.Time) (*SegmentManifest, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("segment %s has no events", id)
+	}
+
+	manifest := &SegmentManifest{
+		ID:        id,
+		Policy:    policy,
+		File:      id + segmentDataSuffix,
+		CreatedAt: time.Now().UTC(),
+		StartTime: events[0].Timestamp,
+		EndTime:   events[len(events)-1].Timestamp,
+		ExpiresAt: expiresAt,
+	}
+
+	dataPath := filepath.Join(dir, manifest.File)
+	tmpPath := dataPath + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create segment file: %w", err)
+	}
+	defer os.Remove(tmpPath)
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(file, hash)}
+	services := make(map[string]bool)
+	eventTypes := make(map[string]bool)
+
+	for offset := 0; offset < len(events); offset += segmentBlockEvents {
+		block := events[offset:min(offset+segmentBlockEvents, len(events))]
+		start := counter.n
+
+		gz := gzip.NewWriter(counter)
+		encoder := json.NewEncoder(gz)
+		for _, event := range block {
+			if err := encoder.Encode(event); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("failed to encode archived event %s: %w", event.ID, err)
+			}
+			services[event.ServiceName] = true
+			eventTypes[event.EventType] = true
+		}
+		if err := gz.Close(); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to compress segment block: %w", err)
+		}
+
+		manifest.Blocks = append(manifest.Blocks, SegmentBlock{
+			Offset:    start,
+			Length:    counter.n - start,
+			StartTime: block[0].Timestamp,
+			EndTime:   block[len(block)-1].Timestamp,
+			Count:     len(block),
+		})
+		manifest.EventCount += len(block)
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to sync segment file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close segment file: %w", err)
+	}
+	if err := os.Rename(tmpPath, dataPath); err != nil {
+		return nil, fmt.Errorf("failed to finalize segment file: %w", err)
+	}
+
+	manifest.SizeBytes = counter.n
+	manifest.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	manifest.Services = sortedKeys(services)
+	manifest.EventTypes = sortedKeys(eventTypes)
+
+	if err := writeJSONFile(filepath.Join(dir, id+segmentManifestExt), manifest); err != nil {
+		os.Remove(dataPath)
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// readSegment hands every archived event in [start, end] to fn, reading only overlapping blocks
+func readSegment(dir string, manifest *SegmentManifest, start, end time.Time, fn func(event *models.AuditEvent) error) error {
+	file, err := os.Open(filepath.Join(dir, manifest.File))
+	if err != nil {
+		return fmt.Errorf("failed to open segment %s: %w", manifest.ID, err)
+	}
+	defer file.Close()
+
+	for _, block := range manifest.Blocks {
+		if block.EndTime.Before(start) || block.StartTime.After(end) {
+			continue
+		}
+		gz, err := gzip.NewReader(io.NewSectionReader(file, block.Offset, block.Length))
+		if err != nil {
+			return fmt.Errorf("failed to read segment %s: %w", manifest.ID, err)
+		}
+		gz.Multistream(false)
+
+		scanner := bufio.NewScanner(gz)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			var event models.AuditEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				return fmt.Errorf("corrupt event in segment %s: %w", manifest.ID, err)
+			}
+			if event.Timestamp.Before(start) || event.Timestamp.After(end) {
+				continue
+			}
+			if err := fn(&event); err != nil {
+				return err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read segment %s: %w", manifest.ID, err)
+		}
+	}
+	return nil
+}
+
+// loadSegmentManifests reads every complete segment in dir, oldest first
+func loadSegmentManifests(dir string) ([]*SegmentManifest, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+segmentManifestExt))
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]*SegmentManifest, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read segment manifest: %w", err)
+		}
+		var manifest SegmentManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse segment manifest %s: %w", filepath.Base(path), err)
+		}
+		manifests = append(manifests, &manifest)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].StartTime.Before(manifests[j].StartTime)
+	})
+	return manifests, nil
+}
+
+// removeSegment deletes a segment's manifest first, so a partial removal leaves no visible segment
+func removeSegment(dir string, manifest *SegmentManifest) error {
+	if err := os.Remove(filepath.Join(dir, manifest.ID+segmentManifestExt)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove segment manifest: %w", err)
+	}
+	if err := os.Remove(filepath.Join(dir, manifest.File)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove segment file: %w", err)
+	}
+	return nil
+}
+
+// writeJSONFile atomically replaces path with the JSON encoding of value
+func writeJSONFile(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// segmentID names a segment after its policy and first event so files sort chronologically
+func segmentID(policy string, first time.Time, seq int) string {
+	return fmt.Sprintf("%s-%s-%04d", strings.ToLower(policy), first.UTC().Format("20060102T150405.000000000Z"), seq)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
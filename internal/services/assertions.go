@@ -0,0 +1,679 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// AssertionType identifies how an assertion is evaluated
+type AssertionType string
+
+const (
+	// AssertionTypeFollowedBy requires every trigger event to be followed by matching events
+	// within the configured duration ("within 3s of X, service Y emits Z at least once")
+	AssertionTypeFollowedBy AssertionType = "followed_by"
+	// AssertionTypeAbsent requires that no matching event occurs during the window
+	AssertionTypeAbsent AssertionType = "absent"
+	// AssertionTypeCount requires the number of matching events to fall within [min_count, max_count]
+	AssertionTypeCount AssertionType = "count"
+)
+
+// Live assertion run statuses
+const (
+	AssertionRunStatusRunning   = "running"
+	AssertionRunStatusCompleted = "completed"
+)
+
+// maxAssertionEvidence bounds the evidence events attached to a single assertion result
+const maxAssertionEvidence = 50
+
+// maxStoredSuiteResults bounds the evaluation history kept per suite
+const maxStoredSuiteResults = 100
+
+// completedLiveRunRetention is how long a completed live run stays queryable
+const completedLiveRunRetention = time.Hour
+
+// maxLiveRunDuration bounds how long a live run collects events
+const maxLiveRunDuration = time.Hour
+
+// EventMatcher selects audit events; empty fields match anything
+type EventMatcher struct {
+	ServiceName string            `json:"service_name,omitempty"`
+	EventType   string            `json:"event_type,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`     // All tags must be present
+	Metadata    map[string]string `json:"metadata,omitempty"` // Top-level metadata values must be equal
+}
+
+// Matches returns true if the event satisfies every populated matcher field
+func (m *EventMatcher) Matches(event *models.AuditEvent) bool {
+	if m == nil {
+		return true
+	}
+	if m.ServiceName != "" && event.ServiceName != m.ServiceName {
+		return false
+	}
+	if m.EventType != "" && event.EventType != m.EventType {
+		return false
+	}
+	for _, tag := range m.Tags {
+		if !containsString(event.Tags, tag) {
+			return false
+		}
+	}
+	for key, value := range m.Metadata {
+		if metadataString(event, key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Assertion is a declarative expectation about the events of a scenario run
+type Assertion struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Type     AssertionType `json:"type"`
+	Trigger  *EventMatcher `json:"trigger,omitempty"` // Required for followed_by
+	Expect   EventMatcher  `json:"expect"`
+	Within   string        `json:"within,omitempty"` // Go duration, required for followed_by
+	MinCount int           `json:"min_count,omitempty"`
+	MaxCount *int          `json:"max_count,omitempty"`
+}
+
+// Validate checks that the assertion is well-formed
+func (a *Assertion) Validate() error {
+	if a.ID == "" {
+		return fmt.Errorf("assertion id is required")
+	}
+
+	switch a.Type {
+	case AssertionTypeFollowedBy:
+		if a.Trigger == nil {
+			return fmt.Errorf("assertion %s: trigger is required for %s", a.ID, a.Type)
+		}
+		within, err := time.ParseDuration(a.Within)
+		if err != nil || within <= 0 {
+			return fmt.Errorf("assertion %s: within must be a positive duration", a.ID)
+		}
+	case AssertionTypeAbsent:
+	case AssertionTypeCount:
+		if a.MaxCount != nil && *a.MaxCount < a.MinCount {
+			return fmt.Errorf("assertion %s: max_count must not be less than min_count", a.ID)
+		}
+	default:
+		return fmt.Errorf("assertion %s: unknown type %q", a.ID, a.Type)
+	}
+
+	if a.MinCount < 0 {
+		return fmt.Errorf("assertion %s: min_count must not be negative", a.ID)
+	}
+	return nil
+}
+
+// AssertionSuite groups the assertions for one scenario
+type AssertionSuite struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	ScenarioID  string      `json:"scenario_id,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Assertions  []Assertion `json:"assertions"`
+}
+
+// Validate checks that the suite and all of its assertions are well-formed
+func (s *AssertionSuite) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("suite id is required")
+	}
+	if len(s.Assertions) == 0 {
+		return fmt.Errorf("suite %s has no assertions", s.ID)
+	}
+
+	seen := make(map[string]bool, len(s.Assertions))
+	for i := range s.Assertions {
+		if err := s.Assertions[i].Validate(); err != nil {
+			return err
+		}
+		if seen[s.Assertions[i].ID] {
+			return fmt.Errorf("suite %s: duplicate assertion id %s", s.ID, s.Assertions[i].ID)
+		}
+		seen[s.Assertions[i].ID] = true
+	}
+	return nil
+}
+
+// maxWithin returns the longest followed_by window in the suite, zero without one
+func (s *AssertionSuite) maxWithin() time.Duration {
+	var longest time.Duration
+	for i := range s.Assertions {
+		if s.Assertions[i].Type == AssertionTypeFollowedBy {
+			within, _ := time.ParseDuration(s.Assertions[i].Within)
+			longest = max(longest, within)
+		}
+	}
+	return longest
+}
+
+// matchesAny reports whether any assertion of the suite could use the event
+func (s *AssertionSuite) matchesAny(event *models.AuditEvent) bool {
+	for i := range s.Assertions {
+		if s.Assertions[i].Expect.Matches(event) || (s.Assertions[i].Trigger != nil && s.Assertions[i].Trigger.Matches(event)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertionResult is the outcome of one assertion with the events supporting it
+type AssertionResult struct {
+	AssertionID string               `json:"assertion_id"`
+	Name        string               `json:"name"`
+	Type        AssertionType        `json:"type"`
+	Passed      bool                 `json:"passed"`
+	NoData      bool                 `json:"no_data,omitempty"` // Passed vacuously, nothing to check
+	Message     string               `json:"message"`
+	Observed    int                  `json:"observed"`
+	Evidence    []*models.AuditEvent `json:"evidence"`
+}
+
+// SuiteResult is the outcome of evaluating a suite over a time window
+type SuiteResult struct {
+	SuiteID     string            `json:"suite_id"`
+	ScenarioID  string            `json:"scenario_id,omitempty"`
+	Source      string            `json:"source"` // "stored", "live" or "inline"
+	Passed      bool              `json:"passed"`
+	Total       int               `json:"total"`
+	PassedCount int               `json:"passed_count"`
+	FailedCount int               `json:"failed_count"`
+	WindowStart time.Time         `json:"window_start"`
+	WindowEnd   time.Time         `json:"window_end"`
+	EventsSeen  int               `json:"events_seen"`
+	EvaluatedAt time.Time         `json:"evaluated_at"`
+	Results     []AssertionResult `json:"results"`
+}
+
+// LiveAssertionRun collects ingested events for a suite until its deadline
+type LiveAssertionRun struct {
+	ID        string       `json:"id"`
+	SuiteID   string       `json:"suite_id"`
+	Status    string       `json:"status"`
+	StartedAt time.Time    `json:"started_at"`
+	EndsAt    time.Time    `json:"ends_at"`
+	Result    *SuiteResult `json:"result,omitempty"`
+
+	suite       *AssertionSuite
+	events      []*models.AuditEvent // only events matching the suite's assertions
+	completedAt time.Time
+}
+
+// EvaluateAssertions evaluates assertions against events ordered on the correlator clock
+func EvaluateAssertions(assertions []Assertion, events []*models.AuditEvent, offsets map[string]ClockOffset) []AssertionResult {
+	return evaluateAssertionsUntil(assertions, events, offsets, time.Time{})
+}
+
+// evaluateAssertionsUntil is EvaluateAssertions where events stamped after until only serve as
+// followed_by followers, so triggers near the end of a window can still be satisfied
+// A zero until counts every event.
+func evaluateAssertionsUntil(assertions []Assertion, events []*models.AuditEvent, offsets map[string]ClockOffset, until time.Time) []AssertionResult {
+	ordered := make([]*models.AuditEvent, len(events))
+	copy(ordered, events)
+	SortByNormalizedTime(ordered, offsets)
+
+	results := make([]AssertionResult, 0, len(assertions))
+	for i := range assertions {
+		results = append(results, evaluateAssertion(&assertions[i], ordered, offsets, until))
+	}
+	return results
+}
+
+func evaluateAssertion(assertion *Assertion, events []*models.AuditEvent, offsets map[string]ClockOffset, until time.Time) AssertionResult {
+	result := AssertionResult{
+		AssertionID: assertion.ID,
+		Name:        assertion.Name,
+		Type:        assertion.Type,
+		Evidence:    []*models.AuditEvent{},
+	}
+	inWindow := func(event *models.AuditEvent) bool {
+		return until.IsZero() || !event.Timestamp.After(until)
+	}
+
+	switch assertion.Type {
+	case AssertionTypeAbsent:
+		for _, event := range events {
+			if inWindow(event) && assertion.Expect.Matches(event) {
+				result.Observed++
+				result.Evidence = appendEvidence(result.Evidence, event)
+			}
+		}
+		result.Passed = result.Observed == 0
+		if result.Passed {
+			result.Message = "no matching events observed"
+		} else {
+			result.Message = fmt.Sprintf("%d matching events observed, expected none", result.Observed)
+		}
+
+	case AssertionTypeCount:
+		for _, event := range events {
+			if inWindow(event) && assertion.Expect.Matches(event) {
+				result.Observed++
+				result.Evidence = appendEvidence(result.Evidence, event)
+			}
+		}
+		result.Passed = result.Observed >= assertion.MinCount &&
+			(assertion.MaxCount == nil || result.Observed <= *assertion.MaxCount)
+		if assertion.MaxCount != nil {
+			result.Message = fmt.Sprintf("observed %d matching events, expected between %d and %d", result.Observed, assertion.MinCount, *assertion.MaxCount)
+		} else {
+			result.Message = fmt.Sprintf("observed %d matching events, expected at least %d", result.Observed, assertion.MinCount)
+		}
+
+	case AssertionTypeFollowedBy:
+		within, _ := time.ParseDuration(assertion.Within)
+		minCount := assertion.MinCount
+		if minCount == 0 {
+			minCount = 1
+		}
+
+		// Evidence is the unsatisfied triggers on failure, or the triggers and their followers on success
+		var satisfiedEvidence, unsatisfiedEvidence []*models.AuditEvent
+		triggers := 0
+		for i, trigger := range events {
+			if !inWindow(trigger) || !assertion.Trigger.Matches(trigger) {
+				continue
+			}
+			triggers++
+			triggerTime := NormalizeTimestamp(trigger, offsets)
+			deadline := triggerTime.Add(within)
+
+			var followers []*models.AuditEvent
+			for _, candidate := range events[i+1:] {
+				candidateTime := NormalizeTimestamp(candidate, offsets)
+				if candidateTime.After(deadline) {
+					break
+				}
+				if assertion.Expect.Matches(candidate) {
+					followers = append(followers, candidate)
+				}
+			}
+
+			result.Observed += len(followers)
+			if len(followers) < minCount {
+				unsatisfiedEvidence = append(unsatisfiedEvidence, trigger)
+				continue
+			}
+			satisfiedEvidence = appendEvidence(satisfiedEvidence, trigger)
+			for _, follower := range followers {
+				satisfiedEvidence = appendEvidence(satisfiedEvidence, follower)
+			}
+		}
+
+		switch {
+		case triggers == 0:
+			result.Passed = true
+			result.NoData = true
+			result.Message = "no trigger events observed, nothing to follow"
+		case len(unsatisfiedEvidence) > 0:
+			for _, trigger := range unsatisfiedEvidence {
+				result.Evidence = appendEvidence(result.Evidence, trigger)
+			}
+			result.Message = fmt.Sprintf("%d of %d trigger events were not followed by at least %d matching events within %s", len(unsatisfiedEvidence), triggers, minCount, within)
+		default:
+			result.Evidence = append(result.Evidence, satisfiedEvidence...)
+			result.Passed = true
+			result.Message = fmt.Sprintf("all %d trigger events followed by at least %d matching events within %s", triggers, minCount, within)
+		}
+	}
+
+	return result
+}
+
+func appendEvidence(evidence []*models.AuditEvent, event *models.AuditEvent) []*models.AuditEvent {
+	if len(evidence) >= maxAssertionEvidence {
+		return evidence
+	}
+	return append(evidence, event)
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertionService manages assertion suites and evaluates them against stored or live events
+type AssertionService struct {
+	auditService *AuditService
+	logger       *logrus.Logger
+
+	mu       sync.RWMutex
+	suites   map[string]*AssertionSuite
+	results  map[string][]*SuiteResult
+	liveRuns map[string]*LiveAssertionRun
+}
+
+// NewAssertionService creates a new assertion service observing events ingested by the audit service
+func NewAssertionService(auditService *AuditService, logger *logrus.Logger) *AssertionService {
+	service := &AssertionService{
+		auditService: auditService,
+		logger:       logger,
+		suites:       make(map[string]*AssertionSuite),
+		results:      make(map[string][]*SuiteResult),
+		liveRuns:     make(map[string]*LiveAssertionRun),
+	}
+	auditService.AddEventObserver(service.observeEvent)
+	return service
+}
+
+// RegisterSuite validates and stores an assertion suite (replacing any suite with the same ID)
+func (s *AssertionService) RegisterSuite(suite *AssertionSuite) error {
+	if suite == nil {
+		return fmt.Errorf("suite cannot be nil")
+	}
+	if err := suite.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.suites[suite.ID] = suite
+	return nil
+}
+
+// LoadSuitesFromFile registers the suites contained in a JSON file (an array of suites)
+func (s *AssertionService) LoadSuitesFromFile(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		s.logger.WithField("path", path).Info("Assertion suites file not found, no suites preloaded")
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read assertion suites file: %w", err)
+	}
+
+	var suites []*AssertionSuite
+	if err := json.Unmarshal(data, &suites); err != nil {
+		return fmt.Errorf("failed to parse assertion suites JSON: %w", err)
+	}
+
+	for _, suite := range suites {
+		if err := s.RegisterSuite(suite); err != nil {
+			return fmt.Errorf("invalid assertion suite: %w", err)
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"path":   path,
+		"suites": len(suites),
+	}).Info("Loaded assertion suites")
+	return nil
+}
+
+// GetSuite returns a registered suite by ID
+func (s *AssertionService) GetSuite(suiteID string) (*AssertionSuite, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	suite, exists := s.suites[suiteID]
+	if !exists {
+		return nil, fmt.Errorf("assertion suite not found: %s", suiteID)
+	}
+	return suite, nil
+}
+
+// ListSuites returns all registered suites ordered by ID
+func (s *AssertionService) ListSuites() []*AssertionSuite {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	suites := make([]*AssertionSuite, 0, len(s.suites))
+	for _, suite := range s.suites {
+		suites = append(suites, suite)
+	}
+	sort.Slice(suites, func(i, j int) bool { return suites[i].ID < suites[j].ID })
+	return suites
+}
+
+// EvaluateStored evaluates a suite against stored events in the given window
+func (s *AssertionService) EvaluateStored(suite *AssertionSuite, start, end time.Time) (*SuiteResult, error) {
+	if err := suite.Validate(); err != nil {
+		return nil, err
+	}
+
+	events, err := s.loadStored(suite, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.evaluate(suite, "stored", events, start, end, end)
+	s.recordResult(result)
+	return result, nil
+}
+
+// loadStored loads the window plus the longest followed_by window after it, where followers
+// of triggers near the end of the window may land
+func (s *AssertionService) loadStored(suite *AssertionSuite, start, end time.Time) ([]*models.AuditEvent, error) {
+	events, err := s.auditService.GetEventsInWindow(start, end.Add(suite.maxWithin()), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events for assertions: %w", err)
+	}
+	return events, nil
+}
+
+// EvaluateInline evaluates an unregistered suite against stored events without recording the
+// result, so suite histories only hold registered suites
+func (s *AssertionService) EvaluateInline(suite *AssertionSuite, start, end time.Time) (*SuiteResult, error) {
+	if err := suite.Validate(); err != nil {
+		return nil, err
+	}
+
+	events, err := s.loadStored(suite, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return s.evaluate(suite, "inline", events, start, end, end), nil
+}
+
+// GetSuiteResults returns previous evaluations of a suite, most recent last
+func (s *AssertionService) GetSuiteResults(suiteID string) []*SuiteResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]*SuiteResult, len(s.results[suiteID]))
+	copy(results, s.results[suiteID])
+	return results
+}
+
+// ResultsInWindow returns all stored results whose window overlaps [start, end]
+func (s *AssertionService) ResultsInWindow(start, end time.Time) []*SuiteResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []*SuiteResult
+	for _, suiteResults := range s.results {
+		for _, result := range suiteResults {
+			if !result.WindowEnd.Before(start) && !result.WindowStart.After(end) {
+				results = append(results, result)
+			}
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].EvaluatedAt.Before(results[j].EvaluatedAt) })
+	return results
+}
+
+// StartLiveRun begins collecting ingested events for a registered suite
+// The run keeps only events some assertion of the suite matches, for at most maxLiveRunDuration
+func (s *AssertionService) StartLiveRun(suiteID string, duration time.Duration) (*LiveAssertionRun, error) {
+	if duration <= 0 || duration > maxLiveRunDuration {
+		return nil, fmt.Errorf("live run duration must be positive and at most %s", maxLiveRunDuration)
+	}
+	suite, err := s.GetSuite(suiteID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	run := &LiveAssertionRun{
+		ID:        fmt.Sprintf("run-%d", now.UnixNano()),
+		SuiteID:   suiteID,
+		Status:    AssertionRunStatusRunning,
+		StartedAt: now,
+		EndsAt:    now.Add(duration),
+		suite:     suite,
+	}
+
+	s.mu.Lock()
+	s.evictLiveRunsLocked(now)
+	s.liveRuns[run.ID] = run
+	s.mu.Unlock()
+
+	// The run completes at its deadline whether or not anyone polls it
+	time.AfterFunc(duration, func() {
+		if _, err := s.evaluateLiveRun(run.ID); err != nil {
+			s.logger.WithError(err).WithField("run_id", run.ID).Warn("Failed to complete live assertion run")
+		}
+	})
+
+	s.logger.WithFields(logrus.Fields{
+		"run_id":   run.ID,
+		"suite_id": suiteID,
+		"ends_at":  run.EndsAt,
+	}).Info("Started live assertion run")
+
+	return run, nil
+}
+
+// GetLiveRun returns a live run, evaluating the events collected so far
+// Completed runs stay available for completedLiveRunRetention
+func (s *AssertionService) GetLiveRun(runID string) (*LiveAssertionRun, error) {
+	return s.evaluateLiveRun(runID)
+}
+
+// evaluateLiveRun evaluates a run's events, completing the run and recording its result once
+// the deadline has passed
+func (s *AssertionService) evaluateLiveRun(runID string) (*LiveAssertionRun, error) {
+	s.mu.Lock()
+	run, exists := s.liveRuns[runID]
+	if !exists {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("live assertion run not found: %s", runID)
+	}
+	if run.Status == AssertionRunStatusCompleted {
+		snapshot := *run
+		s.mu.Unlock()
+		return &snapshot, nil
+	}
+
+	events := make([]*models.AuditEvent, len(run.events))
+	copy(events, run.events)
+	completed := !time.Now().Before(run.EndsAt)
+	s.mu.Unlock()
+
+	result := s.evaluate(run.suite, "live", events, run.StartedAt, run.EndsAt, time.Time{})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if run.Status == AssertionRunStatusCompleted {
+		snapshot := *run
+		return &snapshot, nil
+	}
+	run.Result = result
+	if completed {
+		run.Status = AssertionRunStatusCompleted
+		run.completedAt = time.Now()
+		run.events = nil
+		s.appendResultLocked(result)
+		s.evictLiveRunsLocked(run.completedAt)
+	}
+	snapshot := *run
+	return &snapshot, nil
+}
+
+// evictLiveRunsLocked drops runs completed more than completedLiveRunRetention ago
+func (s *AssertionService) evictLiveRunsLocked(now time.Time) {
+	for id, run := range s.liveRuns {
+		if run.Status == AssertionRunStatusCompleted && now.Sub(run.completedAt) > completedLiveRunRetention {
+			delete(s.liveRuns, id)
+		}
+	}
+}
+
+// observeEvent appends ingested events to every live run still collecting whose suite uses them
+func (s *AssertionService) observeEvent(event *models.AuditEvent) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, run := range s.liveRuns {
+		if run.Status == AssertionRunStatusRunning && now.Before(run.EndsAt) && run.suite.matchesAny(event) {
+			run.events = append(run.events, event)
+		}
+	}
+}
+
+// evaluate runs a suite over events; events stamped after until (when set) only serve as followers
+func (s *AssertionService) evaluate(suite *AssertionSuite, source string, events []*models.AuditEvent, start, end, until time.Time) *SuiteResult {
+	results := evaluateAssertionsUntil(suite.Assertions, events, s.auditService.ClockSkewEstimator().Offsets(), until)
+	seen := 0
+	for _, event := range events {
+		if until.IsZero() || !event.Timestamp.After(until) {
+			seen++
+		}
+	}
+
+	result := &SuiteResult{
+		SuiteID:     suite.ID,
+		ScenarioID:  suite.ScenarioID,
+		Source:      source,
+		Total:       len(results),
+		WindowStart: start,
+		WindowEnd:   end,
+		EventsSeen:  seen,
+		EvaluatedAt: time.Now(),
+		Results:     results,
+	}
+	for _, assertionResult := range results {
+		if assertionResult.Passed {
+			result.PassedCount++
+		} else {
+			result.FailedCount++
+		}
+	}
+	result.Passed = result.FailedCount == 0
+
+	s.logger.WithFields(logrus.Fields{
+		"suite_id": suite.ID,
+		"source":   source,
+		"passed":   result.PassedCount,
+		"failed":   result.FailedCount,
+		"events":   len(events),
+	}).Info("Assertion suite evaluated")
+
+	return result
+}
+
+func (s *AssertionService) recordResult(result *SuiteResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appendResultLocked(result)
+}
+
+func (s *AssertionService) appendResultLocked(result *SuiteResult) {
+	history := append(s.results[result.SuiteID], result)
+	if len(history) > maxStoredSuiteResults {
+		history = history[len(history)-maxStoredSuiteResults:]
+	}
+	s.results[result.SuiteID] = history
+}
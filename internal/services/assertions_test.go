@@ -0,0 +1,317 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newTypedEvent(id, serviceName, eventType string, ts time.Time, metadata string) *models.AuditEvent {
+	event := newTestEvent(id, "", "", serviceName, ts, metadata)
+	event.EventType = eventType
+	return event
+}
+
+func newTestAssertionService() *AssertionService {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	return NewAssertionService(NewAuditService(logger), logger)
+}
+
+func TestEvaluateAssertions_FollowedBy(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assertions := []Assertion{{
+		ID:      "order-acked",
+		Type:    AssertionTypeFollowedBy,
+		Trigger: &EventMatcher{ServiceName: "trading-engine", EventType: "order_submitted"},
+		Expect:  EventMatcher{ServiceName: "exchange-simulator", EventType: "order_ack"},
+		Within:  "3s",
+	}}
+
+	// Given two orders where only the first is acknowledged within 3s
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "trading-engine", "order_submitted", base, ""),
+		newTypedEvent("e2", "exchange-simulator", "order_ack", base.Add(2*time.Second), ""),
+		newTypedEvent("e3", "trading-engine", "order_submitted", base.Add(10*time.Second), ""),
+		newTypedEvent("e4", "exchange-simulator", "order_ack", base.Add(14*time.Second), ""),
+	}
+
+	// When evaluated
+	results := EvaluateAssertions(assertions, events, nil)
+
+	// Then the assertion fails with the unacknowledged trigger as evidence
+	if results[0].Passed {
+		t.Fatal("Expected followed_by assertion to fail")
+	}
+	if len(results[0].Evidence) != 1 || results[0].Evidence[0].ID != "e3" {
+		t.Errorf("Expected evidence [e3], got %v", results[0].Evidence)
+	}
+
+	// And it passes once the late acknowledgement is within the window
+	events[3].Timestamp = base.Add(12 * time.Second)
+	results = EvaluateAssertions(assertions, events, nil)
+	if !results[0].Passed {
+		t.Errorf("Expected followed_by assertion to pass, got: %s", results[0].Message)
+	}
+	if len(results[0].Evidence) != 4 {
+		t.Errorf("Expected triggers and followers as evidence, got %d events", len(results[0].Evidence))
+	}
+}
+
+func TestEvaluateAssertions_FollowedByUsesNormalizedTime(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assertions := []Assertion{{
+		ID:      "fill",
+		Type:    AssertionTypeFollowedBy,
+		Trigger: &EventMatcher{EventType: "order_submitted"},
+		Expect:  EventMatcher{EventType: "fill"},
+		Within:  "1s",
+	}}
+
+	// exchange-simulator clock runs 5s ahead of the correlator
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "trading-engine", "order_submitted", base, ""),
+		newTypedEvent("e2", "exchange-simulator", "fill", base.Add(5500*time.Millisecond), ""),
+	}
+	offsets := map[string]ClockOffset{
+		"exchange-simulator": newClockOffset("exchange-simulator", 5*time.Second, 1, ClockOffsetSourceIngestion),
+	}
+
+	results := EvaluateAssertions(assertions, events, offsets)
+	if !results[0].Passed {
+		t.Errorf("Expected assertion to pass on the correlator clock, got: %s", results[0].Message)
+	}
+}
+
+func TestEvaluateAssertions_AbsentAndCount(t *testing.T) {
+	base := time.Now()
+	maxCount := 2
+	assertions := []Assertion{
+		{
+			ID:     "no-errors",
+			Type:   AssertionTypeAbsent,
+			Expect: EventMatcher{ServiceName: "trading-engine", Metadata: map[string]string{"level": "ERROR"}},
+		},
+		{
+			ID:       "heartbeats",
+			Type:     AssertionTypeCount,
+			Expect:   EventMatcher{EventType: "heartbeat", Tags: []string{"risk"}},
+			MinCount: 1,
+			MaxCount: &maxCount,
+		},
+	}
+
+	heartbeat := newTypedEvent("e2", "risk-monitor", "heartbeat", base, "")
+	heartbeat.Tags = []string{"risk"}
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "trading-engine", "log", base, `{"level": "ERROR"}`),
+		heartbeat,
+		newTypedEvent("e3", "trading-engine", "log", base, `{"level": "INFO"}`),
+	}
+
+	results := EvaluateAssertions(assertions, events, nil)
+	if results[0].Passed || results[0].Observed != 1 || results[0].Evidence[0].ID != "e1" {
+		t.Errorf("Expected absent assertion to fail with e1 as evidence, got %+v", results[0])
+	}
+	if !results[1].Passed || results[1].Observed != 1 {
+		t.Errorf("Expected count assertion to pass with 1 observed, got %+v", results[1])
+	}
+}
+
+func TestAssertionSuite_Validate(t *testing.T) {
+	invalid := []AssertionSuite{
+		{ID: "", Assertions: []Assertion{{ID: "a", Type: AssertionTypeAbsent}}},
+		{ID: "s", Assertions: nil},
+		{ID: "s", Assertions: []Assertion{{ID: "a", Type: AssertionTypeFollowedBy, Within: "3s"}}},
+		{ID: "s", Assertions: []Assertion{{ID: "a", Type: AssertionTypeFollowedBy, Trigger: &EventMatcher{}, Within: "soon"}}},
+		{ID: "s", Assertions: []Assertion{{ID: "a", Type: "eventually"}}},
+		{ID: "s", Assertions: []Assertion{{ID: "a", Type: AssertionTypeAbsent}, {ID: "a", Type: AssertionTypeAbsent}}},
+	}
+
+	for i, suite := range invalid {
+		if err := suite.Validate(); err == nil {
+			t.Errorf("Expected suite %d to be invalid", i)
+		}
+	}
+}
+
+func TestAssertionService_LoadSuitesFromFile(t *testing.T) {
+	service := newTestAssertionService()
+	path := filepath.Join(t.TempDir(), "assertions.json")
+	content := `[{"id": "smoke", "name": "Smoke", "assertions": [
+		{"id": "no-errors", "type": "absent", "expect": {"service_name": "trading-engine", "event_type": "ERROR"}}
+	]}]`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write suites file: %v", err)
+	}
+
+	if err := service.LoadSuitesFromFile(path); err != nil {
+		t.Fatalf("Failed to load suites: %v", err)
+	}
+	if _, err := service.GetSuite("smoke"); err != nil {
+		t.Errorf("Expected suite to be registered: %v", err)
+	}
+
+	// A missing file is not an error
+	if err := service.LoadSuitesFromFile(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("Expected missing file to be ignored, got %v", err)
+	}
+}
+
+func TestAssertionService_LiveRun(t *testing.T) {
+	service := newTestAssertionService()
+	suite := &AssertionSuite{
+		ID: "live",
+		Assertions: []Assertion{{
+			ID:     "no-errors",
+			Type:   AssertionTypeAbsent,
+			Expect: EventMatcher{ServiceName: "trading-engine", EventType: "ERROR"},
+		}},
+	}
+	if err := service.RegisterSuite(suite); err != nil {
+		t.Fatalf("Failed to register suite: %v", err)
+	}
+
+	if _, err := service.StartLiveRun("live", 2*time.Hour); err == nil {
+		t.Error("Expected a run longer than the cap to be rejected")
+	}
+	run, err := service.StartLiveRun("live", time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to start live run: %v", err)
+	}
+
+	// Events ingested during the run are collected (stub mode still notifies observers),
+	// but only those the suite's assertions can use
+	if err := service.auditService.IngestEvent(&models.AuditEvent{ServiceName: "trading-engine", EventType: "ERROR"}); err != nil {
+		t.Fatalf("Failed to ingest event: %v", err)
+	}
+	if err := service.auditService.IngestEvent(&models.AuditEvent{ServiceName: "trading-engine", EventType: "heartbeat"}); err != nil {
+		t.Fatalf("Failed to ingest event: %v", err)
+	}
+
+	current, err := service.GetLiveRun(run.ID)
+	if err != nil {
+		t.Fatalf("Failed to get live run: %v", err)
+	}
+	if current.Status != AssertionRunStatusRunning {
+		t.Errorf("Expected run to still be running, got %s", current.Status)
+	}
+	if current.Result == nil || current.Result.Passed || current.Result.EventsSeen != 1 {
+		t.Errorf("Expected failing interim result over 1 event, got %+v", current.Result)
+	}
+	if len(service.GetSuiteResults("live")) != 0 {
+		t.Error("Expected interim results not to be recorded")
+	}
+}
+
+func TestEvaluateAssertions_FollowedByWithoutTriggers(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assertions := []Assertion{{
+		ID:      "order-acked",
+		Type:    AssertionTypeFollowedBy,
+		Trigger: &EventMatcher{EventType: "order_submitted"},
+		Expect:  EventMatcher{EventType: "order_ack"},
+		Within:  "3s",
+	}}
+
+	// No orders were submitted, so there is nothing to acknowledge
+	results := EvaluateAssertions(assertions, []*models.AuditEvent{
+		newTypedEvent("e1", "exchange-simulator", "heartbeat", base, ""),
+	}, nil)
+	if !results[0].Passed || !results[0].NoData {
+		t.Errorf("Expected a vacuous pass without data, got %+v", results[0])
+	}
+}
+
+func TestAssertionService_EvaluateInlineIsNotRecorded(t *testing.T) {
+	service := newTestAssertionService()
+	suite := &AssertionSuite{
+		ID: "adhoc",
+		Assertions: []Assertion{{
+			ID:     "no-errors",
+			Type:   AssertionTypeAbsent,
+			Expect: EventMatcher{EventType: "ERROR"},
+		}},
+	}
+
+	end := time.Now()
+	result, err := service.EvaluateInline(suite, end.Add(-time.Minute), end)
+	if err != nil {
+		t.Fatalf("EvaluateInline failed: %v", err)
+	}
+	if !result.Passed {
+		t.Errorf("Expected inline suite to pass, got %+v", result)
+	}
+	if got := service.ResultsInWindow(end.Add(-time.Hour), end.Add(time.Hour)); len(got) != 0 {
+		t.Errorf("Expected inline results not to be recorded, got %d", len(got))
+	}
+}
+
+func TestAssertionService_LiveRunCompletesWithoutPolling(t *testing.T) {
+	service := newTestAssertionService()
+	suite := &AssertionSuite{
+		ID: "live",
+		Assertions: []Assertion{{
+			ID:     "no-errors",
+			Type:   AssertionTypeAbsent,
+			Expect: EventMatcher{EventType: "ERROR"},
+		}},
+	}
+	if err := service.RegisterSuite(suite); err != nil {
+		t.Fatalf("Failed to register suite: %v", err)
+	}
+	if _, err := service.StartLiveRun("live", 20*time.Millisecond); err != nil {
+		t.Fatalf("Failed to start live run: %v", err)
+	}
+
+	// The result is recorded at the deadline without anyone asking for the run
+	deadline := time.Now().Add(2 * time.Second)
+	for len(service.GetSuiteResults("live")) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the live run to complete and record its result")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if results := service.GetSuiteResults("live"); len(results) != 1 || results[0].Source != "live" {
+		t.Errorf("Expected one live result, got %+v", results)
+	}
+}
+
+func TestAssertionService_StoredFollowedByLooksPastWindowEnd(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Given an order submitted just before the window ends, acknowledged just after it,
+	// and a second order submitted after the window
+	auditService := NewAuditServiceWithDataAdapter(newMemoryDataAdapter(
+		newTypedEvent("e1", "trading-engine", "order_submitted", end.Add(-time.Second), `{}`),
+		newTypedEvent("e2", "exchange-simulator", "order_ack", end.Add(time.Second), `{}`),
+		newTypedEvent("e3", "trading-engine", "order_submitted", end.Add(2*time.Second), `{}`),
+	), logger)
+	service := NewAssertionService(auditService, logger)
+	suite := &AssertionSuite{
+		ID: "acks",
+		Assertions: []Assertion{{
+			ID:      "order-acked",
+			Type:    AssertionTypeFollowedBy,
+			Trigger: &EventMatcher{EventType: "order_submitted"},
+			Expect:  EventMatcher{EventType: "order_ack"},
+			Within:  "3s",
+		}},
+	}
+
+	// Then the trigger in the window is satisfied and the one after it is not evaluated
+	result, err := service.EvaluateStored(suite, end.Add(-time.Minute), end)
+	if err != nil {
+		t.Fatalf("EvaluateStored failed: %v", err)
+	}
+	if !result.Passed || result.EventsSeen != 1 {
+		t.Errorf("Expected the acknowledged trigger to pass over 1 event in the window, got %+v", result)
+	}
+}
@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -12,24 +16,94 @@ import (
 	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
 )
 
+const (
+	// eventPageSize is the page size used when walking stored events in a time window
+	eventPageSize = 500
+	// maxWindowEvents caps window loads that ask for no limit
+	maxWindowEvents = 100000
+	// maxEventsPerInstant caps the events loaded sharing a single timestamp
+	maxEventsPerInstant = 50000
+)
+
+// ErrInvalidEvent reports an ingested event rejected as malformed
+var ErrInvalidEvent = errors.New("invalid event")
+
+// maxIngestFutureSkew bounds how far ahead of its receipt an ingested event may be stamped;
+// anything further is a broken clock or timestamp and would poison the skew estimate
+const maxIngestFutureSkew = time.Hour
+
+// ErrWindowTruncated reports a window holding more events than can be loaded
+var ErrWindowTruncated = errors.New("too many events in window")
+
+// EventObserver is notified of every event accepted by the audit service
+type EventObserver func(event *models.AuditEvent)
+
 type AuditService struct {
 	dataAdapter adapters.DataAdapter
+	clockSkew   *ClockSkewEstimator
 	logger      *logrus.Logger
+
+	observersMu sync.RWMutex
+	observers   []EventObserver
+
+	businessKeys BusinessKeyFunc
+}
+
+// TimelineEntry represents a single event placed on the correlator clock
+type TimelineEntry struct {
+	EventID             string    `json:"event_id"`
+	TraceID             string    `json:"trace_id"`
+	SpanID              string    `json:"span_id"`
+	ServiceName         string    `json:"service_name"`
+	EventType           string    `json:"event_type"`
+	Timestamp           time.Time `json:"timestamp"`
+	NormalizedTimestamp time.Time `json:"normalized_timestamp"`
+	ClockOffsetMs       float64   `json:"clock_offset_ms"`
 }
 
 func NewAuditService(logger *logrus.Logger) *AuditService {
 	return &AuditService{
-		logger: logger,
+		clockSkew: NewClockSkewEstimator(),
+		logger:    logger,
 	}
 }
 
 func NewAuditServiceWithDataAdapter(dataAdapter adapters.DataAdapter, logger *logrus.Logger) *AuditService {
 	return &AuditService{
 		dataAdapter: dataAdapter,
+		clockSkew:   NewClockSkewEstimator(),
 		logger:      logger,
 	}
 }
 
+// ClockSkewEstimator returns the estimator used to normalize service timestamps
+func (s *AuditService) ClockSkewEstimator() *ClockSkewEstimator {
+	return s.clockSkew
+}
+
+// AddEventObserver registers a callback invoked for every stored or ingested event
+func (s *AuditService) AddEventObserver(observer EventObserver) {
+	s.observersMu.Lock()
+	defer s.observersMu.Unlock()
+	s.observers = append(s.observers, observer)
+}
+
+// SetBusinessKeyFunc sets the extractor used to correlate events by business keys
+func (s *AuditService) SetBusinessKeyFunc(extract BusinessKeyFunc) {
+	s.businessKeys = extract
+}
+
+func (s *AuditService) notifyObservers(event *models.AuditEvent) {
+	s.observersMu.RLock()
+	observers := make([]EventObserver, len(s.observers))
+	copy(observers, s.observers)
+	s.observersMu.RUnlock()
+
+	for _, observer := range observers {
+		observer(event)
+	}
+}
+
 func (s *AuditService) LogEvent(eventType, source, message string) error {
 	ctx := context.Background()
 
@@ -61,6 +135,7 @@ func (s *AuditService) LogEvent(eventType, source, message string) error {
 		s.logger.WithError(err).Error("Failed to store audit event")
 		return fmt.Errorf("failed to store audit event: %w", err)
 	}
+	s.notifyObservers(event)
 
 	s.logger.WithFields(logrus.Fields{
 		"event_id":   event.ID,
@@ -72,6 +147,65 @@ func (s *AuditService) LogEvent(eventType, source, message string) error {
 	return nil
 }
 
+// IngestEvent stores an event reported by another service
+// The receive time feeds clock skew estimation; the original and normalized timestamps are
+// both recorded in the event metadata, while Timestamp keeps the value reported by the service
+// Malformed events fail with ErrInvalidEvent
+func (s *AuditService) IngestEvent(event *models.AuditEvent) error {
+	ctx := context.Background()
+	receivedAt := time.Now()
+
+	if event.ID == "" {
+		event.ID = fmt.Sprintf("audit-%d", receivedAt.UnixNano())
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = receivedAt
+	}
+	if event.Timestamp.Sub(receivedAt) > maxIngestFutureSkew {
+		return fmt.Errorf("%w: timestamp %s is more than %s ahead of receipt", ErrInvalidEvent, event.Timestamp.UTC().Format(time.RFC3339Nano), maxIngestFutureSkew)
+	}
+	event.Status = models.AuditEventStatusPending
+
+	s.clockSkew.ObserveReceipt(event.ServiceName, event.Timestamp, receivedAt)
+	offset := s.clockSkew.OffsetFor(event.ServiceName)
+
+	metadata, err := mergeMetadata(event.Metadata, map[string]interface{}{
+		metadataKeyReceivedAt:          receivedAt.UTC().Format(time.RFC3339Nano),
+		metadataKeyOriginalTimestamp:   event.Timestamp.UTC().Format(time.RFC3339Nano),
+		metadataKeyNormalizedTimestamp: event.Timestamp.Add(-offset).UTC().Format(time.RFC3339Nano),
+		metadataKeyClockOffsetMs:       float64(offset) / float64(time.Millisecond),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	event.Metadata = metadata
+
+	if s.dataAdapter == nil {
+		s.logger.WithFields(logrus.Fields{
+			"event_id":     event.ID,
+			"service_name": event.ServiceName,
+			"event_type":   event.EventType,
+		}).Info("Ingesting audit event (no data adapter)")
+		s.notifyObservers(event)
+		return nil
+	}
+
+	if err := s.dataAdapter.Create(ctx, event); err != nil {
+		s.logger.WithError(err).Error("Failed to store ingested audit event")
+		return fmt.Errorf("failed to store audit event: %w", err)
+	}
+	s.notifyObservers(event)
+
+	s.logger.WithFields(logrus.Fields{
+		"event_id":        event.ID,
+		"service_name":    event.ServiceName,
+		"event_type":      event.EventType,
+		"clock_offset_ms": float64(offset) / float64(time.Millisecond),
+	}).Debug("Audit event ingested")
+
+	return nil
+}
+
 func (s *AuditService) CorrelateEvents(timeWindow string) ([]string, error) {
 	ctx := context.Background()
 
@@ -100,6 +234,9 @@ func (s *AuditService) CorrelateEvents(timeWindow string) ([]string, error) {
 		return nil, fmt.Errorf("failed to query audit events: %w", err)
 	}
 
+	// Learn clock offsets from cross-service spans before placing events in time
+	s.clockSkew.ObserveEvents(events)
+
 	// Enhanced correlation logic using repository patterns
 	correlationIDs, err := s.performCorrelationAnalysis(ctx, events)
 	if err != nil {
@@ -140,18 +277,27 @@ func (s *AuditService) performCorrelationAnalysis(ctx context.Context, events []
 		}
 	}
 
-	// 3. Temporal correlation - find events within close time proximity
-	temporalCorrelations := s.correlateByTemporalProximity(events, 5*time.Second)
+	// 3. Temporal correlation - find events within close time proximity (on the correlator clock)
+	temporalCorrelations := s.correlateByTemporalProximity(events, 5*time.Second, s.clockSkew.Offsets())
 	for i, group := range temporalCorrelations {
 		if len(group) > 1 {
 			correlationIDs = append(correlationIDs, fmt.Sprintf("temporal-group-%d-%d-events", i, len(group)))
 		}
 	}
 
+	// 4. Business-key correlation - group by order, account, instrument, strategy, ...
+	keyCorrelations := s.correlateByBusinessKey(events)
+	for key, eventIDs := range keyCorrelations {
+		if len(eventIDs) > 1 {
+			correlationIDs = append(correlationIDs, fmt.Sprintf("key-%s-%d-events", key, len(eventIDs)))
+		}
+	}
+
 	s.logger.WithFields(logrus.Fields{
 		"trace_correlations":    len(traceCorrelations),
 		"service_correlations":  len(serviceCorrelations),
 		"temporal_correlations": len(temporalCorrelations),
+		"key_correlations":      len(keyCorrelations),
 		"total_correlations":    len(correlationIDs),
 	}).Debug("Correlation analysis completed")
 
@@ -179,25 +325,48 @@ func (s *AuditService) correlateByServiceAndType(events []*models.AuditEvent) ma
 	return correlations
 }
 
+// correlateByBusinessKey groups events by extracted business key, keyed "key=value"
+func (s *AuditService) correlateByBusinessKey(events []*models.AuditEvent) map[string][]string {
+	correlations := make(map[string][]string)
+	if s.businessKeys == nil {
+		return correlations
+	}
+	for _, event := range events {
+		for key, values := range s.businessKeys(event) {
+			for _, value := range values {
+				correlations[key+"="+value] = append(correlations[key+"="+value], event.ID)
+			}
+		}
+	}
+	return correlations
+}
+
 // correlateByTemporalProximity groups events that occur within a time window
-func (s *AuditService) correlateByTemporalProximity(events []*models.AuditEvent, window time.Duration) [][]string {
+// Events are ordered by normalized timestamp so that clock drift between containers
+// cannot produce impossible orderings
+func (s *AuditService) correlateByTemporalProximity(events []*models.AuditEvent, window time.Duration, offsets map[string]ClockOffset) [][]string {
 	if len(events) == 0 {
 		return [][]string{}
 	}
 
+	ordered := make([]*models.AuditEvent, len(events))
+	copy(ordered, events)
+	SortByNormalizedTime(ordered, offsets)
+
 	var groups [][]string
 	var currentGroup []string
 	var groupStartTime time.Time
 
-	for i, event := range events {
+	for i, event := range ordered {
+		eventTime := NormalizeTimestamp(event, offsets)
 		if i == 0 {
 			currentGroup = []string{event.ID}
-			groupStartTime = event.Timestamp
+			groupStartTime = eventTime
 			continue
 		}
 
 		// If within time window, add to current group
-		if event.Timestamp.Sub(groupStartTime) <= window {
+		if eventTime.Sub(groupStartTime) <= window {
 			currentGroup = append(currentGroup, event.ID)
 		} else {
 			// Start new group
@@ -205,7 +374,7 @@ func (s *AuditService) correlateByTemporalProximity(events []*models.AuditEvent,
 				groups = append(groups, currentGroup)
 			}
 			currentGroup = []string{event.ID}
-			groupStartTime = event.Timestamp
+			groupStartTime = eventTime
 		}
 	}
 
@@ -239,6 +408,10 @@ func (s *AuditService) GetEventsByTraceID(traceID string) ([]*models.AuditEvent,
 		return nil, fmt.Errorf("failed to query events by trace ID: %w", err)
 	}
 
+	// Order the trace on the correlator clock rather than on each service's clock
+	s.clockSkew.ObserveEvents(events)
+	SortByNormalizedTime(events, s.clockSkew.Offsets())
+
 	s.logger.WithFields(logrus.Fields{
 		"trace_id":     traceID,
 		"events_found": len(events),
@@ -247,6 +420,283 @@ func (s *AuditService) GetEventsByTraceID(traceID string) ([]*models.AuditEvent,
 	return events, nil
 }
 
+// WindowFilter narrows a window query with exact matches evaluated by the data adapter
+type WindowFilter struct {
+	TraceID     string
+	ServiceName string
+	EventType   string
+	Descending  bool // walk newest first
+}
+
+// GetEventsInWindow retrieves stored events in [start, end] in chronological order
+// At most maxEvents are returned; maxEvents <= 0 loads the whole window, failing with
+// ErrWindowTruncated beyond maxWindowEvents rather than returning part of it
+func (s *AuditService) GetEventsInWindow(start, end time.Time, maxEvents int) ([]*models.AuditEvent, error) {
+	return s.GetFilteredEventsInWindow(WindowFilter{}, start, end, maxEvents)
+}
+
+// GetFilteredEventsInWindow is GetEventsInWindow restricted to events matching filter
+func (s *AuditService) GetFilteredEventsInWindow(filter WindowFilter, start, end time.Time, maxEvents int) ([]*models.AuditEvent, error) {
+	var events []*models.AuditEvent
+	err := s.WalkEventsInWindow(context.Background(), filter, start, end, func(page []*models.AuditEvent) error {
+		for _, event := range page {
+			if maxEvents <= 0 && len(events) >= maxWindowEvents {
+				return fmt.Errorf("%w: more than %d events between %s and %s", ErrWindowTruncated, maxWindowEvents, start.Format(time.RFC3339), end.Format(time.RFC3339))
+			}
+			events = append(events, event)
+			if maxEvents > 0 && len(events) >= maxEvents {
+				return errStopWalk
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []*models.AuditEvent{}
+	}
+	return events, nil
+}
+
+// errStopWalk ends a window walk early without reporting an error
+var errStopWalk = errors.New("stop walk")
+
+// WalkEventsInWindow pages through stored events in [start, end] in (timestamp, id) order,
+// or the reverse when filter.Descending, handing each page to fn so callers never hold
+// the whole window in memory
+// Returning errStopWalk from fn ends the walk without error. More than maxEventsPerInstant
+// events sharing one timestamp fail the walk with ErrWindowTruncated.
+func (s *AuditService) WalkEventsInWindow(ctx context.Context, filter WindowFilter, start, end time.Time, fn func(page []*models.AuditEvent) error) error {
+	if s.dataAdapter == nil {
+		s.logger.WithFields(logrus.Fields{
+			"start": start,
+			"end":   end,
+		}).Info("Getting events in window (no data adapter)")
+		return nil
+	}
+
+	// The store pages by timestamp only, so a full page may end partway through the events
+	// sharing its last timestamp; that instant is loaded whole and the walk resumes after it
+	emit := func(events []*models.AuditEvent) error {
+		if len(events) == 0 {
+			return nil
+		}
+		s.clockSkew.ObserveEvents(events)
+		return fn(events)
+	}
+	if filter.Descending {
+		return s.walkEventsDescending(ctx, filter, start, end, emit)
+	}
+
+	cursor := start
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := s.queryWindowPage(ctx, filter, cursor, end, eventPageSize)
+		if err != nil {
+			return err
+		}
+		sortWithinTimestamp(page)
+
+		var last time.Time
+		before := page
+		if len(page) >= eventPageSize {
+			last = page[len(page)-1].Timestamp
+			before = page[:0:0]
+			for _, event := range page {
+				if event.Timestamp.Before(last) {
+					before = append(before, event)
+				}
+			}
+		}
+		if err := emit(before); err != nil {
+			if errors.Is(err, errStopWalk) {
+				return nil
+			}
+			return err
+		}
+		if len(page) < eventPageSize {
+			return nil
+		}
+
+		instant, err := s.loadInstant(ctx, filter, last)
+		if err != nil {
+			return err
+		}
+		if err := emit(instant); err != nil {
+			if errors.Is(err, errStopWalk) {
+				return nil
+			}
+			return err
+		}
+		cursor = last.Add(time.Nanosecond)
+		if cursor.After(end) {
+			return nil
+		}
+	}
+}
+
+// walkEventsDescending is WalkEventsInWindow from end back to start, in reverse (timestamp, id) order
+func (s *AuditService) walkEventsDescending(ctx context.Context, filter WindowFilter, start, end time.Time, emit func([]*models.AuditEvent) error) error {
+	cursor := end
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := s.queryWindowPage(ctx, filter, start, cursor, eventPageSize)
+		if err != nil {
+			return err
+		}
+		sortWithinTimestamp(page)
+		slices.Reverse(page)
+
+		var first time.Time
+		after := page
+		if len(page) >= eventPageSize {
+			first = page[len(page)-1].Timestamp
+			after = page[:0:0]
+			for _, event := range page {
+				if event.Timestamp.After(first) {
+					after = append(after, event)
+				}
+			}
+		}
+		if err := emit(after); err != nil {
+			if errors.Is(err, errStopWalk) {
+				return nil
+			}
+			return err
+		}
+		if len(page) < eventPageSize {
+			return nil
+		}
+
+		instant, err := s.loadInstant(ctx, filter, first)
+		if err != nil {
+			return err
+		}
+		slices.Reverse(instant)
+		if err := emit(instant); err != nil {
+			if errors.Is(err, errStopWalk) {
+				return nil
+			}
+			return err
+		}
+		cursor = first.Add(-time.Nanosecond)
+		if cursor.Before(start) {
+			return nil
+		}
+	}
+}
+
+// queryWindowPage queries up to limit stored events in [from, end] in timestamp order,
+// newest first when filter.Descending
+func (s *AuditService) queryWindowPage(ctx context.Context, filter WindowFilter, from, end time.Time, limit int) ([]*models.AuditEvent, error) {
+	query := models.AuditQuery{
+		StartTime: &from,
+		EndTime:   &end,
+		Limit:     limit,
+		SortBy:    "timestamp",
+		SortOrder: "asc",
+	}
+	if filter.Descending {
+		query.SortOrder = "desc"
+	}
+	if filter.TraceID != "" {
+		query.TraceID = &filter.TraceID
+	}
+	if filter.ServiceName != "" {
+		query.ServiceName = &filter.ServiceName
+	}
+	if filter.EventType != "" {
+		query.EventType = &filter.EventType
+	}
+
+	page, err := s.dataAdapter.Query(ctx, query)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to query events in window")
+		return nil, fmt.Errorf("failed to query events in window: %w", err)
+	}
+	return page, nil
+}
+
+// loadInstant returns every stored event at exactly one timestamp, sorted by ID
+func (s *AuditService) loadInstant(ctx context.Context, filter WindowFilter, at time.Time) ([]*models.AuditEvent, error) {
+	for limit := eventPageSize * 2; ; limit *= 2 {
+		limit = min(limit, maxEventsPerInstant+1)
+		events, err := s.queryWindowPage(ctx, filter, at, at, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > maxEventsPerInstant {
+			return nil, fmt.Errorf("%w: more than %d events at %s", ErrWindowTruncated, maxEventsPerInstant, at.Format(time.RFC3339Nano))
+		}
+		if len(events) < limit {
+			sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+			return events, nil
+		}
+	}
+}
+
+// sortWithinTimestamp orders events sharing a timestamp by ID, keeping timestamp order
+func sortWithinTimestamp(events []*models.AuditEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Timestamp.Equal(events[j].Timestamp) {
+			return events[i].Timestamp.Before(events[j].Timestamp)
+		}
+		return events[i].ID < events[j].ID
+	})
+}
+
+// GetTraceTimeline returns the events of a trace placed on the correlator clock
+func (s *AuditService) GetTraceTimeline(traceID string) ([]TimelineEntry, error) {
+	events, err := s.GetEventsByTraceID(traceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.BuildTimeline(events), nil
+}
+
+// BuildTimeline converts events into timeline entries ordered by normalized timestamp
+func (s *AuditService) BuildTimeline(events []*models.AuditEvent) []TimelineEntry {
+	offsets := s.clockSkew.Offsets()
+
+	ordered := make([]*models.AuditEvent, len(events))
+	copy(ordered, events)
+	SortByNormalizedTime(ordered, offsets)
+
+	timeline := make([]TimelineEntry, 0, len(ordered))
+	for _, event := range ordered {
+		timeline = append(timeline, TimelineEntry{
+			EventID:             event.ID,
+			TraceID:             event.TraceID,
+			SpanID:              event.SpanID,
+			ServiceName:         event.ServiceName,
+			EventType:           event.EventType,
+			Timestamp:           event.Timestamp,
+			NormalizedTimestamp: NormalizeTimestamp(event, offsets),
+			ClockOffsetMs:       offsets[event.ServiceName].OffsetMs,
+		})
+	}
+	return timeline
+}
+
+// GetClockOffsets returns the current per-service clock offset estimates
+func (s *AuditService) GetClockOffsets() []ClockOffset {
+	offsets := s.clockSkew.Offsets()
+
+	result := make([]ClockOffset, 0, len(offsets))
+	for _, offset := range offsets {
+		result = append(result, offset)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ServiceName < result[j].ServiceName })
+	return result
+}
+
 // GetEventsByServiceType retrieves events for a specific service and event type
 func (s *AuditService) GetEventsByServiceType(serviceName, eventType string, timeWindow time.Duration) ([]*models.AuditEvent, error) {
 	ctx := context.Background()
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func TestAuditService_WalkEventsInWindowPagesPastSharedTimestamps(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Given more events at one instant than fit in a page, followed by later events
+	var events []*models.AuditEvent
+	for i := 0; i < eventPageSize*2+200; i++ {
+		events = append(events, newTypedEvent(fmt.Sprintf("burst-%04d", i), "exchange-simulator", "fill", base, ""))
+	}
+	for i := 0; i < 10; i++ {
+		events = append(events, newTypedEvent(fmt.Sprintf("later-%02d", i), "exchange-simulator", "fill", base.Add(time.Duration(i+1)*time.Second), ""))
+	}
+	service := NewAuditServiceWithDataAdapter(newMemoryDataAdapter(events...), logger)
+
+	// When the window is walked
+	var walked []*models.AuditEvent
+	err := service.WalkEventsInWindow(context.Background(), WindowFilter{}, base, base.Add(time.Minute), func(page []*models.AuditEvent) error {
+		walked = append(walked, page...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkEventsInWindow failed: %v", err)
+	}
+
+	// Then every event is seen once, in (timestamp, id) order
+	if len(walked) != len(events) {
+		t.Fatalf("Expected %d events, got %d", len(events), len(walked))
+	}
+	seen := make(map[string]bool)
+	for i, event := range walked {
+		if seen[event.ID] {
+			t.Fatalf("Event %s walked twice", event.ID)
+		}
+		seen[event.ID] = true
+		if i > 0 {
+			prev := walked[i-1]
+			if event.Timestamp.Before(prev.Timestamp) || (event.Timestamp.Equal(prev.Timestamp) && event.ID < prev.ID) {
+				t.Fatalf("Event %s walked after %s", event.ID, prev.ID)
+			}
+		}
+	}
+
+	// And a descending walk yields the same events in reverse
+	var reversed []*models.AuditEvent
+	err = service.WalkEventsInWindow(context.Background(), WindowFilter{Descending: true}, base, base.Add(time.Minute), func(page []*models.AuditEvent) error {
+		reversed = append(reversed, page...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Descending WalkEventsInWindow failed: %v", err)
+	}
+	if len(reversed) != len(walked) {
+		t.Fatalf("Expected %d events walking back, got %d", len(walked), len(reversed))
+	}
+	for i, event := range reversed {
+		if event.ID != walked[len(walked)-1-i].ID {
+			t.Fatalf("Expected %s at %d walking back, got %s", walked[len(walked)-1-i].ID, i, event.ID)
+		}
+	}
+
+	// And a bounded load stops at its limit
+	limited, err := service.GetEventsInWindow(base, base.Add(time.Minute), 700)
+	if err != nil || len(limited) != 700 {
+		t.Errorf("Expected 700 events without error, got %d and %v", len(limited), err)
+	}
+}
@@ -0,0 +1,454 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// maxIndexedEvents bounds the events held by the business-key index (oldest are evicted first)
+const maxIndexedEvents = 50000
+
+// defaultBusinessKeys are extracted from top-level metadata of every event unless configured otherwise
+var defaultBusinessKeys = []string{"order_id", "account_id", "instrument", "strategy_id"}
+
+// BusinessKeyFunc extracts business keys (key -> values) from an event
+type BusinessKeyFunc func(event *models.AuditEvent) map[string][]string
+
+// KeyExtractor pulls one business key out of event metadata with a JSONPath-style expression
+// Supported syntax: $.field, $['field'], $.list[0], $.list[*].field; a bare name means $.name
+type KeyExtractor struct {
+	Key         string   `json:"key"`
+	Path        string   `json:"path"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	ServiceName string   `json:"service_name,omitempty"`
+
+	segments []keyPathSegment
+}
+
+type keyPathSegment struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// Validate checks the extractor and compiles its path
+func (e *KeyExtractor) Validate() error {
+	if e.Key == "" {
+		return fmt.Errorf("extractor key is required")
+	}
+	segments, err := compileKeyPath(e.Path)
+	if err != nil {
+		return fmt.Errorf("extractor %s: %w", e.Key, err)
+	}
+	e.segments = segments
+	return nil
+}
+
+// Applies reports whether the extractor is configured for the event's type and service
+func (e *KeyExtractor) Applies(event *models.AuditEvent) bool {
+	if e.ServiceName != "" && e.ServiceName != event.ServiceName {
+		return false
+	}
+	return len(e.EventTypes) == 0 || containsString(e.EventTypes, event.EventType)
+}
+
+// Extract returns every scalar value the path selects from decoded metadata
+func (e *KeyExtractor) Extract(metadata map[string]interface{}) []string {
+	current := []interface{}{metadata}
+	for _, segment := range e.segments {
+		var next []interface{}
+		for _, value := range current {
+			next = append(next, segment.apply(value)...)
+		}
+		current = next
+	}
+
+	values := make([]string, 0, len(current))
+	for _, value := range current {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		if rendered := renderMetadataValue(value); rendered != "" {
+			values = append(values, rendered)
+		}
+	}
+	return values
+}
+
+func (s keyPathSegment) apply(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if s.wildcard {
+			values := make([]interface{}, 0, len(v))
+			for _, item := range v {
+				values = append(values, item)
+			}
+			return values
+		}
+		if item, ok := v[s.field]; ok && !s.isIndex {
+			return []interface{}{item}
+		}
+	case []interface{}:
+		if s.wildcard {
+			return v
+		}
+		if s.isIndex && s.index >= 0 && s.index < len(v) {
+			return []interface{}{v[s.index]}
+		}
+	}
+	return nil
+}
+
+// compileKeyPath parses a JSONPath-style expression into segments
+func compileKeyPath(path string) ([]keyPathSegment, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	if !strings.HasPrefix(path, "$") {
+		path = "$." + path
+	}
+
+	var segments []keyPathSegment
+	rest := path[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			field := rest[:end]
+			if field == "" {
+				return nil, fmt.Errorf("empty field in path %q", path)
+			}
+			segments = append(segments, keyPathSegment{field: field, wildcard: field == "*"})
+			rest = rest[end:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated bracket in path %q", path)
+			}
+			segment, err := parseBracketSegment(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", path, err)
+			}
+			segments = append(segments, segment)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in path %q", rest[:1], path)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path %q selects no field", path)
+	}
+	return segments, nil
+}
+
+func parseBracketSegment(inner string) (keyPathSegment, error) {
+	inner = strings.TrimSpace(inner)
+	if inner == "*" {
+		return keyPathSegment{wildcard: true}, nil
+	}
+	if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+		return keyPathSegment{field: inner[1 : len(inner)-1]}, nil
+	}
+	index, err := strconv.Atoi(inner)
+	if err != nil {
+		return keyPathSegment{}, fmt.Errorf("bracket must hold an index, '*' or a quoted field: %s", inner)
+	}
+	return keyPathSegment{index: index, isIndex: true}, nil
+}
+
+// BusinessKeyStats summarises the index for one key
+type BusinessKeyStats struct {
+	Key            string `json:"key"`
+	DistinctValues int    `json:"distinct_values"`
+	IndexedEvents  int    `json:"indexed_events"`
+}
+
+// BusinessKeyService extracts business keys from event metadata and indexes events by them
+type BusinessKeyService struct {
+	auditService *AuditService
+	logger       *logrus.Logger
+
+	mu         sync.RWMutex
+	extractors []*KeyExtractor
+	events     map[string]*models.AuditEvent
+	order      []string
+	index      map[string]map[string][]string // key -> value -> event IDs
+	eventKeys  map[string]map[string][]string // event ID -> key -> values
+}
+
+// NewBusinessKeyService creates a key service with the default extractors and hooks it into the audit service
+func NewBusinessKeyService(auditService *AuditService, logger *logrus.Logger) *BusinessKeyService {
+	service := &BusinessKeyService{
+		auditService: auditService,
+		logger:       logger,
+		events:       make(map[string]*models.AuditEvent),
+		index:        make(map[string]map[string][]string),
+		eventKeys:    make(map[string]map[string][]string),
+	}
+	for _, key := range defaultBusinessKeys {
+		extractor := &KeyExtractor{Key: key, Path: "$." + key}
+		if err := extractor.Validate(); err == nil {
+			service.extractors = append(service.extractors, extractor)
+		}
+	}
+
+	if auditService != nil {
+		auditService.SetBusinessKeyFunc(service.ExtractKeys)
+		auditService.AddEventObserver(service.IndexEvent)
+	}
+	return service
+}
+
+// LoadExtractorsFromFile adds extractors from a JSON file ({"extractors": [...]})
+// A missing file is not an error
+func (s *BusinessKeyService) LoadExtractorsFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.logger.WithField("path", path).Debug("No business key config found")
+			return nil
+		}
+		return fmt.Errorf("failed to read business key config: %w", err)
+	}
+
+	var config struct {
+		Extractors []*KeyExtractor `json:"extractors"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse business key config: %w", err)
+	}
+
+	for _, extractor := range config.Extractors {
+		if err := s.AddExtractor(extractor); err != nil {
+			return err
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"path":       path,
+		"extractors": len(config.Extractors),
+	}).Info("Loaded business key extractors")
+	return nil
+}
+
+// AddExtractor validates and registers an extractor, then re-extracts keys for indexed events
+func (s *BusinessKeyService) AddExtractor(extractor *KeyExtractor) error {
+	if err := extractor.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.extractors = append(s.extractors, extractor)
+	for _, eventID := range s.order {
+		s.indexLocked(s.events[eventID])
+	}
+	return nil
+}
+
+// ListExtractors returns the configured extractors
+func (s *BusinessKeyService) ListExtractors() []KeyExtractor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	extractors := make([]KeyExtractor, 0, len(s.extractors))
+	for _, extractor := range s.extractors {
+		extractors = append(extractors, *extractor)
+	}
+	return extractors
+}
+
+// ExtractKeys applies every matching extractor to the event's metadata
+func (s *BusinessKeyService) ExtractKeys(event *models.AuditEvent) map[string][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.extractLocked(event)
+}
+
+func (s *BusinessKeyService) extractLocked(event *models.AuditEvent) map[string][]string {
+	keys := make(map[string][]string)
+	if event == nil || len(event.Metadata) == 0 {
+		return keys
+	}
+
+	metadata := decodeMetadata(event)
+	for _, extractor := range s.extractors {
+		if !extractor.Applies(event) {
+			continue
+		}
+		for _, value := range extractor.Extract(metadata) {
+			if !containsString(keys[extractor.Key], value) {
+				keys[extractor.Key] = append(keys[extractor.Key], value)
+			}
+		}
+	}
+	return keys
+}
+
+// IndexEvent indexes an event under every business key it carries
+func (s *BusinessKeyService) IndexEvent(event *models.AuditEvent) {
+	if event == nil || event.ID == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.events[event.ID]; !exists {
+		s.order = append(s.order, event.ID)
+	}
+	s.events[event.ID] = event
+	s.indexLocked(event)
+
+	for len(s.order) > maxIndexedEvents {
+		s.removeLocked(s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// IndexWindow indexes stored events in [start, end] and returns how many were read
+func (s *BusinessKeyService) IndexWindow(start, end time.Time) (int, error) {
+	events, err := s.auditService.GetEventsInWindow(start, end, maxIndexedEvents)
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		s.IndexEvent(event)
+	}
+	return len(events), nil
+}
+
+func (s *BusinessKeyService) indexLocked(event *models.AuditEvent) {
+	s.unindexLocked(event.ID)
+
+	keys := s.extractLocked(event)
+	if len(keys) == 0 {
+		return
+	}
+	s.eventKeys[event.ID] = keys
+	for key, values := range keys {
+		if s.index[key] == nil {
+			s.index[key] = make(map[string][]string)
+		}
+		for _, value := range values {
+			s.index[key][value] = append(s.index[key][value], event.ID)
+		}
+	}
+}
+
+func (s *BusinessKeyService) unindexLocked(eventID string) {
+	for key, values := range s.eventKeys[eventID] {
+		for _, value := range values {
+			ids := s.index[key][value]
+			for i, id := range ids {
+				if id == eventID {
+					ids = append(ids[:i], ids[i+1:]...)
+					break
+				}
+			}
+			if len(ids) == 0 {
+				delete(s.index[key], value)
+			} else {
+				s.index[key][value] = ids
+			}
+		}
+		if len(s.index[key]) == 0 {
+			delete(s.index, key)
+		}
+	}
+	delete(s.eventKeys, eventID)
+}
+
+func (s *BusinessKeyService) removeLocked(eventID string) {
+	s.unindexLocked(eventID)
+	delete(s.events, eventID)
+}
+
+// ListKeys returns index statistics for every key seen so far
+func (s *BusinessKeyService) ListKeys() []BusinessKeyStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make([]BusinessKeyStats, 0, len(s.index))
+	for key, values := range s.index {
+		seen := make(map[string]bool)
+		for _, ids := range values {
+			for _, id := range ids {
+				seen[id] = true
+			}
+		}
+		stats = append(stats, BusinessKeyStats{Key: key, DistinctValues: len(values), IndexedEvents: len(seen)})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Key < stats[j].Key })
+	return stats
+}
+
+// GetEvents returns indexed events carrying key=value, ordered on the correlator clock
+func (s *BusinessKeyService) GetEvents(key, value string) []*models.AuditEvent {
+	s.mu.RLock()
+	ids := s.index[key][value]
+	events := make([]*models.AuditEvent, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, s.events[id])
+	}
+	s.mu.RUnlock()
+
+	SortByNormalizedTime(events, s.auditService.ClockSkewEstimator().Offsets())
+	return events
+}
+
+// GetTimeline returns the timeline of every event carrying key=value
+func (s *BusinessKeyService) GetTimeline(key, value string) []TimelineEntry {
+	return s.auditService.BuildTimeline(s.GetEvents(key, value))
+}
+
+// Correlate groups indexed events by the values of one key; groups need at least minEvents events
+func (s *BusinessKeyService) Correlate(key string, minEvents int) []CorrelationGroup {
+	if minEvents < 1 {
+		minEvents = 2
+	}
+
+	s.mu.RLock()
+	groups := make([]CorrelationGroup, 0)
+	for value, ids := range s.index[key] {
+		if len(ids) < minEvents {
+			continue
+		}
+		eventIDs := make([]string, len(ids))
+		copy(eventIDs, ids)
+		groups = append(groups, CorrelationGroup{
+			Type:     "business_key",
+			Key:      key + "=" + value,
+			Count:    len(eventIDs),
+			EventIDs: eventIDs,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
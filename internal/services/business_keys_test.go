@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newTestBusinessKeyService() *BusinessKeyService {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	return NewBusinessKeyService(NewAuditService(logger), logger)
+}
+
+func TestKeyExtractor_Paths(t *testing.T) {
+	event := newTypedEvent("e1", "trading-engine", "order_filled", time.Now(),
+		`{"order": {"id": "ord-1", "legs": [{"instrument": "BTC-USD"}, {"instrument": "ETH-USD"}]}, "account": 42}`)
+	metadata := decodeMetadata(event)
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"$.order.id", []string{"ord-1"}},
+		{"$['order']['id']", []string{"ord-1"}},
+		{"$.order.legs[1].instrument", []string{"ETH-USD"}},
+		{"$.order.legs[*].instrument", []string{"BTC-USD", "ETH-USD"}},
+		{"account", []string{"42"}},
+		{"$.order", []string{}},
+		{"$.missing.field", []string{}},
+	}
+
+	for _, tt := range tests {
+		extractor := &KeyExtractor{Key: "k", Path: tt.path}
+		if err := extractor.Validate(); err != nil {
+			t.Fatalf("Failed to compile %s: %v", tt.path, err)
+		}
+		values := extractor.Extract(metadata)
+		if len(values) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.expected, values)
+			continue
+		}
+		for i := range values {
+			if values[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tt.path, tt.expected, values)
+			}
+		}
+	}
+
+	for _, path := range []string{"", "$.", "$.order[", "$.order[x]", "$order"} {
+		extractor := &KeyExtractor{Key: "k", Path: path}
+		if err := extractor.Validate(); err == nil {
+			t.Errorf("Expected %q to be rejected", path)
+		}
+	}
+}
+
+func TestBusinessKeyService_IndexesAndPivots(t *testing.T) {
+	service := newTestBusinessKeyService()
+	if err := service.AddExtractor(&KeyExtractor{Key: "order_id", Path: "$.order.id", EventTypes: []string{"order_placed"}}); err != nil {
+		t.Fatalf("Failed to add extractor: %v", err)
+	}
+
+	base := time.Now()
+	for _, event := range []*struct {
+		id, service, eventType, metadata string
+		offset                           time.Duration
+	}{
+		{"e1", "trading-engine", "order_placed", `{"order": {"id": "ord-1"}, "account_id": "acc-1"}`, 0},
+		{"e2", "exchange", "order_acknowledged", `{"order_id": "ord-1"}`, time.Second},
+		{"e3", "exchange", "order_acknowledged", `{"order_id": "ord-2", "account_id": "acc-1"}`, 2 * time.Second},
+		// Nested order IDs are only extracted for order_placed events
+		{"e4", "custodian", "settled", `{"order": {"id": "ord-1"}}`, 3 * time.Second},
+	} {
+		service.IndexEvent(newTypedEvent(event.id, event.service, event.eventType, base.Add(event.offset), event.metadata))
+	}
+
+	events := service.GetEvents("order_id", "ord-1")
+	if len(events) != 2 || events[0].ID != "e1" || events[1].ID != "e2" {
+		t.Fatalf("Expected e1 and e2 for ord-1, got %d events", len(events))
+	}
+
+	timeline := service.GetTimeline("account_id", "acc-1")
+	if len(timeline) != 2 || timeline[0].EventID != "e1" || timeline[1].EventID != "e3" {
+		t.Errorf("Unexpected account timeline: %+v", timeline)
+	}
+
+	groups := service.Correlate("order_id", 2)
+	if len(groups) != 1 || groups[0].Key != "order_id=ord-1" || groups[0].Count != 2 {
+		t.Errorf("Unexpected order correlations: %+v", groups)
+	}
+
+	// Re-indexing the same event must not duplicate it
+	service.IndexEvent(newTypedEvent("e2", "exchange", "order_acknowledged", base.Add(time.Second), `{"order_id": "ord-1"}`))
+	if events := service.GetEvents("order_id", "ord-1"); len(events) != 2 {
+		t.Errorf("Expected re-indexed event to replace the original, got %d events", len(events))
+	}
+
+	for _, stats := range service.ListKeys() {
+		if stats.Key == "order_id" && (stats.DistinctValues != 2 || stats.IndexedEvents != 3) {
+			t.Errorf("Unexpected order_id stats: %+v", stats)
+		}
+	}
+}
+
+func TestAuditService_CorrelatesByBusinessKey(t *testing.T) {
+	service := newTestBusinessKeyService()
+	base := time.Now()
+
+	correlations := service.auditService.correlateByBusinessKey([]*models.AuditEvent{
+		newTypedEvent("e1", "trading-engine", "order_placed", base, `{"instrument": "BTC-USD"}`),
+		newTypedEvent("e2", "risk-monitor", "position_updated", base, `{"instrument": "BTC-USD", "strategy_id": "s1"}`),
+	})
+	if ids := correlations["instrument=BTC-USD"]; len(ids) != 2 {
+		t.Errorf("Expected both events correlated by instrument, got %v", correlations)
+	}
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// Clock offset estimation sources
+const (
+	ClockOffsetSourceIngestion = "ingestion"  // Derived from ingestion receive times
+	ClockOffsetSourceSpanPairs = "span_pairs" // Derived from parent/child span pairs across services
+)
+
+const (
+	// defaultMaxClockSamples bounds the samples kept per service or service pair
+	defaultMaxClockSamples = 256
+	// defaultMaxSeenSpans bounds the child spans remembered to sample each span pair once
+	defaultMaxSeenSpans = 65536
+	// receiptTrimFraction is the share of the largest receipt samples ignored, so that a
+	// few events stamped by a clock that jumped ahead do not set the offset
+	receiptTrimFraction = 0.05
+	// receiptOutlierMADs rejects receipt samples this many median absolute deviations above the median
+	receiptOutlierMADs = 5
+)
+
+// ClockOffset describes how far a service clock is ahead of the correlator clock
+type ClockOffset struct {
+	ServiceName string        `json:"service_name"`
+	Offset      time.Duration `json:"-"`
+	OffsetMs    float64       `json:"offset_ms"`
+	Samples     int           `json:"samples"`
+	Source      string        `json:"source"`
+}
+
+// servicePair identifies the caller (parent span) and callee (child span) services
+type servicePair struct {
+	parent string
+	child  string
+}
+
+// ClockSkewEstimator estimates per-service clock offsets so that timestamps reported by
+// different containers can be placed on a single timeline
+//
+// Two kinds of observations are used:
+//   - ingestion receipts: event time minus correlator receive time; transit delay is never
+//     negative, so the largest samples are the ones closest to the true offset; outliers
+//     and the top few percent are discarded before taking the largest remaining sample
+//   - span pairs: a child span must start after and end before its parent span; with both
+//     durations the NTP midpoint gives the relative offset, without them only an ordering
+//     violation (child before parent) is informative; each child span is sampled once, however
+//     often the pair is read back from storage
+type ClockSkewEstimator struct {
+	mu         sync.RWMutex
+	receipts   map[string][]time.Duration
+	pairs      map[servicePair][]time.Duration
+	maxSamples int
+	seen       map[string]struct{} // child spans already sampled
+	seenOrder  []string            // ring of seen child spans, oldest overwritten first
+	seenNext   int
+}
+
+// NewClockSkewEstimator creates a new clock skew estimator
+func NewClockSkewEstimator() *ClockSkewEstimator {
+	return &ClockSkewEstimator{
+		receipts:   make(map[string][]time.Duration),
+		pairs:      make(map[servicePair][]time.Duration),
+		maxSamples: defaultMaxClockSamples,
+		seen:       make(map[string]struct{}),
+	}
+}
+
+// ObserveReceipt records the receive time of an event reported by a service
+func (e *ClockSkewEstimator) ObserveReceipt(serviceName string, eventTime, receivedAt time.Time) {
+	if serviceName == "" || eventTime.IsZero() || receivedAt.IsZero() {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.receipts[serviceName] = appendBounded(e.receipts[serviceName], eventTime.Sub(receivedAt), e.maxSamples)
+}
+
+// ObserveSpanPair records the relative offset between a parent span and a child span
+// emitted by a different service; a child span already sampled is ignored
+func (e *ClockSkewEstimator) ObserveSpanPair(parent, child *models.AuditEvent) {
+	if parent == nil || child == nil || parent.ServiceName == "" || child.ServiceName == "" {
+		return
+	}
+	if parent.ServiceName == child.ServiceName {
+		return
+	}
+
+	startDelta := child.Timestamp.Sub(parent.Timestamp)
+
+	var sample time.Duration
+	parentDuration, parentOK := spanDuration(parent)
+	childDuration, childOK := spanDuration(child)
+	switch {
+	case parentOK && childOK:
+		endDelta := child.Timestamp.Add(childDuration).Sub(parent.Timestamp.Add(parentDuration))
+		sample = (startDelta + endDelta) / 2
+	case startDelta < 0:
+		// Child cannot start before its parent: the smallest correction restoring order
+		sample = startDelta
+	default:
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if child.SpanID != "" && !e.rememberSpanLocked(spanKey(child.TraceID, child.SpanID)) {
+		return
+	}
+	key := servicePair{parent: parent.ServiceName, child: child.ServiceName}
+	e.pairs[key] = appendBounded(e.pairs[key], sample, e.maxSamples)
+}
+
+// rememberSpanLocked marks a child span as sampled, reporting false if it already was
+func (e *ClockSkewEstimator) rememberSpanLocked(key string) bool {
+	if _, seen := e.seen[key]; seen {
+		return false
+	}
+	if len(e.seenOrder) < defaultMaxSeenSpans {
+		e.seenOrder = append(e.seenOrder, key)
+	} else {
+		delete(e.seen, e.seenOrder[e.seenNext])
+		e.seenOrder[e.seenNext] = key
+		e.seenNext = (e.seenNext + 1) % defaultMaxSeenSpans
+	}
+	e.seen[key] = struct{}{}
+	return true
+}
+
+// ObserveEvents records all cross-service parent/child span pairs found in the events
+func (e *ClockSkewEstimator) ObserveEvents(events []*models.AuditEvent) {
+	bySpan := make(map[string]*models.AuditEvent, len(events))
+	for _, event := range events {
+		if event.SpanID != "" {
+			bySpan[spanKey(event.TraceID, event.SpanID)] = event
+		}
+	}
+
+	for _, event := range events {
+		parentSpanID := metadataString(event, metadataKeyParentSpanID)
+		if parentSpanID == "" {
+			continue
+		}
+		if parent, ok := bySpan[spanKey(event.TraceID, parentSpanID)]; ok {
+			e.ObserveSpanPair(parent, event)
+		}
+	}
+}
+
+// Offsets returns the current offset estimate for every observed service
+// Services anchored by ingestion receipts are measured against the correlator clock;
+// span-pair offsets are then propagated outwards from anchored services. Components of
+// the span graph without any anchor are aligned to their first service (alphabetically).
+func (e *ClockSkewEstimator) Offsets() map[string]ClockOffset {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	offsets := make(map[string]ClockOffset)
+
+	for serviceName, samples := range e.receipts {
+		if len(samples) == 0 {
+			continue
+		}
+		offsets[serviceName] = newClockOffset(serviceName, receiptOffset(samples), len(samples), ClockOffsetSourceIngestion)
+	}
+
+	type neighbor struct {
+		service string
+		delta   time.Duration
+		samples int
+	}
+	adjacency := make(map[string][]neighbor)
+	for pair, samples := range e.pairs {
+		if len(samples) == 0 {
+			continue
+		}
+		delta := medianDuration(samples)
+		adjacency[pair.parent] = append(adjacency[pair.parent], neighbor{service: pair.child, delta: delta, samples: len(samples)})
+		adjacency[pair.child] = append(adjacency[pair.child], neighbor{service: pair.parent, delta: -delta, samples: len(samples)})
+	}
+
+	propagate := func(queue []string) {
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, next := range adjacency[current] {
+				if _, known := offsets[next.service]; known {
+					continue
+				}
+				offsets[next.service] = newClockOffset(next.service, offsets[current].Offset+next.delta, next.samples, ClockOffsetSourceSpanPairs)
+				queue = append(queue, next.service)
+			}
+		}
+	}
+
+	anchored := make([]string, 0, len(offsets))
+	for serviceName := range offsets {
+		anchored = append(anchored, serviceName)
+	}
+	sort.Strings(anchored)
+	propagate(anchored)
+
+	remaining := make([]string, 0, len(adjacency))
+	for serviceName := range adjacency {
+		remaining = append(remaining, serviceName)
+	}
+	sort.Strings(remaining)
+	for _, serviceName := range remaining {
+		if _, known := offsets[serviceName]; known {
+			continue
+		}
+		offsets[serviceName] = newClockOffset(serviceName, 0, 0, ClockOffsetSourceSpanPairs)
+		propagate([]string{serviceName})
+	}
+
+	return offsets
+}
+
+// OffsetFor returns the estimated offset for a single service (zero if unknown)
+func (e *ClockSkewEstimator) OffsetFor(serviceName string) time.Duration {
+	return e.Offsets()[serviceName].Offset
+}
+
+// NormalizeTimestamp converts an event timestamp to the correlator clock using the given offsets
+func NormalizeTimestamp(event *models.AuditEvent, offsets map[string]ClockOffset) time.Time {
+	if offset, ok := offsets[event.ServiceName]; ok {
+		return event.Timestamp.Add(-offset.Offset)
+	}
+	return event.Timestamp
+}
+
+// SortByNormalizedTime sorts events chronologically on the correlator clock
+func SortByNormalizedTime(events []*models.AuditEvent, offsets map[string]ClockOffset) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return NormalizeTimestamp(events[i], offsets).Before(NormalizeTimestamp(events[j], offsets))
+	})
+}
+
+// spanDuration reads the span duration reported in event metadata
+func spanDuration(event *models.AuditEvent) (time.Duration, bool) {
+	durationMs, ok := metadataFloat(event, metadataKeyDurationMs)
+	if !ok || durationMs < 0 {
+		return 0, false
+	}
+	return time.Duration(durationMs * float64(time.Millisecond)), true
+}
+
+func spanKey(traceID, spanID string) string {
+	return traceID + "/" + spanID
+}
+
+func newClockOffset(serviceName string, offset time.Duration, samples int, source string) ClockOffset {
+	return ClockOffset{
+		ServiceName: serviceName,
+		Offset:      offset,
+		OffsetMs:    float64(offset) / float64(time.Millisecond),
+		Samples:     samples,
+		Source:      source,
+	}
+}
+
+func appendBounded(samples []time.Duration, sample time.Duration, max int) []time.Duration {
+	samples = append(samples, sample)
+	if len(samples) > max {
+		samples = samples[len(samples)-max:]
+	}
+	return samples
+}
+
+// receiptOffset estimates the offset from receipt samples: samples far above the median are
+// rejected, then the largest receiptTrimFraction of the rest are skipped
+func receiptOffset(samples []time.Duration) time.Duration {
+	sorted := sortedDurations(samples)
+	median := medianOfSorted(sorted)
+
+	deviations := make([]time.Duration, len(sorted))
+	for i, sample := range sorted {
+		deviations[i] = (sample - median).Abs()
+	}
+	limit := median + receiptOutlierMADs*medianOfSorted(sortedDurations(deviations))
+	kept := sort.Search(len(sorted), func(i int) bool { return sorted[i] > limit })
+
+	trimmed := int(float64(kept) * receiptTrimFraction)
+	return sorted[kept-1-trimmed]
+}
+
+func medianDuration(samples []time.Duration) time.Duration {
+	return medianOfSorted(sortedDurations(samples))
+}
+
+func sortedDurations(samples []time.Duration) []time.Duration {
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func medianOfSorted(sorted []time.Duration) time.Duration {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
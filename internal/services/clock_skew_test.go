@@ -0,0 +1,172 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newTestEvent(id, traceID, spanID, serviceName string, ts time.Time, metadata string) *models.AuditEvent {
+	event := &models.AuditEvent{
+		ID:          id,
+		TraceID:     traceID,
+		SpanID:      spanID,
+		ServiceName: serviceName,
+		EventType:   "span",
+		Timestamp:   ts,
+	}
+	if metadata != "" {
+		event.Metadata = json.RawMessage(metadata)
+	}
+	return event
+}
+
+func TestClockSkewEstimator_ObserveReceipt(t *testing.T) {
+	estimator := NewClockSkewEstimator()
+	receivedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Service clock is 300ms ahead; transit delay varies between 5ms and 50ms
+	for _, delay := range []time.Duration{50 * time.Millisecond, 5 * time.Millisecond, 20 * time.Millisecond} {
+		trueTime := receivedAt.Add(-delay)
+		estimator.ObserveReceipt("trading-engine", trueTime.Add(300*time.Millisecond), receivedAt)
+	}
+
+	offset := estimator.Offsets()["trading-engine"]
+	if offset.Source != ClockOffsetSourceIngestion {
+		t.Errorf("Expected source %s, got %s", ClockOffsetSourceIngestion, offset.Source)
+	}
+	if offset.Offset != 295*time.Millisecond {
+		t.Errorf("Expected offset 295ms (minimum delay sample), got %v", offset.Offset)
+	}
+	if offset.Samples != 3 {
+		t.Errorf("Expected 3 samples, got %d", offset.Samples)
+	}
+}
+
+func TestClockSkewEstimator_ReceiptOffsetRejectsOutliers(t *testing.T) {
+	estimator := NewClockSkewEstimator()
+	receivedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Service clock is 300ms ahead with 5-44ms transit delay; two events were stamped while
+	// its clock had jumped 10s ahead
+	for i := 0; i < 40; i++ {
+		delay := time.Duration(5+i) * time.Millisecond
+		estimator.ObserveReceipt("trading-engine", receivedAt.Add(-delay).Add(300*time.Millisecond), receivedAt)
+	}
+	for i := 0; i < 2; i++ {
+		estimator.ObserveReceipt("trading-engine", receivedAt.Add(10*time.Second), receivedAt)
+	}
+
+	offset := estimator.Offsets()["trading-engine"].Offset
+	if offset < 290*time.Millisecond || offset > 295*time.Millisecond {
+		t.Errorf("Expected an offset close to 295ms despite the clock jump, got %v", offset)
+	}
+}
+
+func TestClockSkewEstimator_SamplesEachSpanPairOnce(t *testing.T) {
+	estimator := NewClockSkewEstimator()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	parent := newTestEvent("e1", "t1", "s1", "trading-engine", base, `{"duration_ms": 100}`)
+	child := newTestEvent("e2", "t1", "s2", "exchange-simulator", base.Add(-480*time.Millisecond),
+		`{"parent_span_id": "s1", "duration_ms": 60}`)
+
+	// The same pair read back by several queries counts once
+	for i := 0; i < 3; i++ {
+		estimator.ObserveEvents([]*models.AuditEvent{parent, child})
+	}
+	if samples := estimator.Offsets()["trading-engine"].Samples; samples != 1 {
+		t.Errorf("Expected one sample for a span pair read three times, got %d", samples)
+	}
+}
+
+func TestClockSkewEstimator_SpanPairsWithDurations(t *testing.T) {
+	estimator := NewClockSkewEstimator()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Parent on trading-engine spans 100ms; child on exchange-simulator starts 20ms later and
+	// takes 60ms, but the exchange clock is 500ms behind
+	parent := newTestEvent("e1", "t1", "s1", "trading-engine", base, `{"duration_ms": 100}`)
+	child := newTestEvent("e2", "t1", "s2", "exchange-simulator", base.Add(20*time.Millisecond).Add(-500*time.Millisecond),
+		`{"parent_span_id": "s1", "duration_ms": 60}`)
+
+	estimator.ObserveEvents([]*models.AuditEvent{parent, child})
+
+	offsets := estimator.Offsets()
+	relative := offsets["exchange-simulator"].Offset - offsets["trading-engine"].Offset
+	if relative != -500*time.Millisecond {
+		t.Errorf("Expected relative offset -500ms, got %v", relative)
+	}
+}
+
+func TestClockSkewEstimator_PropagatesFromIngestionAnchor(t *testing.T) {
+	estimator := NewClockSkewEstimator()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// trading-engine is anchored 100ms ahead via ingestion
+	estimator.ObserveReceipt("trading-engine", base.Add(100*time.Millisecond), base)
+
+	// custodian child span appears 400ms before its parent: at least 400ms behind
+	parent := newTestEvent("e1", "t1", "s1", "trading-engine", base, "")
+	child := newTestEvent("e2", "t1", "s2", "custodian", base.Add(-400*time.Millisecond), `{"parent_span_id": "s1"}`)
+	estimator.ObserveEvents([]*models.AuditEvent{parent, child})
+
+	offset := estimator.Offsets()["custodian"]
+	if offset.Source != ClockOffsetSourceSpanPairs {
+		t.Errorf("Expected source %s, got %s", ClockOffsetSourceSpanPairs, offset.Source)
+	}
+	if offset.Offset != -300*time.Millisecond {
+		t.Errorf("Expected custodian offset -300ms, got %v", offset.Offset)
+	}
+
+	// Normalized child must not precede normalized parent
+	offsets := estimator.Offsets()
+	if NormalizeTimestamp(child, offsets).Before(NormalizeTimestamp(parent, offsets)) {
+		t.Error("Expected normalized child span to start at or after its parent")
+	}
+}
+
+func TestClockSkewEstimator_IgnoresSameServicePairs(t *testing.T) {
+	estimator := NewClockSkewEstimator()
+	base := time.Now()
+
+	parent := newTestEvent("e1", "t1", "s1", "risk-monitor", base, "")
+	child := newTestEvent("e2", "t1", "s2", "risk-monitor", base.Add(-time.Second), `{"parent_span_id": "s1"}`)
+	estimator.ObserveEvents([]*models.AuditEvent{parent, child})
+
+	if len(estimator.Offsets()) != 0 {
+		t.Errorf("Expected no offsets for same-service spans, got %d", len(estimator.Offsets()))
+	}
+}
+
+func TestAuditService_TemporalProximityUsesNormalizedTime(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	service := NewAuditService(logger)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// exchange-simulator clock runs 10s ahead, so its raw timestamp falls outside the window
+	service.ClockSkewEstimator().ObserveReceipt("exchange-simulator", base.Add(10*time.Second), base)
+
+	events := []*models.AuditEvent{
+		newTestEvent("e1", "t1", "s1", "trading-engine", base, ""),
+		newTestEvent("e2", "t2", "s2", "exchange-simulator", base.Add(11*time.Second), ""),
+	}
+
+	groups := service.correlateByTemporalProximity(events, 5*time.Second, service.ClockSkewEstimator().Offsets())
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("Expected a single temporal group with 2 events, got %v", groups)
+	}
+
+	timeline := service.BuildTimeline(events)
+	if timeline[1].ClockOffsetMs != 10000 {
+		t.Errorf("Expected exchange-simulator offset 10000ms on timeline, got %v", timeline[1].ClockOffsetMs)
+	}
+	if !timeline[1].NormalizedTimestamp.Equal(base.Add(time.Second)) {
+		t.Errorf("Expected normalized timestamp %v, got %v", base.Add(time.Second), timeline[1].NormalizedTimestamp)
+	}
+}
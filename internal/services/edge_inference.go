@@ -0,0 +1,461 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+)
+
+const (
+	// defaultEdgeInferenceWindow is how far back traces are scanned when no window is given
+	defaultEdgeInferenceWindow = time.Hour
+
+	// Connection labels written by edge inference
+	edgeOriginLabel    = "edge.origin"
+	edgeOriginValue    = "inferred"
+	edgeTrafficLabel   = "traffic.observed"
+	inferredEdgePrefix = "inferred-"
+)
+
+// InferredEdge is the traffic observed between two topology nodes
+type InferredEdge struct {
+	EdgeID        string    `json:"edge_id"`
+	SourceID      string    `json:"source_id"`
+	TargetID      string    `json:"target_id"`
+	SourceService string    `json:"source_service"`
+	TargetService string    `json:"target_service"`
+	Type          string    `json:"type"`
+	Calls         int64     `json:"calls"`
+	Errors        int64     `json:"errors"`
+	LastSeen      time.Time `json:"last_seen"`
+	// Declared is false for edges known only from traces
+	Declared bool `json:"declared"`
+	// Created is true when this run added the edge to the topology
+	Created bool `json:"created"`
+}
+
+// EdgeInferenceResult summarises one scan of traces for cross-service calls
+type EdgeInferenceResult struct {
+	RunAt         time.Time      `json:"run_at"`
+	WindowStart   time.Time      `json:"window_start"`
+	WindowEnd     time.Time      `json:"window_end"`
+	EventsScanned int            `json:"events_scanned"`
+	Edges         []InferredEdge `json:"edges"`
+	// DeclaredWithoutTraffic lists declared edges that saw no calls in the window
+	DeclaredWithoutTraffic []string `json:"declared_without_traffic"`
+	// ObservedUndeclared lists edges that carried calls but were never declared
+	ObservedUndeclared []string `json:"observed_undeclared"`
+	// UnresolvedServices are services seen in cross-service calls with no matching node
+	UnresolvedServices []string `json:"unresolved_services"`
+	Errors             []string `json:"errors,omitempty"`
+}
+
+// observedCall is one child span whose parent span ran in another service
+type observedCall struct {
+	traceID      string
+	parentSpanID string
+	service      string
+	failed       bool
+	at           time.Time
+	connType     entities.ConnectionType
+}
+
+// callPair is a directed pair of services
+type callPair struct {
+	source string
+	target string
+}
+
+// callStats accumulates the calls observed for one service pair
+type callStats struct {
+	calls    int64
+	errors   int64
+	lastSeen time.Time
+	types    map[entities.ConnectionType]int64
+}
+
+// EdgeInferenceService derives topology edges from parent/child spans crossing services
+// Observed pairs without a declared edge are added as inferred edges; call counts are kept
+// in edge metadata so refreshing them does not advance the topology snapshot
+type EdgeInferenceService struct {
+	auditService    *AuditService
+	topologyService *TopologyService
+	logger          *logrus.Logger
+	window          time.Duration
+
+	runMu  sync.Mutex
+	mu     sync.RWMutex
+	latest *EdgeInferenceResult
+}
+
+// NewEdgeInferenceService creates an edge inference service scanning the given window of traces
+func NewEdgeInferenceService(auditService *AuditService, topologyService *TopologyService, window time.Duration, logger *logrus.Logger) *EdgeInferenceService {
+	if window <= 0 {
+		window = defaultEdgeInferenceWindow
+	}
+	return &EdgeInferenceService{
+		auditService:    auditService,
+		topologyService: topologyService,
+		logger:          logger,
+		window:          window,
+	}
+}
+
+// Run infers edges every interval until the context is cancelled
+func (s *EdgeInferenceService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			end := time.Now()
+			if _, err := s.Infer(ctx, end.Add(-s.window), end); err != nil {
+				s.logger.WithError(err).Warn("Edge inference failed")
+			}
+		}
+	}
+}
+
+// Window returns the default scan window
+func (s *EdgeInferenceService) Window() time.Duration {
+	return s.window
+}
+
+// Latest returns the result of the most recent run, or nil before the first run
+func (s *EdgeInferenceService) Latest() *EdgeInferenceResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest
+}
+
+// Infer scans traces in [start, end], creates or refreshes the edges they show and flags
+// declared edges without traffic and observed edges that were never declared
+func (s *EdgeInferenceService) Infer(ctx context.Context, start, end time.Time) (*EdgeInferenceResult, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: window end must be after its start", ErrInvalidSearch)
+	}
+
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	result := &EdgeInferenceResult{
+		RunAt:                  time.Now().UTC(),
+		WindowStart:            start.UTC(),
+		WindowEnd:              end.UTC(),
+		Edges:                  []InferredEdge{},
+		DeclaredWithoutTraffic: []string{},
+		ObservedUndeclared:     []string{},
+		UnresolvedServices:     []string{},
+	}
+
+	pairs, err := s.observeCalls(ctx, start, end, result)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := s.topologyService.Tracker()
+	snapshot, err := tracker.GetTopologySnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topology: %w", err)
+	}
+	resolve := nodeResolver(snapshot)
+	byEndpoints := make(map[callPair]*entities.ServiceConnection, len(snapshot.Connections))
+	for _, conn := range snapshot.Connections {
+		byEndpoints[callPair{source: conn.SourceID, target: conn.TargetID}] = conn
+	}
+
+	unresolved := make(map[string]bool)
+	trafficked := make(map[string]bool)
+	for _, pair := range sortedCallPairs(pairs) {
+		stats := pairs[pair]
+		sourceID, sourceOK := resolve(pair.source)
+		targetID, targetOK := resolve(pair.target)
+		if !sourceOK {
+			unresolved[pair.source] = true
+		}
+		if !targetOK {
+			unresolved[pair.target] = true
+		}
+		if !sourceOK || !targetOK || sourceID == targetID {
+			continue
+		}
+
+		edge := InferredEdge{
+			SourceID:      sourceID,
+			TargetID:      targetID,
+			SourceService: pair.source,
+			TargetService: pair.target,
+			Calls:         stats.calls,
+			Errors:        stats.errors,
+			LastSeen:      stats.lastSeen,
+		}
+		conn, exists := byEndpoints[callPair{source: sourceID, target: targetID}]
+		if !exists {
+			conn = entities.NewServiceConnection(inferredEdgePrefix+sourceID+"-to-"+targetID, sourceID, targetID, stats.connectionType())
+			conn.AddLabel(edgeOriginLabel, edgeOriginValue)
+			edge.Created = true
+		}
+		edge.EdgeID = conn.ID
+		edge.Type = connectionTypeName(conn.Type)
+		edge.Declared = conn.Labels[edgeOriginLabel] != edgeOriginValue
+
+		if err := s.markTraffic(ctx, conn, true); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", conn.ID, err))
+			continue
+		}
+		if err := s.recordEdgeMetadata(ctx, conn.ID, stats, end.Sub(start)); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", conn.ID, err))
+		}
+		trafficked[conn.ID] = true
+		result.Edges = append(result.Edges, edge)
+		if !edge.Declared {
+			result.ObservedUndeclared = append(result.ObservedUndeclared, conn.ID)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(snapshot.Connections)) {
+		conn := snapshot.Connections[id]
+		if trafficked[id] {
+			continue
+		}
+		if err := s.markTraffic(ctx, conn, false); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", id, err))
+		}
+		if conn.Labels[edgeOriginLabel] != edgeOriginValue {
+			result.DeclaredWithoutTraffic = append(result.DeclaredWithoutTraffic, id)
+		}
+	}
+	for service := range unresolved {
+		result.UnresolvedServices = append(result.UnresolvedServices, service)
+	}
+	sort.Strings(result.UnresolvedServices)
+
+	s.mu.Lock()
+	s.latest = result
+	s.mu.Unlock()
+
+	s.logger.WithFields(logrus.Fields{
+		"events_scanned":           result.EventsScanned,
+		"edges":                    len(result.Edges),
+		"declared_without_traffic": len(result.DeclaredWithoutTraffic),
+		"observed_undeclared":      len(result.ObservedUndeclared),
+		"unresolved_services":      len(result.UnresolvedServices),
+	}).Info("Inferred topology edges from traces")
+	return result, nil
+}
+
+// observeCalls walks the window and counts child spans called from another service
+func (s *EdgeInferenceService) observeCalls(ctx context.Context, start, end time.Time, result *EdgeInferenceResult) (map[callPair]*callStats, error) {
+	spanServices := make(map[string]string)
+	calls := make(map[string]*observedCall)
+
+	err := s.auditService.WalkEventsInWindow(ctx, WindowFilter{}, start, end, func(page []*models.AuditEvent) error {
+		for _, event := range page {
+			result.EventsScanned++
+			if event.SpanID == "" || event.ServiceName == "" {
+				continue
+			}
+			key := spanKey(event.TraceID, event.SpanID)
+			spanServices[key] = event.ServiceName
+
+			parentSpanID := metadataString(event, metadataKeyParentSpanID)
+			if parentSpanID == "" {
+				continue
+			}
+			// A span may log several events; it counts as one call that failed if any event did
+			call, exists := calls[key]
+			if !exists {
+				call = &observedCall{traceID: event.TraceID, parentSpanID: parentSpanID, service: event.ServiceName}
+				calls[key] = call
+			}
+			call.failed = call.failed || isErrorEvent(event)
+			if event.Timestamp.After(call.at) {
+				call.at = event.Timestamp
+			}
+			if call.connType == entities.ConnectionTypeUnspecified {
+				call.connType = inferConnectionType(event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[callPair]*callStats)
+	for _, call := range calls {
+		parentService, ok := spanServices[spanKey(call.traceID, call.parentSpanID)]
+		if !ok || parentService == call.service {
+			continue
+		}
+		pair := callPair{source: parentService, target: call.service}
+		stats, exists := pairs[pair]
+		if !exists {
+			stats = &callStats{types: make(map[entities.ConnectionType]int64)}
+			pairs[pair] = stats
+		}
+		stats.calls++
+		if call.failed {
+			stats.errors++
+		}
+		if call.at.After(stats.lastSeen) {
+			stats.lastSeen = call.at
+		}
+		if call.connType != entities.ConnectionTypeUnspecified {
+			stats.types[call.connType]++
+		}
+	}
+	return pairs, nil
+}
+
+// markTraffic registers the connection labelled with whether it carried traffic
+// The label only changes when traffic starts or stops, so steady traffic publishes nothing
+func (s *EdgeInferenceService) markTraffic(ctx context.Context, conn *entities.ServiceConnection, observed bool) error {
+	value := "false"
+	if observed {
+		value = "true"
+	}
+	if conn.Labels[edgeTrafficLabel] == value {
+		return nil
+	}
+	updated := conn.Clone()
+	updated.AddLabel(edgeTrafficLabel, value)
+	_, err := s.topologyService.Tracker().RegisterConnection(ctx, updated)
+	return err
+}
+
+// recordEdgeMetadata stores the observed call counts and rates in the edge metadata
+func (s *EdgeInferenceService) recordEdgeMetadata(ctx context.Context, edgeID string, stats *callStats, window time.Duration) error {
+	tracker := s.topologyService.Tracker()
+	metadata := entities.NewEdgeMetadata(edgeID)
+	if existing, err := tracker.GetEdgeMetadata(ctx, edgeID); err == nil && existing != nil {
+		copied := *existing
+		copied.CustomFields = maps.Clone(existing.CustomFields)
+		metadata = &copied
+	}
+	if metadata.CustomFields == nil {
+		metadata.CustomFields = make(map[string]interface{})
+	}
+
+	metadata.TotalMessages = stats.calls
+	metadata.TotalErrors = stats.errors
+	metadata.Throughput = float64(stats.calls) / window.Seconds()
+	metadata.ErrorRate = float64(stats.errors) / window.Seconds()
+	metadata.LastActiveTime = stats.lastSeen
+	metadata.CustomFields["observed_window_seconds"] = window.Seconds()
+	return tracker.UpdateEdgeMetadata(ctx, metadata)
+}
+
+// connectionType is the most common type observed for the pair, defaulting to gRPC
+func (c *callStats) connectionType() entities.ConnectionType {
+	best, bestCount := entities.ConnectionTypeGRPC, int64(0)
+	for _, connType := range []entities.ConnectionType{entities.ConnectionTypeGRPC, entities.ConnectionTypeHTTP, entities.ConnectionTypeDataFlow} {
+		if count := c.types[connType]; count > bestCount {
+			best, bestCount = connType, count
+		}
+	}
+	return best
+}
+
+// connectionTypeName renders a connection type with the protocol names inferConnectionType reads
+func connectionTypeName(connType entities.ConnectionType) string {
+	switch connType {
+	case entities.ConnectionTypeGRPC:
+		return "grpc"
+	case entities.ConnectionTypeHTTP:
+		return "http"
+	case entities.ConnectionTypeDataFlow:
+		return "data_flow"
+	default:
+		return "unspecified"
+	}
+}
+
+// inferConnectionType reads the transport of a call from its span metadata
+func inferConnectionType(event *models.AuditEvent) entities.ConnectionType {
+	switch strings.ToLower(metadataString(event, "protocol")) {
+	case "grpc":
+		return entities.ConnectionTypeGRPC
+	case "http", "https", "rest":
+		return entities.ConnectionTypeHTTP
+	case "kafka", "redis", "nats", "pubsub", "stream", "data_flow":
+		return entities.ConnectionTypeDataFlow
+	}
+
+	fields := decodeMetadata(event)
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := fields[key]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("grpc_method", "rpc_method", "rpc_service"):
+		return entities.ConnectionTypeGRPC
+	case has("http_method", "http_route", "http_status"):
+		return entities.ConnectionTypeHTTP
+	case has("topic", "queue", "channel", "stream"):
+		return entities.ConnectionTypeDataFlow
+	default:
+		return entities.ConnectionTypeUnspecified
+	}
+}
+
+// nodeResolver maps an event service name to a node by ID, instance name or name, falling back
+// to the only node of that service type
+func nodeResolver(snapshot *entities.NetworkTopology) func(service string) (string, bool) {
+	byName := make(map[string]string)
+	byType := make(map[string][]string)
+	for _, id := range slices.Sorted(maps.Keys(snapshot.Nodes)) {
+		node := snapshot.Nodes[id]
+		for _, name := range []string{node.Name, node.InstanceName} {
+			if _, taken := byName[name]; name != "" && !taken {
+				byName[name] = node.ID
+			}
+		}
+		byType[node.ServiceType] = append(byType[node.ServiceType], node.ID)
+	}
+	for id := range snapshot.Nodes {
+		byName[id] = id
+	}
+
+	return func(service string) (string, bool) {
+		if id, ok := byName[service]; ok {
+			return id, true
+		}
+		if ids := byType[service]; len(ids) == 1 {
+			return ids[0], true
+		}
+		return "", false
+	}
+}
+
+func sortedCallPairs(pairs map[callPair]*callStats) []callPair {
+	result := make([]callPair, 0, len(pairs))
+	for pair := range pairs {
+		result = append(result, pair)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].source != result[j].source {
+			return result[i].source < result[j].source
+		}
+		return result[i].target < result[j].target
+	})
+	return result
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+)
+
+func TestEdgeInference_InfersEdgesAndFlagsDrift(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	topologyService := NewTopologyService(logger)
+	tracker := topologyService.Tracker()
+	for _, node := range []*entities.ServiceNode{
+		entities.NewServiceNode("trading-engine-lh", "trading-engine-lh", "trading-system-engine", "trading-engine-lh"),
+		entities.NewServiceNode("exchange-okx", "exchange-okx", "exchange-simulator", "exchange-okx"),
+		entities.NewServiceNode("risk-monitor-lh", "risk-monitor-lh", "risk-monitor", "risk-monitor-lh"),
+	} {
+		if _, err := tracker.RegisterNode(ctx, node); err != nil {
+			t.Fatalf("Failed to register node: %v", err)
+		}
+	}
+	// Declared: engine -> exchange (carries traffic) and risk -> engine (carries none)
+	for _, conn := range []*entities.ServiceConnection{
+		entities.NewServiceConnection("engine-exchange", "trading-engine-lh", "exchange-okx", entities.ConnectionTypeGRPC),
+		entities.NewServiceConnection("risk-engine", "risk-monitor-lh", "trading-engine-lh", entities.ConnectionTypeGRPC),
+	} {
+		if _, err := tracker.RegisterConnection(ctx, conn); err != nil {
+			t.Fatalf("Failed to register connection: %v", err)
+		}
+	}
+
+	base := time.Now().Add(-10 * time.Minute)
+	events := newMemoryDataAdapter(
+		// Engine calls the exchange twice, once failing; the exchange logs two events for span s2
+		newTestEvent("e1", "t1", "s1", "trading-system-engine", base, ""),
+		newTestEvent("e2", "t1", "s2", "exchange-okx", base.Add(time.Millisecond), `{"parent_span_id":"s1","grpc_method":"PlaceOrder"}`),
+		newTestEvent("e3", "t1", "s2", "exchange-okx", base.Add(2*time.Millisecond), `{"parent_span_id":"s1"}`),
+		newTestEvent("e4", "t2", "s3", "trading-system-engine", base.Add(time.Second), ""),
+		newTestEvent("e5", "t2", "s4", "exchange-okx", base.Add(time.Second+time.Millisecond), `{"parent_span_id":"s3","error":"rejected"}`),
+		// The exchange publishes to the risk monitor, which was never declared
+		newTestEvent("e6", "t2", "s5", "risk-monitor-lh", base.Add(2*time.Second), `{"parent_span_id":"s4","topic":"fills"}`),
+		// Calls within one service and calls from unknown services are not edges
+		newTestEvent("e7", "t2", "s6", "exchange-okx", base.Add(3*time.Second), `{"parent_span_id":"s4"}`),
+		newTestEvent("e8", "t3", "s7", "settlement-batch", base.Add(4*time.Second), ""),
+		newTestEvent("e9", "t3", "s8", "exchange-okx", base.Add(5*time.Second), `{"parent_span_id":"s7"}`),
+	)
+	auditService := NewAuditServiceWithDataAdapter(events, logger)
+
+	inference := NewEdgeInferenceService(auditService, topologyService, time.Hour, logger)
+	end := time.Now()
+	result, err := inference.Infer(ctx, end.Add(-time.Hour), end)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	if result.EventsScanned != 9 || len(result.Edges) != 2 {
+		t.Fatalf("Expected 2 edges from 9 events, got %+v", result)
+	}
+	inferred, declared := result.Edges[0], result.Edges[1]
+	if declared.EdgeID != "engine-exchange" || !declared.Declared || declared.Calls != 2 || declared.Errors != 1 {
+		t.Errorf("Unexpected declared edge %+v", declared)
+	}
+	if inferred.EdgeID != "inferred-exchange-okx-to-risk-monitor-lh" || inferred.Declared || !inferred.Created || inferred.Type != "data_flow" {
+		t.Errorf("Unexpected inferred edge %+v", inferred)
+	}
+	if len(result.DeclaredWithoutTraffic) != 1 || result.DeclaredWithoutTraffic[0] != "risk-engine" {
+		t.Errorf("Expected risk-engine to be flagged without traffic, got %v", result.DeclaredWithoutTraffic)
+	}
+	if len(result.ObservedUndeclared) != 1 || result.ObservedUndeclared[0] != inferred.EdgeID {
+		t.Errorf("Expected the inferred edge to be flagged undeclared, got %v", result.ObservedUndeclared)
+	}
+	if len(result.UnresolvedServices) != 1 || result.UnresolvedServices[0] != "settlement-batch" {
+		t.Errorf("Expected settlement-batch to be unresolved, got %v", result.UnresolvedServices)
+	}
+
+	conn, err := tracker.GetConnection(ctx, inferred.EdgeID)
+	if err != nil {
+		t.Fatalf("Expected the inferred edge in the topology: %v", err)
+	}
+	if conn.Type != entities.ConnectionTypeDataFlow || conn.Labels["edge.origin"] != "inferred" {
+		t.Errorf("Unexpected inferred connection %+v", conn)
+	}
+	if unused, _ := tracker.GetConnection(ctx, "risk-engine"); unused.Labels["traffic.observed"] != "false" {
+		t.Errorf("Expected the unused edge to be labelled without traffic, got %v", unused.Labels)
+	}
+	metadata, err := tracker.GetEdgeMetadata(ctx, "engine-exchange")
+	if err != nil || metadata.TotalMessages != 2 || metadata.TotalErrors != 1 {
+		t.Errorf("Expected call counts in the edge metadata, got %+v (%v)", metadata, err)
+	}
+
+	// A second run over the same traces refreshes counts without changing the topology
+	snapshotID := tracker.SnapshotID()
+	if _, err := inference.Infer(ctx, end.Add(-time.Hour), end); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if tracker.SnapshotID() != snapshotID {
+		t.Errorf("Expected an unchanged topology to keep snapshot %s, got %s", snapshotID, tracker.SnapshotID())
+	}
+	if inference.Latest() == nil {
+		t.Error("Expected the latest result to be kept")
+	}
+}
@@ -0,0 +1,530 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// EventFilter is a compiled filter expression over audit events
+//
+// Grammar (keywords are case-insensitive):
+//
+//	expr       := term ("OR" term)*
+//	term       := factor ("AND" factor)*
+//	factor     := "NOT" factor | "(" expr ")" | comparison
+//	comparison := field op value | field "IN" "(" value ("," value)* ")" | field "HAS" value | field "EXISTS"
+//	op         := "=" | "!=" | ">" | ">=" | "<" | "<=" | "~"
+//
+// Fields are id, trace_id, span_id, service_name (service), event_type (type), status,
+// timestamp, tags and metadata.<path> (see KeyExtractor for path syntax). Values are
+// quoted strings or bare words; timestamps accept RFC3339, now and now-<duration>.
+type EventFilter struct {
+	expression string
+	root       filterNode
+}
+
+// CompileEventFilter parses a filter expression; an empty expression matches everything
+func CompileEventFilter(expression string) (*EventFilter, error) {
+	filter := &EventFilter{expression: strings.TrimSpace(expression)}
+	if filter.expression == "" {
+		return filter, nil
+	}
+
+	tokens, err := tokenizeFilter(filter.expression)
+	if err != nil {
+		return nil, err
+	}
+	parser := &filterParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !parser.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d", parser.peek().text, parser.peek().pos)
+	}
+	filter.root = root
+	return filter, nil
+}
+
+// String returns the source expression
+func (f *EventFilter) String() string {
+	return f.expression
+}
+
+// Matches evaluates the filter against an event
+func (f *EventFilter) Matches(event *models.AuditEvent) bool {
+	if f.root == nil {
+		return true
+	}
+	return f.root.match(&filterTarget{event: event})
+}
+
+// pushdown returns exact matches and time bounds implied by the top-level AND chain
+// These can be evaluated by the data adapter before the filter is applied in memory
+func (f *EventFilter) pushdown() (WindowFilter, *time.Time, *time.Time) {
+	var window WindowFilter
+	var after, before *time.Time
+
+	var walk func(node filterNode)
+	walk = func(node filterNode) {
+		switch n := node.(type) {
+		case *andNode:
+			walk(n.left)
+			walk(n.right)
+		case *comparisonNode:
+			switch {
+			case n.op == "=" && len(n.values) == 1:
+				switch n.field.name {
+				case "trace_id":
+					window.TraceID = n.values[0]
+				case "service_name":
+					window.ServiceName = n.values[0]
+				case "event_type":
+					window.EventType = n.values[0]
+				}
+			case n.field.name == "timestamp" && n.times != nil:
+				bound := n.times[0]
+				if (n.op == ">" || n.op == ">=") && (after == nil || bound.After(*after)) {
+					after = &bound
+				}
+				if (n.op == "<" || n.op == "<=") && (before == nil || bound.Before(*before)) {
+					before = &bound
+				}
+			}
+		}
+	}
+	if f.root != nil {
+		walk(f.root)
+	}
+	return window, after, before
+}
+
+// filterTarget lazily decodes metadata once per evaluated event
+type filterTarget struct {
+	event    *models.AuditEvent
+	metadata map[string]interface{}
+}
+
+func (t *filterTarget) decodedMetadata() map[string]interface{} {
+	if t.metadata == nil {
+		t.metadata = decodeMetadata(t.event)
+	}
+	return t.metadata
+}
+
+type filterNode interface {
+	match(target *filterTarget) bool
+}
+
+type andNode struct{ left, right filterNode }
+type orNode struct{ left, right filterNode }
+type notNode struct{ inner filterNode }
+
+func (n *andNode) match(t *filterTarget) bool { return n.left.match(t) && n.right.match(t) }
+func (n *orNode) match(t *filterTarget) bool  { return n.left.match(t) || n.right.match(t) }
+func (n *notNode) match(t *filterTarget) bool { return !n.inner.match(t) }
+
+// filterField is a resolved field reference
+type filterField struct {
+	name     string
+	metadata *KeyExtractor
+}
+
+func resolveFilterField(name string) (filterField, error) {
+	switch strings.ToLower(name) {
+	case "id", "trace_id", "span_id", "status", "timestamp", "tags":
+		return filterField{name: strings.ToLower(name)}, nil
+	case "service", "service_name":
+		return filterField{name: "service_name"}, nil
+	case "type", "event_type":
+		return filterField{name: "event_type"}, nil
+	}
+
+	if path, ok := strings.CutPrefix(name, "metadata."); ok {
+		extractor := &KeyExtractor{Key: name, Path: "$." + path}
+		if err := extractor.Validate(); err != nil {
+			return filterField{}, err
+		}
+		return filterField{name: "metadata", metadata: extractor}, nil
+	}
+	return filterField{}, fmt.Errorf("unknown field %q", name)
+}
+
+func (f filterField) values(t *filterTarget) []string {
+	event := t.event
+	switch f.name {
+	case "id":
+		return []string{event.ID}
+	case "trace_id":
+		return []string{event.TraceID}
+	case "span_id":
+		return []string{event.SpanID}
+	case "service_name":
+		return []string{event.ServiceName}
+	case "event_type":
+		return []string{event.EventType}
+	case "status":
+		return []string{string(event.Status)}
+	case "tags":
+		return event.Tags
+	case "metadata":
+		return f.metadata.Extract(t.decodedMetadata())
+	}
+	return nil
+}
+
+// comparisonNode compares a field with one or more literal values
+type comparisonNode struct {
+	field  filterField
+	op     string // =, !=, >, >=, <, <=, ~, in, has, exists
+	values []string
+	times  []time.Time // parsed values when field is timestamp
+}
+
+func (n *comparisonNode) match(t *filterTarget) bool {
+	if n.field.name == "timestamp" {
+		return n.matchTime(t.event.Timestamp)
+	}
+
+	actual := n.field.values(t)
+	switch n.op {
+	case "exists":
+		for _, value := range actual {
+			if value != "" {
+				return true
+			}
+		}
+		return false
+	case "!=":
+		for _, value := range actual {
+			if compareFilterValues(value, n.values[0]) == 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, value := range actual {
+		for _, expected := range n.values {
+			if compareWithOp(value, expected, n.op) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *comparisonNode) matchTime(ts time.Time) bool {
+	if n.op == "exists" {
+		return !ts.IsZero()
+	}
+	for _, expected := range n.times {
+		cmp := ts.Compare(expected)
+		if cmpSatisfies(cmp, n.op) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareWithOp(actual, expected, op string) bool {
+	switch op {
+	case "~":
+		return strings.Contains(actual, expected)
+	case "in", "has":
+		op = "="
+	}
+	return cmpSatisfies(compareFilterValues(actual, expected), op)
+}
+
+func cmpSatisfies(cmp int, op string) bool {
+	switch op {
+	case "=", "in", "has":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// compareFilterValues compares numerically when both sides are numbers, otherwise as strings
+func compareFilterValues(a, b string) int {
+	af, aErr := strconv.ParseFloat(a, 64)
+	bf, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// parseFilterTime accepts RFC3339 timestamps, now, and now±<duration>
+func parseFilterTime(value string, now time.Time) (time.Time, error) {
+	lower := strings.ToLower(value)
+	if lower == "now" {
+		return now, nil
+	}
+	if rest, ok := strings.CutPrefix(lower, "now"); ok && (rest[0] == '-' || rest[0] == '+') {
+		duration, err := time.ParseDuration(rest[1:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+		}
+		if rest[0] == '-' {
+			duration = -duration
+		}
+		return now.Add(duration), nil
+	}
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: use RFC3339 or now-<duration>", value)
+	}
+	return ts, nil
+}
+
+type filterTokenKind int
+
+const (
+	tokenWord filterTokenKind = iota
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenEOF
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func tokenizeFilter(input string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: tokenComma, text: ",", pos: i})
+			i++
+		case r == '"' || r == '\'':
+			var b strings.Builder
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, filterToken{kind: tokenString, text: b.String(), pos: start})
+		case strings.ContainsRune("=!<>~", r):
+			start := i
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' && r != '~' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected '!' at position %d", start)
+			}
+			i += len(op)
+			tokens = append(tokens, filterToken{kind: tokenOp, text: op, pos: start})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("(),=!<>~\"'", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: tokenWord, text: string(runes[start:i]), pos: start})
+		}
+	}
+	return append(tokens, filterToken{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	now    time.Time
+}
+
+func (p *filterParser) peek() filterToken { return p.tokens[p.pos] }
+func (p *filterParser) done() bool        { return p.peek().kind == tokenEOF }
+
+func (p *filterParser) next() filterToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("AND") {
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseFactor() (filterNode, error) {
+	token := p.peek()
+	switch {
+	case token.isKeyword("NOT"):
+		p.next()
+		inner, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner: inner}, nil
+	case token.kind == tokenLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenRParen {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", token.pos)
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	fieldToken := p.next()
+	if fieldToken.kind != tokenWord {
+		return nil, fmt.Errorf("expected field at position %d", fieldToken.pos)
+	}
+	field, err := resolveFilterField(fieldToken.text)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &comparisonNode{field: field}
+	opToken := p.next()
+	switch {
+	case opToken.kind == tokenOp:
+		node.op = opToken.text
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		node.values = []string{value}
+	case opToken.isKeyword("IN"):
+		node.op = "in"
+		if p.next().kind != tokenLParen {
+			return nil, fmt.Errorf("expected '(' after IN at position %d", opToken.pos)
+		}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			node.values = append(node.values, value)
+			separator := p.next()
+			if separator.kind == tokenRParen {
+				break
+			}
+			if separator.kind != tokenComma {
+				return nil, fmt.Errorf("expected ',' or ')' at position %d", separator.pos)
+			}
+		}
+	case opToken.isKeyword("HAS"):
+		node.op = "has"
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		node.values = []string{value}
+	case opToken.isKeyword("EXISTS"):
+		node.op = "exists"
+	default:
+		return nil, fmt.Errorf("expected operator after %s at position %d", fieldToken.text, opToken.pos)
+	}
+
+	if field.name == "timestamp" && node.op != "exists" {
+		if node.op == "~" || node.op == "has" {
+			return nil, fmt.Errorf("operator %s is not supported for timestamp", node.op)
+		}
+		if p.now.IsZero() {
+			p.now = time.Now()
+		}
+		for _, value := range node.values {
+			ts, err := parseFilterTime(value, p.now)
+			if err != nil {
+				return nil, err
+			}
+			node.times = append(node.times, ts)
+		}
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseValue() (string, error) {
+	token := p.next()
+	if token.kind != tokenWord && token.kind != tokenString {
+		return "", fmt.Errorf("expected value at position %d", token.pos)
+	}
+	return token.text, nil
+}
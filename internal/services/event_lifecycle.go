@@ -0,0 +1,593 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// Processing states an event moves through after it is stored as pending
+const (
+	EventStatusValidated  models.AuditEventStatus = "validated"
+	EventStatusEnriched   models.AuditEventStatus = "enriched"
+	EventStatusCorrelated models.AuditEventStatus = "correlated"
+	EventStatusFailed     models.AuditEventStatus = "failed"
+)
+
+const (
+	lifecycleQueueSize   = 10000
+	maxLifecycleRecords  = 50000
+	maxLifecycleAttempts = 5
+	lifecycleRetryBase   = time.Second
+	lifecycleRetryMax    = 5 * time.Minute
+	// lifecycleRecordTTL bounds how long lifecycle records are kept in the store
+	lifecycleRecordTTL   = 7 * 24 * time.Hour
+	lifecycleKeyPrefix   = "audit:lifecycle:"
+	maxFutureEventSkew   = 24 * time.Hour
+	lifecycleListDefault = 100
+	// traceCorrelationType links an event to the previous event of its trace
+	traceCorrelationType = "trace"
+)
+
+// LifecycleStore persists lifecycle records; the data adapter's cache repository satisfies it
+type LifecycleStore interface {
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Get(ctx context.Context, key string, dest interface{}) error
+	GetKeysByPattern(ctx context.Context, pattern string) ([]string, error)
+}
+
+// errPermanent marks stage failures that retrying cannot fix
+var errPermanent = errors.New("permanent failure")
+
+// LifecycleTransition is one status change of an event
+type LifecycleTransition struct {
+	From  models.AuditEventStatus `json:"from"`
+	To    models.AuditEventStatus `json:"to"`
+	At    time.Time               `json:"at"`
+	Error string                  `json:"error,omitempty"`
+}
+
+// EventLifecycleRecord tracks the processing of one event
+// Status is the current state; Stage is the last state reached successfully, where a retry resumes
+type EventLifecycleRecord struct {
+	EventID      string                  `json:"event_id"`
+	Status       models.AuditEventStatus `json:"status"`
+	Stage        models.AuditEventStatus `json:"stage"`
+	Attempts     int                     `json:"attempts"`
+	LastError    string                  `json:"last_error,omitempty"`
+	Permanent    bool                    `json:"permanent,omitempty"`
+	NextRetryAt  *time.Time              `json:"next_retry_at,omitempty"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+	BusinessKeys map[string][]string     `json:"business_keys,omitempty"`
+	TraceEvents  int                     `json:"trace_events"`            // events of the trace processed up to this one
+	CorrelatedTo string                  `json:"correlated_to,omitempty"` // previous event of the trace
+	History      []LifecycleTransition   `json:"history"`
+	Event        *models.AuditEvent      `json:"event"`
+}
+
+// snapshot copies a record so it can be read or serialized outside the processor lock
+func (r *EventLifecycleRecord) snapshot() EventLifecycleRecord {
+	copied := *r
+	copied.History = append([]LifecycleTransition(nil), r.History...)
+	if r.Event != nil {
+		event := *r.Event
+		copied.Event = &event
+	}
+	return copied
+}
+
+// lifecycleStage advances an event from one status to the next
+type lifecycleStage struct {
+	from models.AuditEventStatus
+	to   models.AuditEventStatus
+	run  func(ctx context.Context, record *EventLifecycleRecord) error
+}
+
+// traceLink is the last event correlated in a trace
+type traceLink struct {
+	last   string
+	events int
+}
+
+// EventLifecycleProcessor moves stored events through validation, enrichment and correlation
+// Stage failures are retried with exponential backoff; validation failures are permanent
+// The data adapter cannot update stored events, so lifecycle records carry the current status
+// Only correlated records are evicted; once every tracked record is still in progress or
+// failed, new events are rejected rather than dropping work that has not finished
+type EventLifecycleProcessor struct {
+	auditService *AuditService
+	store        LifecycleStore
+	logger       *logrus.Logger
+	queue        chan string
+	stages       []lifecycleStage
+	businessKeys BusinessKeyFunc
+
+	maxRecords int
+
+	mu       sync.RWMutex
+	records  map[string]*EventLifecycleRecord
+	finished *list.List               // correlated event IDs, oldest first
+	inList   map[string]*list.Element // position of each correlated event in finished
+	traces   map[string]*traceLink
+	rejected int64
+}
+
+// NewEventLifecycleProcessor creates a processor fed by the audit service's event observers
+// store may be nil, in which case lifecycle records are kept in memory only
+func NewEventLifecycleProcessor(auditService *AuditService, store LifecycleStore, logger *logrus.Logger) *EventLifecycleProcessor {
+	p := &EventLifecycleProcessor{
+		auditService: auditService,
+		store:        store,
+		logger:       logger,
+		queue:        make(chan string, lifecycleQueueSize),
+		maxRecords:   maxLifecycleRecords,
+		records:      make(map[string]*EventLifecycleRecord),
+		finished:     list.New(),
+		inList:       make(map[string]*list.Element),
+		traces:       make(map[string]*traceLink),
+	}
+	p.stages = []lifecycleStage{
+		{from: models.AuditEventStatusPending, to: EventStatusValidated, run: p.validate},
+		{from: EventStatusValidated, to: EventStatusEnriched, run: p.enrich},
+		{from: EventStatusEnriched, to: EventStatusCorrelated, run: p.correlate},
+	}
+	if auditService != nil {
+		auditService.AddEventObserver(p.Observe)
+	}
+	return p
+}
+
+// SetBusinessKeyFunc sets the extractor used to enrich events with business keys
+func (p *EventLifecycleProcessor) SetBusinessKeyFunc(extract BusinessKeyFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.businessKeys = extract
+}
+
+// Start restores failed records from the store and runs workers until ctx is done
+func (p *EventLifecycleProcessor) Start(ctx context.Context, workers int) {
+	p.restore(ctx)
+
+	for i := 0; i < max(workers, 1); i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case id := <-p.queue:
+					p.process(ctx, id)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(lifecycleRetryBase)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				p.retryDue(now)
+			}
+		}
+	}()
+}
+
+// Observe registers a newly accepted event as pending and queues it
+func (p *EventLifecycleProcessor) Observe(event *models.AuditEvent) {
+	// Work on a copy so status changes do not race with other observers of the event
+	copied := *event
+	copied.Status = models.AuditEventStatusPending
+	now := time.Now()
+
+	p.mu.Lock()
+	p.forgetLocked(event.ID)
+	if !p.makeRoomLocked() {
+		p.rejected++
+		p.mu.Unlock()
+		p.logger.WithField("event_id", event.ID).Warn("Lifecycle records full of unfinished events, event not tracked")
+		return
+	}
+	p.records[event.ID] = &EventLifecycleRecord{
+		EventID:   event.ID,
+		Status:    models.AuditEventStatusPending,
+		Stage:     models.AuditEventStatusPending,
+		UpdatedAt: now,
+		History:   []LifecycleTransition{},
+		Event:     &copied,
+	}
+	p.mu.Unlock()
+
+	p.enqueue(event.ID)
+}
+
+// enqueue hands an event to the workers, deferring it to the retry loop if the queue is full
+func (p *EventLifecycleProcessor) enqueue(id string) {
+	select {
+	case p.queue <- id:
+	default:
+		p.mu.Lock()
+		if record, exists := p.records[id]; exists {
+			retryAt := time.Now().Add(lifecycleRetryBase)
+			record.NextRetryAt = &retryAt
+		}
+		p.mu.Unlock()
+		p.logger.WithField("event_id", id).Warn("Lifecycle queue full, deferring event")
+	}
+}
+
+// process runs the remaining stages of an event, stopping at the first failure
+func (p *EventLifecycleProcessor) process(ctx context.Context, id string) {
+	for _, stage := range p.stages {
+		p.mu.Lock()
+		record, exists := p.records[id]
+		if !exists || record.Stage != stage.from {
+			p.mu.Unlock()
+			continue
+		}
+		record.NextRetryAt = nil
+		working := record.snapshot()
+		p.mu.Unlock()
+
+		err := stage.run(ctx, &working)
+
+		p.mu.Lock()
+		record, exists = p.records[id]
+		if !exists {
+			p.mu.Unlock()
+			return
+		}
+		record.BusinessKeys = working.BusinessKeys
+		record.TraceEvents = working.TraceEvents
+		record.CorrelatedTo = working.CorrelatedTo
+		if err != nil {
+			p.failLocked(record, err)
+			snapshot := record.snapshot()
+			p.mu.Unlock()
+			p.persist(ctx, &snapshot)
+			p.logger.WithError(err).WithFields(logrus.Fields{
+				"event_id": id,
+				"stage":    stage.to,
+				"attempts": snapshot.Attempts,
+			}).Warn("Event lifecycle stage failed")
+			return
+		}
+		p.transitionLocked(record, stage.to, "")
+		record.Stage = stage.to
+		record.Attempts = 0
+		record.LastError = ""
+		if stage.to == EventStatusCorrelated {
+			p.inList[id] = p.finished.PushBack(id)
+		}
+		p.mu.Unlock()
+	}
+
+	p.mu.RLock()
+	record, exists := p.records[id]
+	var snapshot EventLifecycleRecord
+	if exists {
+		snapshot = record.snapshot()
+	}
+	p.mu.RUnlock()
+	if exists {
+		p.persist(ctx, &snapshot)
+	}
+}
+
+func (p *EventLifecycleProcessor) transitionLocked(record *EventLifecycleRecord, to models.AuditEventStatus, errText string) {
+	now := time.Now()
+	record.History = append(record.History, LifecycleTransition{From: record.Status, To: to, At: now, Error: errText})
+	record.Status = to
+	record.UpdatedAt = now
+	if record.Event != nil {
+		record.Event.Status = to
+	}
+}
+
+// failLocked marks a record failed and schedules a retry unless the failure is permanent or retries are exhausted
+func (p *EventLifecycleProcessor) failLocked(record *EventLifecycleRecord, err error) {
+	record.Attempts++
+	record.LastError = err.Error()
+	record.Permanent = errors.Is(err, errPermanent)
+	record.NextRetryAt = nil
+	p.transitionLocked(record, EventStatusFailed, err.Error())
+	if !record.Permanent && record.Attempts < maxLifecycleAttempts {
+		retryAt := record.UpdatedAt.Add(lifecycleBackoff(record.Attempts))
+		record.NextRetryAt = &retryAt
+	}
+}
+
+// lifecycleBackoff doubles the retry delay per attempt up to lifecycleRetryMax
+func lifecycleBackoff(attempts int) time.Duration {
+	delay := lifecycleRetryBase
+	for i := 1; i < attempts && delay < lifecycleRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, lifecycleRetryMax)
+}
+
+// retryDue queues records whose retry time has passed
+func (p *EventLifecycleProcessor) retryDue(now time.Time) {
+	var due []string
+	p.mu.Lock()
+	for id, record := range p.records {
+		if record.NextRetryAt != nil && !record.NextRetryAt.After(now) {
+			record.NextRetryAt = nil
+			due = append(due, id)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, id := range due {
+		p.enqueue(id)
+	}
+}
+
+// Requeue resets the retry budget of a failed event and queues it again
+func (p *EventLifecycleProcessor) Requeue(eventID string) (*EventLifecycleRecord, error) {
+	p.mu.Lock()
+	record, exists := p.records[eventID]
+	if !exists {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("lifecycle record not found: %s", eventID)
+	}
+	if record.Status != EventStatusFailed {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("event %s is %s, only failed events can be requeued", eventID, record.Status)
+	}
+	record.Attempts = 0
+	record.Permanent = false
+	record.NextRetryAt = nil
+	snapshot := record.snapshot()
+	p.mu.Unlock()
+
+	p.enqueue(eventID)
+	return &snapshot, nil
+}
+
+// RequeueFailed requeues every failed event and returns how many were queued
+func (p *EventLifecycleProcessor) RequeueFailed() int {
+	var ids []string
+	p.mu.RLock()
+	for id, record := range p.records {
+		if record.Status == EventStatusFailed {
+			ids = append(ids, id)
+		}
+	}
+	p.mu.RUnlock()
+
+	requeued := 0
+	for _, id := range ids {
+		if _, err := p.Requeue(id); err == nil {
+			requeued++
+		}
+	}
+	return requeued
+}
+
+// GetRecord returns the lifecycle record of an event
+func (p *EventLifecycleProcessor) GetRecord(eventID string) (*EventLifecycleRecord, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	record, exists := p.records[eventID]
+	if !exists {
+		return nil, fmt.Errorf("lifecycle record not found: %s", eventID)
+	}
+	snapshot := record.snapshot()
+	return &snapshot, nil
+}
+
+// ListByStatus returns up to limit records in a status, most recently updated first
+func (p *EventLifecycleProcessor) ListByStatus(status models.AuditEventStatus, limit int) []EventLifecycleRecord {
+	if limit <= 0 {
+		limit = lifecycleListDefault
+	}
+
+	p.mu.RLock()
+	records := make([]EventLifecycleRecord, 0)
+	for _, record := range p.records {
+		if status == "" || record.Status == status {
+			records = append(records, record.snapshot())
+		}
+	}
+	p.mu.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].UpdatedAt.Equal(records[j].UpdatedAt) {
+			return records[i].UpdatedAt.After(records[j].UpdatedAt)
+		}
+		return records[i].EventID < records[j].EventID
+	})
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	return records
+}
+
+// Rejected returns how many events were not tracked because no record could be evicted
+func (p *EventLifecycleProcessor) Rejected() int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.rejected
+}
+
+// StatusCounts returns the number of tracked events in each status
+func (p *EventLifecycleProcessor) StatusCounts() map[models.AuditEventStatus]int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	counts := map[models.AuditEventStatus]int{
+		models.AuditEventStatusPending: 0,
+		EventStatusValidated:           0,
+		EventStatusEnriched:            0,
+		EventStatusCorrelated:          0,
+		EventStatusFailed:              0,
+	}
+	for _, record := range p.records {
+		counts[record.Status]++
+	}
+	return counts
+}
+
+// ParseEventStatus validates a lifecycle status name
+func ParseEventStatus(status string) (models.AuditEventStatus, error) {
+	switch parsed := models.AuditEventStatus(status); parsed {
+	case models.AuditEventStatusPending, EventStatusValidated, EventStatusEnriched, EventStatusCorrelated, EventStatusFailed:
+		return parsed, nil
+	default:
+		return "", fmt.Errorf("unknown event status: %s", status)
+	}
+}
+
+// validate checks the fields every downstream consumer relies on; failures are permanent
+func (p *EventLifecycleProcessor) validate(_ context.Context, record *EventLifecycleRecord) error {
+	event := record.Event
+	switch {
+	case event == nil:
+		return fmt.Errorf("%w: event payload missing", errPermanent)
+	case event.ServiceName == "":
+		return fmt.Errorf("%w: service_name is required", errPermanent)
+	case event.EventType == "":
+		return fmt.Errorf("%w: event_type is required", errPermanent)
+	case event.Timestamp.IsZero():
+		return fmt.Errorf("%w: timestamp is required", errPermanent)
+	case event.Timestamp.After(time.Now().Add(maxFutureEventSkew)):
+		return fmt.Errorf("%w: timestamp is more than %s in the future", errPermanent, maxFutureEventSkew)
+	}
+	if len(event.Metadata) > 0 {
+		var metadata map[string]interface{}
+		if err := json.Unmarshal(event.Metadata, &metadata); err != nil {
+			return fmt.Errorf("%w: metadata is not a JSON object", errPermanent)
+		}
+	}
+	return nil
+}
+
+// enrich attaches the business keys of the event
+func (p *EventLifecycleProcessor) enrich(_ context.Context, record *EventLifecycleRecord) error {
+	p.mu.RLock()
+	extract := p.businessKeys
+	p.mu.RUnlock()
+
+	if extract != nil {
+		record.BusinessKeys = extract(record.Event)
+	}
+	return nil
+}
+
+// correlate stores a trace correlation from the previous event of the trace to this one
+// The predecessor is chosen once, so a retry stores the same correlation
+func (p *EventLifecycleProcessor) correlate(_ context.Context, record *EventLifecycleRecord) error {
+	traceID := record.Event.TraceID
+	if traceID == "" || p.auditService == nil {
+		return nil
+	}
+
+	if record.TraceEvents == 0 {
+		p.mu.Lock()
+		link, exists := p.traces[traceID]
+		if !exists {
+			link = &traceLink{}
+			p.traces[traceID] = link
+		}
+		record.CorrelatedTo = link.last
+		link.last = record.EventID
+		link.events++
+		record.TraceEvents = link.events
+		p.mu.Unlock()
+	}
+
+	if record.CorrelatedTo == "" {
+		return nil
+	}
+	return p.auditService.CreateCorrelation(record.CorrelatedTo, record.EventID, traceCorrelationType, 1.0)
+}
+
+// makeRoomLocked evicts the oldest correlated record when at the record limit, reporting
+// whether there is room for another record
+func (p *EventLifecycleProcessor) makeRoomLocked() bool {
+	if len(p.records) < p.maxRecords {
+		return true
+	}
+	oldest := p.finished.Front()
+	if oldest == nil {
+		return false
+	}
+	p.forgetLocked(oldest.Value.(string))
+	return true
+}
+
+// forgetLocked drops a record and its trace link once no later event can chain to it
+func (p *EventLifecycleProcessor) forgetLocked(id string) {
+	record, exists := p.records[id]
+	if !exists {
+		return
+	}
+	if element, finished := p.inList[id]; finished {
+		p.finished.Remove(element)
+		delete(p.inList, id)
+	}
+	if record.Event != nil {
+		if link, linked := p.traces[record.Event.TraceID]; linked && link.last == id {
+			delete(p.traces, record.Event.TraceID)
+		}
+	}
+	delete(p.records, id)
+}
+
+// persist stores a record best-effort; the in-memory record stays authoritative
+func (p *EventLifecycleProcessor) persist(ctx context.Context, record *EventLifecycleRecord) {
+	if p.store == nil {
+		return
+	}
+	if err := p.store.Set(ctx, lifecycleKeyPrefix+record.EventID, record, lifecycleRecordTTL); err != nil {
+		p.logger.WithError(err).WithField("event_id", record.EventID).Warn("Failed to persist lifecycle record")
+	}
+}
+
+// restore reloads failed records so they can be retried or requeued after a restart
+func (p *EventLifecycleProcessor) restore(ctx context.Context) {
+	if p.store == nil {
+		return
+	}
+	keys, err := p.store.GetKeysByPattern(ctx, lifecycleKeyPrefix+"*")
+	if err != nil {
+		p.logger.WithError(err).Warn("Failed to list persisted lifecycle records")
+		return
+	}
+
+	restored := 0
+	p.mu.Lock()
+	for _, key := range keys {
+		var record EventLifecycleRecord
+		if err := p.store.Get(ctx, key, &record); err != nil || record.Status != EventStatusFailed || record.Event == nil {
+			continue
+		}
+		if _, exists := p.records[record.EventID]; exists {
+			continue
+		}
+		if !p.makeRoomLocked() {
+			p.rejected++
+			continue
+		}
+		p.records[record.EventID] = &record
+		restored++
+	}
+	p.mu.Unlock()
+
+	if restored > 0 {
+		p.logger.WithField("records", restored).Info("Restored failed lifecycle records")
+	}
+}
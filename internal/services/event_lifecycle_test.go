@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newTestLifecycleProcessor() *EventLifecycleProcessor {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	return NewEventLifecycleProcessor(NewAuditService(logger), nil, logger)
+}
+
+// observeAndProcess registers an event and runs it through the pipeline synchronously
+func observeAndProcess(p *EventLifecycleProcessor, event *models.AuditEvent) *EventLifecycleRecord {
+	p.Observe(event)
+	<-p.queue
+	p.process(context.Background(), event.ID)
+	record, _ := p.GetRecord(event.ID)
+	return record
+}
+
+func TestEventLifecycle_AdvancesToCorrelated(t *testing.T) {
+	p := newTestLifecycleProcessor()
+	p.SetBusinessKeyFunc(func(*models.AuditEvent) map[string][]string {
+		return map[string][]string{"order_id": {"ord-1"}}
+	})
+
+	event := newTypedEvent("e1", "exchange", "order_filled", time.Now(), `{"order_id": "ord-1"}`)
+	record := observeAndProcess(p, event)
+
+	if record.Status != EventStatusCorrelated || record.Event.Status != EventStatusCorrelated {
+		t.Fatalf("Expected correlated, got %s", record.Status)
+	}
+	if event.Status == EventStatusCorrelated {
+		t.Errorf("Expected the observed event to be left untouched")
+	}
+	var path []string
+	for _, transition := range record.History {
+		path = append(path, string(transition.To))
+	}
+	if strings.Join(path, ",") != "validated,enriched,correlated" {
+		t.Errorf("Unexpected transitions: %v", path)
+	}
+	if record.BusinessKeys["order_id"][0] != "ord-1" {
+		t.Errorf("Expected business keys from enrichment, got %v", record.BusinessKeys)
+	}
+}
+
+func TestEventLifecycle_ValidationFailureIsPermanent(t *testing.T) {
+	p := newTestLifecycleProcessor()
+
+	record := observeAndProcess(p, newTypedEvent("e1", "exchange", "", time.Now(), `{}`))
+
+	if record.Status != EventStatusFailed || !record.Permanent || record.NextRetryAt != nil {
+		t.Errorf("Expected a permanent failure without retry, got %+v", record)
+	}
+	if !strings.Contains(record.LastError, "event_type") {
+		t.Errorf("Unexpected error: %s", record.LastError)
+	}
+}
+
+func TestEventLifecycle_RetryResumesFailedStage(t *testing.T) {
+	p := newTestLifecycleProcessor()
+	failures := 2
+	enrichCalls := 0
+	p.stages[1].run = func(context.Context, *EventLifecycleRecord) error {
+		enrichCalls++
+		return nil
+	}
+	p.stages[2].run = func(context.Context, *EventLifecycleRecord) error {
+		if failures > 0 {
+			failures--
+			return errors.New("storage unavailable")
+		}
+		return nil
+	}
+
+	record := observeAndProcess(p, newTypedEvent("e1", "exchange", "order_filled", time.Now(), `{}`))
+	if record.Status != EventStatusFailed || record.Stage != EventStatusEnriched || record.NextRetryAt == nil {
+		t.Fatalf("Expected a retryable failure after enrichment, got %+v", record)
+	}
+
+	// The retry loop queues records once their backoff has passed
+	p.retryDue(time.Now().Add(lifecycleRetryMax))
+	<-p.queue
+	p.process(context.Background(), "e1")
+	record, _ = p.GetRecord("e1")
+	if record.Attempts != 2 || record.NextRetryAt.Sub(record.UpdatedAt) < 2*lifecycleRetryBase {
+		t.Errorf("Expected a doubled backoff on the second attempt, got %+v", record)
+	}
+
+	p.retryDue(time.Now().Add(lifecycleRetryMax))
+	<-p.queue
+	p.process(context.Background(), "e1")
+	record, _ = p.GetRecord("e1")
+	if record.Status != EventStatusCorrelated || record.Attempts != 0 {
+		t.Errorf("Expected correlation on the third attempt, got %+v", record)
+	}
+	if enrichCalls != 1 {
+		t.Errorf("Expected retries to resume at correlation, enrichment ran %d times", enrichCalls)
+	}
+}
+
+func TestEventLifecycle_ExhaustedRetriesAndRequeue(t *testing.T) {
+	p := newTestLifecycleProcessor()
+	p.stages[2].run = func(context.Context, *EventLifecycleRecord) error {
+		return errors.New("storage unavailable")
+	}
+
+	p.Observe(newTypedEvent("e1", "exchange", "order_filled", time.Now(), `{}`))
+	for i := 0; i < maxLifecycleAttempts; i++ {
+		<-p.queue
+		p.process(context.Background(), "e1")
+		p.retryDue(time.Now().Add(lifecycleRetryMax))
+	}
+	record, _ := p.GetRecord("e1")
+	if record.Attempts != maxLifecycleAttempts || record.NextRetryAt != nil || len(p.queue) != 0 {
+		t.Fatalf("Expected retries to stop after %d attempts, got %+v", maxLifecycleAttempts, record)
+	}
+
+	if failed := p.ListByStatus(EventStatusFailed, 0); len(failed) != 1 || failed[0].EventID != "e1" {
+		t.Errorf("Expected e1 to be listed as failed, got %v", failed)
+	}
+	if requeued := p.RequeueFailed(); requeued != 1 || len(p.queue) != 1 {
+		t.Errorf("Expected one requeued event, got %d", requeued)
+	}
+	record, _ = p.GetRecord("e1")
+	if record.Attempts != 0 {
+		t.Errorf("Expected requeue to reset attempts, got %d", record.Attempts)
+	}
+
+	p.Observe(newTypedEvent("e2", "exchange", "order_filled", time.Now(), `{}`))
+	if _, err := p.Requeue("e2"); err == nil {
+		t.Errorf("Expected requeue of a pending event to fail")
+	}
+	if _, err := p.Requeue("missing"); err == nil {
+		t.Errorf("Expected requeue of an unknown event to fail")
+	}
+}
+
+func TestEventLifecycle_CorrelatesConsecutiveTraceEvents(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	adapter := newMemoryDataAdapter()
+	p := NewEventLifecycleProcessor(NewAuditServiceWithDataAdapter(adapter, logger), nil, logger)
+
+	base := time.Now()
+	for i, id := range []string{"e1", "e2", "e3"} {
+		observeAndProcess(p, newTestEvent(id, "trace-1", "span-"+id, "exchange", base.Add(time.Duration(i)*time.Second), `{}`))
+	}
+	observeAndProcess(p, newTestEvent("other", "trace-2", "span-other", "exchange", base, `{}`))
+
+	if len(adapter.correlations) != 2 {
+		t.Fatalf("Expected one correlation per linked trace event, got %d", len(adapter.correlations))
+	}
+	for i, want := range [][2]string{{"e1", "e2"}, {"e2", "e3"}} {
+		got := adapter.correlations[i]
+		if got.SourceEventID != want[0] || got.TargetEventID != want[1] || got.CorrelationType != traceCorrelationType {
+			t.Errorf("Expected %s -> %s, got %s -> %s (%s)", want[0], want[1], got.SourceEventID, got.TargetEventID, got.CorrelationType)
+		}
+	}
+	record, _ := p.GetRecord("e3")
+	if record.TraceEvents != 3 || record.CorrelatedTo != "e2" {
+		t.Errorf("Expected e3 to be the third trace event after e2, got %d after %q", record.TraceEvents, record.CorrelatedTo)
+	}
+}
+
+func TestEventLifecycle_EvictsOnlyCorrelatedRecords(t *testing.T) {
+	p := newTestLifecycleProcessor()
+	p.maxRecords = 2
+
+	observeAndProcess(p, newTypedEvent("done", "exchange", "order_filled", time.Now(), `{}`))
+	p.Observe(newTypedEvent("pending", "exchange", "order_filled", time.Now(), `{}`))
+	<-p.queue
+
+	p.Observe(newTypedEvent("new", "exchange", "order_filled", time.Now(), `{}`))
+	<-p.queue
+	if _, err := p.GetRecord("done"); err == nil {
+		t.Errorf("Expected the correlated record to be evicted")
+	}
+	if _, err := p.GetRecord("pending"); err != nil {
+		t.Errorf("Expected the pending record to be kept")
+	}
+
+	p.Observe(newTypedEvent("rejected", "exchange", "order_filled", time.Now(), `{}`))
+	if _, err := p.GetRecord("rejected"); err == nil || p.Rejected() != 1 {
+		t.Errorf("Expected the event to be rejected while only unfinished records are tracked, rejected %d", p.Rejected())
+	}
+	if len(p.queue) != 0 {
+		t.Errorf("Expected a rejected event not to be queued")
+	}
+}
+
+func TestParseEventStatus(t *testing.T) {
+	if status, err := ParseEventStatus("failed"); err != nil || status != EventStatusFailed {
+		t.Errorf("Expected failed, got %s, %v", status, err)
+	}
+	if _, err := ParseEventStatus("archived"); err == nil {
+		t.Errorf("Expected an unknown status to be rejected")
+	}
+}
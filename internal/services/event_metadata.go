@@ -0,0 +1,98 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// Well-known metadata keys written by emitting services and by the correlator itself
+const (
+	metadataKeyParentSpanID        = "parent_span_id"
+	metadataKeyDurationMs          = "duration_ms"
+	metadataKeyScenarioID          = "scenario_id"
+	metadataKeyReceivedAt          = "received_at"
+	metadataKeyOriginalTimestamp   = "original_timestamp"
+	metadataKeyNormalizedTimestamp = "normalized_timestamp"
+	metadataKeyClockOffsetMs       = "clock_offset_ms"
+)
+
+// decodeMetadata decodes an event's raw metadata into a generic map
+// Events with empty or non-object metadata yield an empty map
+func decodeMetadata(event *models.AuditEvent) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if event == nil || len(event.Metadata) == 0 {
+		return fields
+	}
+	if err := json.Unmarshal(event.Metadata, &fields); err != nil {
+		return make(map[string]interface{})
+	}
+	return fields
+}
+
+// metadataString returns a top-level metadata value rendered as a string
+func metadataString(event *models.AuditEvent, key string) string {
+	value, ok := decodeMetadata(event)[key]
+	if !ok {
+		return ""
+	}
+	return renderMetadataValue(value)
+}
+
+// renderMetadataValue renders a decoded JSON value as a string; nil renders empty
+func renderMetadataValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// metadataFloat returns a top-level numeric metadata value
+// Numeric strings are accepted since several emitters serialise numbers as strings
+func metadataFloat(event *models.AuditEvent, key string) (float64, bool) {
+	value, ok := decodeMetadata(event)[key]
+	if !ok || value == nil {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+// mergeMetadata returns raw metadata with the given fields added (existing keys are overwritten)
+func mergeMetadata(raw json.RawMessage, fields map[string]interface{}) (json.RawMessage, error) {
+	merged := make(map[string]interface{})
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &merged); err != nil {
+			return nil, fmt.Errorf("metadata is not a JSON object: %w", err)
+		}
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	encoded, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+const (
+	// defaultSearchWindow is searched when a request gives no time range
+	defaultSearchWindow = 24 * time.Hour
+	defaultSearchLimit  = 100
+	maxSearchLimit      = 1000
+	// maxSearchScan bounds the events read from storage for one search page
+	maxSearchScan = 50000
+)
+
+// ErrInvalidSearch marks errors caused by the search request rather than by storage
+var ErrInvalidSearch = errors.New("invalid search")
+
+// searchSortFields are the fields results can be sorted by
+var searchSortFields = map[string]bool{
+	"timestamp":    true,
+	"service_name": true,
+	"event_type":   true,
+	"trace_id":     true,
+	"id":           true,
+}
+
+// SearchRequest describes an event search
+type SearchRequest struct {
+	Filter    string     `json:"filter"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	SortBy    string     `json:"sort_by"`    // timestamp (default), service_name, event_type, trace_id, id
+	SortOrder string     `json:"sort_order"` // asc or desc (default)
+	Fields    []string   `json:"fields"`     // projection; empty returns whole events
+	Limit     int        `json:"limit"`
+	Cursor    string     `json:"cursor"`
+}
+
+// SearchResult is one page of search results
+type SearchResult struct {
+	Events      []map[string]interface{} `json:"events"`
+	Count       int                      `json:"count"`
+	HasMore     bool                     `json:"has_more"`
+	NextCursor  string                   `json:"next_cursor,omitempty"`
+	Matched     int                      `json:"matched"`
+	Scanned     int                      `json:"scanned"`
+	Truncated   bool                     `json:"truncated"` // the scan limit was reached before the page filled
+	WindowStart time.Time                `json:"window_start"`
+	WindowEnd   time.Time                `json:"window_end"`
+}
+
+// searchCursor is the opaque pagination state handed back to clients
+// The window is frozen on the first page so later pages are stable as new events arrive
+type searchCursor struct {
+	Query    string    `json:"q"`
+	Start    time.Time `json:"s"`
+	End      time.Time `json:"e"`
+	LastKey  string    `json:"k"`
+	LastID   string    `json:"i"`
+	LastTime time.Time `json:"t"`
+}
+
+// SearchService evaluates filter expressions over stored events
+type SearchService struct {
+	auditService *AuditService
+	logger       *logrus.Logger
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(auditService *AuditService, logger *logrus.Logger) *SearchService {
+	return &SearchService{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// searchPlan is a validated search request
+type searchPlan struct {
+	filter     *EventFilter
+	sortBy     string
+	descending bool
+	limit      int
+	fields     []string
+	queryHash  string
+	cursor     *searchCursor
+}
+
+// Search returns one page of events matching the request
+func (s *SearchService) Search(req SearchRequest) (*SearchResult, error) {
+	plan, err := planSearch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := searchWindow(req, plan)
+	if start.After(end) {
+		return nil, fmt.Errorf("%w: window start must not be after end", ErrInvalidSearch)
+	}
+
+	var result *SearchResult
+	if plan.sortBy == "timestamp" {
+		result, err = s.seekPage(plan, start, end)
+	} else {
+		result, err = s.sortedPage(plan, start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"filter":  plan.filter.String(),
+		"scanned": result.Scanned,
+		"matched": result.Matched,
+		"count":   result.Count,
+	}).Debug("Event search completed")
+
+	return result, nil
+}
+
+// planSearch validates a request and applies defaults
+func planSearch(req SearchRequest) (*searchPlan, error) {
+	filter, err := CompileEventFilter(req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+
+	plan := &searchPlan{filter: filter, sortBy: req.SortBy, descending: true, limit: req.Limit, fields: req.Fields}
+	if plan.sortBy == "" {
+		plan.sortBy = "timestamp"
+	}
+	if !searchSortFields[plan.sortBy] {
+		return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidSearch, plan.sortBy)
+	}
+	switch strings.ToLower(req.SortOrder) {
+	case "", "desc":
+	case "asc":
+		plan.descending = false
+	default:
+		return nil, fmt.Errorf("%w: sort_order must be asc or desc", ErrInvalidSearch)
+	}
+
+	if plan.limit <= 0 {
+		plan.limit = defaultSearchLimit
+	}
+	if plan.limit > maxSearchLimit {
+		plan.limit = maxSearchLimit
+	}
+
+	plan.queryHash = searchQueryHash(filter.String(), plan.sortBy, plan.descending, req.Fields)
+	if req.Cursor != "" {
+		plan.cursor, err = decodeSearchCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if plan.cursor.Query != plan.queryHash {
+			return nil, fmt.Errorf("%w: cursor does not belong to this query", ErrInvalidSearch)
+		}
+	}
+	return plan, nil
+}
+
+// seekPage walks storage in the requested timestamp order from the cursor position,
+// stopping once the page is full or maxSearchScan events have been read
+// A page cut short by the scan limit still carries a cursor at the last event read,
+// so clients page on through windows of any size.
+func (s *SearchService) seekPage(p *searchPlan, start, end time.Time) (*SearchResult, error) {
+	pushdown, _, _ := p.filter.pushdown()
+	pushdown.Descending = p.descending
+	from, to := start, end
+	if p.cursor != nil {
+		if p.descending && p.cursor.LastTime.Before(to) {
+			to = p.cursor.LastTime
+		} else if !p.descending && p.cursor.LastTime.After(from) {
+			from = p.cursor.LastTime
+		}
+	}
+
+	result := &SearchResult{Events: make([]map[string]interface{}, 0), WindowStart: start, WindowEnd: end}
+	var last *models.AuditEvent
+	err := s.auditService.WalkEventsInWindow(context.Background(), pushdown, from, to, func(page []*models.AuditEvent) error {
+		for _, event := range page {
+			if p.cursor != nil && !p.after(event) {
+				continue
+			}
+			if result.Scanned >= maxSearchScan {
+				result.Truncated = true
+				result.HasMore = true
+				return errStopWalk
+			}
+			if !p.filter.Matches(event) {
+				result.Scanned++
+				last = event
+				continue
+			}
+			if result.Count == p.limit {
+				result.HasMore = true
+				return errStopWalk
+			}
+			result.Scanned++
+			result.Matched++
+			last = event
+			projected, err := projectEvent(event, p.fields)
+			if err != nil {
+				return err
+			}
+			result.Events = append(result.Events, projected)
+			result.Count++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.HasMore && last != nil {
+		result.NextCursor = encodeSearchCursor(&searchCursor{
+			Query:    p.queryHash,
+			Start:    start,
+			End:      end,
+			LastKey:  searchSortKey(last, p.sortBy),
+			LastID:   last.ID,
+			LastTime: last.Timestamp,
+		})
+	}
+	return result, nil
+}
+
+// after reports whether event comes strictly after the cursor position in walk order
+func (p *searchPlan) after(event *models.AuditEvent) bool {
+	if !event.Timestamp.Equal(p.cursor.LastTime) {
+		return event.Timestamp.After(p.cursor.LastTime) != p.descending
+	}
+	return event.ID != p.cursor.LastID && (event.ID > p.cursor.LastID) != p.descending
+}
+
+// sortedPage serves fields storage cannot order: the newest maxSearchScan events of the
+// window are read and sorted in memory
+func (s *SearchService) sortedPage(p *searchPlan, start, end time.Time) (*SearchResult, error) {
+	pushdown, _, _ := p.filter.pushdown()
+	pushdown.Descending = true
+	events, err := s.auditService.GetFilteredEventsInWindow(pushdown, start, end, maxSearchScan)
+	if err != nil {
+		return nil, err
+	}
+	return p.page(events, start, end)
+}
+
+// page filters, sorts and paginates events read from [start, end]
+func (p *searchPlan) page(events []*models.AuditEvent, start, end time.Time) (*SearchResult, error) {
+	type keyedEvent struct {
+		key   string
+		event *models.AuditEvent
+	}
+	matched := make([]keyedEvent, 0)
+	for _, event := range events {
+		if p.filter.Matches(event) {
+			matched = append(matched, keyedEvent{key: searchSortKey(event, p.sortBy), event: event})
+		}
+	}
+
+	// Sort key plus event ID is a total order, so cursors resume at an exact position
+	less := func(aKey, aID, bKey, bID string) bool {
+		if aKey != bKey {
+			return (aKey < bKey) != p.descending
+		}
+		return (aID < bID) != p.descending
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return less(matched[i].key, matched[i].event.ID, matched[j].key, matched[j].event.ID)
+	})
+
+	offset := 0
+	if p.cursor != nil {
+		offset = sort.Search(len(matched), func(i int) bool {
+			return less(p.cursor.LastKey, p.cursor.LastID, matched[i].key, matched[i].event.ID)
+		})
+	}
+
+	page := matched[offset:]
+	if len(page) > p.limit {
+		page = page[:p.limit]
+	}
+
+	result := &SearchResult{
+		Events:      make([]map[string]interface{}, 0, len(page)),
+		Count:       len(page),
+		HasMore:     offset+len(page) < len(matched),
+		Matched:     len(matched),
+		Scanned:     len(events),
+		Truncated:   len(events) >= maxSearchScan,
+		WindowStart: start,
+		WindowEnd:   end,
+	}
+	for _, item := range page {
+		projected, err := projectEvent(item.event, p.fields)
+		if err != nil {
+			return nil, err
+		}
+		result.Events = append(result.Events, projected)
+	}
+
+	if result.HasMore {
+		last := page[len(page)-1]
+		result.NextCursor = encodeSearchCursor(&searchCursor{
+			Query:    p.queryHash,
+			Start:    start,
+			End:      end,
+			LastKey:  last.key,
+			LastID:   last.event.ID,
+			LastTime: last.event.Timestamp,
+		})
+	}
+	return result, nil
+}
+
+// searchWindow resolves the window from the cursor, the request, or timestamp bounds in the filter
+func searchWindow(req SearchRequest, plan *searchPlan) (time.Time, time.Time) {
+	if plan.cursor != nil {
+		return plan.cursor.Start, plan.cursor.End
+	}
+	return filterWindow(plan.filter, req.StartTime, req.EndTime, defaultSearchWindow)
+}
+
+// filterWindow resolves a window from explicit bounds, then timestamp bounds in the filter,
+// falling back to a window of the given length ending now
+func filterWindow(filter *EventFilter, startTime, endTime *time.Time, fallback time.Duration) (time.Time, time.Time) {
+	_, after, before := filter.pushdown()
+	end := time.Now()
+	if endTime != nil {
+		end = *endTime
+	} else if before != nil {
+		end = *before
+	}
+
+	start := end.Add(-fallback)
+	if startTime != nil {
+		start = *startTime
+	} else if after != nil {
+		start = *after
+	}
+	return start, end
+}
+
+// searchSortKey renders the sort field so that string order matches field order
+func searchSortKey(event *models.AuditEvent, sortBy string) string {
+	switch sortBy {
+	case "service_name":
+		return event.ServiceName
+	case "event_type":
+		return event.EventType
+	case "trace_id":
+		return event.TraceID
+	case "id":
+		return event.ID
+	default:
+		return event.Timestamp.UTC().Format("20060102T150405.000000000")
+	}
+}
+
+// projectEvent returns the requested fields of an event; metadata.<path> fields select into metadata
+func projectEvent(event *models.AuditEvent, fields []string) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	full := make(map[string]interface{})
+	if err := json.Unmarshal(encoded, &full); err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	if len(fields) == 0 {
+		return full, nil
+	}
+
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if path, ok := strings.CutPrefix(field, "metadata."); ok {
+			extractor := &KeyExtractor{Key: field, Path: "$." + path}
+			if err := extractor.Validate(); err != nil {
+				return nil, fmt.Errorf("%w: invalid projection %q: %v", ErrInvalidSearch, field, err)
+			}
+			values := extractor.Extract(decodeMetadata(event))
+			switch len(values) {
+			case 0:
+				projected[field] = nil
+			case 1:
+				projected[field] = values[0]
+			default:
+				projected[field] = values
+			}
+			continue
+		}
+		if value, ok := full[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected, nil
+}
+
+func searchQueryHash(filter, sortBy string, descending bool, fields []string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%t|%s", filter, sortBy, descending, strings.Join(fields, ","))))
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodeSearchCursor(cursor *searchCursor) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeSearchCursor(value string) (*searchCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidSearch)
+	}
+	var cursor searchCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidSearch)
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func TestEventFilter_Matches(t *testing.T) {
+	now := time.Now()
+	event := newTypedEvent("e1", "trading-engine", "order_filled", now.Add(-10*time.Minute),
+		`{"order": {"id": "ord-1", "quantity": 150}, "venue": "OKX"}`)
+	event.TraceID = "trace-1"
+	event.Tags = []string{"scenario-7", "btc"}
+
+	tests := []struct {
+		filter   string
+		expected bool
+	}{
+		{`service_name = "trading-engine"`, true},
+		{`service = trading-engine AND type = order_filled`, true},
+		{`service != trading-engine`, false},
+		{`metadata.order.quantity > 100`, true},
+		{`metadata.order.quantity >= 200`, false},
+		{`metadata.venue IN ("OKX", "Binance")`, true},
+		{`metadata.venue in (Binance)`, false},
+		{`tags HAS btc`, true},
+		{`tags has eth OR trace_id = trace-1`, true},
+		{`NOT (tags has btc)`, false},
+		{`metadata.order.id EXISTS AND metadata.missing exists`, false},
+		{`metadata.missing != x`, true},
+		{`event_type ~ fill`, true},
+		{`timestamp > now-1h AND timestamp < now-5m`, true},
+		{`timestamp >= "` + now.Format(time.RFC3339) + `"`, false},
+		{``, true},
+	}
+
+	for _, tt := range tests {
+		filter, err := CompileEventFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("Failed to compile %q: %v", tt.filter, err)
+		}
+		if got := filter.Matches(event); got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.filter, tt.expected, got)
+		}
+	}
+}
+
+func TestEventFilter_RejectsInvalidExpressions(t *testing.T) {
+	for _, expression := range []string{
+		`unknown = 1`,
+		`service =`,
+		`service = a AND`,
+		`(service = a`,
+		`service = "unterminated`,
+		`service a`,
+		`metadata.x IN (a b)`,
+		`timestamp > yesterday`,
+		`service = a extra`,
+	} {
+		if _, err := CompileEventFilter(expression); err == nil {
+			t.Errorf("Expected %q to be rejected", expression)
+		}
+	}
+}
+
+func TestEventFilter_Pushdown(t *testing.T) {
+	filter, err := CompileEventFilter(`service = exchange AND (type = a OR type = b) AND timestamp >= "2026-01-01T00:00:00Z"`)
+	if err != nil {
+		t.Fatalf("Failed to compile: %v", err)
+	}
+
+	window, after, before := filter.pushdown()
+	if window.ServiceName != "exchange" || window.EventType != "" {
+		t.Errorf("Expected only the service to be pushed down, got %+v", window)
+	}
+	if after == nil || !after.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || before != nil {
+		t.Errorf("Unexpected time bounds: %v %v", after, before)
+	}
+}
+
+func TestSearchPlan_PaginatesWithCursor(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	var events []*models.AuditEvent
+	for i := 0; i < 7; i++ {
+		service := "exchange"
+		if i%2 == 1 {
+			service = "custodian"
+		}
+		// Two events share each timestamp so the ID tie-break is exercised
+		events = append(events, newTypedEvent(fmt.Sprintf("e%d", i), service, "order_filled",
+			base.Add(time.Duration(i/2)*time.Second), fmt.Sprintf(`{"order_id": "ord-%d"}`, i)))
+	}
+
+	req := SearchRequest{Filter: `type = order_filled`, SortOrder: "asc", Limit: 3, Fields: []string{"id", "metadata.order_id"}}
+	var seen []string
+	for page := 0; page < 5; page++ {
+		plan, err := planSearch(req)
+		if err != nil {
+			t.Fatalf("Failed to plan search: %v", err)
+		}
+		result, err := plan.page(events, base, base.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to page: %v", err)
+		}
+		for _, event := range result.Events {
+			seen = append(seen, event["id"].(string))
+			if len(event) != 2 || event["metadata.order_id"] != "ord-"+event["id"].(string)[1:] {
+				t.Errorf("Unexpected projection: %v", event)
+			}
+		}
+		if !result.HasMore {
+			break
+		}
+		req.Cursor = result.NextCursor
+	}
+
+	expected := []string{"e0", "e1", "e2", "e3", "e4", "e5", "e6"}
+	if fmt.Sprint(seen) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v across pages, got %v", expected, seen)
+	}
+
+	// A cursor cannot be replayed against a different query
+	req.Filter = `service = exchange`
+	if _, err := planSearch(req); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("Expected cursor mismatch to be rejected, got %v", err)
+	}
+}
+
+func TestSearchService_SeeksNewestFirstAcrossPages(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Given more events than one storage page, several per timestamp, half of them matching
+	var events []*models.AuditEvent
+	for i := 0; i < eventPageSize*3; i++ {
+		service := "exchange"
+		if i%2 == 1 {
+			service = "custodian"
+		}
+		events = append(events, newTypedEvent(fmt.Sprintf("e%04d", i), service, "order_filled",
+			base.Add(time.Duration(i/3)*time.Millisecond), ""))
+	}
+	search := NewSearchService(NewAuditServiceWithDataAdapter(newMemoryDataAdapter(events...), logger), logger)
+	start, end := base, base.Add(time.Hour)
+
+	// When the default newest-first search is paged with cursors
+	req := SearchRequest{Filter: `service = exchange`, StartTime: &start, EndTime: &end, Limit: 200, Fields: []string{"id"}}
+	var seen []string
+	for page := 0; page < 10; page++ {
+		result, err := search.Search(req)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if result.Scanned > 2*req.Limit+1 {
+			t.Errorf("Expected a page to stop after its matches, scanned %d", result.Scanned)
+		}
+		for _, event := range result.Events {
+			seen = append(seen, event["id"].(string))
+		}
+		if !result.HasMore {
+			break
+		}
+		req.Cursor = result.NextCursor
+	}
+
+	// Then every matching event is returned once, newest first
+	var expected []string
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].ServiceName == "exchange" {
+			expected = append(expected, events[i].ID)
+		}
+	}
+	if fmt.Sprint(seen) != fmt.Sprint(expected) {
+		t.Errorf("Expected %d events newest first, got %d starting %v", len(expected), len(seen), seen[:min(len(seen), 5)])
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+const (
+	defaultTailBufferSize = 256
+	maxTailBufferSize     = 4096
+	// maxTailSubscribers bounds concurrent live tails across all protocols
+	maxTailSubscribers = 256
+)
+
+// ErrTailCapacity is returned when the live tail subscriber limit is reached
+var ErrTailCapacity = errors.New("too many live tail subscribers")
+
+// TailMessage is either an event or a notification that events were dropped
+type TailMessage struct {
+	Event        *models.AuditEvent
+	Dropped      int64 // events dropped since the previous notification
+	TotalDropped int64
+}
+
+// TailSubscription is one live tail consumer with its own bounded buffer
+// Drops are queued as markers between the events they fell between, so consumers see each gap
+// where it happened
+type TailSubscription struct {
+	ID        string
+	Filter    string
+	CreatedAt time.Time
+
+	filter     *EventFilter
+	messages   chan TailMessage
+	totalDrops atomic.Int64
+	delivered  atomic.Int64
+
+	mu           sync.Mutex // orders offers against the drop check in Next
+	pendingDrops int64      // drops not yet queued as a marker
+}
+
+// TailSubscriptionStats describes an active subscription
+type TailSubscriptionStats struct {
+	ID         string    `json:"id"`
+	Filter     string    `json:"filter"`
+	CreatedAt  time.Time `json:"created_at"`
+	BufferSize int       `json:"buffer_size"`
+	Buffered   int       `json:"buffered"`
+	Delivered  int64     `json:"delivered"`
+	Dropped    int64     `json:"dropped"`
+}
+
+// EventTailService fans accepted events out to live tail subscribers
+// Publishing never blocks: a subscriber whose buffer is full loses the event and is told so
+type EventTailService struct {
+	logger *logrus.Logger
+
+	mu          sync.RWMutex
+	subscribers map[string]*TailSubscription
+	nextID      int64
+}
+
+// NewEventTailService creates a tail service fed by the audit service's event observers
+func NewEventTailService(auditService *AuditService, logger *logrus.Logger) *EventTailService {
+	s := &EventTailService{
+		logger:      logger,
+		subscribers: make(map[string]*TailSubscription),
+	}
+	if auditService != nil {
+		auditService.AddEventObserver(s.Publish)
+	}
+	return s
+}
+
+// Subscribe starts a live tail; bufferSize <= 0 uses the default
+func (s *EventTailService) Subscribe(filterExpression string, bufferSize int) (*TailSubscription, error) {
+	filter, err := CompileEventFilter(filterExpression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+	if bufferSize <= 0 {
+		bufferSize = defaultTailBufferSize
+	}
+	bufferSize = min(bufferSize, maxTailBufferSize)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.subscribers) >= maxTailSubscribers {
+		return nil, ErrTailCapacity
+	}
+	s.nextID++
+	sub := &TailSubscription{
+		ID:        fmt.Sprintf("tail-%d", s.nextID),
+		Filter:    filter.String(),
+		CreatedAt: time.Now(),
+		filter:    filter,
+		messages:  make(chan TailMessage, bufferSize),
+	}
+	s.subscribers[sub.ID] = sub
+
+	s.logger.WithFields(logrus.Fields{
+		"subscription_id": sub.ID,
+		"filter":          sub.Filter,
+		"buffer_size":     bufferSize,
+	}).Info("Live tail subscription started")
+
+	return sub, nil
+}
+
+// Unsubscribe ends a live tail
+func (s *EventTailService) Unsubscribe(id string) {
+	s.mu.Lock()
+	sub, exists := s.subscribers[id]
+	delete(s.subscribers, id)
+	s.mu.Unlock()
+
+	if exists {
+		s.logger.WithFields(logrus.Fields{
+			"subscription_id": id,
+			"delivered":       sub.delivered.Load(),
+			"dropped":         sub.totalDrops.Load(),
+		}).Info("Live tail subscription ended")
+	}
+}
+
+// Publish offers an event to every matching subscriber without blocking
+func (s *EventTailService) Publish(event *models.AuditEvent) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sub := range s.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		sub.offer(event)
+	}
+}
+
+// offer queues an event behind a marker for any earlier drops, dropping it when the buffer is full
+func (sub *TailSubscription) offer(event *models.AuditEvent) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.pendingDrops > 0 {
+		select {
+		case sub.messages <- TailMessage{Dropped: sub.pendingDrops, TotalDropped: sub.totalDrops.Load()}:
+			sub.pendingDrops = 0
+		default:
+		}
+	}
+	if sub.pendingDrops == 0 {
+		select {
+		case sub.messages <- TailMessage{Event: event}:
+			return
+		default:
+		}
+	}
+	sub.pendingDrops++
+	sub.totalDrops.Add(1)
+}
+
+// ListSubscriptions returns the active subscriptions
+func (s *EventTailService) ListSubscriptions() []TailSubscriptionStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make([]TailSubscriptionStats, 0, len(s.subscribers))
+	for _, sub := range s.subscribers {
+		stats = append(stats, TailSubscriptionStats{
+			ID:         sub.ID,
+			Filter:     sub.Filter,
+			CreatedAt:  sub.CreatedAt,
+			BufferSize: cap(sub.messages),
+			Buffered:   len(sub.messages),
+			Delivered:  sub.delivered.Load(),
+			Dropped:    sub.totalDrops.Load(),
+		})
+	}
+	return stats
+}
+
+// Next blocks until an event or drop notification is available or ctx is done
+// Messages come in publication order; drops not yet queued are reported once the buffer drains
+func (sub *TailSubscription) Next(ctx context.Context) (TailMessage, error) {
+	select {
+	case msg := <-sub.messages:
+		return sub.deliver(msg), nil
+	default:
+	}
+	if msg, ok := sub.takeDrops(); ok {
+		return msg, nil
+	}
+	// Drops only happen while the buffer is full, so an empty buffer has nothing else to report
+	select {
+	case <-ctx.Done():
+		return TailMessage{}, ctx.Err()
+	case msg := <-sub.messages:
+		return sub.deliver(msg), nil
+	}
+}
+
+// takeDrops reports pending drops when every message before them has been consumed
+func (sub *TailSubscription) takeDrops() (TailMessage, bool) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if sub.pendingDrops == 0 || len(sub.messages) > 0 {
+		return TailMessage{}, false
+	}
+	msg := TailMessage{Dropped: sub.pendingDrops, TotalDropped: sub.totalDrops.Load()}
+	sub.pendingDrops = 0
+	return msg, true
+}
+
+func (sub *TailSubscription) deliver(msg TailMessage) TailMessage {
+	if msg.Event != nil {
+		sub.delivered.Add(1)
+	}
+	return msg
+}
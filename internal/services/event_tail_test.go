@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestEventTail_DeliversMatchingEvents(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	audit := NewAuditService(logger)
+	tail := NewEventTailService(audit, logger)
+
+	sub, err := tail.Subscribe(`service = exchange`, 0)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer tail.Unsubscribe(sub.ID)
+
+	now := time.Now()
+	tail.Publish(newTypedEvent("e1", "custodian", "settlement", now, `{}`))
+	tail.Publish(newTypedEvent("e2", "exchange", "order_filled", now, `{}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	msg, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatalf("Expected an event, got %v", err)
+	}
+	if msg.Event == nil || msg.Event.ID != "e2" {
+		t.Errorf("Expected e2, got %+v", msg)
+	}
+
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	if _, err := sub.Next(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected no further events, got %v", err)
+	}
+}
+
+func TestEventTail_ReportsDropsForSlowConsumers(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	tail := NewEventTailService(nil, logger)
+
+	sub, err := tail.Subscribe("", 2)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	now := time.Now()
+	for _, id := range []string{"e1", "e2", "e3", "e4", "e5"} {
+		tail.Publish(newTypedEvent(id, "exchange", "order_filled", now, `{}`))
+	}
+
+	stats := tail.ListSubscriptions()
+	if len(stats) != 1 || stats[0].Buffered != 2 || stats[0].Dropped != 3 {
+		t.Errorf("Unexpected subscription stats: %+v", stats)
+	}
+
+	ctx := context.Background()
+	for _, expected := range []string{"e1", "e2"} {
+		msg, _ := sub.Next(ctx)
+		if msg.Event == nil || msg.Event.ID != expected {
+			t.Errorf("Expected buffered %s, got %+v", expected, msg)
+		}
+	}
+	gap, _ := sub.Next(ctx)
+	if gap.Event != nil || gap.Dropped != 3 || gap.TotalDropped != 3 {
+		t.Fatalf("Expected the drops after the buffered events, got %+v", gap)
+	}
+
+	// A later event queues the drops before itself, between the events they fell between
+	for _, id := range []string{"e6", "e7", "e8", "e9"} {
+		tail.Publish(newTypedEvent(id, "exchange", "order_filled", now, `{}`))
+	}
+	var sequence []string
+	next := func() {
+		msg, _ := sub.Next(ctx)
+		if msg.Event != nil {
+			sequence = append(sequence, msg.Event.ID)
+		} else {
+			sequence = append(sequence, fmt.Sprintf("dropped:%d/%d", msg.Dropped, msg.TotalDropped))
+		}
+	}
+	next()
+	next()
+	tail.Publish(newTypedEvent("e10", "exchange", "order_filled", now, `{}`))
+	next()
+	next()
+	if got := strings.Join(sequence, ","); got != "e6,e7,dropped:2/5,e10" {
+		t.Errorf("Unexpected tail sequence: %s", got)
+	}
+
+	tail.Unsubscribe(sub.ID)
+	if len(tail.ListSubscriptions()) != 0 {
+		t.Error("Expected the subscription to be removed")
+	}
+}
+
+func TestEventTail_RejectsInvalidFilters(t *testing.T) {
+	tail := NewEventTailService(nil, logrus.New())
+	if _, err := tail.Subscribe("service =", 0); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("Expected an invalid filter error, got %v", err)
+	}
+}
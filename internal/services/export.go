@@ -0,0 +1,498 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// ExportFormat is the encoding of an event export
+type ExportFormat string
+
+const (
+	ExportFormatNDJSON  ExportFormat = "ndjson"
+	ExportFormatCSV     ExportFormat = "csv"
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+// defaultExportWindow is exported when a request gives no time range
+const defaultExportWindow = 24 * time.Hour
+
+// metadataWildcardColumn expands to every flattened metadata path seen in the first page of events
+const metadataWildcardColumn = "metadata.*"
+
+// extraMetadataColumn follows the metadata.* columns with the paths first seen after the first
+// page, as a JSON object of path to value
+const extraMetadataColumn = "_extra_metadata"
+
+// defaultTabularColumns are the CSV and Parquet columns used when none are requested
+var defaultTabularColumns = []string{"id", "trace_id", "span_id", "service_name", "event_type", "timestamp", "status", "tags", metadataWildcardColumn}
+
+// ParseExportFormat converts a format name (or file extension) into an ExportFormat
+func ParseExportFormat(format string) (ExportFormat, error) {
+	switch strings.ToLower(format) {
+	case "", "ndjson", "jsonl":
+		return ExportFormatNDJSON, nil
+	case "csv":
+		return ExportFormatCSV, nil
+	case "parquet":
+		return ExportFormatParquet, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported export format: %s", ErrInvalidSearch, format)
+	}
+}
+
+// ExportRequest describes an event export
+// Columns select event fields and metadata.<path> values; metadata.* flattens all metadata (CSV and Parquet only),
+// with metadata paths missing from the first page in the _extra_metadata column
+type ExportRequest struct {
+	Filter    string     `json:"filter"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Format    string     `json:"format"`
+	Columns   []string   `json:"columns"`
+	Limit     int        `json:"limit"` // 0 exports every matching event
+}
+
+// ExportService streams filtered events in bulk formats
+type ExportService struct {
+	auditService *AuditService
+	logger       *logrus.Logger
+}
+
+// NewExportService creates a new export service
+func NewExportService(auditService *AuditService, logger *logrus.Logger) *ExportService {
+	return &ExportService{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// ExportJob is a validated export ready to be streamed
+type ExportJob struct {
+	auditService *AuditService
+	format       ExportFormat
+	filter       *EventFilter
+	columns      []string
+	start        time.Time
+	end          time.Time
+	limit        int
+}
+
+// Prepare validates an export request so errors can be reported before any output is written
+func (s *ExportService) Prepare(req ExportRequest) (*ExportJob, error) {
+	format, err := ParseExportFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := CompileEventFilter(req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidSearch)
+	}
+
+	columns := req.Columns
+	if len(columns) == 0 && format != ExportFormatNDJSON {
+		columns = defaultTabularColumns
+	}
+	if format == ExportFormatNDJSON && containsString(columns, metadataWildcardColumn) {
+		return nil, fmt.Errorf("%w: %s is only supported for csv and parquet", ErrInvalidSearch, metadataWildcardColumn)
+	}
+	if _, err := resolveExportColumns(columns, nil); err != nil {
+		return nil, err
+	}
+
+	start, end := filterWindow(filter, req.StartTime, req.EndTime, defaultExportWindow)
+	if start.After(end) {
+		return nil, fmt.Errorf("%w: window start must not be after end", ErrInvalidSearch)
+	}
+
+	return &ExportJob{
+		auditService: s.auditService,
+		format:       format,
+		filter:       filter,
+		columns:      columns,
+		start:        start,
+		end:          end,
+		limit:        req.Limit,
+	}, nil
+}
+
+// ContentType returns the MIME type of the export
+func (j *ExportJob) ContentType() string {
+	switch j.format {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// Extension returns the file extension to use for downloads
+func (j *ExportJob) Extension() string {
+	return string(j.format)
+}
+
+// Stream pages through the window and writes matching events to w as they are read
+// It returns the number of events exported; writers implementing Flush are flushed after every page
+func (j *ExportJob) Stream(ctx context.Context, w io.Writer) (int, error) {
+	encoder := newEventEncoder(j.format, j.columns, w)
+	flusher, _ := w.(interface{ Flush() })
+
+	exported := 0
+	pushdown, _, _ := j.filter.pushdown()
+	err := j.auditService.WalkEventsInWindow(ctx, pushdown, j.start, j.end, func(page []*models.AuditEvent) error {
+		for _, event := range page {
+			if !j.filter.Matches(event) {
+				continue
+			}
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+			exported++
+			if j.limit > 0 && exported >= j.limit {
+				return errStopWalk
+			}
+		}
+		if err := encoder.Flush(); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return exported, err
+	}
+	return exported, encoder.Close()
+}
+
+// eventEncoder writes events in one export format
+type eventEncoder interface {
+	Encode(event *models.AuditEvent) error
+	Flush() error // called after every storage page
+	Close() error
+}
+
+func newEventEncoder(format ExportFormat, columns []string, w io.Writer) eventEncoder {
+	switch format {
+	case ExportFormatCSV:
+		return &tabularEncoder{names: columns, sink: &csvSink{writer: csv.NewWriter(w)}}
+	case ExportFormatParquet:
+		return &tabularEncoder{names: columns, sink: &parquetSink{w: w}}
+	default:
+		buffered := bufio.NewWriter(w)
+		return &ndjsonEncoder{buffered: buffered, encoder: json.NewEncoder(buffered), columns: columns}
+	}
+}
+
+type ndjsonEncoder struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+	columns  []string
+}
+
+func (e *ndjsonEncoder) Encode(event *models.AuditEvent) error {
+	if len(e.columns) == 0 {
+		return e.encoder.Encode(event)
+	}
+	projected, err := projectEvent(event, e.columns)
+	if err != nil {
+		return err
+	}
+	return e.encoder.Encode(projected)
+}
+
+func (e *ndjsonEncoder) Flush() error { return e.buffered.Flush() }
+
+func (e *ndjsonEncoder) Close() error { return e.buffered.Flush() }
+
+// tabularSink receives rows of resolved columns; values are nil, string or time.Time
+type tabularSink interface {
+	Begin(columns []exportColumn) error
+	WriteRow(values []interface{}) error
+	Flush() error
+	Close() error
+}
+
+// tabularEncoder holds back the first page of events until the metadata wildcard can be expanded
+type tabularEncoder struct {
+	names   []string
+	columns []exportColumn
+	pending []*models.AuditEvent
+	sink    tabularSink
+}
+
+func (e *tabularEncoder) Encode(event *models.AuditEvent) error {
+	if e.columns == nil {
+		e.pending = append(e.pending, event)
+		return nil
+	}
+	return e.writeRow(event)
+}
+
+func (e *tabularEncoder) Flush() error {
+	if e.columns == nil {
+		if len(e.pending) == 0 {
+			return nil
+		}
+		if err := e.begin(); err != nil {
+			return err
+		}
+	}
+	return e.sink.Flush()
+}
+
+func (e *tabularEncoder) Close() error {
+	if e.columns == nil {
+		if err := e.begin(); err != nil {
+			return err
+		}
+	}
+	return e.sink.Close()
+}
+
+func (e *tabularEncoder) begin() error {
+	columns, err := resolveExportColumns(e.names, e.pending)
+	if err != nil {
+		return err
+	}
+	e.columns = columns
+	if err := e.sink.Begin(columns); err != nil {
+		return err
+	}
+	for _, event := range e.pending {
+		if err := e.writeRow(event); err != nil {
+			return err
+		}
+	}
+	e.pending = nil
+	return nil
+}
+
+func (e *tabularEncoder) writeRow(event *models.AuditEvent) error {
+	row := &exportRow{event: event, metadata: decodeMetadata(event)}
+	values := make([]interface{}, len(e.columns))
+	for i, column := range e.columns {
+		values[i] = column.value(row)
+	}
+	return e.sink.WriteRow(values)
+}
+
+type csvSink struct {
+	writer *csv.Writer
+}
+
+func (s *csvSink) Begin(columns []exportColumn) error {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	return s.writer.Write(header)
+}
+
+func (s *csvSink) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			record[i] = v
+		case time.Time:
+			record[i] = v.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return s.writer.Write(record)
+}
+
+func (s *csvSink) Flush() error {
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *csvSink) Close() error { return s.Flush() }
+
+type parquetSink struct {
+	w      io.Writer
+	writer *parquetWriter
+}
+
+func (s *parquetSink) Begin(columns []exportColumn) error {
+	names := make([]string, len(columns))
+	kinds := make([]parquetColumnKind, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+		kinds[i] = column.kind
+	}
+	writer, err := newParquetWriter(s.w, names, kinds, defaultParquetRowGroupSize)
+	if err != nil {
+		return err
+	}
+	s.writer = writer
+	return nil
+}
+
+func (s *parquetSink) WriteRow(values []interface{}) error { return s.writer.WriteRow(values) }
+
+// Flush is a no-op; the writer emits whole row groups as they fill
+func (s *parquetSink) Flush() error { return nil }
+
+func (s *parquetSink) Close() error { return s.writer.Close() }
+
+// exportRow is an event with its metadata decoded once for all columns
+type exportRow struct {
+	event     *models.AuditEvent
+	metadata  map[string]interface{}
+	flattened map[string]string
+}
+
+func (r *exportRow) flatMetadata() map[string]string {
+	if r.flattened == nil {
+		r.flattened = make(map[string]string)
+		flattenMetadata("", r.metadata, r.flattened)
+	}
+	return r.flattened
+}
+
+// exportColumn is one output column of a tabular export
+type exportColumn struct {
+	name  string
+	kind  parquetColumnKind
+	value func(row *exportRow) interface{}
+}
+
+// resolveExportColumns turns column names into extractors, expanding metadata.* with the
+// metadata paths present in sample; paths first seen after the sample go to extraMetadataColumn
+func resolveExportColumns(names []string, sample []*models.AuditEvent) ([]exportColumn, error) {
+	columns := make([]exportColumn, 0, len(names))
+	seen := make(map[string]bool)
+	add := func(column exportColumn) {
+		if !seen[column.name] {
+			seen[column.name] = true
+			columns = append(columns, column)
+		}
+	}
+
+	for _, name := range names {
+		switch name {
+		case "id":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} { return r.event.ID }})
+		case "trace_id":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} { return r.event.TraceID }})
+		case "span_id":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} { return r.event.SpanID }})
+		case "service_name":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} { return r.event.ServiceName }})
+		case "event_type":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} { return r.event.EventType }})
+		case "timestamp":
+			add(exportColumn{name: name, kind: parquetTimestamp, value: func(r *exportRow) interface{} { return r.event.Timestamp }})
+		case "status":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} { return string(r.event.Status) }})
+		case "tags":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} { return strings.Join(r.event.Tags, ";") }})
+		case "metadata":
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} {
+				if len(r.event.Metadata) == 0 {
+					return nil
+				}
+				return string(r.event.Metadata)
+			}})
+		case metadataWildcardColumn:
+			sampled := make(map[string]bool)
+			for _, path := range sampleMetadataPaths(sample) {
+				path := path
+				sampled[path] = true
+				add(exportColumn{name: "metadata." + path, value: func(r *exportRow) interface{} {
+					if value, ok := r.flatMetadata()[path]; ok {
+						return value
+					}
+					return nil
+				}})
+			}
+			add(exportColumn{name: extraMetadataColumn, value: func(r *exportRow) interface{} {
+				extra := make(map[string]string)
+				for path, value := range r.flatMetadata() {
+					if !sampled[path] {
+						extra[path] = value
+					}
+				}
+				if len(extra) == 0 {
+					return nil
+				}
+				encoded, _ := json.Marshal(extra)
+				return string(encoded)
+			}})
+		default:
+			path, ok := strings.CutPrefix(name, "metadata.")
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown export column %q", ErrInvalidSearch, name)
+			}
+			extractor := &KeyExtractor{Key: name, Path: "$." + path}
+			if err := extractor.Validate(); err != nil {
+				return nil, fmt.Errorf("%w: invalid export column %q: %v", ErrInvalidSearch, name, err)
+			}
+			add(exportColumn{name: name, value: func(r *exportRow) interface{} {
+				values := extractor.Extract(r.metadata)
+				if len(values) == 0 {
+					return nil
+				}
+				return strings.Join(values, ";")
+			}})
+		}
+	}
+	return columns, nil
+}
+
+// sampleMetadataPaths returns the sorted flattened metadata paths present in events
+func sampleMetadataPaths(events []*models.AuditEvent) []string {
+	seen := make(map[string]bool)
+	for _, event := range events {
+		flattened := make(map[string]string)
+		flattenMetadata("", decodeMetadata(event), flattened)
+		for path := range flattened {
+			seen[path] = true
+		}
+	}
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// flattenMetadata writes leaf values under dotted paths; arrays are kept as JSON
+func flattenMetadata(prefix string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flattenMetadata(path, item, out)
+		}
+	case []interface{}:
+		encoded, _ := json.Marshal(v)
+		out[prefix] = string(encoded)
+	default:
+		if prefix != "" {
+			out[prefix] = renderMetadataValue(v)
+		}
+	}
+}
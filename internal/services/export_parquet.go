@@ -0,0 +1,398 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// A minimal Parquet writer for exports: flat schemas of optional UTF8 and
+// TIMESTAMP_MICROS columns, one uncompressed PLAIN data page per column chunk.
+// Rows are buffered one row group at a time so memory is bounded by rowGroupSize.
+
+const parquetMagic = "PAR1"
+
+// defaultParquetRowGroupSize is the number of rows buffered before a row group is written
+const defaultParquetRowGroupSize = 10000
+
+// Parquet physical and converted types, encodings and repetition used by the writer
+const (
+	parquetTypeInt64     = 2
+	parquetTypeByteArray = 6
+
+	parquetConvertedUTF8            = 0
+	parquetConvertedTimestampMicros = 10
+
+	parquetEncodingPlain = 0
+	parquetEncodingRLE   = 3
+
+	parquetRepetitionOptional = 1
+	parquetPageTypeData       = 0
+	parquetCodecUncompressed  = 0
+)
+
+// parquetColumnKind is the logical type of an export column
+type parquetColumnKind int
+
+const (
+	parquetString parquetColumnKind = iota
+	parquetTimestamp
+)
+
+type parquetColumn struct {
+	name    string
+	kind    parquetColumnKind
+	defined []bool
+	strings []string
+	micros  []int64
+}
+
+func (c *parquetColumn) physicalType() int32 {
+	if c.kind == parquetTimestamp {
+		return parquetTypeInt64
+	}
+	return parquetTypeByteArray
+}
+
+func (c *parquetColumn) convertedType() int32 {
+	if c.kind == parquetTimestamp {
+		return parquetConvertedTimestampMicros
+	}
+	return parquetConvertedUTF8
+}
+
+type parquetChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+type parquetRowGroup struct {
+	chunks []parquetChunk
+	rows   int64
+	size   int64
+}
+
+// parquetWriter streams a Parquet file to w
+type parquetWriter struct {
+	w            io.Writer
+	offset       int64
+	columns      []*parquetColumn
+	rows         int
+	totalRows    int64
+	groups       []parquetRowGroup
+	rowGroupSize int
+}
+
+// newParquetWriter writes the file header and returns a writer for the given columns
+func newParquetWriter(w io.Writer, names []string, kinds []parquetColumnKind, rowGroupSize int) (*parquetWriter, error) {
+	if rowGroupSize <= 0 {
+		rowGroupSize = defaultParquetRowGroupSize
+	}
+	pw := &parquetWriter{w: w, rowGroupSize: rowGroupSize}
+	for i, name := range names {
+		pw.columns = append(pw.columns, &parquetColumn{name: name, kind: kinds[i]})
+	}
+	if err := pw.write([]byte(parquetMagic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// WriteRow buffers one row; values are nil, string or time.Time in column order
+func (pw *parquetWriter) WriteRow(values []interface{}) error {
+	if len(values) != len(pw.columns) {
+		return fmt.Errorf("parquet row has %d values, expected %d", len(values), len(pw.columns))
+	}
+	for i, column := range pw.columns {
+		switch v := values[i].(type) {
+		case nil:
+			column.defined = append(column.defined, false)
+		case string:
+			if column.kind != parquetString {
+				return fmt.Errorf("parquet column %s expects a timestamp", column.name)
+			}
+			column.defined = append(column.defined, true)
+			column.strings = append(column.strings, v)
+		case time.Time:
+			if column.kind != parquetTimestamp {
+				return fmt.Errorf("parquet column %s expects a string", column.name)
+			}
+			column.defined = append(column.defined, true)
+			column.micros = append(column.micros, v.UnixMicro())
+		default:
+			return fmt.Errorf("unsupported parquet value %T for column %s", v, column.name)
+		}
+	}
+	pw.rows++
+	if pw.rows >= pw.rowGroupSize {
+		return pw.flushRowGroup()
+	}
+	return nil
+}
+
+// Close writes any buffered rows and the file footer
+func (pw *parquetWriter) Close() error {
+	if pw.rows > 0 {
+		if err := pw.flushRowGroup(); err != nil {
+			return err
+		}
+	}
+
+	footer := pw.encodeFileMetaData()
+	if err := pw.write(footer); err != nil {
+		return err
+	}
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	if err := pw.write(length[:]); err != nil {
+		return err
+	}
+	return pw.write([]byte(parquetMagic))
+}
+
+func (pw *parquetWriter) flushRowGroup() error {
+	group := parquetRowGroup{rows: int64(pw.rows)}
+	for _, column := range pw.columns {
+		page := encodeParquetPage(column)
+
+		header := newThriftCompact()
+		header.i32(1, parquetPageTypeData)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.structBegin(5)
+		header.i32(1, int32(len(column.defined)))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRLE)
+		header.i32(4, parquetEncodingRLE)
+		header.structEnd()
+		header.end()
+
+		chunk := parquetChunk{
+			offset:    pw.offset,
+			size:      int64(header.buf.Len() + len(page)),
+			numValues: int64(len(column.defined)),
+		}
+		if err := pw.write(header.buf.Bytes()); err != nil {
+			return err
+		}
+		if err := pw.write(page); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.size += chunk.size
+
+		column.defined = column.defined[:0]
+		column.strings = column.strings[:0]
+		column.micros = column.micros[:0]
+	}
+	pw.groups = append(pw.groups, group)
+	pw.totalRows += int64(pw.rows)
+	pw.rows = 0
+	return nil
+}
+
+// encodeParquetPage encodes definition levels followed by the PLAIN encoded non-null values
+func encodeParquetPage(column *parquetColumn) []byte {
+	levels := encodeDefinitionLevels(column.defined)
+
+	var page bytes.Buffer
+	var scratch [8]byte
+	binary.LittleEndian.PutUint32(scratch[:4], uint32(len(levels)))
+	page.Write(scratch[:4])
+	page.Write(levels)
+	switch column.kind {
+	case parquetTimestamp:
+		for _, value := range column.micros {
+			binary.LittleEndian.PutUint64(scratch[:], uint64(value))
+			page.Write(scratch[:])
+		}
+	default:
+		for _, value := range column.strings {
+			binary.LittleEndian.PutUint32(scratch[:4], uint32(len(value)))
+			page.Write(scratch[:4])
+			page.WriteString(value)
+		}
+	}
+	return page.Bytes()
+}
+
+// encodeDefinitionLevels writes 1-bit levels as RLE runs of the RLE/bit-packing hybrid encoding
+func encodeDefinitionLevels(defined []bool) []byte {
+	var out bytes.Buffer
+	var scratch [binary.MaxVarintLen64]byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		n := binary.PutUvarint(scratch[:], uint64(j-i)<<1)
+		out.Write(scratch[:n])
+		if defined[i] {
+			out.WriteByte(1)
+		} else {
+			out.WriteByte(0)
+		}
+		i = j
+	}
+	return out.Bytes()
+}
+
+func (pw *parquetWriter) encodeFileMetaData() []byte {
+	t := newThriftCompact()
+	t.i32(1, 1)
+
+	t.listBegin(2, thriftStruct, len(pw.columns)+1)
+	t.elementBegin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(pw.columns)))
+	t.structEnd()
+	for _, column := range pw.columns {
+		t.elementBegin()
+		t.i32(1, column.physicalType())
+		t.i32(3, parquetRepetitionOptional)
+		t.binary(4, column.name)
+		t.i32(6, column.convertedType())
+		t.structEnd()
+	}
+
+	t.i64(3, pw.totalRows)
+
+	t.listBegin(4, thriftStruct, len(pw.groups))
+	for _, group := range pw.groups {
+		t.elementBegin()
+		t.listBegin(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			column := pw.columns[i]
+			t.elementBegin()
+			t.i64(2, chunk.offset)
+			t.structBegin(3)
+			t.i32(1, column.physicalType())
+			t.listBegin(2, thriftI32, 2)
+			t.listI32(parquetEncodingPlain)
+			t.listI32(parquetEncodingRLE)
+			t.listBegin(3, thriftBinary, 1)
+			t.listBinary(column.name)
+			t.i32(4, parquetCodecUncompressed)
+			t.i64(5, chunk.numValues)
+			t.i64(6, chunk.size)
+			t.i64(7, chunk.size)
+			t.i64(9, chunk.offset)
+			t.structEnd()
+			t.structEnd()
+		}
+		t.i64(2, group.size)
+		t.i64(3, group.rows)
+		t.structEnd()
+	}
+
+	t.binary(6, "audit-correlator-go")
+	t.end()
+	return t.buf.Bytes()
+}
+
+func (pw *parquetWriter) write(data []byte) error {
+	n, err := pw.w.Write(data)
+	pw.offset += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write parquet data: %w", err)
+	}
+	return nil
+}
+
+// Thrift compact protocol types used by the Parquet metadata structures
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftCompact encodes a single struct with the Thrift compact protocol
+type thriftCompact struct {
+	buf  bytes.Buffer
+	last []int16 // last field id of each open struct
+}
+
+func newThriftCompact() *thriftCompact {
+	return &thriftCompact{last: []int16{0}}
+}
+
+func (t *thriftCompact) fieldHeader(id int16, fieldType byte) {
+	top := len(t.last) - 1
+	if delta := id - t.last[top]; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		t.buf.WriteByte(fieldType)
+		t.varint(int64(id))
+	}
+	t.last[top] = id
+}
+
+// varint writes a zigzag encoded integer
+func (t *thriftCompact) varint(v int64) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64((v<<1)^(v>>63)))
+	t.buf.Write(scratch[:n])
+}
+
+func (t *thriftCompact) i32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.varint(int64(v))
+}
+
+func (t *thriftCompact) i64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.varint(v)
+}
+
+func (t *thriftCompact) binary(id int16, v string) {
+	t.fieldHeader(id, thriftBinary)
+	t.listBinary(v)
+}
+
+func (t *thriftCompact) structBegin(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.last = append(t.last, 0)
+}
+
+func (t *thriftCompact) structEnd() {
+	t.buf.WriteByte(0)
+	t.last = t.last[:len(t.last)-1]
+}
+
+func (t *thriftCompact) listBegin(id int16, elementType byte, size int) {
+	t.fieldHeader(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elementType)
+		return
+	}
+	t.buf.WriteByte(0xf0 | elementType)
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64(size))
+	t.buf.Write(scratch[:n])
+}
+
+// elementBegin opens a struct element of a list
+func (t *thriftCompact) elementBegin() {
+	t.last = append(t.last, 0)
+}
+
+func (t *thriftCompact) listI32(v int32) {
+	t.varint(int64(v))
+}
+
+func (t *thriftCompact) listBinary(v string) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], uint64(len(v)))
+	t.buf.Write(scratch[:n])
+	t.buf.WriteString(v)
+}
+
+// end closes the top-level struct
+func (t *thriftCompact) end() {
+	t.buf.WriteByte(0)
+}
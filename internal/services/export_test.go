@@ -0,0 +1,222 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newExportEvents() []*models.AuditEvent {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	first := newTypedEvent("e1", "exchange", "order_filled", base, `{"order": {"id": "ord-1", "qty": 5}, "legs": [1, 2]}`)
+	first.Tags = []string{"btc", "spot"}
+	second := newTypedEvent("e2", "custodian", "settlement", base.Add(time.Second), `{"account": "acc-9"}`)
+	return []*models.AuditEvent{first, second}
+}
+
+func encodeEvents(t *testing.T, format ExportFormat, columns []string, events []*models.AuditEvent) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	encoder := newEventEncoder(format, columns, &out)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			t.Fatalf("Failed to encode: %v", err)
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestExport_CSVFlattensMetadata(t *testing.T) {
+	out := encodeEvents(t, ExportFormatCSV, []string{"id", "tags", "metadata.*", "metadata.order.id"}, newExportEvents())
+
+	expected := "id,tags,metadata.account,metadata.legs,metadata.order.id,metadata.order.qty,_extra_metadata\n" +
+		"e1,btc;spot,,\"[1,2]\",ord-1,5,\n" +
+		"e2,,acc-9,,,,\n"
+	if string(out) != expected {
+		t.Errorf("Unexpected CSV:\n%s\nexpected:\n%s", out, expected)
+	}
+}
+
+func TestExport_CSVKeepsMetadataFirstSeenAfterTheFirstPage(t *testing.T) {
+	events := newExportEvents()
+	late := newTypedEvent("e3", "exchange", "order_filled", events[1].Timestamp.Add(time.Second), `{"account": "acc-1", "venue": "okx"}`)
+
+	var out bytes.Buffer
+	encoder := newEventEncoder(ExportFormatCSV, []string{"id", "metadata.*"}, &out)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			t.Fatalf("Failed to encode: %v", err)
+		}
+	}
+	// The first page fixes the columns
+	if err := encoder.Flush(); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+	if err := encoder.Encode(late); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || lines[3] != `e3,acc-1,,,,"{""venue"":""okx""}"` {
+		t.Errorf("Expected the late venue key in the extra metadata column, got:\n%s", out.String())
+	}
+}
+
+func TestExport_NDJSONProjection(t *testing.T) {
+	out := encodeEvents(t, ExportFormatNDJSON, []string{"id", "metadata.order.id"}, newExportEvents())
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	var first map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Invalid NDJSON line: %v", err)
+	}
+	if first["id"] != "e1" || first["metadata.order.id"] != "ord-1" || len(first) != 2 {
+		t.Errorf("Unexpected projection: %v", first)
+	}
+}
+
+func TestExport_ParquetLayout(t *testing.T) {
+	out := encodeEvents(t, ExportFormatParquet, []string{"id", "timestamp", "metadata.account"}, newExportEvents())
+
+	if !bytes.HasPrefix(out, []byte(parquetMagic)) || !bytes.HasSuffix(out, []byte(parquetMagic)) {
+		t.Fatalf("Missing parquet magic")
+	}
+	footerLength := int(binary.LittleEndian.Uint32(out[len(out)-8:]))
+	footer := out[len(out)-8-footerLength : len(out)-8]
+	metadata := readThriftStruct(t, bytes.NewReader(footer))
+
+	if metadata[3] != int64(2) {
+		t.Errorf("Expected 2 rows, got %v", metadata[3])
+	}
+	schema := metadata[2].([]interface{})
+	if len(schema) != 4 || schema[3].(map[int16]interface{})[4] != "metadata.account" {
+		t.Errorf("Unexpected schema: %v", schema)
+	}
+
+	// The metadata.account chunk holds one null and one value
+	group := metadata[4].([]interface{})[0].(map[int16]interface{})
+	chunk := group[1].([]interface{})[2].(map[int16]interface{})
+	columnMeta := chunk[3].(map[int16]interface{})
+	if columnMeta[5] != int64(2) {
+		t.Errorf("Expected 2 values in the chunk, got %v", columnMeta[5])
+	}
+	offset, size := columnMeta[9].(int64), columnMeta[7].(int64)
+	if !bytes.HasSuffix(out[offset:offset+size], []byte("\x05\x00\x00\x00acc-9")) {
+		t.Errorf("Expected the chunk to end with the PLAIN encoded value")
+	}
+}
+
+func TestExportService_Prepare(t *testing.T) {
+	service := NewExportService(NewAuditService(logrus.New()), logrus.New())
+
+	for name, req := range map[string]ExportRequest{
+		"format":          {Format: "xlsx"},
+		"column":          {Format: "csv", Columns: []string{"venue"}},
+		"ndjson wildcard": {Columns: []string{"metadata.*"}},
+		"filter":          {Filter: "service ="},
+		"limit":           {Limit: -1},
+	} {
+		if _, err := service.Prepare(req); !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("%s: expected an invalid request error, got %v", name, err)
+		}
+	}
+
+	job, err := service.Prepare(ExportRequest{Format: "csv", Filter: "service = exchange"})
+	if err != nil {
+		t.Fatalf("Failed to prepare export: %v", err)
+	}
+	if job.ContentType() != "text/csv; charset=utf-8" || job.Extension() != "csv" {
+		t.Errorf("Unexpected content type %q or extension %q", job.ContentType(), job.Extension())
+	}
+
+	// Without a data adapter the export holds only the header
+	var out bytes.Buffer
+	count, err := job.Stream(context.Background(), &out)
+	if err != nil || count != 0 {
+		t.Fatalf("Unexpected stream result: %d, %v", count, err)
+	}
+	if out.String() != "id,trace_id,span_id,service_name,event_type,timestamp,status,tags,_extra_metadata\n" {
+		t.Errorf("Unexpected empty export: %q", out.String())
+	}
+}
+
+// readThriftStruct decodes a Thrift compact struct into field id -> value for assertions
+func readThriftStruct(t *testing.T, r *bytes.Reader) map[int16]interface{} {
+	t.Helper()
+	fields := make(map[int16]interface{})
+	var last int16
+	for {
+		header, err := r.ReadByte()
+		if err != nil {
+			t.Fatalf("Truncated thrift struct: %v", err)
+		}
+		if header == 0 {
+			return fields
+		}
+		fieldType := header & 0x0f
+		if delta := int16(header >> 4); delta != 0 {
+			last += delta
+		} else {
+			last = int16(readZigzag(t, r))
+		}
+		fields[last] = readThriftValue(t, r, fieldType)
+	}
+}
+
+func readThriftValue(t *testing.T, r *bytes.Reader, fieldType byte) interface{} {
+	switch fieldType {
+	case thriftI32, thriftI64:
+		return readZigzag(t, r)
+	case thriftBinary:
+		length, _ := binary.ReadUvarint(r)
+		data := make([]byte, length)
+		r.Read(data)
+		return string(data)
+	case thriftList:
+		header, _ := r.ReadByte()
+		size := int(header >> 4)
+		if size == 15 {
+			extended, _ := binary.ReadUvarint(r)
+			size = int(extended)
+		}
+		values := make([]interface{}, size)
+		for i := range values {
+			values[i] = readThriftValue(t, r, header&0x0f)
+		}
+		return values
+	case thriftStruct:
+		return readThriftStruct(t, r)
+	default:
+		t.Fatalf("Unexpected thrift type %d", fieldType)
+		return nil
+	}
+}
+
+func readZigzag(t *testing.T, r *bytes.Reader) int64 {
+	value, err := binary.ReadUvarint(r)
+	if err != nil {
+		t.Fatalf("Invalid varint: %v", err)
+	}
+	return int64(value>>1) ^ -int64(value&1)
+}
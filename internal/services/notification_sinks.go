@@ -0,0 +1,280 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// Notification sink types
+const (
+	SinkTypeWebhook = "webhook"
+	SinkTypeSlack   = "slack"
+	SinkTypeFile    = "file"
+)
+
+const (
+	defaultSinkTimeout = 10 * time.Second
+	// maxSinkResponseBody bounds the response body kept for delivery errors
+	maxSinkResponseBody = 512
+)
+
+// Notification is what a saved query sends to its sinks when it fires
+type Notification struct {
+	ID          string               `json:"id"`
+	QueryID     string               `json:"query_id"`
+	QueryName   string               `json:"query_name"`
+	Filter      string               `json:"filter"`
+	Mode        string               `json:"mode"`
+	FiredAt     time.Time            `json:"fired_at"`
+	MatchCount  int                  `json:"match_count"`
+	Suppressed  int                  `json:"suppressed"` // matches not notified since the previous notification
+	DedupKey    string               `json:"dedup_key"`
+	WindowStart time.Time            `json:"window_start"`
+	WindowEnd   time.Time            `json:"window_end"`
+	Events      []*models.AuditEvent `json:"events"` // sample of the matching events
+	Deliveries  []SinkDelivery       `json:"deliveries"`
+}
+
+// Event returns the first sampled event, for templates of single-event notifications
+func (n *Notification) Event() *models.AuditEvent {
+	if len(n.Events) == 0 {
+		return &models.AuditEvent{}
+	}
+	return n.Events[0]
+}
+
+// SinkDelivery records the outcome of sending a notification to one sink
+type SinkDelivery struct {
+	SinkID   string    `json:"sink_id"`
+	Success  bool      `json:"success"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error,omitempty"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// NotificationSink is a configured notification destination
+//   - webhook: POSTs Template (default: the notification as JSON) to URL with Headers
+//   - slack: POSTs {"text": Template} to a Slack-compatible incoming webhook URL
+//   - file: appends Template (default: the notification as JSON) as a line to Path, which is
+//     relative to the service's notification directory
+//
+// Templates use Go text/template syntax over the Notification; the json function encodes a value
+type NotificationSink struct {
+	ID             string            `json:"id"`
+	Type           string            `json:"type"`
+	URL            string            `json:"url,omitempty"`
+	Path           string            `json:"path,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Template       string            `json:"template,omitempty"`
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty"`
+
+	template *template.Template
+}
+
+// defaultSlackTemplate is the message sent to Slack sinks without a template
+const defaultSlackTemplate = `:rotating_light: Saved query *{{.QueryName}}* matched {{.MatchCount}} event(s)` +
+	`{{if .Suppressed}} ({{.Suppressed}} suppressed){{end}}` +
+	`{{range .Events}}` + "\n" + `• {{.Timestamp.Format "2006-01-02T15:04:05Z07:00"}} {{.ServiceName}} {{.EventType}} {{.ID}}{{end}}`
+
+var sinkTemplateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+}
+
+// Validate checks the sink and compiles its template
+func (s *NotificationSink) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("sink id is required")
+	}
+	switch s.Type {
+	case SinkTypeWebhook, SinkTypeSlack:
+		parsed, err := url.Parse(s.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("sink %s: url must be an absolute http or https URL", s.ID)
+		}
+	case SinkTypeFile:
+		if !filepath.IsLocal(s.Path) {
+			return fmt.Errorf("sink %s: path must be a relative path inside the notification directory", s.ID)
+		}
+	default:
+		return fmt.Errorf("sink %s: unknown type %q (expected webhook, slack or file)", s.ID, s.Type)
+	}
+	if s.TimeoutSeconds < 0 {
+		return fmt.Errorf("sink %s: timeout_seconds must not be negative", s.ID)
+	}
+
+	text := s.Template
+	if text == "" && s.Type == SinkTypeSlack {
+		text = defaultSlackTemplate
+	}
+	if text != "" {
+		compiled, err := template.New(s.ID).Funcs(sinkTemplateFuncs).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return fmt.Errorf("sink %s: invalid template: %w", s.ID, err)
+		}
+		s.template = compiled
+	}
+	return nil
+}
+
+// render produces the payload for a notification
+func (s *NotificationSink) render(notification *Notification) ([]byte, error) {
+	var payload []byte
+	if s.template == nil {
+		data, err := json.Marshal(notification)
+		if err != nil {
+			return nil, err
+		}
+		payload = data
+	} else {
+		var out bytes.Buffer
+		if err := s.template.Execute(&out, notification); err != nil {
+			return nil, fmt.Errorf("failed to render template: %w", err)
+		}
+		payload = out.Bytes()
+	}
+
+	if s.Type == SinkTypeSlack {
+		return json.Marshal(map[string]string{"text": string(payload)})
+	}
+	return payload, nil
+}
+
+// sinkSender delivers rendered notifications
+// HTTP sinks may only reach the allowed hosts; with none configured they may reach any host
+// resolving to a public address. Redirects are not followed, so neither check is bypassed.
+type sinkSender struct {
+	client       *http.Client
+	fileDir      string
+	allowedHosts map[string]bool
+
+	fileMu sync.Mutex
+}
+
+func newSinkSender(fileDir string) *sinkSender {
+	d := &sinkSender{fileDir: fileDir}
+	dialer := &net.Dialer{Timeout: defaultSinkTimeout, Control: d.checkDial}
+	d.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: defaultSinkTimeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return d
+}
+
+// checkHost rejects sink URLs whose host is not allowed
+func (d *sinkSender) checkHost(rawURL string) error {
+	if len(d.allowedHosts) == 0 {
+		return nil
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid sink url: %w", err)
+	}
+	if !d.allowedHosts[strings.ToLower(parsed.Hostname())] {
+		return fmt.Errorf("sink host %s is not in the allowed hosts", parsed.Hostname())
+	}
+	return nil
+}
+
+// checkDial refuses connections to non-public addresses unless hosts are explicitly allowed
+// It runs on the resolved address, so DNS cannot point an accepted name at an internal host
+func (d *sinkSender) checkDial(_, address string, _ syscall.RawConn) error {
+	if len(d.allowedHosts) > 0 {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("sink address %s is not a public address", host)
+	}
+	return nil
+}
+
+// send delivers one notification to a sink
+func (d *sinkSender) send(ctx context.Context, sink *NotificationSink, notification *Notification) error {
+	payload, err := sink.render(notification)
+	if err != nil {
+		return err
+	}
+
+	if sink.Type == SinkTypeFile {
+		return d.appendFile(filepath.Join(d.fileDir, sink.Path), payload)
+	}
+
+	timeout := defaultSinkTimeout
+	if sink.TimeoutSeconds > 0 {
+		timeout = time.Duration(sink.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := d.checkHost(sink.URL); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range sink.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxSinkResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// appendFile writes the payload as one line, serialising writers so lines never interleave
+func (d *sinkSender) appendFile(path string, payload []byte) error {
+	d.fileMu.Lock()
+	defer d.fileMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create sink directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open sink file: %w", err)
+	}
+	line := append(bytes.ReplaceAll(payload, []byte("\n"), []byte(" ")), '\n')
+	if _, err := file.Write(line); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write sink file: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,472 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// OrderState is the state of an order in its lifecycle state machine
+type OrderState string
+
+const (
+	OrderStateUnknown         OrderState = "unknown" // Events seen before the order creation
+	OrderStateNew             OrderState = "new"
+	OrderStateAcknowledged    OrderState = "acknowledged"
+	OrderStatePartiallyFilled OrderState = "partially_filled"
+	OrderStateFilled          OrderState = "filled"
+	OrderStateCancelled       OrderState = "cancelled"
+	OrderStateRejected        OrderState = "rejected"
+)
+
+// OrderEventKind classifies an audit event within an order lifecycle
+type OrderEventKind string
+
+const (
+	OrderEventNew         OrderEventKind = "new"
+	OrderEventAck         OrderEventKind = "ack"
+	OrderEventPartialFill OrderEventKind = "partial_fill"
+	OrderEventFill        OrderEventKind = "fill"
+	OrderEventCancel      OrderEventKind = "cancel"
+	OrderEventReject      OrderEventKind = "reject"
+)
+
+// Order lifecycle violation types
+const (
+	OrderViolationIllegalTransition = "illegal_transition"
+	OrderViolationFillAfterCancel   = "fill_after_cancel"
+	OrderViolationOverfill          = "overfill"
+	OrderViolationMissingAck        = "missing_ack"
+	OrderViolationMissingNew        = "missing_new"
+)
+
+// Well-known order metadata keys
+const (
+	metadataKeyOrderID       = "order_id"
+	metadataKeyClientOrderID = "client_order_id"
+	metadataKeyOrderEvent    = "order_event"
+	metadataKeyQuantity      = "quantity"
+	metadataKeyFillQuantity  = "fill_quantity"
+	metadataKeyExecutionID   = "execution_id"
+)
+
+const (
+	// defaultOrderAckTimeout is how long a new order may remain unacknowledged
+	defaultOrderAckTimeout = 5 * time.Second
+	// fillSkewTolerance is how far apart on the correlator clock two services may report the same fill
+	fillSkewTolerance = 2 * time.Second
+)
+
+// orderEventKinds maps the event types emitted by the trading services onto lifecycle events
+var orderEventKinds = map[string]OrderEventKind{
+	"order_new":              OrderEventNew,
+	"new_order":              OrderEventNew,
+	"order_submitted":        OrderEventNew,
+	"order_created":          OrderEventNew,
+	"order_ack":              OrderEventAck,
+	"order_acknowledged":     OrderEventAck,
+	"order_accepted":         OrderEventAck,
+	"partial_fill":           OrderEventPartialFill,
+	"order_partial_fill":     OrderEventPartialFill,
+	"order_partially_filled": OrderEventPartialFill,
+	"fill":                   OrderEventFill,
+	"order_fill":             OrderEventFill,
+	"order_filled":           OrderEventFill,
+	"cancel":                 OrderEventCancel,
+	"order_cancel":           OrderEventCancel,
+	"order_cancelled":        OrderEventCancel,
+	"order_canceled":         OrderEventCancel,
+	"reject":                 OrderEventReject,
+	"order_reject":           OrderEventReject,
+	"order_rejected":         OrderEventReject,
+}
+
+// OrderLifecycleEvent is one event applied to an order's state machine
+type OrderLifecycleEvent struct {
+	EventID     string         `json:"event_id"`
+	ServiceName string         `json:"service_name"`
+	EventType   string         `json:"event_type"`
+	Kind        OrderEventKind `json:"kind"`
+	Timestamp   time.Time      `json:"timestamp"` // Normalized to the correlator clock
+	FromState   OrderState     `json:"from_state"`
+	ToState     OrderState     `json:"to_state"`
+	Quantity    float64        `json:"quantity,omitempty"`
+	Duplicate   bool           `json:"duplicate,omitempty"` // Corroborating report from another service
+}
+
+// OrderViolation is an illegal or suspicious step in an order lifecycle
+type OrderViolation struct {
+	Type    string `json:"type"`
+	EventID string `json:"event_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// OrderLifecycle is the reconstructed history of a single order
+type OrderLifecycle struct {
+	OrderID        string                `json:"order_id"`
+	State          OrderState            `json:"state"`
+	Quantity       float64               `json:"quantity"`
+	FilledQuantity float64               `json:"filled_quantity"`
+	Services       []string              `json:"services"`
+	TraceIDs       []string              `json:"trace_ids"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	Events         []OrderLifecycleEvent `json:"events"`
+	Violations     []OrderViolation      `json:"violations"`
+
+	executionIDs map[string]bool
+	fills        []orderFill
+}
+
+// orderFill is a counted fill, kept to recognise the same fill reported by another service
+type orderFill struct {
+	serviceName  string
+	executionID  string
+	quantity     float64
+	price        float64
+	hasPrice     bool
+	timestamp    time.Time
+	corroborated map[string]bool // services whose report of this fill was already matched
+}
+
+// sameFill reports whether a fill from another service describes this fill: the quantity and
+// price agree, the reports are within fillSkewTolerance, and their execution IDs do not differ
+func (f *orderFill) sameFill(serviceName, executionID string, quantity, price float64, hasPrice bool, ts time.Time) bool {
+	if serviceName == f.serviceName || f.corroborated[serviceName] {
+		return false
+	}
+	if executionID != "" && f.executionID != "" && executionID != f.executionID {
+		return false
+	}
+	if quantity != f.quantity || hasPrice != f.hasPrice || (hasPrice && price != f.price) {
+		return false
+	}
+	return absDuration(ts.Sub(f.timestamp)) <= fillSkewTolerance
+}
+
+// OrderIDFromEvent extracts the order ID carried in an event's metadata
+func OrderIDFromEvent(event *models.AuditEvent) string {
+	if orderID := metadataString(event, metadataKeyOrderID); orderID != "" {
+		return orderID
+	}
+	return metadataString(event, metadataKeyClientOrderID)
+}
+
+// orderEventKind classifies an event, preferring an explicit order_event metadata field
+func orderEventKind(event *models.AuditEvent) (OrderEventKind, bool) {
+	if explicit := metadataString(event, metadataKeyOrderEvent); explicit != "" {
+		kind, ok := orderEventKinds[strings.ToLower(explicit)]
+		if !ok {
+			kind = OrderEventKind(strings.ToLower(explicit))
+			ok = isOrderEventKind(kind)
+		}
+		return kind, ok
+	}
+	kind, ok := orderEventKinds[strings.ToLower(event.EventType)]
+	return kind, ok
+}
+
+func isOrderEventKind(kind OrderEventKind) bool {
+	switch kind {
+	case OrderEventNew, OrderEventAck, OrderEventPartialFill, OrderEventFill, OrderEventCancel, OrderEventReject:
+		return true
+	}
+	return false
+}
+
+// ReconstructOrderLifecycles stitches order events from all services into per-order state machines
+// asOf is the reference time for detecting orders still waiting for an acknowledgement
+func ReconstructOrderLifecycles(events []*models.AuditEvent, offsets map[string]ClockOffset, ackTimeout time.Duration, asOf time.Time) []*OrderLifecycle {
+	ordered := make([]*models.AuditEvent, 0, len(events))
+	for _, event := range events {
+		if OrderIDFromEvent(event) == "" {
+			continue
+		}
+		if _, ok := orderEventKind(event); ok {
+			ordered = append(ordered, event)
+		}
+	}
+	SortByNormalizedTime(ordered, offsets)
+
+	lifecycles := make(map[string]*OrderLifecycle)
+	for _, event := range ordered {
+		orderID := OrderIDFromEvent(event)
+		lifecycle, exists := lifecycles[orderID]
+		if !exists {
+			lifecycle = &OrderLifecycle{
+				OrderID:      orderID,
+				State:        OrderStateUnknown,
+				Services:     []string{},
+				TraceIDs:     []string{},
+				Events:       []OrderLifecycleEvent{},
+				Violations:   []OrderViolation{},
+				executionIDs: make(map[string]bool),
+			}
+			lifecycles[orderID] = lifecycle
+		}
+		kind, _ := orderEventKind(event)
+		lifecycle.apply(event, kind, NormalizeTimestamp(event, offsets))
+	}
+
+	result := make([]*OrderLifecycle, 0, len(lifecycles))
+	for _, lifecycle := range lifecycles {
+		if lifecycle.State == OrderStateNew && asOf.Sub(lifecycle.CreatedAt) > ackTimeout {
+			lifecycle.addViolation(OrderViolationMissingAck, "", fmt.Sprintf("order not acknowledged within %s", ackTimeout))
+		}
+		result = append(result, lifecycle)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].OrderID < result[j].OrderID
+	})
+	return result
+}
+
+// apply advances the state machine by one event, recording violations instead of rejecting events
+func (o *OrderLifecycle) apply(event *models.AuditEvent, kind OrderEventKind, ts time.Time) {
+	if len(o.Events) == 0 {
+		o.CreatedAt = ts
+		if kind != OrderEventNew {
+			o.addViolation(OrderViolationMissingNew, event.ID, fmt.Sprintf("%s observed before order creation", kind))
+		}
+	}
+	o.UpdatedAt = ts
+	o.addService(event.ServiceName)
+	o.addTrace(event.TraceID)
+
+	step := OrderLifecycleEvent{
+		EventID:     event.ID,
+		ServiceName: event.ServiceName,
+		EventType:   event.EventType,
+		Kind:        kind,
+		Timestamp:   ts,
+		FromState:   o.State,
+		ToState:     o.State,
+	}
+
+	switch kind {
+	case OrderEventNew:
+		if quantity, ok := metadataFloat(event, metadataKeyQuantity); ok && o.Quantity == 0 {
+			o.Quantity = quantity
+			step.Quantity = quantity
+		}
+		switch o.State {
+		case OrderStateUnknown:
+			step.ToState = OrderStateNew
+		case OrderStateNew:
+			step.Duplicate = true
+		default:
+			o.illegal(event, kind)
+		}
+
+	case OrderEventAck:
+		switch o.State {
+		case OrderStateUnknown, OrderStateNew:
+			step.ToState = OrderStateAcknowledged
+		case OrderStateAcknowledged:
+			step.Duplicate = true
+		default:
+			o.illegal(event, kind)
+		}
+
+	case OrderEventPartialFill, OrderEventFill:
+		executionID := metadataString(event, metadataKeyExecutionID)
+		if executionID != "" && o.executionIDs[executionID] {
+			step.Duplicate = true
+			break
+		}
+		if executionID != "" {
+			o.executionIDs[executionID] = true
+		}
+
+		quantity, ok := metadataFloat(event, metadataKeyFillQuantity)
+		if !ok {
+			quantity, _ = metadataFloat(event, metadataKeyQuantity)
+		}
+		step.Quantity = quantity
+
+		// The engine and the exchange both report fills; without a shared execution ID the
+		// second report is recognised by its quantity, price and time
+		price, hasPrice := metadataFloat(event, metadataKeyFillPrice)
+		if !hasPrice {
+			price, hasPrice = metadataFloat(event, metadataKeyPrice)
+		}
+		if fill := o.matchFill(event.ServiceName, executionID, quantity, price, hasPrice, ts); fill != nil {
+			fill.corroborated[event.ServiceName] = true
+			step.Duplicate = true
+			break
+		}
+		o.fills = append(o.fills, orderFill{
+			serviceName:  event.ServiceName,
+			executionID:  executionID,
+			quantity:     quantity,
+			price:        price,
+			hasPrice:     hasPrice,
+			timestamp:    ts,
+			corroborated: make(map[string]bool),
+		})
+
+		switch o.State {
+		case OrderStateCancelled:
+			o.addViolation(OrderViolationFillAfterCancel, event.ID, fmt.Sprintf("%s reported by %s after cancel", kind, event.ServiceName))
+		case OrderStateFilled, OrderStateRejected:
+			o.illegal(event, kind)
+		case OrderStateNew:
+			o.addViolation(OrderViolationMissingAck, event.ID, fmt.Sprintf("%s received before acknowledgement", kind))
+		}
+
+		o.FilledQuantity += quantity
+		if o.Quantity > 0 && o.FilledQuantity > o.Quantity {
+			o.addViolation(OrderViolationOverfill, event.ID, fmt.Sprintf("filled %g of %g ordered", o.FilledQuantity, o.Quantity))
+		}
+
+		// Terminal states stay terminal so that later events keep being flagged
+		if o.State != OrderStateCancelled && o.State != OrderStateRejected {
+			step.ToState = OrderStatePartiallyFilled
+			if kind == OrderEventFill || (o.Quantity > 0 && o.FilledQuantity >= o.Quantity) {
+				step.ToState = OrderStateFilled
+			}
+		}
+
+	case OrderEventCancel:
+		switch o.State {
+		case OrderStateUnknown, OrderStateNew, OrderStateAcknowledged, OrderStatePartiallyFilled:
+			step.ToState = OrderStateCancelled
+		case OrderStateCancelled:
+			step.Duplicate = true
+		default:
+			o.illegal(event, kind)
+		}
+
+	case OrderEventReject:
+		switch o.State {
+		case OrderStateUnknown, OrderStateNew, OrderStateAcknowledged:
+			step.ToState = OrderStateRejected
+		case OrderStateRejected:
+			step.Duplicate = true
+		default:
+			o.illegal(event, kind)
+		}
+	}
+
+	o.State = step.ToState
+	o.Events = append(o.Events, step)
+}
+
+// matchFill returns the counted fill another service's report describes, if any
+func (o *OrderLifecycle) matchFill(serviceName, executionID string, quantity, price float64, hasPrice bool, ts time.Time) *orderFill {
+	for i := range o.fills {
+		if o.fills[i].sameFill(serviceName, executionID, quantity, price, hasPrice, ts) {
+			return &o.fills[i]
+		}
+	}
+	return nil
+}
+
+func (o *OrderLifecycle) illegal(event *models.AuditEvent, kind OrderEventKind) {
+	o.addViolation(OrderViolationIllegalTransition, event.ID, fmt.Sprintf("%s from %s is not allowed in state %s", kind, event.ServiceName, o.State))
+}
+
+func (o *OrderLifecycle) addViolation(violationType, eventID, message string) {
+	o.Violations = append(o.Violations, OrderViolation{Type: violationType, EventID: eventID, Message: message})
+}
+
+func (o *OrderLifecycle) addService(serviceName string) {
+	if serviceName != "" && !containsString(o.Services, serviceName) {
+		o.Services = append(o.Services, serviceName)
+	}
+}
+
+func (o *OrderLifecycle) addTrace(traceID string) {
+	if traceID != "" && !containsString(o.TraceIDs, traceID) {
+		o.TraceIDs = append(o.TraceIDs, traceID)
+	}
+}
+
+// OrderLifecycleService reconstructs order lifecycles from stored audit events
+type OrderLifecycleService struct {
+	auditService *AuditService
+	ackTimeout   time.Duration
+	logger       *logrus.Logger
+}
+
+// NewOrderLifecycleService creates a new order lifecycle service
+func NewOrderLifecycleService(auditService *AuditService, logger *logrus.Logger) *OrderLifecycleService {
+	return &OrderLifecycleService{
+		auditService: auditService,
+		ackTimeout:   defaultOrderAckTimeout,
+		logger:       logger,
+	}
+}
+
+// GetOrderLifecycle reconstructs the lifecycle of one order from events in the last timeWindow
+// The window is always walked in storage, keeping only events carrying the order ID: the
+// business-key index is partial, so an event missing from it would read as a missing report
+func (s *OrderLifecycleService) GetOrderLifecycle(orderID string, timeWindow time.Duration) (*OrderLifecycle, error) {
+	end := time.Now()
+	start := end.Add(-timeWindow)
+
+	var events []*models.AuditEvent
+	err := s.auditService.WalkEventsInWindow(context.Background(), WindowFilter{}, start, end, func(page []*models.AuditEvent) error {
+		for _, event := range page {
+			if OrderIDFromEvent(event) == orderID {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load order events: %w", err)
+	}
+
+	lifecycles := ReconstructOrderLifecycles(events, s.auditService.ClockSkewEstimator().Offsets(), s.ackTimeout, end)
+	for _, lifecycle := range lifecycles {
+		if lifecycle.OrderID == orderID {
+			return lifecycle, nil
+		}
+	}
+	return nil, fmt.Errorf("order not found: %s", orderID)
+}
+
+// GetOrderLifecycles reconstructs every order with events in the last timeWindow
+func (s *OrderLifecycleService) GetOrderLifecycles(timeWindow time.Duration) ([]*OrderLifecycle, error) {
+	end := time.Now()
+	events, err := s.auditService.GetEventsInWindow(end.Add(-timeWindow), end, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load order events: %w", err)
+	}
+
+	lifecycles := ReconstructOrderLifecycles(events, s.auditService.ClockSkewEstimator().Offsets(), s.ackTimeout, end)
+
+	s.logger.WithFields(logrus.Fields{
+		"time_window": timeWindow,
+		"events":      len(events),
+		"orders":      len(lifecycles),
+	}).Debug("Reconstructed order lifecycles")
+
+	return lifecycles, nil
+}
+
+// GetOrderViolations returns the orders in the last timeWindow with at least one violation
+func (s *OrderLifecycleService) GetOrderViolations(timeWindow time.Duration) ([]*OrderLifecycle, error) {
+	lifecycles, err := s.GetOrderLifecycles(timeWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	violating := []*OrderLifecycle{}
+	for _, lifecycle := range lifecycles {
+		if len(lifecycle.Violations) > 0 {
+			violating = append(violating, lifecycle)
+		}
+	}
+	return violating, nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func violationTypes(lifecycle *OrderLifecycle) []string {
+	var types []string
+	for _, violation := range lifecycle.Violations {
+		types = append(types, violation.Type)
+	}
+	return types
+}
+
+func TestReconstructOrderLifecycles_HappyPath(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "trading-engine", "order_submitted", base, `{"order_id": "o1", "quantity": 10}`),
+		newTypedEvent("e2", "exchange-simulator", "order_ack", base.Add(10*time.Millisecond), `{"order_id": "o1"}`),
+		newTypedEvent("e3", "exchange-simulator", "partial_fill", base.Add(20*time.Millisecond), `{"order_id": "o1", "fill_quantity": 4, "execution_id": "x1"}`),
+		// Same execution reported again by the trading engine
+		newTypedEvent("e4", "trading-engine", "partial_fill", base.Add(25*time.Millisecond), `{"order_id": "o1", "fill_quantity": 4, "execution_id": "x1"}`),
+		newTypedEvent("e5", "exchange-simulator", "fill", base.Add(30*time.Millisecond), `{"order_id": "o1", "fill_quantity": 6, "execution_id": "x2"}`),
+		newTypedEvent("e6", "custodian", "settlement", base.Add(time.Second), `{"order_id": "o1"}`),
+	}
+
+	lifecycles := ReconstructOrderLifecycles(events, nil, time.Second, base.Add(time.Minute))
+	if len(lifecycles) != 1 {
+		t.Fatalf("Expected 1 order, got %d", len(lifecycles))
+	}
+
+	order := lifecycles[0]
+	if order.State != OrderStateFilled {
+		t.Errorf("Expected state filled, got %s", order.State)
+	}
+	if order.FilledQuantity != 10 {
+		t.Errorf("Expected filled quantity 10 (duplicate execution ignored), got %v", order.FilledQuantity)
+	}
+	if len(order.Violations) != 0 {
+		t.Errorf("Expected no violations, got %v", order.Violations)
+	}
+	if len(order.Events) != 5 || !order.Events[3].Duplicate {
+		t.Errorf("Expected 5 lifecycle events with the repeated execution marked duplicate, got %+v", order.Events)
+	}
+	if len(order.Services) != 2 {
+		t.Errorf("Expected events from 2 services, got %v", order.Services)
+	}
+}
+
+func TestReconstructOrderLifecycles_Violations(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := []*models.AuditEvent{
+		// o1: fill after cancel
+		newTypedEvent("a1", "trading-engine", "order_submitted", base, `{"order_id": "o1", "quantity": 5}`),
+		newTypedEvent("a2", "exchange-simulator", "order_ack", base.Add(time.Millisecond), `{"order_id": "o1"}`),
+		newTypedEvent("a3", "trading-engine", "order_cancelled", base.Add(2*time.Millisecond), `{"order_id": "o1"}`),
+		newTypedEvent("a4", "exchange-simulator", "fill", base.Add(3*time.Millisecond), `{"order_id": "o1", "fill_quantity": 5}`),
+		// o2: overfill, fill without ack
+		newTypedEvent("b1", "trading-engine", "order_submitted", base, `{"order_id": "o2", "quantity": 5}`),
+		newTypedEvent("b2", "exchange-simulator", "fill", base.Add(time.Millisecond), `{"order_id": "o2", "fill_quantity": 7}`),
+		// o3: never acknowledged
+		newTypedEvent("c1", "trading-engine", "order_submitted", base, `{"client_order_id": "o3", "quantity": 1}`),
+	}
+
+	lifecycles := ReconstructOrderLifecycles(events, nil, time.Second, base.Add(time.Minute))
+	byID := make(map[string]*OrderLifecycle)
+	for _, lifecycle := range lifecycles {
+		byID[lifecycle.OrderID] = lifecycle
+	}
+
+	expected := map[string][]string{
+		"o1": {OrderViolationFillAfterCancel},
+		"o2": {OrderViolationMissingAck, OrderViolationOverfill},
+		"o3": {OrderViolationMissingAck},
+	}
+	for orderID, want := range expected {
+		order, ok := byID[orderID]
+		if !ok {
+			t.Fatalf("Expected order %s to be reconstructed", orderID)
+		}
+		got := violationTypes(order)
+		if len(got) != len(want) {
+			t.Errorf("Order %s: expected violations %v, got %v", orderID, want, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Order %s: expected violations %v, got %v", orderID, want, got)
+				break
+			}
+		}
+	}
+
+	if byID["o1"].State != OrderStateCancelled {
+		t.Errorf("Expected cancelled order to stay cancelled, got %s", byID["o1"].State)
+	}
+}
+
+func TestReconstructOrderLifecycles_OrdersOnCorrelatorClock(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// The exchange clock is 2s behind, so its raw ack timestamp precedes the submission
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "trading-engine", "order_submitted", base, `{"order_id": "o1"}`),
+		newTypedEvent("e2", "exchange-simulator", "order_ack", base.Add(-1990*time.Millisecond), `{"order_id": "o1"}`),
+	}
+	offsets := map[string]ClockOffset{
+		"exchange-simulator": newClockOffset("exchange-simulator", -2*time.Second, 1, ClockOffsetSourceIngestion),
+	}
+
+	order := ReconstructOrderLifecycles(events, offsets, time.Second, base.Add(time.Minute))[0]
+	if order.State != OrderStateAcknowledged || len(order.Violations) != 0 {
+		t.Errorf("Expected acknowledged order without violations, got %s %v", order.State, order.Violations)
+	}
+}
+
+func TestReconstructOrderLifecycles_CrossServiceFillReports(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := []*models.AuditEvent{
+		newTypedEvent("e1", "trading-engine", "order_submitted", base, `{"order_id": "o1", "quantity": 10}`),
+		newTypedEvent("e2", "exchange-simulator", "order_ack", base.Add(10*time.Millisecond), `{"order_id": "o1"}`),
+		// The exchange and the engine report the same fills without execution IDs
+		newTypedEvent("e3", "exchange-simulator", "partial_fill", base.Add(20*time.Millisecond), `{"order_id": "o1", "fill_quantity": 5, "price": 100}`),
+		newTypedEvent("e4", "trading-engine", "partial_fill", base.Add(300*time.Millisecond), `{"order_id": "o1", "fill_quantity": 5, "price": 100}`),
+		newTypedEvent("e5", "exchange-simulator", "fill", base.Add(400*time.Millisecond), `{"order_id": "o1", "fill_quantity": 5, "price": 101}`),
+		newTypedEvent("e6", "trading-engine", "fill", base.Add(500*time.Millisecond), `{"order_id": "o1", "fill_quantity": 5, "price": 101}`),
+	}
+
+	order := ReconstructOrderLifecycles(events, nil, time.Second, base.Add(time.Minute))[0]
+	if order.FilledQuantity != 10 || order.State != OrderStateFilled {
+		t.Errorf("Expected the order filled at 10 with each fill counted once, got %v in state %s", order.FilledQuantity, order.State)
+	}
+	if len(order.Violations) != 0 {
+		t.Errorf("Expected no overfill, got %v", order.Violations)
+	}
+	if !order.Events[3].Duplicate || !order.Events[5].Duplicate || order.Events[4].Duplicate {
+		t.Errorf("Expected the engine reports marked duplicate, got %+v", order.Events)
+	}
+
+	// The same service reporting two equal fills is two fills, and so is a report outside the skew tolerance
+	events = []*models.AuditEvent{
+		newTypedEvent("a1", "trading-engine", "order_submitted", base, `{"order_id": "o2", "quantity": 10}`),
+		newTypedEvent("a2", "exchange-simulator", "order_ack", base.Add(time.Millisecond), `{"order_id": "o2"}`),
+		newTypedEvent("a3", "exchange-simulator", "partial_fill", base.Add(2*time.Millisecond), `{"order_id": "o2", "fill_quantity": 5}`),
+		newTypedEvent("a4", "exchange-simulator", "partial_fill", base.Add(3*time.Millisecond), `{"order_id": "o2", "fill_quantity": 5}`),
+		newTypedEvent("a5", "trading-engine", "partial_fill", base.Add(time.Minute), `{"order_id": "o2", "fill_quantity": 5}`),
+	}
+	order = ReconstructOrderLifecycles(events, nil, time.Second, base.Add(time.Hour))[0]
+	if types := violationTypes(order); order.FilledQuantity != 15 || !containsString(types, OrderViolationOverfill) {
+		t.Errorf("Expected a real overfill of 15, got %v with %v", order.FilledQuantity, order.Violations)
+	}
+}
+
+func TestOrderLifecycleService_GetOrderLifecycleFromStorage(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	// Given stored order events that were never seen by the business-key index
+	now := time.Now()
+	auditService := NewAuditServiceWithDataAdapter(newMemoryDataAdapter(
+		newTypedEvent("e1", "trading-engine", "order_submitted", now.Add(-time.Minute), `{"order_id": "o1", "quantity": 1}`),
+		newTypedEvent("e2", "exchange-simulator", "order_ack", now.Add(-time.Minute+time.Millisecond), `{"order_id": "o1"}`),
+		newTypedEvent("e3", "trading-engine", "order_submitted", now.Add(-time.Minute), `{"order_id": "o2", "quantity": 1}`),
+		newTypedEvent("e4", "trading-engine", "order_submitted", now.Add(-48*time.Hour), `{"order_id": "o1", "quantity": 1}`),
+	), logger)
+	orderService := NewOrderLifecycleService(auditService, logger)
+
+	// Then the order is rebuilt from its events in the window, not reported missing its ack
+	order, err := orderService.GetOrderLifecycle("o1", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("GetOrderLifecycle failed: %v", err)
+	}
+	if order.State != OrderStateAcknowledged || len(order.Events) != 2 || len(order.Violations) != 0 {
+		t.Errorf("Expected the acknowledged order from its two events in the window, got %s with %+v and %+v", order.State, order.Events, order.Violations)
+	}
+
+	if _, err := orderService.GetOrderLifecycle("o3", 24*time.Hour); err == nil {
+		t.Error("Expected an error for an unknown order")
+	}
+}
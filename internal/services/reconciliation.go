@@ -0,0 +1,594 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// TradeRecordSource identifies which side of a trade a record was reported by
+type TradeRecordSource string
+
+const (
+	TradeSourceTradingEngine TradeRecordSource = "trading_engine"
+	TradeSourceExchange      TradeRecordSource = "exchange"
+	TradeSourceCustodian     TradeRecordSource = "custodian"
+)
+
+// Trade break types
+const (
+	BreakMissingExchangeExecution = "missing_exchange_execution"
+	BreakMissingEngineFill        = "missing_engine_fill"
+	BreakPriceMismatch            = "price_mismatch"
+	BreakQuantityMismatch         = "quantity_mismatch"
+	BreakTimeMismatch             = "time_mismatch"
+	BreakMissingSettlement        = "missing_settlement"
+	BreakSettlementMismatch       = "settlement_quantity_mismatch"
+	BreakUnexpectedSettlement     = "unexpected_settlement"
+)
+
+// Trade break statuses
+const (
+	BreakStatusOpen         = "open"
+	BreakStatusResolved     = "resolved"
+	BreakStatusAutoResolved = "auto_resolved" // No longer found when its window was reconciled again
+)
+
+const (
+	// maxReconciliationRuns bounds the run history kept in memory
+	maxReconciliationRuns = 100
+	// maxResolvedBreaks bounds the resolved breaks kept in memory; the oldest resolutions go first
+	maxResolvedBreaks = 10000
+)
+
+// Well-known trade metadata keys
+const (
+	metadataKeyPrice          = "price"
+	metadataKeyFillPrice      = "fill_price"
+	metadataKeyExecutionPrice = "execution_price"
+)
+
+// settlementEventTypes are the custodian event types treated as settlements
+var settlementEventTypes = map[string]bool{
+	"settlement":           true,
+	"trade_settled":        true,
+	"settlement_completed": true,
+}
+
+// executionEventTypes are exchange event types treated as executions in addition to fills
+var executionEventTypes = map[string]bool{
+	"execution":        true,
+	"trade_executed":   true,
+	"execution_report": true,
+}
+
+// ReconciliationConfig configures record classification and matching tolerances
+type ReconciliationConfig struct {
+	TradingEngineService string  `json:"trading_engine_service"`
+	ExchangeService      string  `json:"exchange_service"`
+	CustodianService     string  `json:"custodian_service"`
+	PriceTolerance       float64 `json:"price_tolerance"`    // Absolute price difference
+	QuantityTolerance    float64 `json:"quantity_tolerance"` // Absolute quantity difference
+	TimeToleranceMs      int64   `json:"time_tolerance_ms"`
+	SettlementTimeoutMs  int64   `json:"settlement_timeout_ms"` // Age after which an unsettled execution is a break
+}
+
+// DefaultReconciliationConfig returns the tolerances used when none are supplied
+func DefaultReconciliationConfig() ReconciliationConfig {
+	return ReconciliationConfig{
+		TradingEngineService: "trading-engine",
+		ExchangeService:      "exchange-simulator",
+		CustodianService:     "custodian",
+		PriceTolerance:       0.0001,
+		QuantityTolerance:    0,
+		TimeToleranceMs:      2000,
+		SettlementTimeoutMs:  5 * 60 * 1000,
+	}
+}
+
+// TimeTolerance returns the maximum allowed time difference between engine and exchange records
+func (c ReconciliationConfig) TimeTolerance() time.Duration {
+	return time.Duration(c.TimeToleranceMs) * time.Millisecond
+}
+
+// SettlementTimeout returns the age after which an unsettled execution is reported
+func (c ReconciliationConfig) SettlementTimeout() time.Duration {
+	return time.Duration(c.SettlementTimeoutMs) * time.Millisecond
+}
+
+// TradeRecord is a fill, execution or settlement extracted from an audit event
+type TradeRecord struct {
+	Source      TradeRecordSource `json:"source"`
+	EventID     string            `json:"event_id"`
+	ServiceName string            `json:"service_name"`
+	OrderID     string            `json:"order_id"`
+	ExecutionID string            `json:"execution_id,omitempty"`
+	Price       float64           `json:"price"`
+	Quantity    float64           `json:"quantity"`
+	Timestamp   time.Time         `json:"timestamp"` // Normalized to the correlator clock
+}
+
+// TradeBreak is a discrepancy between the trading engine, exchange and custodian records
+type TradeBreak struct {
+	ID          string       `json:"id"`
+	Type        string       `json:"type"`
+	Status      string       `json:"status"`
+	OrderID     string       `json:"order_id"`
+	ExecutionID string       `json:"execution_id,omitempty"`
+	Message     string       `json:"message"`
+	Engine      *TradeRecord `json:"engine,omitempty"`
+	Exchange    *TradeRecord `json:"exchange,omitempty"`
+	Custodian   *TradeRecord `json:"custodian,omitempty"`
+	DetectedAt  time.Time    `json:"detected_at"`
+	LastSeenAt  time.Time    `json:"last_seen_at"`
+	ResolvedAt  *time.Time   `json:"resolved_at,omitempty"`
+	Resolution  string       `json:"resolution,omitempty"`
+	RunID       string       `json:"run_id"`
+}
+
+// clone copies a break so callers can read it while the stored break changes
+func (b *TradeBreak) clone() *TradeBreak {
+	clone := *b
+	if b.ResolvedAt != nil {
+		resolvedAt := *b.ResolvedAt
+		clone.ResolvedAt = &resolvedAt
+	}
+	return &clone
+}
+
+// occurredAt is the time of the earliest record involved in the break
+func (b *TradeBreak) occurredAt() time.Time {
+	var earliest time.Time
+	for _, record := range []*TradeRecord{b.Engine, b.Exchange, b.Custodian} {
+		if record != nil && (earliest.IsZero() || record.Timestamp.Before(earliest)) {
+			earliest = record.Timestamp
+		}
+	}
+	return earliest
+}
+
+// TradeBreakFilter selects breaks; empty fields match anything
+type TradeBreakFilter struct {
+	Type    string
+	Status  string
+	OrderID string
+	Since   time.Time
+	Limit   int
+}
+
+// ReconciliationRun summarizes one reconciliation pass
+type ReconciliationRun struct {
+	ID                 string               `json:"id"`
+	WindowStart        time.Time            `json:"window_start"`
+	WindowEnd          time.Time            `json:"window_end"`
+	RunAt              time.Time            `json:"run_at"`
+	Config             ReconciliationConfig `json:"config"`
+	EngineFills        int                  `json:"engine_fills"`
+	ExchangeExecutions int                  `json:"exchange_executions"`
+	Settlements        int                  `json:"settlements"`
+	Matched            int                  `json:"matched"`
+	Breaks             int                  `json:"breaks"`
+	BreaksByType       map[string]int       `json:"breaks_by_type"`
+}
+
+// ExtractTradeRecords classifies events into engine fills, exchange executions and custodian settlements
+// Records reported more than once with the same execution ID are kept once per source
+func ExtractTradeRecords(events []*models.AuditEvent, offsets map[string]ClockOffset, config ReconciliationConfig) (engine, exchange, custodian []*TradeRecord) {
+	seen := make(map[string]bool)
+
+	for _, event := range events {
+		orderID := OrderIDFromEvent(event)
+		executionID := metadataString(event, metadataKeyExecutionID)
+		if orderID == "" && executionID == "" {
+			continue
+		}
+
+		var source TradeRecordSource
+		kind, isOrderEvent := orderEventKind(event)
+		isFill := isOrderEvent && (kind == OrderEventFill || kind == OrderEventPartialFill)
+		eventType := strings.ToLower(event.EventType)
+
+		switch {
+		case event.ServiceName == config.TradingEngineService && isFill:
+			source = TradeSourceTradingEngine
+		case event.ServiceName == config.ExchangeService && (isFill || executionEventTypes[eventType]):
+			source = TradeSourceExchange
+		case event.ServiceName == config.CustodianService && settlementEventTypes[eventType]:
+			source = TradeSourceCustodian
+		default:
+			continue
+		}
+
+		if executionID != "" {
+			key := string(source) + "|" + executionID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+
+		record := &TradeRecord{
+			Source:      source,
+			EventID:     event.ID,
+			ServiceName: event.ServiceName,
+			OrderID:     orderID,
+			ExecutionID: executionID,
+			Price:       firstMetadataFloat(event, metadataKeyPrice, metadataKeyFillPrice, metadataKeyExecutionPrice),
+			Quantity:    firstMetadataFloat(event, metadataKeyFillQuantity, metadataKeyQuantity),
+			Timestamp:   NormalizeTimestamp(event, offsets),
+		}
+
+		switch source {
+		case TradeSourceTradingEngine:
+			engine = append(engine, record)
+		case TradeSourceExchange:
+			exchange = append(exchange, record)
+		case TradeSourceCustodian:
+			custodian = append(custodian, record)
+		}
+	}
+	return engine, exchange, custodian
+}
+
+func firstMetadataFloat(event *models.AuditEvent, keys ...string) float64 {
+	for _, key := range keys {
+		if value, ok := metadataFloat(event, key); ok {
+			return value
+		}
+	}
+	return 0
+}
+
+// ReconcileTrades matches engine fills to exchange executions and executions to settlements
+// asOf is the reference time for deciding that a settlement is overdue
+func ReconcileTrades(engine, exchange, custodian []*TradeRecord, config ReconciliationConfig, asOf time.Time) (matched int, breaks []*TradeBreak) {
+	exchangeUsed := make([]bool, len(exchange))
+
+	for _, fill := range engine {
+		index := bestTradeMatch(fill, exchange, exchangeUsed)
+		if index < 0 {
+			breaks = append(breaks, newTradeBreak(BreakMissingExchangeExecution, fill.OrderID, fill.ExecutionID,
+				fmt.Sprintf("engine fill %s has no matching exchange execution", fill.EventID), fill, nil, nil))
+			continue
+		}
+		exchangeUsed[index] = true
+		execution := exchange[index]
+		matched++
+
+		executionID := execution.ExecutionID
+		if executionID == "" {
+			executionID = fill.ExecutionID
+		}
+		if math.Abs(fill.Price-execution.Price) > config.PriceTolerance {
+			breaks = append(breaks, newTradeBreak(BreakPriceMismatch, fill.OrderID, executionID,
+				fmt.Sprintf("engine price %g differs from exchange price %g", fill.Price, execution.Price), fill, execution, nil))
+		}
+		if math.Abs(fill.Quantity-execution.Quantity) > config.QuantityTolerance {
+			breaks = append(breaks, newTradeBreak(BreakQuantityMismatch, fill.OrderID, executionID,
+				fmt.Sprintf("engine quantity %g differs from exchange quantity %g", fill.Quantity, execution.Quantity), fill, execution, nil))
+		}
+		if delta := absDuration(fill.Timestamp.Sub(execution.Timestamp)); delta > config.TimeTolerance() {
+			breaks = append(breaks, newTradeBreak(BreakTimeMismatch, fill.OrderID, executionID,
+				fmt.Sprintf("engine and exchange timestamps differ by %s", delta), fill, execution, nil))
+		}
+	}
+
+	for i, execution := range exchange {
+		if !exchangeUsed[i] {
+			breaks = append(breaks, newTradeBreak(BreakMissingEngineFill, execution.OrderID, execution.ExecutionID,
+				fmt.Sprintf("exchange execution %s has no matching engine fill", execution.EventID), nil, execution, nil))
+		}
+	}
+
+	custodianUsed := make([]bool, len(custodian))
+	for _, execution := range exchange {
+		index := bestTradeMatch(execution, custodian, custodianUsed)
+		if index < 0 {
+			if asOf.Sub(execution.Timestamp) > config.SettlementTimeout() {
+				breaks = append(breaks, newTradeBreak(BreakMissingSettlement, execution.OrderID, execution.ExecutionID,
+					fmt.Sprintf("execution %s not settled within %s", execution.EventID, config.SettlementTimeout()), nil, execution, nil))
+			}
+			continue
+		}
+		custodianUsed[index] = true
+		settlement := custodian[index]
+		if math.Abs(execution.Quantity-settlement.Quantity) > config.QuantityTolerance {
+			breaks = append(breaks, newTradeBreak(BreakSettlementMismatch, execution.OrderID, execution.ExecutionID,
+				fmt.Sprintf("settled quantity %g differs from executed quantity %g", settlement.Quantity, execution.Quantity), nil, execution, settlement))
+		}
+	}
+
+	for i, settlement := range custodian {
+		if !custodianUsed[i] {
+			breaks = append(breaks, newTradeBreak(BreakUnexpectedSettlement, settlement.OrderID, settlement.ExecutionID,
+				fmt.Sprintf("settlement %s has no matching exchange execution", settlement.EventID), nil, nil, settlement))
+		}
+	}
+
+	return matched, breaks
+}
+
+// bestTradeMatch returns the index of the unused candidate matching the record, or -1
+// Execution IDs are authoritative; otherwise candidates for the same order are ranked by
+// quantity agreement and then time proximity
+func bestTradeMatch(record *TradeRecord, candidates []*TradeRecord, used []bool) int {
+	if record.ExecutionID != "" {
+		for i, candidate := range candidates {
+			if !used[i] && candidate.ExecutionID == record.ExecutionID {
+				return i
+			}
+		}
+	}
+
+	best := -1
+	for i, candidate := range candidates {
+		if used[i] || record.OrderID == "" || candidate.OrderID != record.OrderID {
+			continue
+		}
+		if record.ExecutionID != "" && candidate.ExecutionID != "" {
+			continue // Both carry execution IDs and they differ
+		}
+		if best < 0 || betterTradeMatch(record, candidate, candidates[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func betterTradeMatch(record, candidate, current *TradeRecord) bool {
+	candidateQty := candidate.Quantity == record.Quantity
+	currentQty := current.Quantity == record.Quantity
+	if candidateQty != currentQty {
+		return candidateQty
+	}
+	return absDuration(candidate.Timestamp.Sub(record.Timestamp)) < absDuration(current.Timestamp.Sub(record.Timestamp))
+}
+
+func newTradeBreak(breakType, orderID, executionID, message string, engine, exchange, custodian *TradeRecord) *TradeBreak {
+	// IDs are derived from the records involved so that re-running a window updates existing breaks
+	reference := executionID
+	for _, record := range []*TradeRecord{engine, exchange, custodian} {
+		if reference == "" && record != nil {
+			reference = record.EventID
+		}
+	}
+
+	return &TradeBreak{
+		ID:          fmt.Sprintf("break-%s-%s-%s", breakType, orderID, reference),
+		Type:        breakType,
+		Status:      BreakStatusOpen,
+		OrderID:     orderID,
+		ExecutionID: executionID,
+		Message:     message,
+		Engine:      engine,
+		Exchange:    exchange,
+		Custodian:   custodian,
+	}
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// ReconciliationService reconciles trades from audit events and stores the resulting breaks
+type ReconciliationService struct {
+	auditService *AuditService
+	config       ReconciliationConfig
+	logger       *logrus.Logger
+
+	mu     sync.RWMutex
+	breaks map[string]*TradeBreak
+	runs   []*ReconciliationRun
+}
+
+// NewReconciliationService creates a new reconciliation service with default tolerances
+func NewReconciliationService(auditService *AuditService, logger *logrus.Logger) *ReconciliationService {
+	return &ReconciliationService{
+		auditService: auditService,
+		config:       DefaultReconciliationConfig(),
+		logger:       logger,
+		breaks:       make(map[string]*TradeBreak),
+	}
+}
+
+// Config returns the default reconciliation configuration
+func (s *ReconciliationService) Config() ReconciliationConfig {
+	return s.config
+}
+
+// Reconcile reconciles all trades with events in [start, end] using the given configuration
+func (s *ReconciliationService) Reconcile(start, end time.Time, config ReconciliationConfig) (*ReconciliationRun, error) {
+	if start.After(end) {
+		return nil, fmt.Errorf("reconciliation window start must not be after end")
+	}
+
+	events, err := s.auditService.GetEventsInWindow(start, end, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trade events: %w", err)
+	}
+
+	return s.reconcileEvents(events, start, end, config), nil
+}
+
+func (s *ReconciliationService) reconcileEvents(events []*models.AuditEvent, start, end time.Time, config ReconciliationConfig) *ReconciliationRun {
+	runAt := time.Now()
+
+	engine, exchange, custodian := ExtractTradeRecords(events, s.auditService.ClockSkewEstimator().Offsets(), config)
+	matched, breaks := ReconcileTrades(engine, exchange, custodian, config, runAt)
+
+	run := &ReconciliationRun{
+		ID:                 fmt.Sprintf("recon-%d", runAt.UnixNano()),
+		WindowStart:        start,
+		WindowEnd:          end,
+		RunAt:              runAt,
+		Config:             config,
+		EngineFills:        len(engine),
+		ExchangeExecutions: len(exchange),
+		Settlements:        len(custodian),
+		Matched:            matched,
+		Breaks:             len(breaks),
+		BreaksByType:       make(map[string]int),
+	}
+
+	s.mu.Lock()
+	found := make(map[string]bool, len(breaks))
+	for _, tradeBreak := range breaks {
+		run.BreaksByType[tradeBreak.Type]++
+		tradeBreak.RunID = run.ID
+		tradeBreak.LastSeenAt = runAt
+		found[tradeBreak.ID] = true
+
+		// Manual resolutions stick; a break found again after being auto-resolved reopens
+		if existing, exists := s.breaks[tradeBreak.ID]; exists {
+			tradeBreak.DetectedAt = existing.DetectedAt
+			if existing.Status == BreakStatusResolved {
+				tradeBreak.Status = existing.Status
+				tradeBreak.ResolvedAt = existing.ResolvedAt
+				tradeBreak.Resolution = existing.Resolution
+			}
+		} else {
+			tradeBreak.DetectedAt = runAt
+		}
+		s.breaks[tradeBreak.ID] = tradeBreak
+	}
+
+	// Open breaks whose records fall in this window but which were not found again are gone
+	for id, tradeBreak := range s.breaks {
+		if found[id] || tradeBreak.Status != BreakStatusOpen {
+			continue
+		}
+		if occurredAt := tradeBreak.occurredAt(); occurredAt.Before(start) || occurredAt.After(end) {
+			continue
+		}
+		resolved := *tradeBreak
+		resolved.Status = BreakStatusAutoResolved
+		resolved.ResolvedAt = &runAt
+		resolved.Resolution = fmt.Sprintf("not found by reconciliation run %s", run.ID)
+		s.breaks[id] = &resolved
+	}
+	s.evictResolvedLocked()
+
+	s.runs = append(s.runs, run)
+	if len(s.runs) > maxReconciliationRuns {
+		s.runs = s.runs[len(s.runs)-maxReconciliationRuns:]
+	}
+	s.mu.Unlock()
+
+	s.logger.WithFields(logrus.Fields{
+		"run_id":   run.ID,
+		"engine":   run.EngineFills,
+		"exchange": run.ExchangeExecutions,
+		"custody":  run.Settlements,
+		"matched":  run.Matched,
+		"breaks":   run.Breaks,
+	}).Info("Trade reconciliation completed")
+
+	return run
+}
+
+// evictResolvedLocked drops the oldest resolved breaks beyond maxResolvedBreaks
+func (s *ReconciliationService) evictResolvedLocked() {
+	var resolved []*TradeBreak
+	for _, tradeBreak := range s.breaks {
+		if tradeBreak.Status != BreakStatusOpen {
+			resolved = append(resolved, tradeBreak)
+		}
+	}
+	if len(resolved) <= maxResolvedBreaks {
+		return
+	}
+
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].ResolvedAt.Before(*resolved[j].ResolvedAt) })
+	for _, tradeBreak := range resolved[:len(resolved)-maxResolvedBreaks] {
+		delete(s.breaks, tradeBreak.ID)
+	}
+}
+
+// ListRuns returns previous reconciliation runs, most recent first
+func (s *ReconciliationService) ListRuns() []*ReconciliationRun {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]*ReconciliationRun, 0, len(s.runs))
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		runs = append(runs, s.runs[i])
+	}
+	return runs
+}
+
+// ListBreaks returns stored breaks matching the filter, most recently seen first
+func (s *ReconciliationService) ListBreaks(filter TradeBreakFilter) []*TradeBreak {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	breaks := []*TradeBreak{}
+	for _, tradeBreak := range s.breaks {
+		if filter.Type != "" && tradeBreak.Type != filter.Type {
+			continue
+		}
+		if filter.Status != "" && tradeBreak.Status != filter.Status {
+			continue
+		}
+		if filter.OrderID != "" && tradeBreak.OrderID != filter.OrderID {
+			continue
+		}
+		if !filter.Since.IsZero() && tradeBreak.LastSeenAt.Before(filter.Since) {
+			continue
+		}
+		breaks = append(breaks, tradeBreak.clone())
+	}
+
+	sort.Slice(breaks, func(i, j int) bool {
+		if !breaks[i].LastSeenAt.Equal(breaks[j].LastSeenAt) {
+			return breaks[i].LastSeenAt.After(breaks[j].LastSeenAt)
+		}
+		return breaks[i].ID < breaks[j].ID
+	})
+	if filter.Limit > 0 && len(breaks) > filter.Limit {
+		breaks = breaks[:filter.Limit]
+	}
+	return breaks
+}
+
+// GetBreak returns a stored break by ID
+func (s *ReconciliationService) GetBreak(breakID string) (*TradeBreak, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tradeBreak, exists := s.breaks[breakID]
+	if !exists {
+		return nil, fmt.Errorf("trade break not found: %s", breakID)
+	}
+	return tradeBreak.clone(), nil
+}
+
+// ResolveBreak marks a break as resolved with a resolution note
+func (s *ReconciliationService) ResolveBreak(breakID, resolution string) (*TradeBreak, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tradeBreak, exists := s.breaks[breakID]
+	if !exists {
+		return nil, fmt.Errorf("trade break not found: %s", breakID)
+	}
+
+	// Breaks handed out earlier are copies, so the stored one is replaced rather than changed
+	now := time.Now()
+	resolved := *tradeBreak
+	resolved.Status = BreakStatusResolved
+	resolved.ResolvedAt = &now
+	resolved.Resolution = resolution
+	s.breaks[breakID] = &resolved
+	s.evictResolvedLocked()
+	return resolved.clone(), nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func breakTypes(breaks []*TradeBreak) map[string]int {
+	types := make(map[string]int)
+	for _, tradeBreak := range breaks {
+		types[tradeBreak.Type]++
+	}
+	return types
+}
+
+func TestReconcileTrades_MatchesAndBreaks(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := DefaultReconciliationConfig()
+
+	events := []*models.AuditEvent{
+		// x1: clean trade, settled
+		newTypedEvent("te1", "trading-engine", "fill", base, `{"order_id": "o1", "execution_id": "x1", "price": 100, "fill_quantity": 5}`),
+		newTypedEvent("ex1", "exchange-simulator", "execution", base.Add(100*time.Millisecond), `{"order_id": "o1", "execution_id": "x1", "price": 100, "quantity": 5}`),
+		newTypedEvent("cu1", "custodian", "settlement", base.Add(time.Second), `{"order_id": "o1", "execution_id": "x1", "quantity": 5}`),
+		// x2: price and quantity mismatch, settled with wrong quantity
+		newTypedEvent("te2", "trading-engine", "fill", base, `{"order_id": "o2", "execution_id": "x2", "price": 101, "fill_quantity": 3}`),
+		newTypedEvent("ex2", "exchange-simulator", "fill", base, `{"order_id": "o2", "execution_id": "x2", "price": 100.5, "fill_quantity": 4}`),
+		newTypedEvent("cu2", "custodian", "settlement", base.Add(time.Second), `{"order_id": "o2", "execution_id": "x2", "quantity": 3}`),
+		// o3: engine fill without exchange execution
+		newTypedEvent("te3", "trading-engine", "partial_fill", base, `{"order_id": "o3", "price": 10, "fill_quantity": 1}`),
+		// o4: matched by order ID without execution IDs, 10s apart, never settled
+		newTypedEvent("te4", "trading-engine", "fill", base, `{"order_id": "o4", "price": 50, "fill_quantity": 2}`),
+		newTypedEvent("ex4", "exchange-simulator", "execution", base.Add(10*time.Second), `{"order_id": "o4", "price": 50, "quantity": 2}`),
+		// Settlement without any execution
+		newTypedEvent("cu5", "custodian", "trade_settled", base, `{"order_id": "o5", "execution_id": "x5", "quantity": 9}`),
+	}
+
+	engine, exchange, custodian := ExtractTradeRecords(events, nil, config)
+	if len(engine) != 4 || len(exchange) != 3 || len(custodian) != 3 {
+		t.Fatalf("Expected 4/3/3 records, got %d/%d/%d", len(engine), len(exchange), len(custodian))
+	}
+
+	matched, breaks := ReconcileTrades(engine, exchange, custodian, config, base.Add(time.Hour))
+	if matched != 3 {
+		t.Errorf("Expected 3 matched trades, got %d", matched)
+	}
+
+	want := map[string]int{
+		BreakPriceMismatch:            1,
+		BreakQuantityMismatch:         1,
+		BreakSettlementMismatch:       1,
+		BreakMissingExchangeExecution: 1,
+		BreakTimeMismatch:             1,
+		BreakMissingSettlement:        1,
+		BreakUnexpectedSettlement:     1,
+	}
+	got := breakTypes(breaks)
+	for breakType, count := range want {
+		if got[breakType] != count {
+			t.Errorf("Expected %d %s breaks, got %d (all: %v)", count, breakType, got[breakType], got)
+		}
+	}
+	if len(breaks) != 7 {
+		t.Errorf("Expected 7 breaks, got %d: %v", len(breaks), got)
+	}
+}
+
+func TestReconciliationService_BreaksAreQueryableAndStable(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	service := NewReconciliationService(NewAuditService(logger), logger)
+	base := time.Now().Add(-time.Hour)
+
+	events := []*models.AuditEvent{
+		newTypedEvent("te1", "trading-engine", "fill", base, `{"order_id": "o1", "execution_id": "x1", "price": 100, "fill_quantity": 5}`),
+	}
+
+	first := service.reconcileEvents(events, base, base.Add(time.Minute), service.Config())
+	if first.Breaks != 1 {
+		t.Fatalf("Expected only the missing execution break, got %d breaks", first.Breaks)
+	}
+
+	breaks := service.ListBreaks(TradeBreakFilter{Type: BreakMissingExchangeExecution})
+	if len(breaks) != 1 || breaks[0].OrderID != "o1" {
+		t.Fatalf("Expected 1 missing execution break for o1, got %+v", breaks)
+	}
+
+	if _, err := service.ResolveBreak(breaks[0].ID, "late exchange report"); err != nil {
+		t.Fatalf("Failed to resolve break: %v", err)
+	}
+
+	// Re-running the same window updates the existing break rather than reopening it
+	service.reconcileEvents(events, base, base.Add(time.Minute), service.Config())
+	tradeBreak, err := service.GetBreak(breaks[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get break: %v", err)
+	}
+	if tradeBreak.Status != BreakStatusResolved {
+		t.Errorf("Expected break to stay resolved, got %s", tradeBreak.Status)
+	}
+	if len(service.ListBreaks(TradeBreakFilter{Status: BreakStatusOpen})) != 0 {
+		t.Error("Expected no open breaks after resolving")
+	}
+	if len(service.ListRuns()) != 2 {
+		t.Errorf("Expected 2 runs, got %d", len(service.ListRuns()))
+	}
+}
+
+func TestReconciliationService_RerunAutoResolvesVanishedBreaks(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	service := NewReconciliationService(NewAuditService(logger), logger)
+	base := time.Now().Add(-time.Hour)
+
+	fill := newTypedEvent("te1", "trading-engine", "fill", base, `{"order_id": "o1", "execution_id": "x1", "price": 100, "fill_quantity": 5}`)
+	service.reconcileEvents([]*models.AuditEvent{fill}, base, base.Add(time.Minute), service.Config())
+
+	breaks := service.ListBreaks(TradeBreakFilter{Status: BreakStatusOpen})
+	if len(breaks) != 1 {
+		t.Fatalf("Expected one open break, got %+v", breaks)
+	}
+	// Callers get copies, so changing one does not change the stored break
+	breaks[0].Status = BreakStatusResolved
+	if stored, _ := service.GetBreak(breaks[0].ID); stored.Status != BreakStatusOpen {
+		t.Errorf("Expected the stored break to stay open, got %s", stored.Status)
+	}
+
+	// The late exchange execution arrives and the window is reconciled again
+	execution := newTypedEvent("ex1", "exchange-simulator", "fill", base.Add(time.Millisecond), `{"order_id": "o1", "execution_id": "x1", "price": 100, "fill_quantity": 5}`)
+	service.reconcileEvents([]*models.AuditEvent{fill, execution}, base, base.Add(time.Minute), service.Config())
+
+	tradeBreak, err := service.GetBreak(breaks[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get break: %v", err)
+	}
+	if tradeBreak.Status != BreakStatusAutoResolved || tradeBreak.ResolvedAt == nil {
+		t.Errorf("Expected the vanished break auto-resolved, got %+v", tradeBreak)
+	}
+
+	// A run over another window leaves breaks outside it alone, and a break found again reopens
+	service.reconcileEvents(nil, base.Add(-time.Hour), base.Add(-time.Minute), service.Config())
+	if tradeBreak, _ := service.GetBreak(breaks[0].ID); tradeBreak.Status != BreakStatusAutoResolved {
+		t.Errorf("Expected a run over another window to leave the break alone, got %s", tradeBreak.Status)
+	}
+	service.reconcileEvents([]*models.AuditEvent{fill}, base, base.Add(time.Minute), service.Config())
+	if tradeBreak, _ := service.GetBreak(breaks[0].ID); tradeBreak.Status != BreakStatusOpen || tradeBreak.ResolvedAt != nil {
+		t.Errorf("Expected the break reopened, got %+v", tradeBreak)
+	}
+}
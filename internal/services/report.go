@@ -0,0 +1,565 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// ReportFormat is the output format of a correlation report
+type ReportFormat string
+
+const (
+	ReportFormatMarkdown ReportFormat = "markdown"
+	ReportFormatHTML     ReportFormat = "html"
+	ReportFormatJSON     ReportFormat = "json"
+)
+
+// Report generation limits
+const (
+	maxReportEvents         = 10000
+	maxReportGroupsPerType  = 50
+	defaultReportTimelines  = 20
+	defaultReportTimeWindow = 1 * time.Hour
+)
+
+// ParseReportFormat converts a format name (or file extension) into a ReportFormat
+func ParseReportFormat(format string) (ReportFormat, error) {
+	switch format {
+	case "", "md", "markdown":
+		return ReportFormatMarkdown, nil
+	case "html", "htm":
+		return ReportFormatHTML, nil
+	case "json":
+		return ReportFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+// ReportRequest selects the events covered by a report
+// A zero StartTime uses the windows of the scenario's assertion results, or the default window
+type ReportRequest struct {
+	StartTime    time.Time
+	EndTime      time.Time
+	ScenarioID   string
+	MaxTimelines int
+}
+
+// ReportSummary holds the headline statistics of a report
+type ReportSummary struct {
+	TotalEvents      int            `json:"total_events"`
+	Truncated        bool           `json:"truncated"`
+	TraceCount       int            `json:"trace_count"`
+	ServiceCount     int            `json:"service_count"`
+	EventsByService  map[string]int `json:"events_by_service"`
+	EventsByType     map[string]int `json:"events_by_type"`
+	FirstEvent       *time.Time     `json:"first_event,omitempty"`
+	LastEvent        *time.Time     `json:"last_event,omitempty"`
+	CorrelationCount int            `json:"correlation_count"`
+	AssertionsPassed int            `json:"assertions_passed"`
+	AssertionsFailed int            `json:"assertions_failed"`
+}
+
+// CorrelationGroup is a set of events correlated by trace, service/type, business key or time proximity
+type CorrelationGroup struct {
+	Type     string   `json:"type"` // "trace", "service", "business_key", "temporal"
+	Key      string   `json:"key"`
+	Count    int      `json:"count"`
+	EventIDs []string `json:"event_ids"`
+}
+
+// TraceTimeline is the normalized timeline of one trace
+type TraceTimeline struct {
+	TraceID    string          `json:"trace_id"`
+	Services   []string        `json:"services"`
+	DurationMs float64         `json:"duration_ms"`
+	Entries    []TimelineEntry `json:"entries"`
+}
+
+// maxReportStatusChanges bounds the topology status changes listed in a report
+const maxReportStatusChanges = 500
+
+// NodeHealth is the status of a topology node at the window end and the worst it had in the window
+type NodeHealth struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	ServiceType   string    `json:"service_type"`
+	Status        string    `json:"status"`
+	WorstStatus   string    `json:"worst_status"`
+	StatusChanges int       `json:"status_changes"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+}
+
+// EdgeHealth is the status of a topology edge at the window end and the worst it had in the window
+type EdgeHealth struct {
+	ID            string `json:"id"`
+	SourceID      string `json:"source_id"`
+	TargetID      string `json:"target_id"`
+	Status        string `json:"status"`
+	WorstStatus   string `json:"worst_status"`
+	StatusChanges int    `json:"status_changes"`
+	Critical      bool   `json:"critical"`
+}
+
+// TopologyStatusChange is a node or edge status change recorded in the topology history
+type TopologyStatusChange struct {
+	At     time.Time `json:"at"`
+	Kind   string    `json:"kind"` // node or edge
+	ID     string    `json:"id"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// TopologyHealth summarizes the service topology for a report
+type TopologyHealth struct {
+	CapturedAt       time.Time              `json:"captured_at"` // The topology is as it was at this time, the report window end
+	SnapshotID       string                 `json:"snapshot_id"`
+	TotalNodes       int                    `json:"total_nodes"`
+	HealthyNodes     int                    `json:"healthy_nodes"`
+	TotalEdges       int                    `json:"total_edges"`
+	HealthyEdges     int                    `json:"healthy_edges"`
+	Nodes            []NodeHealth           `json:"nodes"`
+	Edges            []EdgeHealth           `json:"edges"`
+	Changes          []TopologyStatusChange `json:"changes"` // Status changes in the window, oldest first
+	ChangesTruncated bool                   `json:"changes_truncated,omitempty"`
+}
+
+// CorrelationReport is a self-contained report over a time window or scenario
+type CorrelationReport struct {
+	ID                string             `json:"id"`
+	Title             string             `json:"title"`
+	ScenarioID        string             `json:"scenario_id,omitempty"`
+	WindowStart       time.Time          `json:"window_start"`
+	WindowEnd         time.Time          `json:"window_end"`
+	GeneratedAt       time.Time          `json:"generated_at"`
+	Summary           ReportSummary      `json:"summary"`
+	CorrelationGroups []CorrelationGroup `json:"correlation_groups"`
+	Timelines         []TraceTimeline    `json:"timelines"`
+	AssertionResults  []*SuiteResult     `json:"assertion_results"`
+	Topology          *TopologyHealth    `json:"topology,omitempty"`
+}
+
+// ReportService builds correlation reports from audit events, assertion results and topology
+type ReportService struct {
+	auditService     *AuditService
+	assertionService *AssertionService
+	topologyService  *TopologyService
+	logger           *logrus.Logger
+}
+
+// NewReportService creates a new report service
+// assertionService and topologyService are optional; their sections are omitted when nil
+func NewReportService(auditService *AuditService, assertionService *AssertionService, topologyService *TopologyService, logger *logrus.Logger) *ReportService {
+	return &ReportService{
+		auditService:     auditService,
+		assertionService: assertionService,
+		topologyService:  topologyService,
+		logger:           logger,
+	}
+}
+
+// Generate builds a correlation report
+func (s *ReportService) Generate(req ReportRequest) (*CorrelationReport, error) {
+	generatedAt := time.Now()
+	start, end := s.resolveWindow(req, generatedAt)
+	if start.After(end) {
+		return nil, fmt.Errorf("report window start must not be after end")
+	}
+
+	// The scenario filter runs before the cap so a busy window cannot crowd out the scenario
+	events := []*models.AuditEvent{}
+	truncated := false
+	err := s.auditService.WalkEventsInWindow(context.Background(), WindowFilter{}, start, end, func(page []*models.AuditEvent) error {
+		if req.ScenarioID != "" {
+			page = filterScenarioEvents(page, req.ScenarioID)
+		}
+		for _, event := range page {
+			if len(events) >= maxReportEvents {
+				truncated = true
+				return errStopWalk
+			}
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events for report: %w", err)
+	}
+
+	title := fmt.Sprintf("Correlation Report %s – %s", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	if req.ScenarioID != "" {
+		title = fmt.Sprintf("Correlation Report: scenario %s", req.ScenarioID)
+	}
+
+	report := &CorrelationReport{
+		ID:                fmt.Sprintf("report-%d", generatedAt.UnixNano()),
+		Title:             title,
+		ScenarioID:        req.ScenarioID,
+		WindowStart:       start,
+		WindowEnd:         end,
+		GeneratedAt:       generatedAt,
+		CorrelationGroups: s.buildCorrelationGroups(events),
+		Timelines:         s.buildTimelines(events, req.MaxTimelines),
+		AssertionResults:  s.assertionResults(start, end, req.ScenarioID),
+		Topology:          s.topologyHealth(start, end),
+	}
+	report.Summary = s.buildSummary(events, report)
+	report.Summary.Truncated = truncated
+
+	s.logger.WithFields(logrus.Fields{
+		"report_id":   report.ID,
+		"scenario_id": req.ScenarioID,
+		"events":      report.Summary.TotalEvents,
+		"groups":      len(report.CorrelationGroups),
+	}).Info("Correlation report generated")
+
+	return report, nil
+}
+
+// resolveWindow determines the report window, deriving it from the scenario's assertion runs if needed
+func (s *ReportService) resolveWindow(req ReportRequest, now time.Time) (time.Time, time.Time) {
+	end := req.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	if !req.StartTime.IsZero() {
+		return req.StartTime, end
+	}
+
+	if req.ScenarioID != "" && s.assertionService != nil {
+		var start, scenarioEnd time.Time
+		for _, result := range s.assertionService.ResultsInWindow(time.Time{}, end) {
+			if result.ScenarioID != req.ScenarioID {
+				continue
+			}
+			if start.IsZero() || result.WindowStart.Before(start) {
+				start = result.WindowStart
+			}
+			if result.WindowEnd.After(scenarioEnd) {
+				scenarioEnd = result.WindowEnd
+			}
+		}
+		if !start.IsZero() {
+			if req.EndTime.IsZero() {
+				end = scenarioEnd
+			}
+			return start, end
+		}
+	}
+
+	return end.Add(-defaultReportTimeWindow), end
+}
+
+// filterScenarioEvents keeps events tagged with the scenario ID or carrying it in metadata
+func filterScenarioEvents(events []*models.AuditEvent, scenarioID string) []*models.AuditEvent {
+	var filtered []*models.AuditEvent
+	for _, event := range events {
+		if metadataString(event, metadataKeyScenarioID) == scenarioID || containsString(event.Tags, scenarioID) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+func (s *ReportService) buildSummary(events []*models.AuditEvent, report *CorrelationReport) ReportSummary {
+	summary := ReportSummary{
+		TotalEvents:      len(events),
+		EventsByService:  make(map[string]int),
+		EventsByType:     make(map[string]int),
+		CorrelationCount: len(report.CorrelationGroups),
+	}
+
+	offsets := s.auditService.ClockSkewEstimator().Offsets()
+	traces := make(map[string]bool)
+	for _, event := range events {
+		summary.EventsByService[event.ServiceName]++
+		summary.EventsByType[event.EventType]++
+		if event.TraceID != "" {
+			traces[event.TraceID] = true
+		}
+
+		ts := NormalizeTimestamp(event, offsets)
+		if summary.FirstEvent == nil || ts.Before(*summary.FirstEvent) {
+			first := ts
+			summary.FirstEvent = &first
+		}
+		if summary.LastEvent == nil || ts.After(*summary.LastEvent) {
+			last := ts
+			summary.LastEvent = &last
+		}
+	}
+	summary.TraceCount = len(traces)
+	summary.ServiceCount = len(summary.EventsByService)
+
+	for _, result := range report.AssertionResults {
+		summary.AssertionsPassed += result.PassedCount
+		summary.AssertionsFailed += result.FailedCount
+	}
+	return summary
+}
+
+func (s *ReportService) buildCorrelationGroups(events []*models.AuditEvent) []CorrelationGroup {
+	groups := []CorrelationGroup{}
+	groups = append(groups, topGroups("trace", s.auditService.correlateByTraceID(events))...)
+	groups = append(groups, topGroups("service", s.auditService.correlateByServiceAndType(events))...)
+	groups = append(groups, topGroups("business_key", s.auditService.correlateByBusinessKey(events))...)
+
+	temporal := make(map[string][]string)
+	for i, group := range s.auditService.correlateByTemporalProximity(events, 5*time.Second, s.auditService.ClockSkewEstimator().Offsets()) {
+		temporal[fmt.Sprintf("temporal-group-%d", i)] = group
+	}
+	groups = append(groups, topGroups("temporal", temporal)...)
+
+	return groups
+}
+
+// topGroups returns the largest multi-event groups of one type, largest first
+func topGroups(groupType string, correlations map[string][]string) []CorrelationGroup {
+	var groups []CorrelationGroup
+	for key, eventIDs := range correlations {
+		if len(eventIDs) > 1 {
+			groups = append(groups, CorrelationGroup{Type: groupType, Key: key, Count: len(eventIDs), EventIDs: eventIDs})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	if len(groups) > maxReportGroupsPerType {
+		groups = groups[:maxReportGroupsPerType]
+	}
+	return groups
+}
+
+// buildTimelines returns timelines for the traces with the most events
+func (s *ReportService) buildTimelines(events []*models.AuditEvent, maxTimelines int) []TraceTimeline {
+	if maxTimelines <= 0 {
+		maxTimelines = defaultReportTimelines
+	}
+
+	byTrace := make(map[string][]*models.AuditEvent)
+	for _, event := range events {
+		if event.TraceID != "" {
+			byTrace[event.TraceID] = append(byTrace[event.TraceID], event)
+		}
+	}
+
+	traceIDs := make([]string, 0, len(byTrace))
+	for traceID := range byTrace {
+		traceIDs = append(traceIDs, traceID)
+	}
+	sort.Slice(traceIDs, func(i, j int) bool {
+		if len(byTrace[traceIDs[i]]) != len(byTrace[traceIDs[j]]) {
+			return len(byTrace[traceIDs[i]]) > len(byTrace[traceIDs[j]])
+		}
+		return traceIDs[i] < traceIDs[j]
+	})
+	if len(traceIDs) > maxTimelines {
+		traceIDs = traceIDs[:maxTimelines]
+	}
+
+	timelines := make([]TraceTimeline, 0, len(traceIDs))
+	for _, traceID := range traceIDs {
+		entries := s.auditService.BuildTimeline(byTrace[traceID])
+
+		var services []string
+		seen := make(map[string]bool)
+		for _, entry := range entries {
+			if !seen[entry.ServiceName] {
+				seen[entry.ServiceName] = true
+				services = append(services, entry.ServiceName)
+			}
+		}
+
+		duration := entries[len(entries)-1].NormalizedTimestamp.Sub(entries[0].NormalizedTimestamp)
+		timelines = append(timelines, TraceTimeline{
+			TraceID:    traceID,
+			Services:   services,
+			DurationMs: float64(duration) / float64(time.Millisecond),
+			Entries:    entries,
+		})
+	}
+	return timelines
+}
+
+func (s *ReportService) assertionResults(start, end time.Time, scenarioID string) []*SuiteResult {
+	results := []*SuiteResult{}
+	if s.assertionService == nil {
+		return results
+	}
+
+	for _, result := range s.assertionService.ResultsInWindow(start, end) {
+		if scenarioID == "" || result.ScenarioID == scenarioID {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// topologyHealth captures the topology as it was at the window end from the topology history,
+// with the worst status each node and edge had and the status changes recorded in the window;
+// nil when no topology service is configured or no topology was recorded by the window end
+func (s *ReportService) topologyHealth(start, end time.Time) *TopologyHealth {
+	if s.topologyService == nil {
+		return nil
+	}
+	ctx := context.Background()
+	tracker := s.topologyService.Tracker()
+
+	snapshot, err := tracker.GetTopologyAsOf(ctx, end)
+	if err != nil {
+		s.logger.WithError(err).WithField("as_of", end).Warn("Failed to get topology snapshot for report")
+		return nil
+	}
+
+	health := &TopologyHealth{
+		CapturedAt:   end,
+		SnapshotID:   snapshot.SnapshotID,
+		TotalNodes:   len(snapshot.Nodes),
+		HealthyNodes: snapshot.CountHealthyNodes(),
+		TotalEdges:   len(snapshot.Connections),
+		HealthyEdges: snapshot.CountHealthyConnections(),
+		Nodes:        []NodeHealth{},
+		Edges:        []EdgeHealth{},
+		Changes:      []TopologyStatusChange{},
+	}
+
+	// Worst statuses start from the topology at the window start; a window starting before the
+	// retained history starts from the status before each recorded change instead
+	worstNodes := make(map[string]entities.NodeStatus)
+	worstEdges := make(map[string]entities.EdgeStatus)
+	nodeChanges := make(map[string]int)
+	edgeChanges := make(map[string]int)
+	if initial, err := tracker.GetTopologyAsOf(ctx, start); err == nil {
+		for id, node := range initial.Nodes {
+			worstNodes[id] = node.Status
+		}
+		for id, conn := range initial.Connections {
+			worstEdges[id] = conn.Status
+		}
+	}
+	changes, err := tracker.GetTopologyChanges(ctx, start, end)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to get topology changes for report")
+	}
+	for _, change := range changes {
+		switch change.ChangeType {
+		case ports.TopologyChangeTypeNodeAdded, ports.TopologyChangeTypeNodeUpdated:
+			id := change.Node.ID
+			worstNodes[id] = worseNodeStatus(worstNodes[id], change.Node.Status)
+			if change.ChangeType != ports.TopologyChangeTypeNodeUpdated || change.PreviousNodeStatus == change.Node.Status {
+				continue
+			}
+			worstNodes[id] = worseNodeStatus(worstNodes[id], change.PreviousNodeStatus)
+			nodeChanges[id]++
+			health.addChange(TopologyStatusChange{
+				At:     change.Timestamp,
+				Kind:   "node",
+				ID:     id,
+				From:   nodeStatusName(change.PreviousNodeStatus),
+				To:     nodeStatusName(change.Node.Status),
+				Reason: change.Reason,
+			})
+		case ports.TopologyChangeTypeEdgeAdded, ports.TopologyChangeTypeEdgeUpdated:
+			id := change.Connection.ID
+			worstEdges[id] = worseEdgeStatus(worstEdges[id], change.Connection.Status)
+			if change.ChangeType != ports.TopologyChangeTypeEdgeUpdated || change.PreviousEdgeStatus == change.Connection.Status {
+				continue
+			}
+			worstEdges[id] = worseEdgeStatus(worstEdges[id], change.PreviousEdgeStatus)
+			edgeChanges[id]++
+			health.addChange(TopologyStatusChange{
+				At:     change.Timestamp,
+				Kind:   "edge",
+				ID:     id,
+				From:   edgeStatusName(change.PreviousEdgeStatus),
+				To:     edgeStatusName(change.Connection.Status),
+				Reason: change.Reason,
+			})
+		}
+	}
+
+	for _, node := range snapshot.Nodes {
+		health.Nodes = append(health.Nodes, NodeHealth{
+			ID:            node.ID,
+			Name:          node.Name,
+			ServiceType:   node.ServiceType,
+			Status:        nodeStatusName(node.Status),
+			WorstStatus:   nodeStatusName(worseNodeStatus(worstNodes[node.ID], node.Status)),
+			StatusChanges: nodeChanges[node.ID],
+			LastSeenAt:    node.LastSeenAt,
+		})
+	}
+	sort.Slice(health.Nodes, func(i, j int) bool { return health.Nodes[i].ID < health.Nodes[j].ID })
+
+	for _, conn := range snapshot.Connections {
+		health.Edges = append(health.Edges, EdgeHealth{
+			ID:            conn.ID,
+			SourceID:      conn.SourceID,
+			TargetID:      conn.TargetID,
+			Status:        edgeStatusName(conn.Status),
+			WorstStatus:   edgeStatusName(worseEdgeStatus(worstEdges[conn.ID], conn.Status)),
+			StatusChanges: edgeChanges[conn.ID],
+			Critical:      conn.IsCritical,
+		})
+	}
+	sort.Slice(health.Edges, func(i, j int) bool { return health.Edges[i].ID < health.Edges[j].ID })
+
+	return health
+}
+
+// addChange lists a status change unless the list is full
+func (h *TopologyHealth) addChange(change TopologyStatusChange) {
+	if len(h.Changes) >= maxReportStatusChanges {
+		h.ChangesTruncated = true
+		return
+	}
+	h.Changes = append(h.Changes, change)
+}
+
+// worseNodeStatus orders statuses live, degraded, dead; unspecified is never worse
+func worseNodeStatus(a, b entities.NodeStatus) entities.NodeStatus {
+	return max(a, b)
+}
+
+// worseEdgeStatus orders statuses active, degraded, failed; unspecified is never worse
+func worseEdgeStatus(a, b entities.EdgeStatus) entities.EdgeStatus {
+	return max(a, b)
+}
+
+func nodeStatusName(status entities.NodeStatus) string {
+	switch status {
+	case entities.NodeStatusLive:
+		return "live"
+	case entities.NodeStatusDegraded:
+		return "degraded"
+	case entities.NodeStatusDead:
+		return "dead"
+	default:
+		return "unspecified"
+	}
+}
+
+func edgeStatusName(status entities.EdgeStatus) string {
+	switch status {
+	case entities.EdgeStatusActive:
+		return "active"
+	case entities.EdgeStatusDegraded:
+		return "degraded"
+	case entities.EdgeStatusFailed:
+		return "failed"
+	default:
+		return "unspecified"
+	}
+}
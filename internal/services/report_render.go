@@ -0,0 +1,269 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RenderReport renders a report in the requested format
+// It returns the document, its content type and the file extension to use for downloads
+func RenderReport(report *CorrelationReport, format ReportFormat) ([]byte, string, string, error) {
+	switch format {
+	case ReportFormatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to render JSON report: %w", err)
+		}
+		return data, "application/json", "json", nil
+	case ReportFormatHTML:
+		var buf bytes.Buffer
+		if err := reportHTMLTemplate.Execute(&buf, report); err != nil {
+			return nil, "", "", fmt.Errorf("failed to render HTML report: %w", err)
+		}
+		return buf.Bytes(), "text/html; charset=utf-8", "html", nil
+	case ReportFormatMarkdown:
+		return []byte(renderMarkdownReport(report)), "text/markdown; charset=utf-8", "md", nil
+	default:
+		return nil, "", "", fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+func renderMarkdownReport(report *CorrelationReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", report.Title)
+	fmt.Fprintf(&b, "- **Report ID:** %s\n", report.ID)
+	if report.ScenarioID != "" {
+		fmt.Fprintf(&b, "- **Scenario:** %s\n", report.ScenarioID)
+	}
+	fmt.Fprintf(&b, "- **Window:** %s – %s\n", formatReportTime(report.WindowStart), formatReportTime(report.WindowEnd))
+	fmt.Fprintf(&b, "- **Generated:** %s\n\n", formatReportTime(report.GeneratedAt))
+
+	summary := report.Summary
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Events | %d%s |\n", summary.TotalEvents, truncatedNote(summary.Truncated))
+	fmt.Fprintf(&b, "| Traces | %d |\n", summary.TraceCount)
+	fmt.Fprintf(&b, "| Services | %d |\n", summary.ServiceCount)
+	fmt.Fprintf(&b, "| Correlation groups | %d |\n", summary.CorrelationCount)
+	fmt.Fprintf(&b, "| Assertions passed | %d |\n", summary.AssertionsPassed)
+	fmt.Fprintf(&b, "| Assertions failed | %d |\n\n", summary.AssertionsFailed)
+
+	if len(summary.EventsByService) > 0 {
+		b.WriteString("### Events by service\n\n| Service | Events |\n|---|---|\n")
+		for _, key := range sortedCountKeys(summary.EventsByService) {
+			fmt.Fprintf(&b, "| %s | %d |\n", escapeMarkdown(key), summary.EventsByService[key])
+		}
+		b.WriteString("\n### Events by type\n\n| Event type | Events |\n|---|---|\n")
+		for _, key := range sortedCountKeys(summary.EventsByType) {
+			fmt.Fprintf(&b, "| %s | %d |\n", escapeMarkdown(key), summary.EventsByType[key])
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Correlation Groups\n\n")
+	if len(report.CorrelationGroups) == 0 {
+		b.WriteString("_No correlation groups._\n\n")
+	} else {
+		b.WriteString("| Type | Key | Events |\n|---|---|---|\n")
+		for _, group := range report.CorrelationGroups {
+			fmt.Fprintf(&b, "| %s | %s | %d |\n", group.Type, escapeMarkdown(group.Key), group.Count)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Timelines\n\n")
+	if len(report.Timelines) == 0 {
+		b.WriteString("_No traces in window._\n\n")
+	}
+	for _, timeline := range report.Timelines {
+		fmt.Fprintf(&b, "### Trace %s\n\n", escapeMarkdown(timeline.TraceID))
+		fmt.Fprintf(&b, "Services: %s · Duration: %.1f ms\n\n", escapeMarkdown(strings.Join(timeline.Services, ", ")), timeline.DurationMs)
+		b.WriteString("| Normalized time | Service | Event type | Span | Clock offset (ms) |\n|---|---|---|---|---|\n")
+		for _, entry := range timeline.Entries {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %.1f |\n",
+				formatReportTime(entry.NormalizedTimestamp), escapeMarkdown(entry.ServiceName),
+				escapeMarkdown(entry.EventType), escapeMarkdown(entry.SpanID), entry.ClockOffsetMs)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Assertion Results\n\n")
+	if len(report.AssertionResults) == 0 {
+		b.WriteString("_No assertion suites evaluated in window._\n\n")
+	}
+	for _, suite := range report.AssertionResults {
+		fmt.Fprintf(&b, "### Suite %s (%s) — %s\n\n", escapeMarkdown(suite.SuiteID), suite.Source, passFail(suite.Passed))
+		b.WriteString("| Assertion | Result | Observed | Message |\n|---|---|---|---|\n")
+		for _, result := range suite.Results {
+			fmt.Fprintf(&b, "| %s | %s | %d | %s |\n", escapeMarkdown(result.AssertionID), passFail(result.Passed), result.Observed, escapeMarkdown(result.Message))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Topology Health\n\n")
+	if report.Topology == nil {
+		b.WriteString("_Topology not available._\n")
+		return b.String()
+	}
+	topology := report.Topology
+	fmt.Fprintf(&b, "As of %s (%s): %d/%d nodes healthy, %d/%d edges healthy.\n\n",
+		formatReportTime(topology.CapturedAt), topology.SnapshotID, topology.HealthyNodes, topology.TotalNodes, topology.HealthyEdges, topology.TotalEdges)
+	if len(topology.Nodes) > 0 {
+		b.WriteString("| Node | Type | Status | Worst in window | Changes |\n|---|---|---|---|---|\n")
+		for _, node := range topology.Nodes {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %d |\n", escapeMarkdown(node.Name), escapeMarkdown(node.ServiceType), node.Status, node.WorstStatus, node.StatusChanges)
+		}
+		b.WriteString("\n")
+	}
+	if len(topology.Edges) > 0 {
+		b.WriteString("| Edge | Source | Target | Status | Worst in window | Changes | Critical |\n|---|---|---|---|---|---|---|\n")
+		for _, edge := range topology.Edges {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %d | %t |\n", escapeMarkdown(edge.ID), escapeMarkdown(edge.SourceID), escapeMarkdown(edge.TargetID), edge.Status, edge.WorstStatus, edge.StatusChanges, edge.Critical)
+		}
+		b.WriteString("\n")
+	}
+	if len(topology.Changes) > 0 {
+		b.WriteString("### Status Changes\n\n| Time | Kind | ID | From | To |\n|---|---|---|---|---|\n")
+		for _, change := range topology.Changes {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", formatReportTime(change.At), change.Kind, escapeMarkdown(change.ID), change.From, change.To)
+		}
+		if topology.ChangesTruncated {
+			fmt.Fprintf(&b, "\n_Only the first %d status changes are listed._\n", maxReportStatusChanges)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func formatReportTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.000Z")
+}
+
+func passFail(passed bool) string {
+	if passed {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+func truncatedNote(truncated bool) string {
+	if truncated {
+		return " (truncated)"
+	}
+	return ""
+}
+
+// escapeMarkdown keeps user-supplied values from breaking table layout
+func escapeMarkdown(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+func sortedCountKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"time":      formatReportTime,
+	"passFail":  passFail,
+	"sortedKey": sortedCountKeys,
+	"join":      strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+table { border-collapse: collapse; margin-bottom: 1.5rem; }
+th, td { border: 1px solid #d0d7de; padding: 4px 10px; text-align: left; font-size: 0.9rem; }
+th { background: #f6f8fa; }
+.pass { color: #1a7f37; font-weight: bold; }
+.fail { color: #cf222e; font-weight: bold; }
+.muted { color: #656d76; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="muted">Report {{.ID}}{{if .ScenarioID}} · scenario {{.ScenarioID}}{{end}} · window {{time .WindowStart}} – {{time .WindowEnd}} · generated {{time .GeneratedAt}}</p>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Events</th><td>{{.Summary.TotalEvents}}{{if .Summary.Truncated}} (truncated){{end}}</td></tr>
+<tr><th>Traces</th><td>{{.Summary.TraceCount}}</td></tr>
+<tr><th>Services</th><td>{{.Summary.ServiceCount}}</td></tr>
+<tr><th>Correlation groups</th><td>{{.Summary.CorrelationCount}}</td></tr>
+<tr><th>Assertions passed</th><td>{{.Summary.AssertionsPassed}}</td></tr>
+<tr><th>Assertions failed</th><td>{{.Summary.AssertionsFailed}}</td></tr>
+</table>
+{{if .Summary.EventsByService}}
+<table>
+<tr><th>Service</th><th>Events</th></tr>
+{{range sortedKey .Summary.EventsByService}}<tr><td>{{.}}</td><td>{{index $.Summary.EventsByService .}}</td></tr>
+{{end}}</table>
+<table>
+<tr><th>Event type</th><th>Events</th></tr>
+{{range sortedKey .Summary.EventsByType}}<tr><td>{{.}}</td><td>{{index $.Summary.EventsByType .}}</td></tr>
+{{end}}</table>
+{{end}}
+
+<h2>Correlation Groups</h2>
+{{if .CorrelationGroups}}<table>
+<tr><th>Type</th><th>Key</th><th>Events</th></tr>
+{{range .CorrelationGroups}}<tr><td>{{.Type}}</td><td>{{.Key}}</td><td>{{.Count}}</td></tr>
+{{end}}</table>{{else}}<p class="muted">No correlation groups.</p>{{end}}
+
+<h2>Timelines</h2>
+{{range .Timelines}}<h3>Trace {{.TraceID}}</h3>
+<p class="muted">Services: {{join .Services ", "}} · Duration: {{printf "%.1f" .DurationMs}} ms</p>
+<table>
+<tr><th>Normalized time</th><th>Service</th><th>Event type</th><th>Span</th><th>Clock offset (ms)</th></tr>
+{{range .Entries}}<tr><td>{{time .NormalizedTimestamp}}</td><td>{{.ServiceName}}</td><td>{{.EventType}}</td><td>{{.SpanID}}</td><td>{{printf "%.1f" .ClockOffsetMs}}</td></tr>
+{{end}}</table>
+{{else}}<p class="muted">No traces in window.</p>{{end}}
+
+<h2>Assertion Results</h2>
+{{range .AssertionResults}}<h3>Suite {{.SuiteID}} ({{.Source}}) — <span class="{{if .Passed}}pass{{else}}fail{{end}}">{{passFail .Passed}}</span></h3>
+<table>
+<tr><th>Assertion</th><th>Result</th><th>Observed</th><th>Message</th></tr>
+{{range .Results}}<tr><td>{{.AssertionID}}</td><td class="{{if .Passed}}pass{{else}}fail{{end}}">{{passFail .Passed}}</td><td>{{.Observed}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{else}}<p class="muted">No assertion suites evaluated in window.</p>{{end}}
+
+<h2>Topology Health</h2>
+{{with .Topology}}<p>As of {{time .CapturedAt}} ({{.SnapshotID}}): {{.HealthyNodes}}/{{.TotalNodes}} nodes healthy, {{.HealthyEdges}}/{{.TotalEdges}} edges healthy.</p>
+{{if .Nodes}}<table>
+<tr><th>Node</th><th>Type</th><th>Status</th><th>Worst in window</th><th>Changes</th></tr>
+{{range .Nodes}}<tr><td>{{.Name}}</td><td>{{.ServiceType}}</td><td>{{.Status}}</td><td>{{.WorstStatus}}</td><td>{{.StatusChanges}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Edges}}<table>
+<tr><th>Edge</th><th>Source</th><th>Target</th><th>Status</th><th>Worst in window</th><th>Changes</th><th>Critical</th></tr>
+{{range .Edges}}<tr><td>{{.ID}}</td><td>{{.SourceID}}</td><td>{{.TargetID}}</td><td>{{.Status}}</td><td>{{.WorstStatus}}</td><td>{{.StatusChanges}}</td><td>{{.Critical}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Changes}}<h3>Status Changes</h3>
+<table>
+<tr><th>Time</th><th>Kind</th><th>ID</th><th>From</th><th>To</th></tr>
+{{range .Changes}}<tr><td>{{time .At}}</td><td>{{.Kind}}</td><td>{{.ID}}</td><td>{{.From}}</td><td>{{.To}}</td></tr>
+{{end}}</table>
+{{if .ChangesTruncated}}<p class="muted">Only the first listed status changes are shown.</p>{{end}}{{end}}
+{{else}}<p class="muted">Topology not available.</p>{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newTestReportService(t *testing.T) *ReportService {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	auditService := NewAuditService(logger)
+	topologyService := NewTopologyService(logger)
+
+	node := entities.NewServiceNode("trading-engine", "trading-engine", "trading-engine", "trading-engine")
+	node.UpdateStatus(entities.NodeStatusDegraded)
+	if _, err := topologyService.Tracker().RegisterNode(context.Background(), node); err != nil {
+		t.Fatalf("Failed to register node: %v", err)
+	}
+
+	return NewReportService(auditService, NewAssertionService(auditService, logger), topologyService, logger)
+}
+
+func TestReportService_BuildTimelinesAndGroups(t *testing.T) {
+	service := newTestReportService(t)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []*models.AuditEvent{
+		newTestEvent("e1", "t1", "s1", "trading-engine", base, ""),
+		newTestEvent("e2", "t1", "s2", "exchange-simulator", base.Add(200*time.Millisecond), `{"parent_span_id": "s1"}`),
+		newTestEvent("e3", "t2", "s3", "custodian", base.Add(time.Minute), ""),
+	}
+
+	timelines := service.buildTimelines(events, 1)
+	if len(timelines) != 1 || timelines[0].TraceID != "t1" {
+		t.Fatalf("Expected only the largest trace t1, got %+v", timelines)
+	}
+	if timelines[0].DurationMs != 200 || len(timelines[0].Services) != 2 {
+		t.Errorf("Expected 200ms across 2 services, got %vms across %v", timelines[0].DurationMs, timelines[0].Services)
+	}
+
+	groups := service.buildCorrelationGroups(events)
+	var traceGroups int
+	for _, group := range groups {
+		if group.Type == "trace" {
+			traceGroups++
+			if group.Key != "t1" || group.Count != 2 {
+				t.Errorf("Unexpected trace group %+v", group)
+			}
+		}
+	}
+	if traceGroups != 1 {
+		t.Errorf("Expected 1 trace group, got %d", traceGroups)
+	}
+}
+
+func TestReportService_GenerateAndRender(t *testing.T) {
+	service := newTestReportService(t)
+	now := time.Now()
+
+	suite := &AssertionSuite{
+		ID:         "smoke",
+		ScenarioID: "scenario-1",
+		Assertions: []Assertion{{ID: "no-errors", Type: AssertionTypeAbsent, Expect: EventMatcher{EventType: "ERROR"}}},
+	}
+	if _, err := service.assertionService.EvaluateStored(suite, now.Add(-10*time.Minute), now); err != nil {
+		t.Fatalf("Failed to evaluate suite: %v", err)
+	}
+
+	// The scenario window is derived from its assertion runs
+	report, err := service.Generate(ReportRequest{ScenarioID: "scenario-1"})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	if !report.WindowStart.Equal(now.Add(-10 * time.Minute)) {
+		t.Errorf("Expected window start from assertion run, got %v", report.WindowStart)
+	}
+	if len(report.AssertionResults) != 1 || report.Summary.AssertionsPassed != 1 {
+		t.Errorf("Expected 1 passing assertion suite in report, got %+v", report.Summary)
+	}
+	if report.Topology == nil || report.Topology.TotalNodes != 1 || report.Topology.Nodes[0].Status != "degraded" {
+		t.Errorf("Expected degraded trading-engine in topology health, got %+v", report.Topology)
+	}
+
+	for _, format := range []ReportFormat{ReportFormatMarkdown, ReportFormatHTML, ReportFormatJSON} {
+		data, contentType, extension, err := RenderReport(report, format)
+		if err != nil {
+			t.Fatalf("Failed to render %s: %v", format, err)
+		}
+		if contentType == "" || extension == "" {
+			t.Errorf("Expected content type and extension for %s", format)
+		}
+		if !strings.Contains(string(data), "no-errors") {
+			t.Errorf("Expected %s report to include assertion results", format)
+		}
+	}
+
+	data, _, _, _ := RenderReport(report, ReportFormatJSON)
+	var decoded CorrelationReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Errorf("Expected valid JSON report: %v", err)
+	}
+}
+
+func TestReportService_TopologyAsOfWindowEnd(t *testing.T) {
+	service := newTestReportService(t)
+	tracker := service.topologyService.Tracker()
+
+	time.Sleep(5 * time.Millisecond)
+	windowEnd := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	if _, err := tracker.UpdateNodeStatus(context.Background(), "trading-engine", entities.NodeStatusDead); err != nil {
+		t.Fatalf("Failed to update node status: %v", err)
+	}
+
+	// A past window shows the topology of its end, not the current one
+	report, err := service.Generate(ReportRequest{StartTime: windowEnd.Add(-time.Minute), EndTime: windowEnd})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	if report.Topology == nil || report.Topology.Nodes[0].Status != "degraded" || !report.Topology.CapturedAt.Equal(windowEnd) {
+		t.Errorf("Expected the degraded node as of the window end, got %+v", report.Topology)
+	}
+
+	report, err = service.Generate(ReportRequest{StartTime: windowEnd, EndTime: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	if report.Topology == nil || report.Topology.Nodes[0].Status != "dead" || report.Topology.SnapshotID != tracker.SnapshotID() {
+		t.Errorf("Expected the dead node in the current snapshot, got %+v", report.Topology)
+	}
+
+	// Before any topology was recorded there is nothing to show
+	report, err = service.Generate(ReportRequest{StartTime: windowEnd.Add(-2 * time.Hour), EndTime: windowEnd.Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	if report.Topology != nil {
+		t.Errorf("Expected no topology before any was recorded, got %+v", report.Topology)
+	}
+}
+
+func TestReportService_TopologyShowsStatusChangesInWindow(t *testing.T) {
+	service := newTestReportService(t)
+	tracker := service.topologyService.Tracker()
+
+	time.Sleep(5 * time.Millisecond)
+	windowStart := time.Now()
+	for _, status := range []entities.NodeStatus{entities.NodeStatusDead, entities.NodeStatusDegraded} {
+		time.Sleep(time.Millisecond)
+		if _, err := tracker.UpdateNodeStatus(context.Background(), "trading-engine", status); err != nil {
+			t.Fatalf("Failed to update node status: %v", err)
+		}
+	}
+
+	// The node recovered before the window end, but the outage in between is reported
+	report, err := service.Generate(ReportRequest{StartTime: windowStart, EndTime: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	node := report.Topology.Nodes[0]
+	if node.Status != "degraded" || node.WorstStatus != "dead" || node.StatusChanges != 2 {
+		t.Errorf("Expected a degraded node that was dead in the window, got %+v", node)
+	}
+	changes := report.Topology.Changes
+	if len(changes) != 2 || changes[0].From != "degraded" || changes[0].To != "dead" || changes[1].To != "degraded" {
+		t.Errorf("Expected both status changes oldest first, got %+v", changes)
+	}
+
+	// A window before the outage only shows the status it had then
+	report, err = service.Generate(ReportRequest{StartTime: windowStart.Add(-time.Minute), EndTime: windowStart})
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	if node := report.Topology.Nodes[0]; node.WorstStatus != "degraded" || node.StatusChanges != 0 {
+		t.Errorf("Expected no status changes before the outage, got %+v", node)
+	}
+}
@@ -0,0 +1,737 @@
+package services
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+const (
+	// maxSegmentEvents bounds the events buffered for, and written to, one segment
+	maxSegmentEvents = 50000
+	// defaultArchiveQueryWindow is searched when an archive query gives no time range
+	defaultArchiveQueryWindow = 30 * 24 * time.Hour
+	retentionStateFile        = "retention_state.json"
+)
+
+var policyNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// EventPurger is implemented by data adapters that can delete stored events
+// Without one, archived events also stay in hot storage and queries deduplicate them
+type EventPurger interface {
+	DeleteEvents(ctx context.Context, ids []string) (int64, error)
+}
+
+// RetentionPolicy moves events older than HotRetentionHours from the data adapter into
+// archive segments, which are deleted ArchiveRetentionHours later (0 keeps them forever)
+// Empty ServiceName or EventType match any; the most specific matching policy applies
+type RetentionPolicy struct {
+	Name                  string `json:"name"`
+	ServiceName           string `json:"service_name,omitempty"`
+	EventType             string `json:"event_type,omitempty"`
+	HotRetentionHours     int64  `json:"hot_retention_hours"`
+	ArchiveRetentionHours int64  `json:"archive_retention_hours,omitempty"`
+}
+
+// Validate checks the policy
+func (p *RetentionPolicy) Validate() error {
+	if !policyNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("policy name must be lowercase letters, digits, '-' or '_': %q", p.Name)
+	}
+	if p.HotRetentionHours <= 0 {
+		return fmt.Errorf("policy %s: hot_retention_hours must be positive", p.Name)
+	}
+	if p.ArchiveRetentionHours < 0 {
+		return fmt.Errorf("policy %s: archive_retention_hours must not be negative", p.Name)
+	}
+	return nil
+}
+
+// Applies reports whether the policy covers the event's service and type
+func (p *RetentionPolicy) Applies(event *models.AuditEvent) bool {
+	return (p.ServiceName == "" || p.ServiceName == event.ServiceName) &&
+		(p.EventType == "" || p.EventType == event.EventType)
+}
+
+func (p *RetentionPolicy) specificity() int {
+	score := 0
+	if p.ServiceName != "" {
+		score += 2
+	}
+	if p.EventType != "" {
+		score++
+	}
+	return score
+}
+
+func (p *RetentionPolicy) hotRetention() time.Duration {
+	return time.Duration(p.HotRetentionHours) * time.Hour
+}
+
+// LegalHold exempts events matching Filter from deletion, in hot storage and in the archive
+type LegalHold struct {
+	ID        string    `json:"id"`
+	Filter    string    `json:"filter"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+
+	filter *EventFilter
+}
+
+// SweepResult summarises one retention sweep
+type SweepResult struct {
+	StartedAt        time.Time `json:"started_at"`
+	DurationMs       float64   `json:"duration_ms"`
+	Archived         int       `json:"archived"`
+	Purged           int64     `json:"purged"`
+	Held             int       `json:"held"`
+	SegmentsCreated  []string  `json:"segments_created"`
+	SegmentsExpired  []string  `json:"segments_expired"`
+	SegmentsOnHold   []string  `json:"segments_on_hold"`
+	PurgeUnsupported bool      `json:"purge_unsupported,omitempty"`
+}
+
+// ArchiveQueryRequest queries live and archived events together
+type ArchiveQueryRequest struct {
+	Filter    string     `json:"filter"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Limit     int        `json:"limit"`
+}
+
+// ArchiveQueryResult holds the most recent matching events, newest first
+// LiveMatches and ArchivedMatches count the returned events by where they were read from
+type ArchiveQueryResult struct {
+	Events          []*models.AuditEvent `json:"events"`
+	Count           int                  `json:"count"`
+	LiveMatches     int                  `json:"live_matches"`
+	ArchivedMatches int                  `json:"archived_matches"`
+	SegmentsRead    int                  `json:"segments_read"`
+	WindowStart     time.Time            `json:"window_start"`
+	WindowEnd       time.Time            `json:"window_end"`
+}
+
+// retentionState is persisted in the archive directory across restarts
+type retentionState struct {
+	Watermarks map[string]time.Time `json:"watermarks"`
+	Holds      []*LegalHold         `json:"legal_holds"`
+	NextHoldID int                  `json:"next_hold_id"`
+}
+
+// RetentionService archives aged events into compressed segment files and expires them
+// Each policy archives up to a watermark, so events stored with timestamps older than a
+// policy's watermark are left in hot storage
+type RetentionService struct {
+	auditService *AuditService
+	purger       EventPurger
+	dir          string
+	logger       *logrus.Logger
+
+	sweepMu    sync.Mutex
+	mu         sync.RWMutex
+	policies   []*RetentionPolicy
+	holds      map[string]*LegalHold
+	nextHoldID int
+	watermarks map[string]time.Time
+	segments   []*SegmentManifest
+}
+
+// NewRetentionService creates a retention service archiving into dir; purger may be nil
+func NewRetentionService(auditService *AuditService, purger EventPurger, dir string, logger *logrus.Logger) *RetentionService {
+	return &RetentionService{
+		auditService: auditService,
+		purger:       purger,
+		dir:          dir,
+		logger:       logger,
+		holds:        make(map[string]*LegalHold),
+		watermarks:   make(map[string]time.Time),
+	}
+}
+
+// Open creates the archive directory and loads legal holds, watermarks and segment manifests
+func (s *RetentionService) Open() error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	segments, err := loadSegmentManifests(s.dir)
+	if err != nil {
+		return err
+	}
+
+	var state retentionState
+	data, err := os.ReadFile(filepath.Join(s.dir, retentionStateFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read retention state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &state); err != nil {
+			return fmt.Errorf("failed to parse retention state: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.segments = segments
+	s.nextHoldID = state.NextHoldID
+	for name, watermark := range state.Watermarks {
+		s.watermarks[name] = watermark
+	}
+	for _, hold := range state.Holds {
+		filter, err := CompileEventFilter(hold.Filter)
+		if err != nil {
+			return fmt.Errorf("legal hold %s: %w", hold.ID, err)
+		}
+		hold.filter = filter
+		s.holds[hold.ID] = hold
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"dir":         s.dir,
+		"segments":    len(segments),
+		"legal_holds": len(s.holds),
+	}).Info("Opened event archive")
+	return nil
+}
+
+// LoadPoliciesFromFile sets policies from a JSON file ({"policies": [...]})
+// A missing file is not an error
+func (s *RetentionService) LoadPoliciesFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.logger.WithField("path", path).Debug("No retention policy config found")
+			return nil
+		}
+		return fmt.Errorf("failed to read retention policy config: %w", err)
+	}
+
+	var config struct {
+		Policies []*RetentionPolicy `json:"policies"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse retention policy config: %w", err)
+	}
+	if err := s.SetPolicies(config.Policies); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"path":     path,
+		"policies": len(config.Policies),
+	}).Info("Loaded retention policies")
+	return nil
+}
+
+// SetPolicies validates and replaces all retention policies
+func (s *RetentionService) SetPolicies(policies []*RetentionPolicy) error {
+	seen := make(map[string]bool)
+	for _, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+		if seen[policy.Name] {
+			return fmt.Errorf("duplicate retention policy: %s", policy.Name)
+		}
+		seen[policy.Name] = true
+	}
+
+	ordered := append([]*RetentionPolicy(nil), policies...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].specificity() > ordered[j].specificity()
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policies = ordered
+	return nil
+}
+
+// ListPolicies returns the policies in the order they are matched
+func (s *RetentionService) ListPolicies() []RetentionPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	policies := make([]RetentionPolicy, 0, len(s.policies))
+	for _, policy := range s.policies {
+		policies = append(policies, *policy)
+	}
+	return policies
+}
+
+// policyForLocked returns the most specific policy covering the event, or nil
+func (s *RetentionService) policyForLocked(event *models.AuditEvent) *RetentionPolicy {
+	for _, policy := range s.policies {
+		if policy.Applies(event) {
+			return policy
+		}
+	}
+	return nil
+}
+
+// AddLegalHold exempts events matching a filter expression from deletion
+func (s *RetentionService) AddLegalHold(filterExpression, reason string) (*LegalHold, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: legal hold reason is required", ErrInvalidSearch)
+	}
+	filter, err := CompileEventFilter(filterExpression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+
+	s.mu.Lock()
+	s.nextHoldID++
+	hold := &LegalHold{
+		ID:        fmt.Sprintf("hold-%d", s.nextHoldID),
+		Filter:    filter.String(),
+		Reason:    reason,
+		CreatedAt: time.Now().UTC(),
+		filter:    filter,
+	}
+	s.holds[hold.ID] = hold
+	err = s.saveStateLocked()
+	if err != nil {
+		delete(s.holds, hold.ID)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"hold_id": hold.ID,
+		"filter":  hold.Filter,
+		"reason":  reason,
+	}).Info("Legal hold placed")
+	return hold, nil
+}
+
+// ReleaseLegalHold removes a legal hold
+func (s *RetentionService) ReleaseLegalHold(id string) error {
+	s.mu.Lock()
+	hold, exists := s.holds[id]
+	if !exists {
+		s.mu.Unlock()
+		return fmt.Errorf("legal hold not found: %s", id)
+	}
+	delete(s.holds, id)
+	err := s.saveStateLocked()
+	if err != nil {
+		s.holds[id] = hold
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	s.logger.WithField("hold_id", id).Info("Legal hold released")
+	return nil
+}
+
+// ListLegalHolds returns active legal holds, oldest first
+func (s *RetentionService) ListLegalHolds() []LegalHold {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	holds := make([]LegalHold, 0, len(s.holds))
+	for _, hold := range s.holds {
+		holds = append(holds, *hold)
+	}
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].CreatedAt.Before(holds[j].CreatedAt)
+	})
+	return holds
+}
+
+// heldLocked reports whether any legal hold covers the event
+func (s *RetentionService) heldLocked(event *models.AuditEvent) bool {
+	for _, hold := range s.holds {
+		if hold.filter.Matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSegments returns archive segment manifests, oldest first
+func (s *RetentionService) ListSegments() []SegmentManifest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	segments := make([]SegmentManifest, 0, len(s.segments))
+	for _, segment := range s.segments {
+		segments = append(segments, *segment)
+	}
+	return segments
+}
+
+// Run sweeps on every interval until ctx is done
+func (s *RetentionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Sweep(ctx); err != nil {
+				s.logger.WithError(err).Warn("Retention sweep failed")
+			}
+		}
+	}
+}
+
+// Sweep archives events past their hot retention, purges them from hot storage unless held,
+// and deletes expired archive segments that hold no events under legal hold
+func (s *RetentionService) Sweep(ctx context.Context) (*SweepResult, error) {
+	s.sweepMu.Lock()
+	defer s.sweepMu.Unlock()
+
+	now := time.Now()
+	result := &SweepResult{
+		StartedAt:        now.UTC(),
+		SegmentsCreated:  []string{},
+		SegmentsExpired:  []string{},
+		SegmentsOnHold:   []string{},
+		PurgeUnsupported: s.purger == nil,
+	}
+
+	for _, policy := range s.ListPolicies() {
+		if err := s.archivePolicy(ctx, policy, now, result); err != nil {
+			return result, fmt.Errorf("policy %s: %w", policy.Name, err)
+		}
+	}
+	if err := s.expireSegments(now, result); err != nil {
+		return result, err
+	}
+	result.DurationMs = float64(time.Since(now)) / float64(time.Millisecond)
+
+	s.logger.WithFields(logrus.Fields{
+		"archived":         result.Archived,
+		"purged":           result.Purged,
+		"held":             result.Held,
+		"segments_created": len(result.SegmentsCreated),
+		"segments_expired": len(result.SegmentsExpired),
+	}).Info("Retention sweep completed")
+	return result, nil
+}
+
+// archivePolicy archives the policy's events between its watermark and its hot retention cutoff
+func (s *RetentionService) archivePolicy(ctx context.Context, policy RetentionPolicy, now time.Time, result *SweepResult) error {
+	cutoff := now.Add(-policy.hotRetention())
+
+	s.mu.RLock()
+	from, exists := s.watermarks[policy.Name]
+	s.mu.RUnlock()
+	if !exists {
+		from = time.Unix(0, 0)
+	}
+	if !from.Before(cutoff) {
+		return nil
+	}
+
+	var batch []*models.AuditEvent
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.archiveBatch(ctx, policy, batch, len(result.SegmentsCreated), result); err != nil {
+			return err
+		}
+		batch = nil
+		return nil
+	}
+
+	filter := WindowFilter{ServiceName: policy.ServiceName, EventType: policy.EventType}
+	// The window end is exclusive so the next sweep can start exactly at this cutoff
+	err := s.auditService.WalkEventsInWindow(ctx, filter, from, cutoff.Add(-time.Nanosecond), func(page []*models.AuditEvent) error {
+		s.mu.RLock()
+		for _, event := range page {
+			if owner := s.policyForLocked(event); owner != nil && owner.Name == policy.Name {
+				batch = append(batch, event)
+			}
+		}
+		s.mu.RUnlock()
+		if len(batch) >= maxSegmentEvents {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watermarks[policy.Name] = cutoff
+	return s.saveStateLocked()
+}
+
+// archiveBatch writes one segment and purges its events from hot storage, keeping held events
+func (s *RetentionService) archiveBatch(ctx context.Context, policy RetentionPolicy, events []*models.AuditEvent, seq int, result *SweepResult) error {
+	var expiresAt *time.Time
+	if policy.ArchiveRetentionHours > 0 {
+		expiry := events[len(events)-1].Timestamp.Add(policy.hotRetention() + time.Duration(policy.ArchiveRetentionHours)*time.Hour)
+		expiresAt = &expiry
+	}
+
+	manifest, err := writeSegment(s.dir, segmentID(policy.Name, events[0].Timestamp, seq), policy.Name, events, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.segments = append(s.segments, manifest)
+	purgeable := make([]string, 0, len(events))
+	for _, event := range events {
+		if s.heldLocked(event) {
+			result.Held++
+			continue
+		}
+		purgeable = append(purgeable, event.ID)
+	}
+	s.mu.Unlock()
+
+	result.Archived += len(events)
+	result.SegmentsCreated = append(result.SegmentsCreated, manifest.ID)
+
+	if s.purger != nil && len(purgeable) > 0 {
+		purged, err := s.purger.DeleteEvents(ctx, purgeable)
+		result.Purged += purged
+		if err != nil {
+			return fmt.Errorf("failed to purge archived events: %w", err)
+		}
+	}
+	return nil
+}
+
+// expireSegments deletes segments past their expiry unless they hold events under legal hold
+func (s *RetentionService) expireSegments(now time.Time, result *SweepResult) error {
+	s.mu.RLock()
+	var expired []*SegmentManifest
+	for _, segment := range s.segments {
+		if segment.ExpiresAt != nil && segment.ExpiresAt.Before(now) {
+			expired = append(expired, segment)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, segment := range expired {
+		held := false
+		err := readSegment(s.dir, segment, segment.StartTime, segment.EndTime, func(event *models.AuditEvent) error {
+			s.mu.RLock()
+			held = s.heldLocked(event)
+			s.mu.RUnlock()
+			if held {
+				return errStopWalk
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopWalk) {
+			return err
+		}
+		if held {
+			result.SegmentsOnHold = append(result.SegmentsOnHold, segment.ID)
+			continue
+		}
+
+		if err := removeSegment(s.dir, segment); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		for i, candidate := range s.segments {
+			if candidate == segment {
+				s.segments = append(s.segments[:i], s.segments[i+1:]...)
+				break
+			}
+		}
+		s.mu.Unlock()
+		result.SegmentsExpired = append(result.SegmentsExpired, segment.ID)
+	}
+	return nil
+}
+
+// Query returns events matching the request from hot storage and the archive, deduplicated
+func (s *RetentionService) Query(ctx context.Context, req ArchiveQueryRequest) (*ArchiveQueryResult, error) {
+	filter, err := CompileEventFilter(req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+	limit := req.Limit
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidSearch)
+	}
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	start, end := filterWindow(filter, req.StartTime, req.EndTime, defaultArchiveQueryWindow)
+	if start.After(end) {
+		return nil, fmt.Errorf("%w: window start must not be after end", ErrInvalidSearch)
+	}
+
+	result := &ArchiveQueryResult{WindowStart: start, WindowEnd: end}
+	newest := newNewestEvents(limit)
+
+	// Hot storage is walked newest first, so it is done once the heap is full
+	pushdown, _, _ := filter.pushdown()
+	live := pushdown
+	live.Descending = true
+	err = s.auditService.WalkEventsInWindow(ctx, live, start, end, func(page []*models.AuditEvent) error {
+		for _, event := range page {
+			if newest.full() {
+				return errStopWalk
+			}
+			if filter.Matches(event) {
+				newest.offer(event, false)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Segments are read newest first, skipping blocks older than everything kept so far
+	segments := s.ListSegments()
+	sort.Slice(segments, func(i, j int) bool { return segments[i].EndTime.After(segments[j].EndTime) })
+	for i := range segments {
+		segment := &segments[i]
+		from := start
+		if oldest, ok := newest.oldest(); ok && oldest.After(from) {
+			from = oldest
+		}
+		if !segment.overlaps(from, end) || !segment.mayContain(pushdown) {
+			continue
+		}
+		result.SegmentsRead++
+		err := readSegment(s.dir, segment, from, end, func(event *models.AuditEvent) error {
+			if filter.Matches(event) {
+				newest.offer(event, true)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Events, result.LiveMatches, result.ArchivedMatches = newest.sorted()
+	result.Count = len(result.Events)
+	return result, nil
+}
+
+// newestEvents keeps the limit newest events offered, by (timestamp, id), in a min-heap
+// An event offered twice, live and archived, is kept once
+type newestEvents struct {
+	limit int
+	items []newestEvent
+	ids   map[string]bool
+}
+
+type newestEvent struct {
+	event    *models.AuditEvent
+	archived bool
+}
+
+func newNewestEvents(limit int) *newestEvents {
+	return &newestEvents{limit: limit, ids: make(map[string]bool, limit)}
+}
+
+func (h *newestEvents) Len() int { return len(h.items) }
+
+func (h *newestEvents) Less(i, j int) bool { return eventBefore(h.items[i].event, h.items[j].event) }
+
+func (h *newestEvents) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *newestEvents) Push(x any) { h.items = append(h.items, x.(newestEvent)) }
+
+func (h *newestEvents) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+func (h *newestEvents) full() bool {
+	return len(h.items) >= h.limit
+}
+
+// oldest returns the timestamp of the oldest event kept once the heap is full
+func (h *newestEvents) oldest() (time.Time, bool) {
+	if !h.full() {
+		return time.Time{}, false
+	}
+	return h.items[0].event.Timestamp, true
+}
+
+func (h *newestEvents) offer(event *models.AuditEvent, archived bool) {
+	if h.ids[event.ID] {
+		return
+	}
+	if h.full() {
+		if !eventBefore(h.items[0].event, event) {
+			return
+		}
+		delete(h.ids, heap.Pop(h).(newestEvent).event.ID)
+	}
+	h.ids[event.ID] = true
+	heap.Push(h, newestEvent{event: event, archived: archived})
+}
+
+// sorted returns the kept events newest first, with how many came from each source
+func (h *newestEvents) sorted() ([]*models.AuditEvent, int, int) {
+	events := make([]*models.AuditEvent, len(h.items))
+	live, archived := 0, 0
+	for i := len(h.items) - 1; i >= 0; i-- {
+		item := heap.Pop(h).(newestEvent)
+		events[i] = item.event
+		if item.archived {
+			archived++
+		} else {
+			live++
+		}
+	}
+	return events, live, archived
+}
+
+// eventBefore orders events by (timestamp, id)
+func eventBefore(a, b *models.AuditEvent) bool {
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.Before(b.Timestamp)
+	}
+	return a.ID < b.ID
+}
+
+// saveStateLocked persists watermarks and legal holds
+func (s *RetentionService) saveStateLocked() error {
+	state := retentionState{
+		Watermarks: s.watermarks,
+		Holds:      make([]*LegalHold, 0, len(s.holds)),
+		NextHoldID: s.nextHoldID,
+	}
+	for _, hold := range s.holds {
+		state.Holds = append(state.Holds, hold)
+	}
+	sort.Slice(state.Holds, func(i, j int) bool {
+		return state.Holds[i].CreatedAt.Before(state.Holds[j].CreatedAt)
+	})
+	return writeJSONFile(filepath.Join(s.dir, retentionStateFile), state)
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/adapters"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// memoryDataAdapter is an in-memory adapter that supports the queries and deletes retention uses
+type memoryDataAdapter struct {
+	adapters.DataAdapter
+
+	mu           sync.Mutex
+	events       map[string]*models.AuditEvent
+	correlations []*models.AuditCorrelation
+}
+
+func newMemoryDataAdapter(events ...*models.AuditEvent) *memoryDataAdapter {
+	adapter := &memoryDataAdapter{events: make(map[string]*models.AuditEvent)}
+	for _, event := range events {
+		adapter.events[event.ID] = event
+	}
+	return adapter
+}
+
+func (a *memoryDataAdapter) Query(_ context.Context, query models.AuditQuery) ([]*models.AuditEvent, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var events []*models.AuditEvent
+	for _, event := range a.events {
+		switch {
+		case query.StartTime != nil && event.Timestamp.Before(*query.StartTime),
+			query.EndTime != nil && event.Timestamp.After(*query.EndTime),
+			query.ServiceName != nil && event.ServiceName != *query.ServiceName,
+			query.EventType != nil && event.EventType != *query.EventType,
+			query.TraceID != nil && event.TraceID != *query.TraceID:
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Timestamp.Before(events[j].Timestamp) })
+	if query.SortOrder == "desc" {
+		slices.Reverse(events)
+	}
+	if query.Limit > 0 && len(events) > query.Limit {
+		events = events[:query.Limit]
+	}
+	return events, nil
+}
+
+func (a *memoryDataAdapter) DeleteEvents(_ context.Context, ids []string) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var deleted int64
+	for _, id := range ids {
+		if _, exists := a.events[id]; exists {
+			delete(a.events, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (a *memoryDataAdapter) CreateCorrelation(_ context.Context, correlation *models.AuditCorrelation) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.correlations = append(a.correlations, correlation)
+	return nil
+}
+
+func newRetentionEvent(id, service, eventType, traceID string, ts time.Time) *models.AuditEvent {
+	event := newTypedEvent(id, service, eventType, ts, `{}`)
+	event.TraceID = traceID
+	return event
+}
+
+func TestArchiveSegment_BlocksAndWindowedReads(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := make([]*models.AuditEvent, 2500)
+	for i := range events {
+		events[i] = newRetentionEvent(fmt.Sprintf("e%d", i), "exchange", "order_filled", "", base.Add(time.Duration(i)*time.Second))
+	}
+
+	manifest, err := writeSegment(dir, segmentID("default", base, 0), "default", events, nil)
+	if err != nil {
+		t.Fatalf("Failed to write segment: %v", err)
+	}
+	if manifest.EventCount != 2500 || len(manifest.Blocks) != 3 || manifest.Blocks[2].Count != 500 {
+		t.Fatalf("Unexpected manifest: %d events, %d blocks", manifest.EventCount, len(manifest.Blocks))
+	}
+	if manifest.Services[0] != "exchange" || manifest.SHA256 == "" {
+		t.Errorf("Unexpected manifest index: %+v", manifest)
+	}
+
+	var read []string
+	err = readSegment(dir, manifest, base.Add(1500*time.Second), base.Add(1502*time.Second), func(event *models.AuditEvent) error {
+		read = append(read, event.ID)
+		return nil
+	})
+	if err != nil || len(read) != 3 || read[0] != "e1500" {
+		t.Errorf("Unexpected windowed read: %v, %v", read, err)
+	}
+
+	loaded, err := loadSegmentManifests(dir)
+	if err != nil || len(loaded) != 1 || loaded[0].ID != manifest.ID {
+		t.Errorf("Expected the manifest to be reloaded, got %v, %v", loaded, err)
+	}
+}
+
+func TestRetentionPolicy_Validate(t *testing.T) {
+	for _, policy := range []RetentionPolicy{
+		{Name: "Bad Name", HotRetentionHours: 1},
+		{Name: "zero", HotRetentionHours: 0},
+		{Name: "negative", HotRetentionHours: 1, ArchiveRetentionHours: -1},
+	} {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("Expected policy %q to be rejected", policy.Name)
+		}
+	}
+}
+
+func TestRetentionService_SweepHoldAndQuery(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	now := time.Now()
+	adapter := newMemoryDataAdapter(
+		newRetentionEvent("old-1", "exchange", "order_filled", "t1", now.Add(-10*24*time.Hour)),
+		newRetentionEvent("old-held", "exchange", "order_filled", "t-held", now.Add(-10*24*time.Hour+time.Minute)),
+		newRetentionEvent("custodian-1", "custodian", "settlement", "t2", now.Add(-24*time.Hour)),
+		newRetentionEvent("recent", "exchange", "order_filled", "t3", now.Add(-time.Hour)),
+	)
+	auditService := NewAuditServiceWithDataAdapter(adapter, logger)
+	dir := t.TempDir()
+
+	service := NewRetentionService(auditService, adapter, dir, logger)
+	if err := service.Open(); err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	err := service.SetPolicies([]*RetentionPolicy{
+		{Name: "default", HotRetentionHours: 72},
+		{Name: "exchange", ServiceName: "exchange", HotRetentionHours: 24, ArchiveRetentionHours: 48},
+	})
+	if err != nil {
+		t.Fatalf("Failed to set policies: %v", err)
+	}
+	if policies := service.ListPolicies(); policies[0].Name != "exchange" {
+		t.Errorf("Expected the most specific policy first, got %s", policies[0].Name)
+	}
+	hold, err := service.AddLegalHold("trace_id = t-held", "litigation")
+	if err != nil {
+		t.Fatalf("Failed to add hold: %v", err)
+	}
+
+	result, err := service.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if result.Archived != 2 || result.Purged != 1 || result.Held != 1 {
+		t.Errorf("Unexpected sweep result: %+v", result)
+	}
+	// The exchange segment is already past its archive retention but holds a held event
+	if len(result.SegmentsOnHold) != 1 || len(result.SegmentsExpired) != 0 {
+		t.Errorf("Expected the segment to be kept for the hold: %+v", result)
+	}
+	if _, live := adapter.events["old-1"]; live {
+		t.Errorf("Expected old-1 to be purged from hot storage")
+	}
+	if _, live := adapter.events["old-held"]; !live {
+		t.Errorf("Expected the held event to stay in hot storage")
+	}
+
+	start := now.Add(-11 * 24 * time.Hour)
+	query, err := service.Query(context.Background(), ArchiveQueryRequest{Filter: "service = exchange", StartTime: &start})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if query.Count != 3 || query.LiveMatches != 2 || query.ArchivedMatches != 1 || query.Events[2].ID != "old-1" {
+		t.Errorf("Unexpected combined query: %+v", query)
+	}
+
+	// A reopened service keeps the hold, the segment and the watermarks
+	reopened := NewRetentionService(auditService, adapter, dir, logger)
+	if err := reopened.Open(); err != nil {
+		t.Fatalf("Failed to reopen archive: %v", err)
+	}
+	reopened.SetPolicies(service.policies)
+	if len(reopened.ListLegalHolds()) != 1 || len(reopened.ListSegments()) != 1 {
+		t.Fatalf("Expected state to survive a restart")
+	}
+
+	if err := reopened.ReleaseLegalHold(hold.ID); err != nil {
+		t.Fatalf("Failed to release hold: %v", err)
+	}
+	result, err = reopened.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if result.Archived != 0 || len(result.SegmentsExpired) != 1 || len(reopened.ListSegments()) != 0 {
+		t.Errorf("Expected only the expired segment to be removed: %+v", result)
+	}
+}
+
+func TestRetentionService_QueryMergesNewestFirst(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+
+	// Given hot events spanning more than the limit, an archived event newer than most of
+	// them, and one event both archived and still hot
+	var hot []*models.AuditEvent
+	for i := 0; i < eventPageSize+10; i++ {
+		hot = append(hot, newRetentionEvent(fmt.Sprintf("hot-%04d", i), "exchange", "order_filled", "", base.Add(time.Duration(i)*time.Second)))
+	}
+	archived := []*models.AuditEvent{
+		hot[0],
+		newRetentionEvent("archived-new", "exchange", "order_filled", "", base.Add(time.Duration(eventPageSize+5)*time.Second+time.Millisecond)),
+	}
+	if _, err := writeSegment(dir, segmentID("default", base, 0), "default", archived, nil); err != nil {
+		t.Fatalf("Failed to write segment: %v", err)
+	}
+	adapter := newMemoryDataAdapter(hot...)
+	service := NewRetentionService(NewAuditServiceWithDataAdapter(adapter, logger), adapter, dir, logger)
+	if err := service.Open(); err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+
+	// When the newest few are queried
+	start, end := base, base.Add(time.Hour)
+	result, err := service.Query(context.Background(), ArchiveQueryRequest{StartTime: &start, EndTime: &end, Limit: 6})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	// Then the archived event takes its place among the newest hot events
+	var ids []string
+	for _, event := range result.Events {
+		ids = append(ids, event.ID)
+	}
+	expected := "[hot-0509 hot-0508 hot-0507 hot-0506 archived-new hot-0505]"
+	if fmt.Sprint(ids) != expected {
+		t.Errorf("Expected %s, got %v", expected, ids)
+	}
+	if result.LiveMatches != 5 || result.ArchivedMatches != 1 {
+		t.Errorf("Expected 5 live and 1 archived match, got %d and %d", result.LiveMatches, result.ArchivedMatches)
+	}
+
+	// And the whole window returns each event once
+	result, err = service.Query(context.Background(), ArchiveQueryRequest{StartTime: &start, EndTime: &end, Limit: maxSearchLimit})
+	if err != nil || result.Count != len(hot)+1 || result.Events[len(result.Events)-1].ID != "hot-0000" {
+		t.Errorf("Expected %d distinct events oldest last, got %d (%v)", len(hot)+1, result.Count, err)
+	}
+}
@@ -0,0 +1,626 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// Scenario run statuses
+const (
+	ScenarioRunPending  = "pending"
+	ScenarioRunDetected = "detected"
+	ScenarioRunMissed   = "missed"
+)
+
+// defaultRiskMonitorService is the service whose alerts validate scenarios
+const defaultRiskMonitorService = "risk-monitor"
+
+// defaultDetectionTimeout applies to scenarios that do not configure one
+const defaultDetectionTimeout = 60 * time.Second
+
+// maxRunsPerScenario bounds the run history kept per scenario
+const maxRunsPerScenario = 500
+
+// runStartTolerance is how far apart the reported and observed starts of one run may be, e.g.
+// a run recorded by a test coordinator and its scenario_started event found by a scan
+const runStartTolerance = 2 * time.Second
+
+// maxTrackedAlerts bounds the alert history kept for effectiveness scoring
+const maxTrackedAlerts = 10000
+
+// Well-known risk metadata keys
+const (
+	metadataKeyAlertType = "alert_type"
+	metadataKeyRunID     = "run_id"
+)
+
+// scenarioStartEventTypes mark the injection of a scenario
+var scenarioStartEventTypes = map[string]bool{
+	"scenario_started":  true,
+	"scenario_injected": true,
+	"chaos_injected":    true,
+}
+
+// riskAlertEventTypes are the risk monitor event types treated as alerts
+var riskAlertEventTypes = map[string]bool{
+	"risk_alert":    true,
+	"alert_raised":  true,
+	"alert_created": true,
+}
+
+// RiskScenario is a catalogued risk scenario and the alert it should trigger
+type RiskScenario struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	Description       string   `json:"description,omitempty"`
+	Category          string   `json:"category,omitempty"`
+	Severity          string   `json:"severity,omitempty"`
+	ExpectedAlertType string   `json:"expected_alert_type"`
+	DetectionTimeout  string   `json:"detection_timeout,omitempty"` // Go duration, default 60s
+	Tags              []string `json:"tags,omitempty"`
+
+	timeout time.Duration
+}
+
+// Timeout returns the parsed detection timeout
+func (s *RiskScenario) Timeout() time.Duration {
+	if s.timeout > 0 {
+		return s.timeout
+	}
+	return defaultDetectionTimeout
+}
+
+// Validate checks the scenario definition and parses its timeout
+func (s *RiskScenario) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("scenario id is required")
+	}
+	if s.ExpectedAlertType == "" {
+		return fmt.Errorf("scenario %s: expected_alert_type is required", s.ID)
+	}
+	if s.DetectionTimeout != "" {
+		timeout, err := time.ParseDuration(s.DetectionTimeout)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("scenario %s: detection_timeout must be a positive duration", s.ID)
+		}
+		s.timeout = timeout
+	}
+	return nil
+}
+
+// RiskScenarioCatalogue is the JSON layout of the scenario catalogue file
+type RiskScenarioCatalogue struct {
+	Scenarios []*RiskScenario `json:"scenarios"`
+}
+
+// ScenarioRun is one execution of a scenario and its detection outcome
+type ScenarioRun struct {
+	ID                 string     `json:"id"`
+	ScenarioID         string     `json:"scenario_id"`
+	StartedAt          time.Time  `json:"started_at"`
+	Status             string     `json:"status"`
+	AlertEventID       string     `json:"alert_event_id,omitempty"`
+	AlertType          string     `json:"alert_type,omitempty"`
+	DetectedAt         *time.Time `json:"detected_at,omitempty"`
+	DetectionLatencyMs float64    `json:"detection_latency_ms,omitempty"`
+}
+
+// RiskAlert is an alert raised by the risk monitor and the run it was matched to (if any)
+type RiskAlert struct {
+	EventID      string    `json:"event_id"`
+	AlertType    string    `json:"alert_type"`
+	ScenarioID   string    `json:"scenario_id,omitempty"`
+	RaisedAt     time.Time `json:"raised_at"`
+	MatchedRunID string    `json:"matched_run_id,omitempty"`
+}
+
+// LatencyStats summarizes detection latencies in milliseconds
+type LatencyStats struct {
+	Count int     `json:"count"`
+	MinMs float64 `json:"min_ms"`
+	AvgMs float64 `json:"avg_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+// ScenarioCoverage is one row of the coverage matrix
+type ScenarioCoverage struct {
+	ScenarioID        string       `json:"scenario_id"`
+	Name              string       `json:"name"`
+	Category          string       `json:"category,omitempty"`
+	ExpectedAlertType string       `json:"expected_alert_type"`
+	Runs              int          `json:"runs"`
+	Detected          int          `json:"detected"`
+	Missed            int          `json:"missed"`
+	Pending           int          `json:"pending"`
+	DetectionRate     float64      `json:"detection_rate"`
+	Covered           bool         `json:"covered"`   // At least one run recorded
+	Validated         bool         `json:"validated"` // Most recent completed run was detected
+	LastRunAt         *time.Time   `json:"last_run_at,omitempty"`
+	LastStatus        string       `json:"last_status,omitempty"`
+	Latency           LatencyStats `json:"latency"`
+}
+
+// CoverageMatrix is the aggregate coverage of the scenario catalogue
+type CoverageMatrix struct {
+	GeneratedAt        time.Time          `json:"generated_at"`
+	TotalScenarios     int                `json:"total_scenarios"`
+	CoveredScenarios   int                `json:"covered_scenarios"`
+	ValidatedScenarios int                `json:"validated_scenarios"`
+	CoveragePercent    float64            `json:"coverage_percent"`
+	ValidatedPercent   float64            `json:"validated_percent"`
+	Scenarios          []ScenarioCoverage `json:"scenarios"`
+}
+
+// CoverageSnapshot is a consistent copy of the runs and alerts in a window
+type CoverageSnapshot struct {
+	Runs []ScenarioRun
+	// ExpectedAlertTypes maps each catalogued scenario to the alert type it should raise
+	ExpectedAlertTypes map[string]string
+	Alerts             []RiskAlert
+}
+
+// RiskCoverageTracker records scenario runs and matches them with risk monitor alerts
+type RiskCoverageTracker struct {
+	auditService       *AuditService
+	riskMonitorService string
+	metrics            ports.MetricsPort
+	logger             *logrus.Logger
+
+	mu        sync.RWMutex
+	scenarios map[string]*RiskScenario
+	runs      map[string][]*ScenarioRun
+	alertIDs  map[string]bool
+	alerts    []*RiskAlert
+}
+
+// NewRiskCoverageTracker creates a tracker that observes events ingested by the audit service
+func NewRiskCoverageTracker(auditService *AuditService, logger *logrus.Logger) *RiskCoverageTracker {
+	tracker := &RiskCoverageTracker{
+		auditService:       auditService,
+		riskMonitorService: defaultRiskMonitorService,
+		logger:             logger,
+		scenarios:          make(map[string]*RiskScenario),
+		runs:               make(map[string][]*ScenarioRun),
+		alertIDs:           make(map[string]bool),
+	}
+	if auditService != nil {
+		auditService.AddEventObserver(tracker.ObserveEvent)
+	}
+	return tracker
+}
+
+// SetMetricsPort enables detection counters and latency histograms
+func (t *RiskCoverageTracker) SetMetricsPort(metrics ports.MetricsPort) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.metrics = metrics
+}
+
+// LoadCatalogueFromFile loads the scenario catalogue from a JSON file
+func (t *RiskCoverageTracker) LoadCatalogueFromFile(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		t.logger.WithField("path", path).Info("Risk scenario catalogue not found, starting with empty catalogue")
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read risk scenario catalogue: %w", err)
+	}
+
+	var catalogue RiskScenarioCatalogue
+	if err := json.Unmarshal(data, &catalogue); err != nil {
+		return fmt.Errorf("failed to parse risk scenario catalogue JSON: %w", err)
+	}
+
+	for _, scenario := range catalogue.Scenarios {
+		if err := t.RegisterScenario(scenario); err != nil {
+			return fmt.Errorf("invalid risk scenario: %w", err)
+		}
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"path":      path,
+		"scenarios": len(catalogue.Scenarios),
+	}).Info("Loaded risk scenario catalogue")
+	return nil
+}
+
+// RegisterScenario adds or replaces a catalogued scenario
+func (t *RiskCoverageTracker) RegisterScenario(scenario *RiskScenario) error {
+	if scenario == nil {
+		return fmt.Errorf("scenario cannot be nil")
+	}
+	if err := scenario.Validate(); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scenarios[scenario.ID] = scenario
+	return nil
+}
+
+// ListScenarios returns the catalogue ordered by ID
+func (t *RiskCoverageTracker) ListScenarios() []*RiskScenario {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	scenarios := make([]*RiskScenario, 0, len(t.scenarios))
+	for _, scenario := range t.scenarios {
+		scenarios = append(scenarios, scenario)
+	}
+	sort.Slice(scenarios, func(i, j int) bool { return scenarios[i].ID < scenarios[j].ID })
+	return scenarios
+}
+
+// RecordRun records the start of a scenario run
+// A run of the same scenario with the same ID is the same run reported twice and is returned
+// instead; without a run ID, so is a run starting within runStartTolerance. Named runs are
+// distinct however close together they start
+func (t *RiskCoverageTracker) RecordRun(scenarioID, runID string, startedAt time.Time) (*ScenarioRun, error) {
+	named := runID != ""
+	if !named {
+		runID = fmt.Sprintf("run-%s-%d", scenarioID, startedAt.UnixNano())
+	}
+	return t.recordRun(scenarioID, runID, named, startedAt)
+}
+
+// recordRun records a run under runID; only a run that was not named by its reporter is
+// matched to a recorded run by its start time
+func (t *RiskCoverageTracker) recordRun(scenarioID, runID string, named bool, startedAt time.Time) (*ScenarioRun, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.scenarios[scenarioID]; !exists {
+		return nil, fmt.Errorf("risk scenario not found: %s", scenarioID)
+	}
+	for _, run := range t.runs[scenarioID] {
+		if run.ID == runID || (!named && run.StartedAt.Sub(startedAt).Abs() <= runStartTolerance) {
+			return run, nil
+		}
+	}
+
+	run := &ScenarioRun{
+		ID:         runID,
+		ScenarioID: scenarioID,
+		StartedAt:  startedAt,
+		Status:     ScenarioRunPending,
+	}
+
+	runs := append(t.runs[scenarioID], run)
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartedAt.Before(runs[j].StartedAt) })
+	if len(runs) > maxRunsPerScenario {
+		runs = runs[len(runs)-maxRunsPerScenario:]
+	}
+	t.runs[scenarioID] = runs
+
+	return run, nil
+}
+
+// RecordAlert matches a risk alert to the earliest pending run expecting it
+// Alerts naming a scenario are only matched to that scenario's runs; unmatched
+// alerts are kept as false positive candidates
+func (t *RiskCoverageTracker) RecordAlert(alertEventID, alertType, scenarioID string, raisedAt time.Time) *ScenarioRun {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if alertEventID != "" {
+		if t.alertIDs[alertEventID] {
+			return nil
+		}
+		t.alertIDs[alertEventID] = true
+	}
+
+	var best *ScenarioRun
+	for id, scenario := range t.scenarios {
+		if scenario.ExpectedAlertType != alertType || (scenarioID != "" && id != scenarioID) {
+			continue
+		}
+		for _, run := range t.runs[id] {
+			if run.Status != ScenarioRunPending || raisedAt.Before(run.StartedAt) || raisedAt.Sub(run.StartedAt) > scenario.Timeout() {
+				continue
+			}
+			if best == nil || run.StartedAt.Before(best.StartedAt) {
+				best = run
+			}
+			break
+		}
+	}
+
+	alert := &RiskAlert{
+		EventID:    alertEventID,
+		AlertType:  alertType,
+		ScenarioID: scenarioID,
+		RaisedAt:   raisedAt,
+	}
+	t.alerts = append(t.alerts, alert)
+	if len(t.alerts) > maxTrackedAlerts {
+		t.alerts = t.alerts[len(t.alerts)-maxTrackedAlerts:]
+	}
+
+	if best == nil {
+		if t.metrics != nil {
+			t.metrics.IncCounter("risk_alerts_total", map[string]string{"alert_type": alertType, "outcome": "unmatched"})
+		}
+		return nil
+	}
+
+	detectedAt := raisedAt
+	best.Status = ScenarioRunDetected
+	best.AlertEventID = alertEventID
+	best.AlertType = alertType
+	best.DetectedAt = &detectedAt
+	best.DetectionLatencyMs = float64(raisedAt.Sub(best.StartedAt)) / float64(time.Millisecond)
+	alert.MatchedRunID = best.ID
+	alert.ScenarioID = best.ScenarioID
+
+	if t.metrics != nil {
+		labels := map[string]string{"alert_type": alertType}
+		t.metrics.ObserveHistogram("risk_alert_detection_latency_seconds", raisedAt.Sub(best.StartedAt).Seconds(), labels)
+		t.metrics.IncCounter("risk_alerts_total", map[string]string{"alert_type": alertType, "outcome": "matched"})
+	}
+	return best
+}
+
+// ObserveEvent records scenario starts and risk monitor alerts from ingested events
+// Timestamps are normalized with the current clock offsets, as ScanWindow does
+func (t *RiskCoverageTracker) ObserveEvent(event *models.AuditEvent) {
+	if !t.tracksEvent(event) {
+		return
+	}
+	t.observeEvent(event, t.currentOffsets())
+}
+
+// tracksEvent reports whether an event is a scenario start or a risk monitor alert
+func (t *RiskCoverageTracker) tracksEvent(event *models.AuditEvent) bool {
+	eventType := strings.ToLower(event.EventType)
+	if scenarioStartEventTypes[eventType] {
+		return metadataString(event, metadataKeyScenarioID) != ""
+	}
+	return event.ServiceName == t.riskMonitorService && riskAlertEventTypes[eventType]
+}
+
+// currentOffsets returns the audit service's clock offsets, or none without an audit service
+func (t *RiskCoverageTracker) currentOffsets() map[string]ClockOffset {
+	if t.auditService == nil {
+		return nil
+	}
+	return t.auditService.ClockSkewEstimator().Offsets()
+}
+
+func (t *RiskCoverageTracker) observeEvent(event *models.AuditEvent, offsets map[string]ClockOffset) {
+	eventType := strings.ToLower(event.EventType)
+	scenarioID := metadataString(event, metadataKeyScenarioID)
+	ts := NormalizeTimestamp(event, offsets)
+
+	switch {
+	case scenarioStartEventTypes[eventType] && scenarioID != "":
+		// Without a run ID the start event names the run
+		runID := metadataString(event, metadataKeyRunID)
+		named := runID != ""
+		if !named {
+			runID = event.ID
+		}
+		if _, err := t.recordRun(scenarioID, runID, named, ts); err != nil {
+			t.logger.WithError(err).WithField("event_id", event.ID).Debug("Ignoring run of uncatalogued scenario")
+		}
+
+	case event.ServiceName == t.riskMonitorService && riskAlertEventTypes[eventType]:
+		alertType := metadataString(event, metadataKeyAlertType)
+		if alertType == "" {
+			return
+		}
+		if run := t.RecordAlert(event.ID, alertType, scenarioID, ts); run != nil {
+			t.logger.WithFields(logrus.Fields{
+				"scenario_id": run.ScenarioID,
+				"run_id":      run.ID,
+				"latency_ms":  run.DetectionLatencyMs,
+			}).Info("Risk scenario detected")
+		}
+	}
+}
+
+// ScanEvents replays stored events in chronological order (on the correlator clock)
+func (t *RiskCoverageTracker) ScanEvents(events []*models.AuditEvent, offsets map[string]ClockOffset) {
+	ordered := make([]*models.AuditEvent, len(events))
+	copy(ordered, events)
+	SortByNormalizedTime(ordered, offsets)
+
+	for _, event := range ordered {
+		t.observeEvent(event, offsets)
+	}
+}
+
+// ScanWindow replays stored events in [start, end] to record runs and detections
+// Events already seen (by run or alert ID) are not counted twice
+func (t *RiskCoverageTracker) ScanWindow(start, end time.Time) (int, error) {
+	events, err := t.auditService.GetEventsInWindow(start, end, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load events for coverage scan: %w", err)
+	}
+
+	t.ScanEvents(events, t.currentOffsets())
+	return len(events), nil
+}
+
+// GetRuns returns the runs of a scenario, most recent first
+func (t *RiskCoverageTracker) GetRuns(scenarioID string) ([]ScenarioRun, error) {
+	t.ExpirePendingRuns(time.Now())
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if _, exists := t.scenarios[scenarioID]; !exists {
+		return nil, fmt.Errorf("risk scenario not found: %s", scenarioID)
+	}
+
+	runs := make([]ScenarioRun, 0, len(t.runs[scenarioID]))
+	for i := len(t.runs[scenarioID]) - 1; i >= 0; i-- {
+		runs = append(runs, *t.runs[scenarioID][i])
+	}
+	return runs, nil
+}
+
+// Snapshot copies the runs started and alerts raised in [start, end], after expiring overdue runs
+func (t *RiskCoverageTracker) Snapshot(start, end time.Time) *CoverageSnapshot {
+	t.ExpirePendingRuns(time.Now())
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snapshot := &CoverageSnapshot{ExpectedAlertTypes: make(map[string]string, len(t.scenarios))}
+	for id, scenario := range t.scenarios {
+		snapshot.ExpectedAlertTypes[id] = scenario.ExpectedAlertType
+	}
+	for _, runs := range t.runs {
+		for _, run := range runs {
+			if !run.StartedAt.Before(start) && !run.StartedAt.After(end) {
+				snapshot.Runs = append(snapshot.Runs, *run)
+			}
+		}
+	}
+	for _, alert := range t.alerts {
+		if !alert.RaisedAt.Before(start) && !alert.RaisedAt.After(end) {
+			snapshot.Alerts = append(snapshot.Alerts, *alert)
+		}
+	}
+	return snapshot
+}
+
+// CoverageMatrix aggregates runs per catalogued scenario
+func (t *RiskCoverageTracker) CoverageMatrix() *CoverageMatrix {
+	now := time.Now()
+	t.ExpirePendingRuns(now)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	matrix := &CoverageMatrix{
+		GeneratedAt:    now,
+		TotalScenarios: len(t.scenarios),
+		Scenarios:      []ScenarioCoverage{},
+	}
+
+	for id, scenario := range t.scenarios {
+		row := ScenarioCoverage{
+			ScenarioID:        id,
+			Name:              scenario.Name,
+			Category:          scenario.Category,
+			ExpectedAlertType: scenario.ExpectedAlertType,
+		}
+
+		var latencies []float64
+		for _, run := range t.runs[id] {
+			row.Runs++
+			switch run.Status {
+			case ScenarioRunDetected:
+				row.Detected++
+				latencies = append(latencies, run.DetectionLatencyMs)
+			case ScenarioRunMissed:
+				row.Missed++
+			default:
+				row.Pending++
+			}
+			if run.Status != ScenarioRunPending {
+				row.Validated = run.Status == ScenarioRunDetected
+			}
+		}
+
+		if row.Runs > 0 {
+			last := t.runs[id][row.Runs-1]
+			lastRunAt := last.StartedAt
+			row.LastRunAt = &lastRunAt
+			row.LastStatus = last.Status
+			row.Covered = true
+			matrix.CoveredScenarios++
+		}
+		if completed := row.Detected + row.Missed; completed > 0 {
+			row.DetectionRate = float64(row.Detected) / float64(completed)
+		}
+		if row.Validated {
+			matrix.ValidatedScenarios++
+		}
+		row.Latency = ComputeLatencyStats(latencies)
+
+		matrix.Scenarios = append(matrix.Scenarios, row)
+	}
+
+	sort.Slice(matrix.Scenarios, func(i, j int) bool { return matrix.Scenarios[i].ScenarioID < matrix.Scenarios[j].ScenarioID })
+	if matrix.TotalScenarios > 0 {
+		matrix.CoveragePercent = 100 * float64(matrix.CoveredScenarios) / float64(matrix.TotalScenarios)
+		matrix.ValidatedPercent = 100 * float64(matrix.ValidatedScenarios) / float64(matrix.TotalScenarios)
+	}
+	return matrix
+}
+
+// ExpirePendingRuns marks pending runs whose detection timeout has elapsed as missed,
+// counting each in risk_scenario_missed_total
+func (t *RiskCoverageTracker) ExpirePendingRuns(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, runs := range t.runs {
+		timeout := t.scenarios[id].Timeout()
+		for _, run := range runs {
+			if run.Status == ScenarioRunPending && now.Sub(run.StartedAt) > timeout {
+				run.Status = ScenarioRunMissed
+				if t.metrics != nil {
+					t.metrics.IncCounter("risk_scenario_missed_total", map[string]string{"alert_type": t.scenarios[id].ExpectedAlertType})
+				}
+			}
+		}
+	}
+}
+
+// ComputeLatencyStats summarizes a set of latencies in milliseconds
+func ComputeLatencyStats(latencies []float64) LatencyStats {
+	stats := LatencyStats{Count: len(latencies)}
+	if len(latencies) == 0 {
+		return stats
+	}
+
+	sorted := make([]float64, len(latencies))
+	copy(sorted, latencies)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	stats.MinMs = sorted[0]
+	stats.MaxMs = sorted[len(sorted)-1]
+	stats.AvgMs = total / float64(len(sorted))
+	stats.P50Ms = percentile(sorted, 50)
+	stats.P95Ms = percentile(sorted, 95)
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newTestCoverageTracker(t *testing.T) *RiskCoverageTracker {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	tracker := NewRiskCoverageTracker(NewAuditService(logger), logger)
+
+	path := filepath.Join(t.TempDir(), "risk_scenarios.json")
+	catalogue := `{"scenarios": [
+		{"id": "position-limit", "name": "Position limit breach", "expected_alert_type": "position_limit", "detection_timeout": "10s"},
+		{"id": "flash-crash", "name": "Flash crash", "expected_alert_type": "price_shock"},
+		{"id": "stale-prices", "name": "Stale prices", "expected_alert_type": "stale_market_data"}
+	]}`
+	if err := os.WriteFile(path, []byte(catalogue), 0o644); err != nil {
+		t.Fatalf("Failed to write catalogue: %v", err)
+	}
+	if err := tracker.LoadCatalogueFromFile(path); err != nil {
+		t.Fatalf("Failed to load catalogue: %v", err)
+	}
+	return tracker
+}
+
+func TestRiskCoverageTracker_DetectsAndMisses(t *testing.T) {
+	tracker := newTestCoverageTracker(t)
+	base := time.Now().Add(-time.Hour)
+
+	events := []*models.AuditEvent{
+		newTypedEvent("s1", "test-coordinator", "scenario_started", base, `{"scenario_id": "position-limit"}`),
+		newTypedEvent("a1", "risk-monitor", "risk_alert", base.Add(1500*time.Millisecond), `{"alert_type": "position_limit"}`),
+		// Second run is never alerted on within its 10s timeout
+		newTypedEvent("s2", "test-coordinator", "scenario_started", base.Add(time.Minute), `{"scenario_id": "position-limit"}`),
+		newTypedEvent("a2", "risk-monitor", "risk_alert", base.Add(2*time.Minute), `{"alert_type": "position_limit"}`),
+		// Flash crash detected; alert attributed explicitly
+		newTypedEvent("s3", "test-coordinator", "chaos_injected", base, `{"scenario_id": "flash-crash", "run_id": "r3"}`),
+		newTypedEvent("a3", "risk-monitor", "alert_raised", base.Add(200*time.Millisecond), `{"alert_type": "price_shock", "scenario_id": "flash-crash"}`),
+	}
+	tracker.ScanEvents(events, nil)
+	// Replaying the same events must not double count
+	tracker.ScanEvents(events, nil)
+
+	runs, err := tracker.GetRuns("position-limit")
+	if err != nil {
+		t.Fatalf("Failed to get runs: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("Expected 2 runs, got %d", len(runs))
+	}
+	if runs[1].Status != ScenarioRunDetected || runs[1].DetectionLatencyMs != 1500 {
+		t.Errorf("Expected first run detected after 1500ms, got %+v", runs[1])
+	}
+	if runs[0].Status != ScenarioRunMissed {
+		t.Errorf("Expected second run missed, got %s", runs[0].Status)
+	}
+
+	matrix := tracker.CoverageMatrix()
+	if matrix.TotalScenarios != 3 || matrix.CoveredScenarios != 2 || matrix.ValidatedScenarios != 1 {
+		t.Errorf("Unexpected coverage totals: %+v", matrix)
+	}
+
+	rows := make(map[string]ScenarioCoverage)
+	for _, row := range matrix.Scenarios {
+		rows[row.ScenarioID] = row
+	}
+	if row := rows["position-limit"]; row.DetectionRate != 0.5 || row.Validated || row.Latency.Count != 1 {
+		t.Errorf("Unexpected position-limit coverage: %+v", row)
+	}
+	if row := rows["flash-crash"]; !row.Validated || row.Latency.MaxMs != 200 {
+		t.Errorf("Unexpected flash-crash coverage: %+v", row)
+	}
+	if row := rows["stale-prices"]; row.Covered {
+		t.Errorf("Expected stale-prices to be uncovered, got %+v", row)
+	}
+}
+
+func TestRiskCoverageTracker_ObservesIngestedEvents(t *testing.T) {
+	tracker := newTestCoverageTracker(t)
+
+	if err := tracker.auditService.IngestEvent(&models.AuditEvent{
+		ServiceName: "test-coordinator",
+		EventType:   "scenario_started",
+		Metadata:    []byte(`{"scenario_id": "stale-prices"}`),
+	}); err != nil {
+		t.Fatalf("Failed to ingest event: %v", err)
+	}
+
+	runs, _ := tracker.GetRuns("stale-prices")
+	if len(runs) != 1 || runs[0].Status != ScenarioRunPending {
+		t.Fatalf("Expected 1 pending run, got %+v", runs)
+	}
+}
+
+func TestRiskCoverageTracker_LiveEventsUseClockOffsets(t *testing.T) {
+	tracker := newTestCoverageTracker(t)
+
+	// The coordinator's clock runs 5s ahead; the live path places the run on the correlator clock
+	started := time.Now().Add(5 * time.Second)
+	if err := tracker.auditService.IngestEvent(&models.AuditEvent{
+		ServiceName: "test-coordinator",
+		EventType:   "scenario_started",
+		Timestamp:   started,
+		Metadata:    []byte(`{"scenario_id": "stale-prices"}`),
+	}); err != nil {
+		t.Fatalf("Failed to ingest event: %v", err)
+	}
+
+	runs, _ := tracker.GetRuns("stale-prices")
+	if len(runs) != 1 {
+		t.Fatalf("Expected 1 run, got %+v", runs)
+	}
+	if skew := started.Sub(runs[0].StartedAt); skew < 4*time.Second {
+		t.Errorf("Expected the run start normalized by the 5s offset, got %v", runs[0].StartedAt)
+	}
+}
+
+func TestRiskCoverageTracker_RecordedRunIsNotRediscovered(t *testing.T) {
+	tracker := newTestCoverageTracker(t)
+	base := time.Now().Add(-time.Hour)
+
+	// Given a run reported by the coordinator and its start event found later by a scan
+	if _, err := tracker.RecordRun("flash-crash", "coordinator-1", base); err != nil {
+		t.Fatalf("Failed to record run: %v", err)
+	}
+	tracker.ScanEvents([]*models.AuditEvent{
+		newTypedEvent("s1", "test-coordinator", "scenario_started", base.Add(500*time.Millisecond), `{"scenario_id": "flash-crash"}`),
+	}, nil)
+
+	// Then it is one run
+	if runs, _ := tracker.GetRuns("flash-crash"); len(runs) != 1 || runs[0].ID != "coordinator-1" {
+		t.Errorf("Expected the reported run only, got %+v", runs)
+	}
+
+	// And named runs starting close together are distinct
+	if _, err := tracker.RecordRun("flash-crash", "coordinator-2", base.Add(time.Second)); err != nil {
+		t.Fatalf("Failed to record run: %v", err)
+	}
+	tracker.ScanEvents([]*models.AuditEvent{
+		newTypedEvent("s2", "test-coordinator", "scenario_started", base.Add(1500*time.Millisecond), `{"scenario_id": "flash-crash", "run_id": "coordinator-3"}`),
+	}, nil)
+	if runs, _ := tracker.GetRuns("flash-crash"); len(runs) != 3 {
+		t.Errorf("Expected three named runs, got %+v", runs)
+	}
+
+	// And the same run ID under another scenario is a different run
+	run, err := tracker.RecordRun("position-limit", "coordinator-1", base)
+	if err != nil {
+		t.Fatalf("Failed to record run: %v", err)
+	}
+	if run.ScenarioID != "position-limit" {
+		t.Errorf("Expected a position-limit run, got %+v", run)
+	}
+}
+
+func TestComputeLatencyStats(t *testing.T) {
+	stats := ComputeLatencyStats([]float64{40, 10, 30, 20, 100})
+	if stats.MinMs != 10 || stats.MaxMs != 100 || stats.AvgMs != 40 || stats.P50Ms != 30 || stats.P95Ms != 100 {
+		t.Errorf("Unexpected latency stats: %+v", stats)
+	}
+}
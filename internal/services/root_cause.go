@@ -0,0 +1,710 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+)
+
+// RootCauseKind identifies what a root-cause hypothesis points at
+type RootCauseKind string
+
+const (
+	RootCauseKindNode        RootCauseKind = "node"
+	RootCauseKindEdge        RootCauseKind = "edge"
+	RootCauseKindAuditEvents RootCauseKind = "audit_events"
+)
+
+const (
+	// defaultRootCauseLookback is how far before a degradation candidates are considered
+	defaultRootCauseLookback = 15 * time.Minute
+	// maxRootCauseDepth bounds how many hops from the degraded node are searched
+	maxRootCauseDepth = 3
+	// maxRootCauseAnalyses bounds the analysis history kept in memory
+	maxRootCauseAnalyses = 200
+	// maxStatusHistory bounds the status/error-rate samples kept per node or edge
+	maxStatusHistory = 100
+	// maxEvidencePerHypothesis caps the evidence attached to one hypothesis
+	maxEvidencePerHypothesis = 10
+	// rootCauseQueueSize bounds the degradations waiting for analysis
+	rootCauseQueueSize = 64
+
+	// An edge error rate "rises" when it at least doubles and grows by minErrorRateRise errors/s
+	errorRateRiseFactor = 2.0
+	minErrorRateRise    = 0.1
+)
+
+// errorEventMarkers are event type fragments that mark an audit event as an error signal
+var errorEventMarkers = []string{"error", "fail", "timeout", "reject", "exception", "degrad"}
+
+// RootCauseEvidence is one observation supporting a hypothesis
+type RootCauseEvidence struct {
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	ObservedAt  time.Time `json:"observed_at"`
+	EventID     string    `json:"event_id,omitempty"`
+	Value       float64   `json:"value,omitempty"`
+}
+
+// RootCauseHypothesis is a ranked candidate cause for a node degradation
+type RootCauseHypothesis struct {
+	Rank        int           `json:"rank"`
+	Kind        RootCauseKind `json:"kind"`
+	NodeID      string        `json:"node_id,omitempty"`
+	EdgeID      string        `json:"edge_id,omitempty"`
+	ServiceName string        `json:"service_name,omitempty"`
+	// Relation is self, upstream (feeds the degraded node) or downstream (fed by it)
+	Relation        string              `json:"relation"`
+	Distance        int                 `json:"distance"`
+	Score           float64             `json:"score"`
+	FirstObservedAt *time.Time          `json:"first_observed_at,omitempty"`
+	LeadTimeMs      float64             `json:"lead_time_ms"`
+	Summary         string              `json:"summary"`
+	Evidence        []RootCauseEvidence `json:"evidence"`
+}
+
+// RootCauseAnalysis ranks candidate causes for one node status change
+type RootCauseAnalysis struct {
+	ID         string                `json:"id"`
+	NodeID     string                `json:"node_id"`
+	NodeName   string                `json:"node_name"`
+	Status     string                `json:"status"`
+	ChangedAt  time.Time             `json:"changed_at"`
+	SnapshotID string                `json:"snapshot_id"` // Topology analyzed, as of ChangedAt
+	AnalyzedAt time.Time             `json:"analyzed_at"`
+	LookbackMs float64               `json:"lookback_ms"`
+	Hypotheses []RootCauseHypothesis `json:"hypotheses"`
+}
+
+type statusSample struct {
+	status string
+	at     time.Time
+}
+
+type errorRateSample struct {
+	rate float64
+	at   time.Time
+}
+
+// analysisRequest is a degradation waiting for the analysis worker
+type analysisRequest struct {
+	nodeID    string
+	changedAt time.Time
+}
+
+// graphNeighbour is a node reachable from the degraded node
+type graphNeighbour struct {
+	node     *entities.ServiceNode
+	distance int
+	relation string
+}
+
+// RootCauseService ranks root-cause hypotheses when topology nodes degrade
+type RootCauseService struct {
+	auditService    *AuditService
+	topologyService *TopologyService
+	logger          *logrus.Logger
+	lookback        time.Duration
+	queue           chan analysisRequest
+
+	mu             sync.RWMutex
+	nodeStatus     map[string]entities.NodeStatus
+	nodeHistory    map[string][]statusSample
+	edgeHistory    map[string][]statusSample
+	edgeErrorRates map[string][]errorRateSample
+	analyses       []*RootCauseAnalysis
+}
+
+// NewRootCauseService creates a new root-cause service
+func NewRootCauseService(auditService *AuditService, topologyService *TopologyService, logger *logrus.Logger) *RootCauseService {
+	return &RootCauseService{
+		auditService:    auditService,
+		topologyService: topologyService,
+		logger:          logger,
+		lookback:        defaultRootCauseLookback,
+		queue:           make(chan analysisRequest, rootCauseQueueSize),
+		nodeStatus:      make(map[string]entities.NodeStatus),
+		nodeHistory:     make(map[string][]statusSample),
+		edgeHistory:     make(map[string][]statusSample),
+		edgeErrorRates:  make(map[string][]errorRateSample),
+		analyses:        make([]*RootCauseAnalysis, 0),
+	}
+}
+
+// Start follows topology changes and samples edge error rates every interval until ctx is cancelled
+// Degradations are analyzed by a separate worker so slow analyses never hold up the
+// subscription and make the publisher drop changes
+func (s *RootCauseService) Start(ctx context.Context, sampleInterval time.Duration) error {
+	changes, err := s.topologyService.ChangePublisher().Subscribe(ctx, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to topology changes: %w", err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case req := <-s.queue:
+				s.analyzeDegradation(req.nodeID, req.changedAt)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(sampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok := <-changes:
+				if !ok {
+					return
+				}
+				s.HandleTopologyChange(change)
+			case <-ticker.C:
+				s.sampleEdgeErrorRates(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// HandleTopologyChange records node and edge status from a topology change event,
+// queueing an analysis when a node degrades
+func (s *RootCauseService) HandleTopologyChange(change *ports.TopologyChangeEvent) {
+	if change == nil {
+		return
+	}
+	at := change.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if change.Node != nil && change.ChangeType != ports.TopologyChangeTypeNodeRemoved && s.recordNodeStatus(change.Node.ID, change.Node.Status, at) {
+		select {
+		case s.queue <- analysisRequest{nodeID: change.Node.ID, changedAt: at}:
+		default:
+			s.logger.WithField("node_id", change.Node.ID).Warn("Root-cause analysis queue full, degradation not analyzed")
+		}
+	}
+	if change.Connection != nil && change.ChangeType != ports.TopologyChangeTypeEdgeRemoved {
+		s.RecordEdgeStatus(change.Connection.ID, change.Connection.Status, at)
+	}
+}
+
+// RecordNodeStatus records a node status; a transition to degraded or dead is analyzed before returning
+func (s *RootCauseService) RecordNodeStatus(nodeID string, status entities.NodeStatus, at time.Time) *RootCauseAnalysis {
+	if !s.recordNodeStatus(nodeID, status, at) {
+		return nil
+	}
+	return s.analyzeDegradation(nodeID, at)
+}
+
+// recordNodeStatus records a node status, reporting whether it is a transition to degraded or dead
+func (s *RootCauseService) recordNodeStatus(nodeID string, status entities.NodeStatus, at time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, seen := s.nodeStatus[nodeID]
+	if seen && previous == status {
+		return false
+	}
+	s.nodeStatus[nodeID] = status
+	s.nodeHistory[nodeID] = appendStatusSample(s.nodeHistory[nodeID], statusSample{status: nodeStatusName(status), at: at})
+	return status == entities.NodeStatusDegraded || status == entities.NodeStatusDead
+}
+
+// analyzeDegradation analyzes a degradation, logging rather than returning a failure
+func (s *RootCauseService) analyzeDegradation(nodeID string, at time.Time) *RootCauseAnalysis {
+	analysis, err := s.Analyze(nodeID, at)
+	if err != nil {
+		s.logger.WithError(err).WithField("node_id", nodeID).Warn("Failed to analyze node degradation")
+		return nil
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"node_id":    nodeID,
+		"status":     analysis.Status,
+		"hypotheses": len(analysis.Hypotheses),
+	}).Info("Ranked root-cause hypotheses for node degradation")
+	return analysis
+}
+
+// RecordEdgeStatus records an edge status change
+func (s *RootCauseService) RecordEdgeStatus(edgeID string, status entities.EdgeStatus, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := edgeStatusName(status)
+	history := s.edgeHistory[edgeID]
+	if len(history) > 0 && history[len(history)-1].status == name {
+		return
+	}
+	s.edgeHistory[edgeID] = appendStatusSample(history, statusSample{status: name, at: at})
+}
+
+// RecordEdgeErrorRate records an edge error rate sample (errors per second)
+func (s *RootCauseService) RecordEdgeErrorRate(edgeID string, rate float64, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	samples := append(s.edgeErrorRates[edgeID], errorRateSample{rate: rate, at: at})
+	if len(samples) > maxStatusHistory {
+		samples = samples[len(samples)-maxStatusHistory:]
+	}
+	s.edgeErrorRates[edgeID] = samples
+}
+
+func (s *RootCauseService) sampleEdgeErrorRates(ctx context.Context) {
+	connections, err := s.topologyService.TopologyRepository().GetConnections(ctx, nil)
+	if err != nil || len(connections) == 0 {
+		return
+	}
+
+	edgeIDs := make([]string, 0, len(connections))
+	for _, conn := range connections {
+		edgeIDs = append(edgeIDs, conn.ID)
+	}
+
+	metadata, err := s.topologyService.MetadataRepository().GetEdgesMetadata(ctx, edgeIDs)
+	if err != nil {
+		s.logger.WithError(err).Debug("Failed to sample edge error rates")
+		return
+	}
+
+	now := time.Now()
+	for _, edge := range metadata {
+		s.RecordEdgeErrorRate(edge.EdgeID, edge.ErrorRate, now)
+	}
+}
+
+// Analyze ranks root-cause hypotheses for a node that changed status at changedAt
+// The topology is taken as it was at changedAt, so later changes do not hide the causes
+func (s *RootCauseService) Analyze(nodeID string, changedAt time.Time) (*RootCauseAnalysis, error) {
+	snapshot, err := s.topologyService.Tracker().GetTopologyAsOf(context.Background(), changedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topology as of %s: %w", changedAt.Format(time.RFC3339), err)
+	}
+
+	target, exists := snapshot.GetNode(nodeID)
+	if !exists {
+		return nil, fmt.Errorf("node not found: %s", nodeID)
+	}
+
+	windowStart := changedAt.Add(-s.lookback)
+	neighbours, edgeDistances := topologyNeighbourhood(snapshot, nodeID, maxRootCauseDepth)
+
+	var hypotheses []RootCauseHypothesis
+	s.mu.RLock()
+	for _, neighbour := range neighbours {
+		if neighbour.distance == 0 {
+			continue
+		}
+		if hypothesis, ok := s.nodeHypothesis(neighbour, windowStart, changedAt); ok {
+			hypotheses = append(hypotheses, hypothesis)
+		}
+	}
+	for edgeID, distance := range edgeDistances {
+		conn := snapshot.Connections[edgeID]
+		if hypothesis, ok := s.edgeHypothesis(conn, distance, nodeID, windowStart, changedAt); ok {
+			hypotheses = append(hypotheses, hypothesis)
+		}
+	}
+	s.mu.RUnlock()
+
+	eventHypotheses, err := s.auditEventHypotheses(neighbours, windowStart, changedAt)
+	if err != nil {
+		return nil, err
+	}
+	hypotheses = append(hypotheses, eventHypotheses...)
+
+	sort.SliceStable(hypotheses, func(i, j int) bool {
+		if hypotheses[i].Score != hypotheses[j].Score {
+			return hypotheses[i].Score > hypotheses[j].Score
+		}
+		return hypotheses[i].LeadTimeMs > hypotheses[j].LeadTimeMs
+	})
+	for i := range hypotheses {
+		hypotheses[i].Rank = i + 1
+	}
+	if hypotheses == nil {
+		hypotheses = []RootCauseHypothesis{}
+	}
+
+	analysis := &RootCauseAnalysis{
+		ID:         fmt.Sprintf("rca-%s-%d", nodeID, changedAt.UnixNano()),
+		NodeID:     nodeID,
+		NodeName:   target.Name,
+		Status:     nodeStatusName(target.Status),
+		ChangedAt:  changedAt,
+		SnapshotID: snapshot.SnapshotID,
+		AnalyzedAt: time.Now(),
+		LookbackMs: float64(s.lookback.Milliseconds()),
+		Hypotheses: hypotheses,
+	}
+
+	s.mu.Lock()
+	s.analyses = append(s.analyses, analysis)
+	if len(s.analyses) > maxRootCauseAnalyses {
+		s.analyses = s.analyses[len(s.analyses)-maxRootCauseAnalyses:]
+	}
+	s.mu.Unlock()
+
+	return analysis, nil
+}
+
+// nodeHypothesis reports a neighbour that degraded before the target; callers hold s.mu
+func (s *RootCauseService) nodeHypothesis(neighbour graphNeighbour, windowStart, changedAt time.Time) (RootCauseHypothesis, bool) {
+	node := neighbour.node
+	hypothesis := RootCauseHypothesis{
+		Kind:        RootCauseKindNode,
+		NodeID:      node.ID,
+		ServiceName: node.Name,
+		Relation:    neighbour.relation,
+		Distance:    neighbour.distance,
+	}
+
+	var first *time.Time
+	weight := 0.0
+	for _, sample := range s.nodeHistory[node.ID] {
+		if sample.at.Before(windowStart) || sample.at.After(changedAt) {
+			continue
+		}
+		if sample.status != "degraded" && sample.status != "dead" {
+			continue
+		}
+		at := sample.at
+		if first == nil {
+			first = &at
+		}
+		weight = max(weight, nodeStatusWeight(sample.status))
+		hypothesis.Evidence = append(hypothesis.Evidence, RootCauseEvidence{
+			Type:        "node_status",
+			Description: fmt.Sprintf("%s became %s", node.Name, sample.status),
+			ObservedAt:  sample.at,
+		})
+	}
+
+	// A neighbour that is unhealthy now but whose transition was not observed still counts, without precedence
+	if first == nil {
+		if node.IsHealthy() || node.Status == entities.NodeStatusUnspecified {
+			return hypothesis, false
+		}
+		status := nodeStatusName(node.Status)
+		weight = nodeStatusWeight(status)
+		hypothesis.Evidence = append(hypothesis.Evidence, RootCauseEvidence{
+			Type:        "node_status",
+			Description: fmt.Sprintf("%s is %s (transition time unknown)", node.Name, status),
+			ObservedAt:  node.LastSeenAt,
+		})
+	}
+
+	hypothesis.FirstObservedAt = first
+	hypothesis.Score = s.scoreHypothesis(weight, neighbour.distance, first, changedAt)
+	hypothesis.LeadTimeMs = leadTimeMs(first, changedAt)
+	hypothesis.Summary = fmt.Sprintf("%s node %s degraded before the change", neighbour.relation, node.Name)
+	hypothesis.Evidence = capEvidence(hypothesis.Evidence)
+	return hypothesis, true
+}
+
+// edgeHypothesis reports an edge whose status or error rate worsened before the change; callers hold s.mu
+func (s *RootCauseService) edgeHypothesis(conn *entities.ServiceConnection, distance int, nodeID string, windowStart, changedAt time.Time) (RootCauseHypothesis, bool) {
+	relation := "upstream"
+	if conn.SourceID == nodeID {
+		relation = "downstream"
+	}
+	hypothesis := RootCauseHypothesis{
+		Kind:     RootCauseKindEdge,
+		EdgeID:   conn.ID,
+		Relation: relation,
+		Distance: distance,
+	}
+
+	var first *time.Time
+	weight := 0.0
+	observe := func(at time.Time, signalWeight float64, evidence RootCauseEvidence) {
+		if first == nil || at.Before(*first) {
+			observed := at
+			first = &observed
+		}
+		weight = max(weight, signalWeight)
+		hypothesis.Evidence = append(hypothesis.Evidence, evidence)
+	}
+
+	for _, sample := range s.edgeHistory[conn.ID] {
+		if sample.at.Before(windowStart) || sample.at.After(changedAt) {
+			continue
+		}
+		if sample.status != "degraded" && sample.status != "failed" {
+			continue
+		}
+		observe(sample.at, edgeStatusWeight(sample.status), RootCauseEvidence{
+			Type:        "edge_status",
+			Description: fmt.Sprintf("edge %s -> %s became %s", conn.SourceID, conn.TargetID, sample.status),
+			ObservedAt:  sample.at,
+		})
+	}
+
+	if rise, baseline, ok := errorRateRise(s.edgeErrorRates[conn.ID], windowStart, changedAt); ok {
+		observe(rise.at, 0.6, RootCauseEvidence{
+			Type:        "edge_error_rate",
+			Description: fmt.Sprintf("error rate on %s -> %s rose from %.2f/s to %.2f/s", conn.SourceID, conn.TargetID, baseline, rise.rate),
+			ObservedAt:  rise.at,
+			Value:       rise.rate,
+		})
+	}
+
+	if first == nil {
+		return hypothesis, false
+	}
+
+	sort.Slice(hypothesis.Evidence, func(i, j int) bool {
+		return hypothesis.Evidence[i].ObservedAt.Before(hypothesis.Evidence[j].ObservedAt)
+	})
+	hypothesis.FirstObservedAt = first
+	hypothesis.Score = s.scoreHypothesis(weight, distance, first, changedAt)
+	hypothesis.LeadTimeMs = leadTimeMs(first, changedAt)
+	hypothesis.Summary = fmt.Sprintf("%s edge %s -> %s worsened before the change", relation, conn.SourceID, conn.TargetID)
+	hypothesis.Evidence = capEvidence(hypothesis.Evidence)
+	return hypothesis, true
+}
+
+// auditEventHypotheses groups error audit events from the neighbourhood by service
+// Each neighbour's events are walked on their own, so only the error events are held
+func (s *RootCauseService) auditEventHypotheses(neighbours []graphNeighbour, windowStart, changedAt time.Time) ([]RootCauseHypothesis, error) {
+	services := make(map[string]graphNeighbour)
+	for _, neighbour := range neighbours {
+		services[neighbour.node.Name] = neighbour
+		services[neighbour.node.ID] = neighbour
+	}
+
+	var events []*models.AuditEvent
+	for service := range services {
+		err := s.auditService.WalkEventsInWindow(context.Background(), WindowFilter{ServiceName: service}, windowStart, changedAt, func(page []*models.AuditEvent) error {
+			for _, event := range page {
+				if isErrorEvent(event) {
+					events = append(events, event)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load audit events from %s: %w", service, err)
+		}
+	}
+	offsets := s.auditService.ClockSkewEstimator().Offsets()
+	SortByNormalizedTime(events, offsets)
+
+	byService := make(map[string]*RootCauseHypothesis)
+	var order []string
+	for _, event := range events {
+		neighbour := services[event.ServiceName]
+		ts := NormalizeTimestamp(event, offsets)
+		if ts.Before(windowStart) || ts.After(changedAt) {
+			continue
+		}
+
+		hypothesis, exists := byService[event.ServiceName]
+		if !exists {
+			first := ts
+			hypothesis = &RootCauseHypothesis{
+				Kind:            RootCauseKindAuditEvents,
+				NodeID:          neighbour.node.ID,
+				ServiceName:     event.ServiceName,
+				Relation:        neighbour.relation,
+				Distance:        neighbour.distance,
+				FirstObservedAt: &first,
+			}
+			byService[event.ServiceName] = hypothesis
+			order = append(order, event.ServiceName)
+		}
+		hypothesis.Evidence = append(hypothesis.Evidence, RootCauseEvidence{
+			Type:        "audit_event",
+			Description: event.EventType,
+			ObservedAt:  ts,
+			EventID:     event.ID,
+		})
+	}
+
+	hypotheses := make([]RootCauseHypothesis, 0, len(order))
+	for _, service := range order {
+		hypothesis := byService[service]
+		count := len(hypothesis.Evidence)
+		weight := 0.4 + 0.02*float64(min(count, 10))
+		if hypothesis.Relation == "self" {
+			// Errors on the degraded node itself are as likely symptoms as causes
+			weight /= 2
+		}
+		hypothesis.Score = s.scoreHypothesis(weight, hypothesis.Distance, hypothesis.FirstObservedAt, changedAt)
+		hypothesis.LeadTimeMs = leadTimeMs(hypothesis.FirstObservedAt, changedAt)
+		hypothesis.Summary = fmt.Sprintf("%d error events from %s preceded the change", count, service)
+		hypothesis.Evidence = capEvidence(hypothesis.Evidence)
+		hypotheses = append(hypotheses, *hypothesis)
+	}
+	return hypotheses, nil
+}
+
+// scoreHypothesis weighs a signal by graph proximity and by how early it preceded the change
+func (s *RootCauseService) scoreHypothesis(weight float64, distance int, first *time.Time, changedAt time.Time) float64 {
+	proximity := 1 / float64(distance+1)
+	precedence := 0.5
+	if first != nil && s.lookback > 0 {
+		lead := changedAt.Sub(*first)
+		if lead > s.lookback {
+			lead = s.lookback
+		}
+		precedence = 0.5 + 0.5*float64(lead)/float64(s.lookback)
+	}
+	return weight * proximity * precedence
+}
+
+// ListAnalyses returns analyses newest first, optionally restricted to one node
+func (s *RootCauseService) ListAnalyses(nodeID string) []*RootCauseAnalysis {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	analyses := make([]*RootCauseAnalysis, 0)
+	for i := len(s.analyses) - 1; i >= 0; i-- {
+		if nodeID == "" || s.analyses[i].NodeID == nodeID {
+			analyses = append(analyses, s.analyses[i])
+		}
+	}
+	return analyses
+}
+
+// GetAnalysis returns a stored analysis by ID
+func (s *RootCauseService) GetAnalysis(analysisID string) (*RootCauseAnalysis, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, analysis := range s.analyses {
+		if analysis.ID == analysisID {
+			return analysis, nil
+		}
+	}
+	return nil, fmt.Errorf("root-cause analysis not found: %s", analysisID)
+}
+
+// topologyNeighbourhood walks connections in both directions up to maxDepth hops
+// It returns reachable nodes and the distance of every traversed edge from the start node
+func topologyNeighbourhood(snapshot *entities.NetworkTopology, startID string, maxDepth int) ([]graphNeighbour, map[string]int) {
+	start := snapshot.Nodes[startID]
+	visited := map[string]bool{startID: true}
+	neighbours := []graphNeighbour{{node: start, distance: 0, relation: "self"}}
+	edges := make(map[string]int)
+
+	for i := 0; i < len(neighbours); i++ {
+		current := neighbours[i]
+		if current.distance >= maxDepth {
+			continue
+		}
+
+		for _, conn := range snapshot.Connections {
+			var nextID, relation string
+			switch current.node.ID {
+			case conn.TargetID:
+				nextID, relation = conn.SourceID, "upstream"
+			case conn.SourceID:
+				nextID, relation = conn.TargetID, "downstream"
+			default:
+				continue
+			}
+			if _, seen := edges[conn.ID]; !seen {
+				edges[conn.ID] = current.distance
+			}
+
+			next, exists := snapshot.Nodes[nextID]
+			if !exists || visited[nextID] {
+				continue
+			}
+			visited[nextID] = true
+			if current.distance > 0 {
+				relation = current.relation
+			}
+			neighbours = append(neighbours, graphNeighbour{node: next, distance: current.distance + 1, relation: relation})
+		}
+	}
+	return neighbours, edges
+}
+
+// errorRateRise finds the first sample in [start, end] where the rate rose above the running baseline
+// The baseline is the lowest rate seen before the candidate sample
+func errorRateRise(samples []errorRateSample, start, end time.Time) (errorRateSample, float64, bool) {
+	baseline := -1.0
+	for _, sample := range samples {
+		if sample.at.After(end) {
+			break
+		}
+		if baseline >= 0 && !sample.at.Before(start) &&
+			sample.rate >= baseline*errorRateRiseFactor && sample.rate-baseline >= minErrorRateRise {
+			return sample, baseline, true
+		}
+		if baseline < 0 || sample.rate < baseline {
+			baseline = sample.rate
+		}
+	}
+	return errorRateSample{}, 0, false
+}
+
+// isErrorEvent reports whether an audit event signals a failure
+func isErrorEvent(event *models.AuditEvent) bool {
+	eventType := strings.ToLower(event.EventType)
+	for _, marker := range errorEventMarkers {
+		if strings.Contains(eventType, marker) {
+			return true
+		}
+	}
+	return metadataString(event, "error") != ""
+}
+
+func nodeStatusWeight(status string) float64 {
+	if status == "dead" {
+		return 1.0
+	}
+	return 0.8
+}
+
+func edgeStatusWeight(status string) float64 {
+	if status == "failed" {
+		return 0.9
+	}
+	return 0.7
+}
+
+func appendStatusSample(history []statusSample, sample statusSample) []statusSample {
+	history = append(history, sample)
+	if len(history) > maxStatusHistory {
+		history = history[len(history)-maxStatusHistory:]
+	}
+	return history
+}
+
+func leadTimeMs(first *time.Time, changedAt time.Time) float64 {
+	if first == nil {
+		return 0
+	}
+	return float64(changedAt.Sub(*first).Milliseconds())
+}
+
+func capEvidence(evidence []RootCauseEvidence) []RootCauseEvidence {
+	if len(evidence) > maxEvidencePerHypothesis {
+		return evidence[:maxEvidencePerHypothesis]
+	}
+	if evidence == nil {
+		return []RootCauseEvidence{}
+	}
+	return evidence
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+
+	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/entities"
+)
+
+func newTestRootCauseService(t *testing.T) *RootCauseService {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	topologyService := NewTopologyService(logger)
+	tracker := topologyService.Tracker()
+	ctx := context.Background()
+
+	// exchange -> trading-engine -> risk-monitor, plus an unrelated custodian
+	for _, id := range []string{"exchange", "trading-engine", "risk-monitor", "custodian"} {
+		if _, err := tracker.RegisterNode(ctx, entities.NewServiceNode(id, id, id, id)); err != nil {
+			t.Fatalf("Failed to register node: %v", err)
+		}
+	}
+	for _, conn := range []*entities.ServiceConnection{
+		entities.NewServiceConnection("exchange-engine", "exchange", "trading-engine", entities.ConnectionTypeGRPC),
+		entities.NewServiceConnection("engine-risk", "trading-engine", "risk-monitor", entities.ConnectionTypeDataFlow),
+	} {
+		if _, err := tracker.RegisterConnection(ctx, conn); err != nil {
+			t.Fatalf("Failed to register connection: %v", err)
+		}
+	}
+
+	return NewRootCauseService(NewAuditService(logger), topologyService, logger)
+}
+
+func TestRootCauseService_RanksEarlierDegradations(t *testing.T) {
+	service := newTestRootCauseService(t)
+	changedAt := time.Now()
+
+	service.RecordNodeStatus("exchange", entities.NodeStatusLive, changedAt.Add(-time.Hour))
+	service.RecordNodeStatus("exchange", entities.NodeStatusDead, changedAt.Add(-5*time.Minute))
+	service.RecordNodeStatus("custodian", entities.NodeStatusDead, changedAt.Add(-5*time.Minute))
+	service.RecordEdgeErrorRate("exchange-engine", 0.1, changedAt.Add(-20*time.Minute))
+	service.RecordEdgeErrorRate("exchange-engine", 0.1, changedAt.Add(-10*time.Minute))
+	service.RecordEdgeErrorRate("exchange-engine", 1.0, changedAt.Add(-8*time.Minute))
+	// Degrades after the engine, so it cannot be the cause
+	service.RecordEdgeStatus("engine-risk", entities.EdgeStatusDegraded, changedAt.Add(time.Minute))
+
+	analysis := service.RecordNodeStatus("trading-engine", entities.NodeStatusDegraded, changedAt)
+	if analysis == nil {
+		t.Fatal("Expected an analysis for the degraded node")
+	}
+	if len(analysis.Hypotheses) != 2 {
+		t.Fatalf("Expected 2 hypotheses, got %+v", analysis.Hypotheses)
+	}
+
+	edge := analysis.Hypotheses[0]
+	if edge.Kind != RootCauseKindEdge || edge.EdgeID != "exchange-engine" || edge.Relation != "upstream" || edge.Rank != 1 {
+		t.Errorf("Expected exchange-engine edge ranked first, got %+v", edge)
+	}
+	if edge.LeadTimeMs != float64((8 * time.Minute).Milliseconds()) {
+		t.Errorf("Expected the error rate rise 8m before the change, got %vms", edge.LeadTimeMs)
+	}
+
+	node := analysis.Hypotheses[1]
+	if node.Kind != RootCauseKindNode || node.NodeID != "exchange" || node.Distance != 1 {
+		t.Errorf("Expected upstream exchange node ranked second, got %+v", node)
+	}
+
+	if _, err := service.GetAnalysis(analysis.ID); err != nil {
+		t.Errorf("Expected analysis to be stored: %v", err)
+	}
+	if analyses := service.ListAnalyses("risk-monitor"); len(analyses) != 0 {
+		t.Errorf("Expected no analyses for risk-monitor, got %d", len(analyses))
+	}
+}
+
+func TestRootCauseService_IgnoresRepeatedStatus(t *testing.T) {
+	service := newTestRootCauseService(t)
+	now := time.Now()
+
+	if analysis := service.RecordNodeStatus("risk-monitor", entities.NodeStatusDead, now); analysis == nil {
+		t.Fatal("Expected an analysis for the first transition")
+	}
+	if analysis := service.RecordNodeStatus("risk-monitor", entities.NodeStatusDead, now.Add(time.Second)); analysis != nil {
+		t.Error("Expected no analysis when the status did not change")
+	}
+	if _, err := service.Analyze("unknown", now); err == nil {
+		t.Error("Expected an error for an unknown node")
+	}
+}
+
+func TestRootCauseService_AnalyzesTrackedDegradations(t *testing.T) {
+	service := newTestRootCauseService(t)
+	tracker := service.topologyService.Tracker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := service.Start(ctx, time.Hour); err != nil {
+		t.Fatalf("Failed to start root-cause service: %v", err)
+	}
+
+	// Given the exchange dies and then the trading engine degrades, both through the tracker
+	if _, err := tracker.UpdateNodeStatus(ctx, "exchange", entities.NodeStatusDead); err != nil {
+		t.Fatalf("Failed to update exchange: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, err := tracker.UpdateNodeStatus(ctx, "trading-engine", entities.NodeStatusDegraded); err != nil {
+		t.Fatalf("Failed to update trading-engine: %v", err)
+	}
+
+	// Then an analysis is triggered without anyone asking for it
+	var analyses []*RootCauseAnalysis
+	deadline := time.Now().Add(2 * time.Second)
+	for len(analyses) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the degradation to trigger an analysis")
+		}
+		time.Sleep(10 * time.Millisecond)
+		analyses = service.ListAnalyses("trading-engine")
+	}
+	incident := analyses[0]
+	if len(incident.Hypotheses) == 0 || incident.Hypotheses[0].NodeID != "exchange" {
+		t.Fatalf("Expected the dead exchange as the top hypothesis, got %+v", incident.Hypotheses)
+	}
+
+	// And once the exchange is removed, analyzing the incident again still uses the topology
+	// at incident time
+	if _, err := tracker.DeregisterNode(ctx, "exchange"); err != nil {
+		t.Fatalf("Failed to deregister exchange: %v", err)
+	}
+	again, err := service.Analyze("trading-engine", incident.ChangedAt)
+	if err != nil {
+		t.Fatalf("Failed to analyze: %v", err)
+	}
+	if again.SnapshotID != incident.SnapshotID || len(again.Hypotheses) == 0 || again.Hypotheses[0].NodeID != "exchange" {
+		t.Errorf("Expected the incident-time topology (%s), got %s with %+v", incident.SnapshotID, again.SnapshotID, again.Hypotheses)
+	}
+}
+
+func TestRootCauseService_RanksNeighbourErrorEvents(t *testing.T) {
+	service := newTestRootCauseService(t)
+	changedAt := time.Now()
+
+	// Given error events from the upstream exchange and the unrelated custodian, among a busy
+	// stream of routine events from the degraded node
+	events := []*models.AuditEvent{
+		newTypedEvent("x1", "exchange", "order_rejected", changedAt.Add(-3*time.Minute), `{}`),
+		newTypedEvent("x2", "exchange", "connection_timeout", changedAt.Add(-2*time.Minute), `{}`),
+		newTypedEvent("c1", "custodian", "settlement_failed", changedAt.Add(-time.Minute), `{}`),
+	}
+	for i := 0; i < eventPageSize*2; i++ {
+		events = append(events, newTypedEvent(fmt.Sprintf("t%d", i), "trading-engine", "order_submitted", changedAt.Add(-time.Duration(i)*time.Millisecond), `{}`))
+	}
+	service.auditService = NewAuditServiceWithDataAdapter(newMemoryDataAdapter(events...), service.logger)
+
+	analysis, err := service.Analyze("trading-engine", changedAt)
+	if err != nil {
+		t.Fatalf("Failed to analyze: %v", err)
+	}
+
+	// Then only the neighbour's errors form a hypothesis
+	var found []string
+	for _, hypothesis := range analysis.Hypotheses {
+		if hypothesis.Kind == RootCauseKindAuditEvents {
+			found = append(found, fmt.Sprintf("%s:%d", hypothesis.ServiceName, len(hypothesis.Evidence)))
+		}
+	}
+	if fmt.Sprint(found) != "[exchange:2]" {
+		t.Errorf("Expected one hypothesis from the exchange's two errors, got %v", found)
+	}
+}
+
+func TestErrorRateRise(t *testing.T) {
+	base := time.Now()
+	samples := []errorRateSample{
+		{rate: 0.5, at: base},
+		{rate: 0.2, at: base.Add(time.Minute)},
+		{rate: 0.3, at: base.Add(2 * time.Minute)},
+		{rate: 0.45, at: base.Add(3 * time.Minute)},
+	}
+
+	rise, baseline, ok := errorRateRise(samples, base.Add(30*time.Second), base.Add(5*time.Minute))
+	if !ok || baseline != 0.2 || !rise.at.Equal(base.Add(3*time.Minute)) {
+		t.Errorf("Expected rise from 0.2 at +3m, got %+v from %v (ok=%v)", rise, baseline, ok)
+	}
+}
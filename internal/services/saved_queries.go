@@ -0,0 +1,778 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+// Saved query evaluation modes
+const (
+	SavedQueryModeContinuous = "continuous" // evaluated against each accepted event
+	SavedQueryModeScheduled  = "scheduled"  // evaluated over stored events every interval
+)
+
+const (
+	// maxNotificationEvents bounds the matching events sampled into a notification
+	maxNotificationEvents = 10
+	// maxQueryNotifications bounds the notification history kept per query
+	maxQueryNotifications = 100
+	notificationQueueSize = 1000
+	maxSinkAttempts       = 3
+	// defaultManualRunWindow is evaluated by manual runs of queries without a window
+	defaultManualRunWindow    = time.Hour
+	savedQueryKeyPrefix       = "audit:saved_query:"
+	notificationSinkKeyPrefix = "audit:notification_sink:"
+)
+
+// ErrNotFound marks lookups of saved queries or sinks that do not exist
+var ErrNotFound = errors.New("not found")
+
+// SavedQueryStore persists saved queries and sinks; the data adapter's cache repository satisfies it
+type SavedQueryStore interface {
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Get(ctx context.Context, key string, dest interface{}) error
+	Delete(ctx context.Context, key string) error
+	GetKeysByPattern(ctx context.Context, pattern string) ([]string, error)
+}
+
+// SavedQuery is a filter expression that notifies sinks when at least Threshold events match
+// Continuous queries count matches over a sliding WindowSeconds (0 fires per event); scheduled
+// queries search the last WindowSeconds (default IntervalSeconds) every IntervalSeconds
+// Notifications with the same DedupKey (a template over the notification, default the query ID)
+// are dropped within DedupWindowSeconds, and none are sent within CooldownSeconds of the last one
+type SavedQuery struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Description        string   `json:"description,omitempty"`
+	Filter             string   `json:"filter"`
+	Mode               string   `json:"mode"`
+	IntervalSeconds    int      `json:"interval_seconds,omitempty"`
+	WindowSeconds      int      `json:"window_seconds,omitempty"`
+	Threshold          int      `json:"threshold,omitempty"`
+	Sinks              []string `json:"sinks"`
+	DedupKey           string   `json:"dedup_key,omitempty"`
+	DedupWindowSeconds int      `json:"dedup_window_seconds,omitempty"`
+	CooldownSeconds    int      `json:"cooldown_seconds,omitempty"`
+	Paused             bool     `json:"paused,omitempty"`
+}
+
+// Validate checks the query and applies defaults
+func (q *SavedQuery) Validate() error {
+	if q.ID == "" {
+		return fmt.Errorf("saved query id is required")
+	}
+	if q.Name == "" {
+		q.Name = q.ID
+	}
+	if _, err := CompileEventFilter(q.Filter); err != nil {
+		return fmt.Errorf("saved query %s: %w", q.ID, err)
+	}
+	switch q.Mode {
+	case SavedQueryModeContinuous:
+	case SavedQueryModeScheduled:
+		if q.IntervalSeconds <= 0 {
+			return fmt.Errorf("saved query %s: interval_seconds must be positive for scheduled queries", q.ID)
+		}
+	default:
+		return fmt.Errorf("saved query %s: mode must be continuous or scheduled", q.ID)
+	}
+	if q.WindowSeconds < 0 || q.Threshold < 0 || q.DedupWindowSeconds < 0 || q.CooldownSeconds < 0 {
+		return fmt.Errorf("saved query %s: durations and threshold must not be negative", q.ID)
+	}
+	if q.Threshold == 0 {
+		q.Threshold = 1
+	}
+	if len(q.Sinks) == 0 {
+		return fmt.Errorf("saved query %s: at least one sink is required", q.ID)
+	}
+	if _, err := template.New(q.ID).Funcs(sinkTemplateFuncs).Parse(q.DedupKey); err != nil {
+		return fmt.Errorf("saved query %s: invalid dedup_key template: %w", q.ID, err)
+	}
+	return nil
+}
+
+// window is the lookback of scheduled runs and the sliding window of continuous queries
+func (q *SavedQuery) window() time.Duration {
+	if q.WindowSeconds == 0 && q.Mode == SavedQueryModeScheduled {
+		return time.Duration(q.IntervalSeconds) * time.Second
+	}
+	return time.Duration(q.WindowSeconds) * time.Second
+}
+
+// SavedQueryStatus is a saved query with its evaluation state
+type SavedQueryStatus struct {
+	SavedQuery
+	LastEvaluatedAt *time.Time `json:"last_evaluated_at,omitempty"`
+	LastFiredAt     *time.Time `json:"last_fired_at,omitempty"`
+	NextRunAt       *time.Time `json:"next_run_at,omitempty"`
+	Suppressed      int        `json:"suppressed"`
+	Notifications   int        `json:"notifications"`
+}
+
+// SavedQueryRun is the outcome of evaluating a query over a window
+type SavedQueryRun struct {
+	QueryID      string        `json:"query_id"`
+	WindowStart  time.Time     `json:"window_start"`
+	WindowEnd    time.Time     `json:"window_end"`
+	MatchCount   int           `json:"match_count"`
+	Fired        bool          `json:"fired"`
+	Notification *Notification `json:"notification,omitempty"`
+}
+
+// savedQueryState is the runtime state of one query
+type savedQueryState struct {
+	query  *SavedQuery
+	filter *EventFilter
+	dedup  *template.Template
+
+	recent        []time.Time // continuous match times within the window
+	recentEvents  []*models.AuditEvent
+	suppressed    int
+	dedupSeen     map[string]time.Time
+	lastFired     time.Time
+	lastEvaluated time.Time
+	nextRun       time.Time
+	history       []*Notification
+}
+
+// SavedQueryService evaluates saved queries and delivers notifications to sinks
+type SavedQueryService struct {
+	auditService *AuditService
+	store        SavedQueryStore
+	logger       *logrus.Logger
+	sender       *sinkSender
+	queue        chan *Notification
+
+	mu      sync.RWMutex
+	queries map[string]*savedQueryState
+	sinks   map[string]*NotificationSink
+	nextID  int64
+}
+
+// NewSavedQueryService creates a service fed by the audit service's event observers
+// File sinks write inside fileDir; store may be nil, in which case queries and sinks are kept in memory only
+func NewSavedQueryService(auditService *AuditService, store SavedQueryStore, fileDir string, logger *logrus.Logger) *SavedQueryService {
+	s := &SavedQueryService{
+		auditService: auditService,
+		store:        store,
+		logger:       logger,
+		sender:       newSinkSender(fileDir),
+		queue:        make(chan *Notification, notificationQueueSize),
+		queries:      make(map[string]*savedQueryState),
+		sinks:        make(map[string]*NotificationSink),
+	}
+	if auditService != nil {
+		auditService.AddEventObserver(s.observeEvent)
+	}
+	return s
+}
+
+// SetAllowedSinkHosts restricts webhook and Slack sinks to the given hosts, which may then
+// include internal ones; without it sinks may only reach public addresses
+func (s *SavedQueryService) SetAllowedSinkHosts(hosts []string) {
+	allowed := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		allowed[strings.ToLower(host)] = true
+	}
+	s.sender.allowedHosts = allowed
+}
+
+// LoadFromFile registers sinks and queries from a JSON file ({"sinks": [...], "queries": [...]})
+// A missing file is not an error
+func (s *SavedQueryService) LoadFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.logger.WithField("path", path).Debug("No saved query config found")
+			return nil
+		}
+		return fmt.Errorf("failed to read saved query config: %w", err)
+	}
+
+	var config struct {
+		Sinks   []*NotificationSink `json:"sinks"`
+		Queries []*SavedQuery       `json:"queries"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse saved query config: %w", err)
+	}
+	for _, sink := range config.Sinks {
+		if err := s.registerSink(sink, false); err != nil {
+			return err
+		}
+	}
+	for _, query := range config.Queries {
+		if err := s.registerQuery(query, false); err != nil {
+			return err
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"path":    path,
+		"sinks":   len(config.Sinks),
+		"queries": len(config.Queries),
+	}).Info("Loaded saved queries")
+	return nil
+}
+
+// Start loads persisted sinks and queries, then runs scheduled queries and deliveries until ctx is done
+func (s *SavedQueryService) Start(ctx context.Context) {
+	s.restore(ctx)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case notification := <-s.queue:
+				s.deliver(ctx, notification)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.runDue(ctx, now)
+			}
+		}
+	}()
+}
+
+// RegisterSink validates and stores a sink (replacing any sink with the same ID)
+func (s *SavedQueryService) RegisterSink(sink *NotificationSink) error {
+	return s.registerSink(sink, true)
+}
+
+func (s *SavedQueryService) registerSink(sink *NotificationSink, persist bool) error {
+	if sink == nil {
+		return fmt.Errorf("sink cannot be nil")
+	}
+	if err := sink.Validate(); err != nil {
+		return err
+	}
+	if sink.Type != SinkTypeFile {
+		if err := s.sender.checkHost(sink.URL); err != nil {
+			return fmt.Errorf("sink %s: %w", sink.ID, err)
+		}
+	}
+
+	s.mu.Lock()
+	s.sinks[sink.ID] = sink
+	s.mu.Unlock()
+
+	if persist {
+		s.persist(notificationSinkKeyPrefix+sink.ID, sink)
+	}
+	return nil
+}
+
+// DeleteSink removes a sink that no saved query uses
+func (s *SavedQueryService) DeleteSink(id string) error {
+	s.mu.Lock()
+	if _, exists := s.sinks[id]; !exists {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: notification sink %s", ErrNotFound, id)
+	}
+	for _, state := range s.queries {
+		if containsString(state.query.Sinks, id) {
+			s.mu.Unlock()
+			return fmt.Errorf("notification sink %s is used by saved query %s", id, state.query.ID)
+		}
+	}
+	delete(s.sinks, id)
+	s.mu.Unlock()
+
+	s.unpersist(notificationSinkKeyPrefix + id)
+	return nil
+}
+
+// ListSinks returns the registered sinks ordered by ID
+func (s *SavedQueryService) ListSinks() []NotificationSink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sinks := make([]NotificationSink, 0, len(s.sinks))
+	for _, sink := range s.sinks {
+		sinks = append(sinks, *sink)
+	}
+	sort.Slice(sinks, func(i, j int) bool { return sinks[i].ID < sinks[j].ID })
+	return sinks
+}
+
+// TestSink sends a sample notification to a sink synchronously
+func (s *SavedQueryService) TestSink(ctx context.Context, id string) error {
+	s.mu.RLock()
+	sink, exists := s.sinks[id]
+	s.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("%w: notification sink %s", ErrNotFound, id)
+	}
+
+	now := time.Now().UTC()
+	notification := &Notification{
+		ID:          "test",
+		QueryID:     "test",
+		QueryName:   "Sink test",
+		Mode:        "test",
+		FiredAt:     now,
+		WindowStart: now,
+		WindowEnd:   now,
+		Events:      []*models.AuditEvent{},
+		Deliveries:  []SinkDelivery{},
+	}
+	return s.sender.send(ctx, sink, notification)
+}
+
+// RegisterQuery validates and stores a saved query (replacing any query with the same ID)
+func (s *SavedQueryService) RegisterQuery(query *SavedQuery) error {
+	return s.registerQuery(query, true)
+}
+
+func (s *SavedQueryService) registerQuery(query *SavedQuery, persist bool) error {
+	if query == nil {
+		return fmt.Errorf("saved query cannot be nil")
+	}
+	if err := query.Validate(); err != nil {
+		return err
+	}
+	filter, _ := CompileEventFilter(query.Filter)
+	dedup := template.Must(template.New(query.ID).Funcs(sinkTemplateFuncs).Option("missingkey=zero").Parse(query.DedupKey))
+
+	s.mu.Lock()
+	for _, sinkID := range query.Sinks {
+		if _, exists := s.sinks[sinkID]; !exists {
+			s.mu.Unlock()
+			return fmt.Errorf("saved query %s: unknown sink %s", query.ID, sinkID)
+		}
+	}
+	state := &savedQueryState{
+		query:     query,
+		filter:    filter,
+		dedup:     dedup,
+		dedupSeen: make(map[string]time.Time),
+	}
+	if query.Mode == SavedQueryModeScheduled {
+		state.nextRun = time.Now().Add(time.Duration(query.IntervalSeconds) * time.Second)
+	}
+	// Re-registering keeps notification history and cooldown state
+	if previous, exists := s.queries[query.ID]; exists {
+		state.history = previous.history
+		state.lastFired = previous.lastFired
+		state.dedupSeen = previous.dedupSeen
+	}
+	s.queries[query.ID] = state
+	s.mu.Unlock()
+
+	if persist {
+		s.persist(savedQueryKeyPrefix+query.ID, query)
+	}
+	return nil
+}
+
+// DeleteQuery removes a saved query
+func (s *SavedQueryService) DeleteQuery(id string) error {
+	s.mu.Lock()
+	if _, exists := s.queries[id]; !exists {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: saved query %s", ErrNotFound, id)
+	}
+	delete(s.queries, id)
+	s.mu.Unlock()
+
+	s.unpersist(savedQueryKeyPrefix + id)
+	return nil
+}
+
+// GetQuery returns a saved query with its evaluation state
+func (s *SavedQueryService) GetQuery(id string) (*SavedQueryStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, exists := s.queries[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: saved query %s", ErrNotFound, id)
+	}
+	status := state.status()
+	return &status, nil
+}
+
+// ListQueries returns all saved queries ordered by ID
+func (s *SavedQueryService) ListQueries() []SavedQueryStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	queries := make([]SavedQueryStatus, 0, len(s.queries))
+	for _, state := range s.queries {
+		queries = append(queries, state.status())
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].ID < queries[j].ID })
+	return queries
+}
+
+func (st *savedQueryState) status() SavedQueryStatus {
+	status := SavedQueryStatus{
+		SavedQuery:    *st.query,
+		Suppressed:    st.suppressed,
+		Notifications: len(st.history),
+	}
+	if !st.lastEvaluated.IsZero() {
+		evaluated := st.lastEvaluated
+		status.LastEvaluatedAt = &evaluated
+	}
+	if !st.lastFired.IsZero() {
+		fired := st.lastFired
+		status.LastFiredAt = &fired
+	}
+	if !st.nextRun.IsZero() && !st.query.Paused {
+		next := st.nextRun
+		status.NextRunAt = &next
+	}
+	return status
+}
+
+// GetNotifications returns the notifications a query has fired, newest first
+func (s *SavedQueryService) GetNotifications(id string) ([]Notification, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, exists := s.queries[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: saved query %s", ErrNotFound, id)
+	}
+	notifications := make([]Notification, 0, len(state.history))
+	for i := len(state.history) - 1; i >= 0; i-- {
+		notification := *state.history[i]
+		notification.Deliveries = append([]SinkDelivery{}, notification.Deliveries...)
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+// RunQuery evaluates a query over its window now, firing a notification if it matches
+// Queries without a window are evaluated over the last hour
+func (s *SavedQueryService) RunQuery(ctx context.Context, id string) (*SavedQueryRun, error) {
+	s.mu.RLock()
+	state, exists := s.queries[id]
+	s.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: saved query %s", ErrNotFound, id)
+	}
+	return s.evaluate(ctx, state, time.Now())
+}
+
+// runDue evaluates scheduled queries whose next run has passed
+func (s *SavedQueryService) runDue(ctx context.Context, now time.Time) {
+	var due []*savedQueryState
+	s.mu.Lock()
+	for _, state := range s.queries {
+		if state.query.Mode == SavedQueryModeScheduled && !state.query.Paused && !state.nextRun.After(now) {
+			state.nextRun = now.Add(time.Duration(state.query.IntervalSeconds) * time.Second)
+			due = append(due, state)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, state := range due {
+		if _, err := s.evaluate(ctx, state, now); err != nil {
+			s.logger.WithError(err).WithField("query_id", state.query.ID).Warn("Scheduled saved query failed")
+		}
+	}
+}
+
+// evaluate searches stored events in the query's window ending at now
+func (s *SavedQueryService) evaluate(ctx context.Context, state *savedQueryState, now time.Time) (*SavedQueryRun, error) {
+	window := state.query.window()
+	if window == 0 {
+		window = defaultManualRunWindow
+	}
+	run := &SavedQueryRun{QueryID: state.query.ID, WindowStart: now.Add(-window), WindowEnd: now}
+
+	var sample []*models.AuditEvent
+	scanned := 0
+	pushdown, _, _ := state.filter.pushdown()
+	err := s.auditService.WalkEventsInWindow(ctx, pushdown, run.WindowStart, run.WindowEnd, func(page []*models.AuditEvent) error {
+		for _, event := range page {
+			scanned++
+			if state.filter.Matches(event) {
+				run.MatchCount++
+				if len(sample) < maxNotificationEvents {
+					sample = append(sample, event)
+				}
+			}
+			if scanned >= maxSearchScan {
+				return errStopWalk
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	state.lastEvaluated = now
+	if run.MatchCount >= state.query.Threshold && run.MatchCount > 0 {
+		notification := s.newNotificationLocked(state, now, run.MatchCount, sample, run.WindowStart, run.WindowEnd)
+		if s.fireLocked(state, notification, now) {
+			// The caller gets a copy since delivery results are appended to the original
+			copied := *notification
+			run.Fired = true
+			run.Notification = &copied
+			defer s.enqueue(notification)
+		}
+	}
+	s.mu.Unlock()
+
+	return run, nil
+}
+
+// observeEvent evaluates continuous queries against a newly accepted event
+func (s *SavedQueryService) observeEvent(event *models.AuditEvent) {
+	now := time.Now()
+	var fired []*Notification
+
+	s.mu.Lock()
+	for _, state := range s.queries {
+		if state.query.Mode != SavedQueryModeContinuous || state.query.Paused || !state.filter.Matches(event) {
+			continue
+		}
+		state.lastEvaluated = now
+
+		// Keep a copy: observers share the event and it may change after this call
+		copied := *event
+		state.recent = append(state.recent, now)
+		state.recentEvents = append(state.recentEvents, &copied)
+		if window := state.query.window(); window > 0 {
+			cutoff := now.Add(-window)
+			drop := 0
+			for drop < len(state.recent) && state.recent[drop].Before(cutoff) {
+				drop++
+			}
+			state.recent = state.recent[drop:]
+			state.recentEvents = state.recentEvents[drop:]
+		}
+		if len(state.recent) < state.query.Threshold {
+			continue
+		}
+
+		sample := state.recentEvents[max(0, len(state.recentEvents)-maxNotificationEvents):]
+		notification := s.newNotificationLocked(state, now, len(state.recent), sample, state.recent[0], now)
+		state.recent = nil
+		state.recentEvents = nil
+		if s.fireLocked(state, notification, now) {
+			fired = append(fired, notification)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, notification := range fired {
+		s.enqueue(notification)
+	}
+}
+
+func (s *SavedQueryService) newNotificationLocked(state *savedQueryState, now time.Time, matches int, sample []*models.AuditEvent, start, end time.Time) *Notification {
+	s.nextID++
+	return &Notification{
+		ID:          fmt.Sprintf("notif-%d", s.nextID),
+		QueryID:     state.query.ID,
+		QueryName:   state.query.Name,
+		Filter:      state.filter.String(),
+		Mode:        state.query.Mode,
+		FiredAt:     now.UTC(),
+		MatchCount:  matches,
+		WindowStart: start,
+		WindowEnd:   end,
+		Events:      append([]*models.AuditEvent{}, sample...),
+		Deliveries:  []SinkDelivery{},
+	}
+}
+
+// fireLocked applies cooldown and deduplication, recording the notification if it may be sent
+// Matches that are not notified are counted into the next notification's Suppressed
+func (s *SavedQueryService) fireLocked(state *savedQueryState, notification *Notification, now time.Time) bool {
+	query := state.query
+
+	notification.DedupKey = query.ID
+	var key bytes.Buffer
+	if err := state.dedup.Execute(&key, notification); err != nil {
+		s.logger.WithError(err).WithField("query_id", query.ID).Warn("Failed to render dedup key, using query id")
+	} else if key.Len() > 0 {
+		notification.DedupKey = key.String()
+	}
+
+	cooldown := time.Duration(query.CooldownSeconds) * time.Second
+	if !state.lastFired.IsZero() && now.Before(state.lastFired.Add(cooldown)) {
+		state.suppressed += notification.MatchCount
+		return false
+	}
+	dedupWindow := time.Duration(query.DedupWindowSeconds) * time.Second
+	for dedupKey, seen := range state.dedupSeen {
+		if !now.Before(seen.Add(dedupWindow)) {
+			delete(state.dedupSeen, dedupKey)
+		}
+	}
+	if _, duplicate := state.dedupSeen[notification.DedupKey]; duplicate {
+		state.suppressed += notification.MatchCount
+		return false
+	}
+
+	notification.Suppressed = state.suppressed
+	state.suppressed = 0
+	state.lastFired = now
+	if dedupWindow > 0 {
+		state.dedupSeen[notification.DedupKey] = now
+	}
+	state.history = append(state.history, notification)
+	if len(state.history) > maxQueryNotifications {
+		state.history = state.history[len(state.history)-maxQueryNotifications:]
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"query_id":        query.ID,
+		"notification_id": notification.ID,
+		"matches":         notification.MatchCount,
+		"suppressed":      notification.Suppressed,
+	}).Info("Saved query fired")
+	return true
+}
+
+// enqueue hands a notification to the delivery worker without blocking event ingestion
+func (s *SavedQueryService) enqueue(notification *Notification) {
+	select {
+	case s.queue <- notification:
+	default:
+		s.logger.WithField("notification_id", notification.ID).Error("Notification queue full, dropping notification")
+	}
+}
+
+// deliver sends a notification to each of its query's sinks, retrying failures
+func (s *SavedQueryService) deliver(ctx context.Context, notification *Notification) {
+	s.mu.RLock()
+	var sinkIDs []string
+	if state, exists := s.queries[notification.QueryID]; exists {
+		sinkIDs = append(sinkIDs, state.query.Sinks...)
+	}
+	s.mu.RUnlock()
+
+	for _, sinkID := range sinkIDs {
+		s.mu.RLock()
+		sink, exists := s.sinks[sinkID]
+		s.mu.RUnlock()
+
+		delivery := SinkDelivery{SinkID: sinkID}
+		if !exists {
+			delivery.Error = "sink not found"
+		}
+		for exists && delivery.Attempts < maxSinkAttempts {
+			if delivery.Attempts > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(time.Duration(delivery.Attempts) * time.Second):
+				}
+			}
+			delivery.Attempts++
+			err := s.sender.send(ctx, sink, notification)
+			if err == nil {
+				delivery.Success = true
+				delivery.Error = ""
+				break
+			}
+			delivery.Error = err.Error()
+		}
+		delivery.SentAt = time.Now().UTC()
+
+		if !delivery.Success {
+			s.logger.WithFields(logrus.Fields{
+				"notification_id": notification.ID,
+				"sink_id":         sinkID,
+				"attempts":        delivery.Attempts,
+				"error":           delivery.Error,
+			}).Warn("Notification delivery failed")
+		}
+
+		s.mu.Lock()
+		notification.Deliveries = append(notification.Deliveries, delivery)
+		s.mu.Unlock()
+	}
+}
+
+// persist stores a sink or query best-effort; the in-memory copy stays authoritative
+func (s *SavedQueryService) persist(key string, value interface{}) {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.Set(context.Background(), key, value, 0); err != nil {
+		s.logger.WithError(err).WithField("key", key).Warn("Failed to persist saved query config")
+	}
+}
+
+func (s *SavedQueryService) unpersist(key string) {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.Delete(context.Background(), key); err != nil {
+		s.logger.WithError(err).WithField("key", key).Warn("Failed to delete persisted saved query config")
+	}
+}
+
+// restore registers persisted sinks before the queries that reference them
+func (s *SavedQueryService) restore(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+
+	sinkKeys, err := s.store.GetKeysByPattern(ctx, notificationSinkKeyPrefix+"*")
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to list persisted notification sinks")
+		return
+	}
+	for _, key := range sinkKeys {
+		var sink NotificationSink
+		if err := s.store.Get(ctx, key, &sink); err != nil {
+			continue
+		}
+		if err := s.registerSink(&sink, false); err != nil {
+			s.logger.WithError(err).WithField("key", key).Warn("Skipping invalid persisted notification sink")
+		}
+	}
+
+	queryKeys, err := s.store.GetKeysByPattern(ctx, savedQueryKeyPrefix+"*")
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to list persisted saved queries")
+		return
+	}
+	for _, key := range queryKeys {
+		var query SavedQuery
+		if err := s.store.Get(ctx, key, &query); err != nil {
+			continue
+		}
+		if err := s.registerQuery(&query, false); err != nil {
+			s.logger.WithError(err).WithField("key", key).Warn("Skipping invalid persisted saved query")
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"sinks":   len(sinkKeys),
+		"queries": len(queryKeys),
+	}).Info("Restored saved queries")
+}
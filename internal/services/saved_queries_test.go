@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quantfidential/trading-ecosystem/audit-data-adapter-go/pkg/models"
+)
+
+func newTestSavedQueryService(t *testing.T) (*SavedQueryService, string) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	dir := t.TempDir()
+	service := NewSavedQueryService(NewAuditService(logger), nil, dir, logger)
+
+	if err := service.RegisterSink(&NotificationSink{ID: "file", Type: SinkTypeFile, Path: "alerts/notifications.log"}); err != nil {
+		t.Fatalf("Failed to register sink: %v", err)
+	}
+	return service, filepath.Join(dir, "alerts", "notifications.log")
+}
+
+func ingest(t *testing.T, service *SavedQueryService, serviceName, eventType string) {
+	t.Helper()
+	if err := service.auditService.IngestEvent(&models.AuditEvent{ServiceName: serviceName, EventType: eventType}); err != nil {
+		t.Fatalf("Failed to ingest: %v", err)
+	}
+}
+
+func TestSavedQuery_Validate(t *testing.T) {
+	service, _ := newTestSavedQueryService(t)
+
+	for name, query := range map[string]*SavedQuery{
+		"mode":     {ID: "q", Filter: "", Mode: "hourly", Sinks: []string{"file"}},
+		"interval": {ID: "q", Mode: SavedQueryModeScheduled, Sinks: []string{"file"}},
+		"filter":   {ID: "q", Filter: "service =", Mode: SavedQueryModeContinuous, Sinks: []string{"file"}},
+		"template": {ID: "q", Mode: SavedQueryModeContinuous, Sinks: []string{"file"}, DedupKey: "{{.Event"},
+		"sink":     {ID: "q", Mode: SavedQueryModeContinuous, Sinks: []string{"pager"}},
+		"no sinks": {ID: "q", Mode: SavedQueryModeContinuous},
+	} {
+		if err := service.RegisterQuery(query); err == nil {
+			t.Errorf("%s: expected the query to be rejected", name)
+		}
+	}
+	if err := service.RegisterSink(&NotificationSink{ID: "hook", Type: SinkTypeWebhook, URL: "ftp://example"}); err == nil {
+		t.Errorf("Expected a non-http webhook URL to be rejected")
+	}
+	if err := service.RegisterSink(&NotificationSink{ID: "escape", Type: SinkTypeFile, Path: "../etc/passwd"}); err == nil {
+		t.Errorf("Expected a file sink outside the notification directory to be rejected")
+	}
+}
+
+func TestSavedQuery_ThresholdAndCooldown(t *testing.T) {
+	service, path := newTestSavedQueryService(t)
+	err := service.RegisterQuery(&SavedQuery{
+		ID:              "rejects",
+		Filter:          "event_type = order_rejected",
+		Mode:            SavedQueryModeContinuous,
+		Threshold:       2,
+		WindowSeconds:   60,
+		CooldownSeconds: 3600,
+		Sinks:           []string{"file"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register query: %v", err)
+	}
+
+	ingest(t, service, "exchange", "order_rejected")
+	ingest(t, service, "exchange", "order_filled")
+	if len(service.queue) != 0 {
+		t.Fatalf("Expected no notification below the threshold")
+	}
+	ingest(t, service, "exchange", "order_rejected")
+	if len(service.queue) != 1 {
+		t.Fatalf("Expected a notification at the threshold")
+	}
+
+	// Within the cooldown further matches are only counted
+	for i := 0; i < 4; i++ {
+		ingest(t, service, "exchange", "order_rejected")
+	}
+	status, _ := service.GetQuery("rejects")
+	if len(service.queue) != 1 || status.Suppressed != 4 || status.Notifications != 1 {
+		t.Errorf("Expected matches to be suppressed during the cooldown, got %+v", status)
+	}
+
+	service.deliver(context.Background(), <-service.queue)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read sink file: %v", err)
+	}
+	var written Notification
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Expected one JSON line, got %q", data)
+	}
+	if written.QueryID != "rejects" || written.MatchCount != 2 || len(written.Events) != 2 {
+		t.Errorf("Unexpected notification: %+v", written)
+	}
+
+	notifications, _ := service.GetNotifications("rejects")
+	if len(notifications[0].Deliveries) != 1 || !notifications[0].Deliveries[0].Success {
+		t.Errorf("Expected a successful delivery to be recorded, got %+v", notifications[0].Deliveries)
+	}
+}
+
+func TestSavedQuery_DedupKey(t *testing.T) {
+	service, _ := newTestSavedQueryService(t)
+	err := service.RegisterQuery(&SavedQuery{
+		ID:                 "errors",
+		Filter:             "event_type = error",
+		Mode:               SavedQueryModeContinuous,
+		DedupKey:           "{{.Event.ServiceName}}",
+		DedupWindowSeconds: 3600,
+		Sinks:              []string{"file"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register query: %v", err)
+	}
+
+	ingest(t, service, "exchange", "error")
+	ingest(t, service, "exchange", "error")
+	ingest(t, service, "custodian", "error")
+
+	notifications, _ := service.GetNotifications("errors")
+	if len(notifications) != 2 {
+		t.Fatalf("Expected one notification per service, got %d", len(notifications))
+	}
+	if notifications[0].DedupKey != "custodian" || notifications[0].Suppressed != 1 {
+		t.Errorf("Expected the custodian notification to report the suppressed duplicate, got %+v", notifications[0])
+	}
+}
+
+func TestNotificationSinks_WebhookAndSlack(t *testing.T) {
+	var mu sync.Mutex
+	bodies := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies[r.URL.Path] = string(body)
+		bodies[r.URL.Path+"/auth"] = r.Header.Get("Authorization")
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	webhook := &NotificationSink{
+		ID:       "hook",
+		Type:     SinkTypeWebhook,
+		URL:      server.URL + "/hook",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Template: `{"query": {{json .QueryID}}, "matches": {{.MatchCount}}, "first": {{json .Event.ID}}}`,
+	}
+	slack := &NotificationSink{ID: "slack", Type: SinkTypeSlack, URL: server.URL + "/slack"}
+	for _, sink := range []*NotificationSink{webhook, slack} {
+		if err := sink.Validate(); err != nil {
+			t.Fatalf("Invalid sink: %v", err)
+		}
+	}
+
+	notification := &Notification{
+		QueryID:    "rejects",
+		QueryName:  "Rejected orders",
+		MatchCount: 3,
+		Suppressed: 1,
+		Events:     []*models.AuditEvent{{ID: "e1", ServiceName: "exchange", EventType: "order_rejected"}},
+	}
+	sender := newSinkSender(t.TempDir())
+	sender.allowedHosts = map[string]bool{"127.0.0.1": true}
+	for _, sink := range []*NotificationSink{webhook, slack} {
+		if err := sender.send(context.Background(), sink, notification); err != nil {
+			t.Fatalf("Failed to send to %s: %v", sink.ID, err)
+		}
+	}
+
+	if bodies["/hook"] != `{"query": "rejects", "matches": 3, "first": "e1"}` || bodies["/hook/auth"] != "Bearer token" {
+		t.Errorf("Unexpected webhook request: %q %q", bodies["/hook"], bodies["/hook/auth"])
+	}
+	var message map[string]string
+	if err := json.Unmarshal([]byte(bodies["/slack"]), &message); err != nil {
+		t.Fatalf("Expected Slack JSON, got %q", bodies["/slack"])
+	}
+	if !strings.Contains(message["text"], "*Rejected orders* matched 3 event(s) (1 suppressed)") || !strings.Contains(message["text"], "exchange order_rejected e1") {
+		t.Errorf("Unexpected Slack text: %q", message["text"])
+	}
+}
+
+func TestNotificationSinks_RefuseInternalTargets(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer server.Close()
+
+	sink := &NotificationSink{ID: "hook", Type: SinkTypeWebhook, URL: server.URL + "/hook"}
+	if err := sink.Validate(); err != nil {
+		t.Fatalf("Invalid sink: %v", err)
+	}
+	notification := &Notification{QueryID: "rejects"}
+
+	// Without an allow-list, loopback and private addresses are refused
+	sender := newSinkSender(t.TempDir())
+	if err := sender.send(context.Background(), sink, notification); err == nil || !strings.Contains(err.Error(), "not a public address") {
+		t.Errorf("Expected the loopback sink to be refused, got %v", err)
+	}
+
+	// An allowed host is reached, but its redirect is not followed
+	sender.allowedHosts = map[string]bool{"127.0.0.1": true}
+	if err := sender.send(context.Background(), sink, notification); err == nil || redirected {
+		t.Errorf("Expected the redirect to fail delivery without being followed, got %v (followed=%v)", err, redirected)
+	}
+
+	// And sinks outside the allow-list cannot be registered
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+	service := NewSavedQueryService(nil, nil, t.TempDir(), logger)
+	service.SetAllowedSinkHosts([]string{"hooks.example.com"})
+	if err := service.RegisterSink(&NotificationSink{ID: "internal", Type: SinkTypeWebhook, URL: "http://169.254.169.254/latest"}); err == nil {
+		t.Error("Expected a sink outside the allowed hosts to be rejected")
+	}
+	if err := service.RegisterSink(&NotificationSink{ID: "allowed", Type: SinkTypeWebhook, URL: "https://hooks.example.com/alerts"}); err != nil {
+		t.Errorf("Expected an allowed sink to register, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/application/usecases/topology"
 	"github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/ports"
+	domainservices "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/domain/services"
 	infratopology "github.com/quantfidential/trading-ecosystem/audit-correlator-go/internal/infrastructure/topology"
 )
 
@@ -19,6 +20,9 @@ type TopologyService struct {
 	changePublisher  ports.TopologyChangePublisher
 	metricsCollector ports.MetricsCollector
 
+	// Domain services; all topology changes go through the tracker so they are versioned and published
+	tracker *domainservices.DefaultTopologyTracker
+
 	// Use Cases (Application layer)
 	getTopologyStructure *topology.GetTopologyStructureUseCase
 	getNodeMetadata      *topology.GetNodeMetadataUseCase
@@ -35,7 +39,14 @@ func NewTopologyService(logger *logrus.Logger) *TopologyService {
 	topologyRepo := infratopology.NewMemoryTopologyRepository()
 	metadataRepo := infratopology.NewMemoryMetadataRepository()
 	changePublisher := infratopology.NewChannelChangePublisher()
-	metricsCollector := infratopology.NewMockMetricsCollector()
+	metricsCollector := infratopology.NewPrometheusMetricsCollector(topologyRepo, infratopology.PrometheusCollectorOptions{})
+	history := infratopology.NewMemoryTopologyHistory()
+
+	// Initialize domain layer
+	tracker, err := domainservices.NewTopologyTracker(topologyRepo, metadataRepo, changePublisher, history)
+	if err != nil {
+		logger.WithError(err).Error("Topology history starts empty; time-travel queries before the next change will fail")
+	}
 
 	// Initialize application layer (use cases)
 	getTopologyStructure := topology.NewGetTopologyStructureUseCase(topologyRepo)
@@ -49,6 +60,7 @@ func NewTopologyService(logger *logrus.Logger) *TopologyService {
 		metadataRepo:         metadataRepo,
 		changePublisher:      changePublisher,
 		metricsCollector:     metricsCollector,
+		tracker:              tracker,
 		getTopologyStructure: getTopologyStructure,
 		getNodeMetadata:      getNodeMetadata,
 		getEdgeMetadata:      getEdgeMetadata,
@@ -65,17 +77,15 @@ func (s *TopologyService) GetTopologyRepository() ports.TopologyRepository {
 
 // LoadConfigFromFile loads topology from a JSON configuration file
 func (s *TopologyService) LoadConfigFromFile(configPath string) error {
-	// Type assert to *MemoryTopologyRepository to access loading functionality
-	memRepo, ok := s.topologyRepo.(*infratopology.MemoryTopologyRepository)
-	if !ok {
-		s.logger.Warn("Cannot load config: topology repository is not in-memory type")
-		return nil
-	}
-
-	loader := infratopology.NewConfigLoader(memRepo, s.logger)
+	loader := infratopology.NewConfigLoader(s.tracker, s.logger)
 	return loader.LoadFromFile(configPath)
 }
 
+// Tracker returns the topology tracker, through which topology changes should be made
+func (s *TopologyService) Tracker() domainservices.TopologyTracker {
+	return s.tracker
+}
+
 // GetTopologyStructureUseCase returns the use case for getting topology structure
 func (s *TopologyService) GetTopologyStructureUseCase() *topology.GetTopologyStructureUseCase {
 	return s.getTopologyStructure